@@ -24,9 +24,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/resolver"
 
-	coli_conf "github.com/scionproto/scion/go/co/reservation/conf"
 	admission "github.com/scionproto/scion/go/co/reservation/segment/admission/stateless"
-	"github.com/scionproto/scion/go/co/reservationstorage"
 	"github.com/scionproto/scion/go/co/reservationstore"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/coliquic"
@@ -45,6 +43,9 @@ import (
 	"github.com/scionproto/scion/go/pkg/storage"
 )
 
+// maxRequestBytes is the maximum size of the requests received by the colibri gRPC server.
+const maxRequestBytes = 256 * 1024
+
 func main() {
 	// deleteme TODO(juagargi) this service seems to panic again when sigterm. WTF? it was fixed?
 	var cfg config.Config
@@ -133,7 +134,8 @@ func setupNetwork(ctx context.Context, cfg *config.Config, topo *topology.Loader
 		log.Info("debug server will be listening", "address", debugSvcAddr.String())
 	}
 
-	stack, err := coliquic.NewServerStack(ctx, serverAddr, debugSvcAddr, cfg.Daemon.Address)
+	stack, err := coliquic.NewServerStack(ctx, serverAddr, debugSvcAddr, cfg.Daemon.Address,
+		cfg.Colibri.ALPN...)
 	if err != nil {
 		return nil, serrors.WrapStr("initializing server stack", err)
 	}
@@ -179,7 +181,8 @@ func setupColibri(ctx context.Context, g *errgroup.Group, cleanup *app.Cleanup,
 	}
 	// client manager will find/build the right gRPC client used in every RPC
 	operator, err := coliquic.NewServiceClientOperator(topo, cfgObjs.stack.ClientPacketConn,
-		cfgObjs.stack.Router, cfgObjs.stack.Resolver)
+		cfgObjs.stack.Router, cfgObjs.stack.Resolver, coliquic.NewPayloadSizesHistogram(),
+		cfg.Colibri.ALPN...)
 	if err != nil {
 		return serrors.WrapStr("error creating operator", err)
 	}
@@ -196,11 +199,35 @@ func setupColibri(ctx context.Context, g *errgroup.Group, cleanup *app.Cleanup,
 		Store: colibriStore,
 	}
 
+	// manager keeps the configured reservations, and serves some of the debug commands
+	mgrCfg := reservationstore.ManagerConfig{
+		Reports: reservationstore.ReportIntervals{
+			Segments: cfg.Colibri.SegmentsReportInterval.Duration,
+			E2Es:     cfg.Colibri.E2EsReportInterval.Duration,
+		},
+		ReadOnly:     cfg.Colibri.ReadOnly,
+		LogFullPaths: cfg.Colibri.LogFullPaths,
+	}
+	if cfg.Colibri.DisableSegmentsReport {
+		mgrCfg.Reports.Segments = 0
+	}
+	if cfg.Colibri.DisableE2EsReport {
+		mgrCfg.Reports.E2Es = 0
+	}
+	mgr, err := reservationstore.NewColibriManager(ctx, topo.IA(), cfgObjs.stack.Router,
+		colibriStore, cfg.Colibri.Reservations, mgrCfg, reservationstore.NewMetrics())
+	if err != nil {
+		return serrors.WrapStr("could not start colibri manager", err)
+	}
+
 	// debug service used both from the command line and as part of the colibri debug services
-	debugService := colgrpc.NewDebugService(db, operator, topo, colibriStore)
+	debugService := colgrpc.NewDebugService(db, operator, topo, colibriStore, mgr)
+	debugService.DebugCommands = cfg.Colibri.DebugCommands
+	debugService.ReadOnly = cfg.Colibri.ReadOnly
 
 	// QUIC (regular API and debug services)
-	quicServer := coliquic.NewGrpcServer(libgrpc.UnaryServerInterceptor())
+	quicServer := coliquic.NewGrpcServer(topo.IA(), libgrpc.UnaryServerInterceptor(),
+		coliquic.WithMaxRequestBytes(maxRequestBytes), coliquic.WithALPN(cfg.Colibri.ALPN...))
 	colpb.RegisterColibriServiceServer(quicServer, colibriService)
 	colpb.RegisterColibriDebugServiceServer(quicServer, debugService)
 	g.Go(func() error {
@@ -235,25 +262,8 @@ func setupColibri(ctx context.Context, g *errgroup.Group, cleanup *app.Cleanup,
 		cleanup.Add(func() error { debugTcpServer.GracefulStop(); return nil })
 	}
 
-	manager, err := colibriManager(ctx, topo, cfgObjs.stack.Router, colibriStore,
-		cfg.Colibri.Reservations)
-	if err != nil {
-		return serrors.WrapStr("starting colibri manager", err)
-	}
+	manager := periodic.Start(mgr, 100*time.Millisecond, 5*time.Second)
 	cleanup.Add(func() error { manager.Kill(); return nil })
 
 	return nil
 }
-
-func colibriManager(ctx context.Context, topo *topology.Loader, router snet.Router, store reservationstorage.Store,
-	initialRsvs *coli_conf.Reservations) (*periodic.Runner, error) {
-
-	if store == nil {
-		return nil, nil
-	}
-	mgr, err := reservationstore.NewColibriManager(ctx, topo.IA(), router, store, initialRsvs)
-	if err != nil {
-		return nil, serrors.WrapStr("could not start colibri manager", err)
-	}
-	return periodic.Start(mgr, 100*time.Millisecond, 5*time.Second), nil
-}
@@ -20,11 +20,81 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/pathpol"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
 type Reservations struct {
 	Rsvs []ReservationEntry `json:"reservation_list"`
+	// TeardownOrphans indicates that the existing reservations that match no entry
+	// should be torn down at startup, instead of only being reported.
+	TeardownOrphans bool `json:"teardown_orphans,omitempty"`
+	// MinSizeFloors optionally sets, per path type, the lowest min_size an entry may have.
+	MinSizeFloors *MinSizeFloors `json:"min_size_floors,omitempty"`
+	// MaxReservations optionally limits the number of entries. Zero means no limit.
+	MaxReservations int `json:"max_reservations,omitempty"`
+	// TruncateExcess indicates that the entries above MaxReservations should be ignored,
+	// instead of rejecting the whole list.
+	TruncateExcess bool `json:"truncate_excess,omitempty"`
+	// SetupRateLimit optionally replaces the default limit of new reservation setups attempted
+	// per destination.
+	SetupRateLimit *SetupRateLimit `json:"setup_rate_limit,omitempty"`
+	// PathRefreshInterval optionally sets how often the paths to the destination of an existing
+	// reservation are fetched again, to detect that its path changed. It is independent of the
+	// renewal of the reservation. If not set, or zero, they are checked every five minutes.
+	PathRefreshInterval *util.DurWrap `json:"path_refresh_interval,omitempty"`
+	// SkipStartupCleanup disables the deletion of the expired indices when the keeper starts.
+	// Otherwise they are deleted in the background, once the reservations have been read.
+	SkipStartupCleanup bool `json:"skip_startup_cleanup,omitempty"`
+	// MaxEntriesPerRun optionally limits the number of entries kept in each run of the keeper.
+	// The entries kept least recently go first, so that all of them are eventually kept.
+	// Zero keeps all the entries in every run.
+	MaxEntriesPerRun int `json:"max_entries_per_run,omitempty"`
+	// MaxIndexLifetime optionally bounds the validity of the indices requested by the keeper,
+	// so that an index does not hold bandwidth much longer than needed if the keeper stops.
+	// If not set, or zero, there is no limit.
+	MaxIndexLifetime *util.DurWrap `json:"max_index_lifetime,omitempty"`
+}
+
+// SetupRateLimit limits the attempts to set up new reservations to the same destination, with
+// a token bucket per destination. A zero burst or interval disables the limit.
+type SetupRateLimit struct {
+	// Burst is the number of setups that can be attempted in a row.
+	Burst int `json:"burst"`
+	// Interval is the time needed to regain one attempt.
+	Interval util.DurWrap `json:"interval"`
+}
+
+// MinSizeFloors contains the lowest allowed min_size per path type. A zero value means there
+// is no floor for that path type.
+type MinSizeFloors struct {
+	Core     reservation.BWCls `json:"core,omitempty"`
+	Down     reservation.BWCls `json:"down,omitempty"`
+	Up       reservation.BWCls `json:"up,omitempty"`
+	PeerDown reservation.BWCls `json:"peer_down,omitempty"`
+	PeerUp   reservation.BWCls `json:"peer_up,omitempty"`
+}
+
+// Floor returns the floor for the path type, or zero if there is none.
+func (f *MinSizeFloors) Floor(pathType reservation.PathType) reservation.BWCls {
+	if f == nil {
+		return 0
+	}
+	switch pathType {
+	case reservation.CorePath:
+		return f.Core
+	case reservation.DownPath:
+		return f.Down
+	case reservation.UpPath:
+		return f.Up
+	case reservation.PeeringDownPath:
+		return f.PeerDown
+	case reservation.PeeringUpPath:
+		return f.PeerUp
+	default:
+		return 0
+	}
 }
 
 func ReservationsFromFile(filename string) (*Reservations, error) {
@@ -50,6 +120,15 @@ type ReservationEntry struct {
 	MinSize       reservation.BWCls    `json:"min_size"`
 	SplitCls      reservation.SplitCls `json:"split_cls"`
 	EndProps      EndProps             `json:"end_props"`
+	// RLC is the requested latency class, i.e. a latency of 2^RLC milliseconds, at most 63.
+	RLC reservation.RLC `json:"rlc,omitempty"`
+	// Labels are arbitrary key-value pairs identifying the entry in the logs and tools.
+	// The keys and values must be valid metric labels.
+	Labels map[string]string `json:"labels,omitempty"`
+	// PathPolicy optionally restricts the paths with a richer policy than the predicate, e.g.
+	// with an ACL excluding some ASes or ISDs. Its sequence, if any, replaces the predicate,
+	// which must then be empty.
+	PathPolicy *pathpol.Policy `json:"path_policy,omitempty"`
 }
 
 type EndProps reservation.PathEndProps
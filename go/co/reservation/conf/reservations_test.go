@@ -18,10 +18,12 @@ import (
 	"encoding/json"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
 
@@ -74,6 +76,105 @@ func TestReservationsJson(t *testing.T) {
 				},
 			},
 		},
+		"with floors": {
+			filename: "floors.json",
+			rsvs: Reservations{
+				Rsvs: []ReservationEntry{
+					{
+						DstAS:         xtest.MustParseIA("1-ff00:1:112"),
+						PathType:      reservation.CorePath,
+						PathPredicate: "1-ff00:1:112#0",
+						MaxSize:       13,
+						MinSize:       7,
+						SplitCls:      7,
+						EndProps: EndProps(reservation.NewPathEndProps(false, false,
+							false, false)),
+					},
+				},
+				MinSizeFloors: &MinSizeFloors{
+					Core: 7,
+					Down: 2,
+				},
+			},
+		},
+		"with setup rate limit": {
+			filename: "setup_rate_limit.json",
+			rsvs: Reservations{
+				Rsvs: []ReservationEntry{
+					{
+						DstAS:         xtest.MustParseIA("1-ff00:1:112"),
+						PathType:      reservation.CorePath,
+						PathPredicate: "1-ff00:1:112#0",
+						MaxSize:       13,
+						MinSize:       7,
+						SplitCls:      7,
+						EndProps: EndProps(reservation.NewPathEndProps(false, false,
+							false, false)),
+					},
+				},
+				SetupRateLimit: &SetupRateLimit{
+					Burst:    2,
+					Interval: util.DurWrap{Duration: 30 * time.Second},
+				},
+			},
+		},
+		"with path refresh interval": {
+			filename: "path_refresh_interval.json",
+			rsvs: Reservations{
+				Rsvs: []ReservationEntry{
+					{
+						DstAS:         xtest.MustParseIA("1-ff00:1:112"),
+						PathType:      reservation.CorePath,
+						PathPredicate: "1-ff00:1:112#0",
+						MaxSize:       13,
+						MinSize:       7,
+						SplitCls:      7,
+						EndProps: EndProps(reservation.NewPathEndProps(false, false,
+							false, false)),
+					},
+				},
+				PathRefreshInterval: &util.DurWrap{Duration: 5 * time.Minute},
+			},
+		},
+		"with max index lifetime": {
+			filename: "max_index_lifetime.json",
+			rsvs: Reservations{
+				Rsvs: []ReservationEntry{
+					{
+						DstAS:         xtest.MustParseIA("1-ff00:1:112"),
+						PathType:      reservation.CorePath,
+						PathPredicate: "1-ff00:1:112#0",
+						MaxSize:       13,
+						MinSize:       7,
+						SplitCls:      7,
+						EndProps: EndProps(reservation.NewPathEndProps(false, false,
+							false, false)),
+					},
+				},
+				MaxIndexLifetime: &util.DurWrap{Duration: 2 * time.Minute},
+			},
+		},
+		"with labels": {
+			filename: "labels.json",
+			rsvs: Reservations{
+				Rsvs: []ReservationEntry{
+					{
+						DstAS:         xtest.MustParseIA("1-ff00:1:112"),
+						PathType:      reservation.CorePath,
+						PathPredicate: "1-ff00:1:112#0",
+						MaxSize:       13,
+						MinSize:       7,
+						SplitCls:      7,
+						EndProps: EndProps(reservation.NewPathEndProps(false, false,
+							false, false)),
+						Labels: map[string]string{
+							"role":   "backup",
+							"tenant": "X",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -107,6 +107,10 @@ func (r *Reservation) Validate() error {
 func (r *Reservation) NewIndex(expTime time.Time, bw reservation.BWCls) (
 	reservation.IndexNumber, error) {
 
+	expTick, err := reservation.TimeToExpTick(expTime)
+	if err != nil {
+		return 0, err
+	}
 	idx := reservation.IndexNumber(0)
 	if len(r.Indices) > 0 {
 		idx = r.Indices[len(r.Indices)-1].Idx.Add(1)
@@ -120,7 +124,7 @@ func (r *Reservation) NewIndex(expTime time.Time, bw reservation.BWCls) (
 		Token: &reservation.Token{
 			InfoField: reservation.InfoField{
 				Idx:            idx,
-				ExpirationTick: reservation.TickFromTime(expTime),
+				ExpirationTick: reservation.Tick(expTick),
 				BWCls:          bw,
 				RLC:            0,
 				PathType:       reservation.E2EPath,
@@ -199,7 +203,7 @@ func DeriveColibriPath(id *reservation.ID, srcIA addr.IA, srcHost net.IP,
 			Ver:         uint8(tok.Idx),
 			HFCount:     uint8(len(tok.HopFields)),
 			ResIdSuffix: make([]byte, 12),
-			ExpTick:     uint32(tok.ExpirationTick),
+			ExpTick:     tok.ExpirationTick.ToExpTick(),
 			BwCls:       uint8(tok.BWCls),
 			Rlc:         uint8(tok.RLC),
 		},
@@ -43,7 +43,6 @@ func (r *SetupResponseSuccess) ToRaw(step int, rsvID *reservation.ID) ([]byte, e
 		return nil, serrors.WrapStr("loading token", err)
 	}
 	colPath := DeriveColibriPath(rsvID, 0, net.IPv4(0, 0, 0, 0), 0, net.IPv4(0, 0, 0, 0), tok)
-	colPath.InfoField.HFCount = uint8(len(colPath.HopFields))
 
 	// marker + authenticated response + path
 	buff := make([]byte, 1+4+colPath.Len())
@@ -99,6 +99,29 @@ func PathStepsFromRaw(raw []byte) PathSteps {
 	return steps
 }
 
+// NewPathSteps returns the path steps built from the steps given in order, after validating
+// them: there are at least two steps, every step has an AS, the first ingress and last egress
+// interfaces are zero, and the other interfaces are not.
+func NewPathSteps(steps ...PathStep) (PathSteps, error) {
+	if len(steps) < 2 {
+		return nil, serrors.New("a path needs at least two steps", "steps", len(steps))
+	}
+	for i, s := range steps {
+		if s.IA.IsZero() || s.IA.IsWildcard() {
+			return nil, serrors.New("invalid AS in step", "step", i, "ia", s.IA)
+		}
+		if in := s.Ingress; (i == 0) != (in == 0) {
+			return nil, serrors.New("invalid ingress interface in step", "step", i,
+				"ingress", in)
+		}
+		if eg := s.Egress; (i == len(steps)-1) != (eg == 0) {
+			return nil, serrors.New("invalid egress interface in step", "step", i,
+				"egress", eg)
+		}
+	}
+	return append(PathSteps{}, steps...), nil
+}
+
 func (p PathSteps) Copy() PathSteps {
 	return append(p[:0:0], p...)
 }
@@ -141,6 +164,24 @@ func (p PathSteps) String() string {
 	return strings.Join(strs, " > ")
 }
 
+// AnonymizedString returns the steps like String, but safe to log. The steps never contain
+// MACs, so only the interface IDs are concealed, and only if hashIfaces is set.
+func (p PathSteps) AnonymizedString(hashIfaces bool) string {
+	if !hashIfaces {
+		return p.String()
+	}
+	strs := make([]string, len(p))
+	for i, s := range p {
+		in, eg := colpath.AnonymizedIfID(s.Ingress), colpath.AnonymizedIfID(s.Egress)
+		if s.IA.IsZero() {
+			strs[i] = fmt.Sprintf("%s,%s", in, eg)
+		} else {
+			strs[i] = fmt.Sprintf("%s,%s,%s", in, s.IA, eg)
+		}
+	}
+	return strings.Join(strs, " > ")
+}
+
 // ValidateEquivalent checks that these steps are compatible with the path.
 // Compatible means the ingress/egress interface of the current step is the same
 // as those of the transport path.
@@ -190,7 +231,11 @@ func StepsFromSnet(p snet.Path) (PathSteps, error) {
 	if p == nil {
 		return nil, nil
 	}
-	steps, err := StepsFromInterfaces(p.Metadata().Interfaces)
+	md := p.Metadata()
+	if md == nil {
+		return nil, serrors.New("path without metadata")
+	}
+	steps, err := StepsFromInterfaces(md.Interfaces)
 	if err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ package reservation
 
 import (
 	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -79,6 +80,26 @@ func TestPathStepsToRawFromRaw(t *testing.T) {
 	}
 }
 
+func TestPathStepsAnonymizedString(t *testing.T) {
+	steps := PathSteps{
+		{Ingress: 0, Egress: 41, IA: xtest.MustParseIA("1-ff00:0:111")},
+		{Ingress: 42, Egress: 0, IA: xtest.MustParseIA("1-ff00:0:112")},
+	}
+	require.Equal(t, steps.String(), steps.AnonymizedString(false))
+
+	str := steps.AnonymizedString(true)
+	strs := strings.Split(str, " > ")
+	require.Len(t, strs, len(steps))
+	for i, s := range strs {
+		fields := strings.Split(s, ",")
+		require.Len(t, fields, 3)
+		require.Equal(t, steps[i].IA.String(), fields[1])
+		require.Equal(t, colpath.AnonymizedIfID(steps[i].Ingress), fields[0])
+		require.Equal(t, colpath.AnonymizedIfID(steps[i].Egress), fields[2])
+	}
+	require.Equal(t, str, steps.AnonymizedString(true), "must be stable")
+}
+
 func TestReverse(t *testing.T) {
 	// TODO(juagargi) use go/co/reservation/test.NewPath for the tests
 	cases := map[string]struct {
@@ -246,6 +267,91 @@ func TestPathStepsValidateEquivalent(t *testing.T) {
 	}
 }
 
+func TestNewPathSteps(t *testing.T) {
+	ia110 := xtest.MustParseIA("1-ff00:0:110")
+	ia111 := xtest.MustParseIA("1-ff00:0:111")
+	ia112 := xtest.MustParseIA("1-ff00:0:112")
+	cases := map[string]struct {
+		steps       []PathStep
+		expectedErr bool
+	}{
+		"two_steps": {
+			steps: []PathStep{
+				{IA: ia111, Ingress: 0, Egress: 1},
+				{IA: ia110, Ingress: 2, Egress: 0},
+			},
+		},
+		"three_steps": {
+			steps: []PathStep{
+				{IA: ia111, Ingress: 0, Egress: 1},
+				{IA: ia110, Ingress: 2, Egress: 3},
+				{IA: ia112, Ingress: 4, Egress: 0},
+			},
+		},
+		"no_steps": {
+			expectedErr: true,
+		},
+		"one_step": {
+			steps: []PathStep{
+				{IA: ia111},
+			},
+			expectedErr: true,
+		},
+		"first_ingress_not_zero": {
+			steps: []PathStep{
+				{IA: ia111, Ingress: 5, Egress: 1},
+				{IA: ia110, Ingress: 2, Egress: 0},
+			},
+			expectedErr: true,
+		},
+		"last_egress_not_zero": {
+			steps: []PathStep{
+				{IA: ia111, Ingress: 0, Egress: 1},
+				{IA: ia110, Ingress: 2, Egress: 5},
+			},
+			expectedErr: true,
+		},
+		"zero_transit_interface": {
+			steps: []PathStep{
+				{IA: ia111, Ingress: 0, Egress: 1},
+				{IA: ia110, Ingress: 2, Egress: 0},
+				{IA: ia112, Ingress: 4, Egress: 0},
+			},
+			expectedErr: true,
+		},
+		"zero_ia": {
+			steps: []PathStep{
+				{IA: ia111, Ingress: 0, Egress: 1},
+				{Ingress: 2, Egress: 0},
+			},
+			expectedErr: true,
+		},
+		"wildcard_ia": {
+			steps: []PathStep{
+				{IA: xtest.MustParseIA("1-0"), Ingress: 0, Egress: 1},
+				{IA: ia110, Ingress: 2, Egress: 0},
+			},
+			expectedErr: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			steps, err := NewPathSteps(tc.steps...)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, PathSteps(tc.steps), steps)
+			// the steps are copied
+			tc.steps[0].Egress++
+			require.NotEqual(t, tc.steps[0], steps[0])
+		})
+	}
+}
+
 func TestPathStepsFromSnet(t *testing.T) {
 	cases := map[string]struct {
 		snetPath    snet.Path
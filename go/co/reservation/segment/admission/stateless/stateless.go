@@ -238,7 +238,7 @@ func (a *StatelessAdmission) srcDem(rsvs []*segment.Reservation, ingress, egress
 	var srcDem uint64
 	for _, r := range rsvs {
 		if r.Ingress() == ingress && r.Egress() == egress && !r.ID.Equal(&req.ID) {
-			capReqDem := minBW(capIn, capEg, a.reqDem(*r, req))
+			capReqDem := minBW(capIn, capEg, a.reqDem(r, req))
 			srcDem += capReqDem
 		}
 	}
@@ -252,7 +252,7 @@ func (a *StatelessAdmission) srcDem(rsvs []*segment.Reservation, ingress, egress
 	return srcDem
 }
 
-func (a *StatelessAdmission) reqDem(r segment.Reservation, req segment.SetupReq) uint64 {
+func (a *StatelessAdmission) reqDem(r *segment.Reservation, req segment.SetupReq) uint64 {
 	var bw uint64
 	if r.ID.Equal(&req.ID) {
 		bw = req.MaxBW.ToKbps()
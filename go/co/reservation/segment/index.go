@@ -15,12 +15,15 @@
 package segment
 
 import (
+	"encoding/binary"
 	"fmt"
 	"strings"
 	"time"
 
 	base "github.com/scionproto/scion/go/co/reservation"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
 type IndexState uint8
@@ -57,6 +60,15 @@ func NewIndex(idx reservation.IndexNumber, expiration time.Time, state IndexStat
 	}
 }
 
+// preferredOver returns true if this index is more advanced than other: it has a more advanced
+// state, or the same state and a later expiration.
+func (index *Index) preferredOver(other *Index) bool {
+	if index.State != other.State {
+		return index.State > other.State
+	}
+	return index.Expiration.After(other.Expiration)
+}
+
 // Indices is a collection of Index that implements IndicesInterface.
 type Indices []Index
 
@@ -71,6 +83,26 @@ func (idxs Indices) Rotate(i int) base.IndicesInterface {
 	return append(idxs[i:], idxs[:i]...)
 }
 
+// copy returns a copy of the indices, not sharing their tokens.
+func (idxs Indices) copy() Indices {
+	if idxs == nil {
+		return nil
+	}
+	c := make(Indices, len(idxs))
+	for i, index := range idxs {
+		c[i] = index
+		if index.Token != nil {
+			tok := *index.Token
+			if index.Token.HopFields != nil {
+				tok.HopFields = make([]reservation.HopField, len(index.Token.HopFields))
+				copy(tok.HopFields, index.Token.HopFields)
+			}
+			c[i].Token = &tok
+		}
+	}
+	return c
+}
+
 func (idxs Indices) String() string {
 	strs := make([]string, len(idxs))
 	for i, index := range idxs {
@@ -174,3 +206,75 @@ func NotSwitchableFrom(index *Index) IndexFilter {
 		return NotConfirmed()(ind) && ByExpiration(atLeastUntil)(ind)
 	}
 }
+
+// MaxIndexHistory is the number of removed indices remembered by a reservation.
+const MaxIndexHistory = 16
+
+// indexHistoryEntryLen is the length of a serialized IndexHistoryEntry.
+const indexHistoryEntryLen = 10
+
+// IndexHistoryEntry records an index that was removed from its reservation.
+type IndexHistoryEntry struct {
+	Idx        reservation.IndexNumber
+	AllocBW    reservation.BWCls
+	Expiration time.Time
+	Removed    time.Time
+}
+
+// IndexHistory is a bounded ring buffer of removed indices. Recording an entry when the
+// history is full discards the oldest one.
+type IndexHistory struct {
+	entries [MaxIndexHistory]IndexHistoryEntry
+	next    int // position of the next entry to record
+	count   int
+}
+
+// Record adds the entry as the newest in the history.
+func (h *IndexHistory) Record(e IndexHistoryEntry) {
+	h.entries[h.next] = e
+	h.next = (h.next + 1) % MaxIndexHistory
+	if h.count < MaxIndexHistory {
+		h.count++
+	}
+}
+
+// Entries returns a copy of the recorded entries, from oldest to newest.
+func (h IndexHistory) Entries() []IndexHistoryEntry {
+	entries := make([]IndexHistoryEntry, h.count)
+	first := (h.next - h.count + MaxIndexHistory) % MaxIndexHistory
+	for i := range entries {
+		entries[i] = h.entries[(first+i)%MaxIndexHistory]
+	}
+	return entries
+}
+
+// ToRaw serializes the entries of the history, from oldest to newest.
+func (h IndexHistory) ToRaw() []byte {
+	entries := h.Entries()
+	buff := make([]byte, len(entries)*indexHistoryEntryLen)
+	for i, e := range entries {
+		raw := buff[i*indexHistoryEntryLen:]
+		raw[0] = byte(e.Idx)
+		raw[1] = byte(e.AllocBW)
+		binary.BigEndian.PutUint32(raw[2:], util.TimeToSecs(e.Expiration))
+		binary.BigEndian.PutUint32(raw[6:], util.TimeToSecs(e.Removed))
+	}
+	return buff
+}
+
+// IndexHistoryFromRaw returns the history serialized with ToRaw.
+func IndexHistoryFromRaw(raw []byte) (IndexHistory, error) {
+	var h IndexHistory
+	if len(raw)%indexHistoryEntryLen != 0 {
+		return h, serrors.New("bad index history length", "len", len(raw))
+	}
+	for ; len(raw) > 0; raw = raw[indexHistoryEntryLen:] {
+		h.Record(IndexHistoryEntry{
+			Idx:        reservation.IndexNumber(raw[0]),
+			AllocBW:    reservation.BWCls(raw[1]),
+			Expiration: util.SecsToTime(binary.BigEndian.Uint32(raw[2:])),
+			Removed:    util.SecsToTime(binary.BigEndian.Uint32(raw[6:])),
+		})
+	}
+	return h, nil
+}
@@ -48,12 +48,18 @@ type SetupReq struct {
 }
 
 // Validate takes as argument a function that returns the neighboring IA given the
-// interface ID. When 0, the function must return the local IA.
+// interface ID. When 0, the function must return the local IA. If the function is nil, as when
+// the initiator validates the request before sending it, the steps are not checked against the
+// topology.
 // Validate returns an error if not valid, or nil if okay.
 func (r *SetupReq) Validate(getNeighborIA func(ifaceID uint16) addr.IA) error {
 	if err := r.Request.Validate(r.Steps); err != nil {
 		return err
 	}
+	if r.MinBW > r.MaxBW {
+		return serrors.New("min bw must be less or equal than max bw",
+			"min_bw", r.MinBW, "max_bw", r.MaxBW)
+	}
 	if len(r.AllocTrail) > len(r.Steps) {
 		return serrors.New("inconsistent trail and setup path", "trail", r.AllocTrail,
 			"path", r.Steps)
@@ -62,6 +68,9 @@ func (r *SetupReq) Validate(getNeighborIA func(ifaceID uint16) addr.IA) error {
 		return serrors.New("incompatible path type and props", "path_type", r.PathType,
 			"props", r.PathProps)
 	}
+	if err := validateNotLocal(r.Steps); err != nil {
+		return err
+	}
 	if r.Steps == nil || len(r.Steps) < 2 {
 		return serrors.New("Wrong steps state")
 	}
@@ -80,6 +89,9 @@ func (r *SetupReq) Validate(getNeighborIA func(ifaceID uint16) addr.IA) error {
 	if err := r.Steps.ValidateEquivalent(r.TransportPath, r.CurrentStep); err != nil {
 		return serrors.WrapStr("invalid steps/raw path", err)
 	}
+	if getNeighborIA == nil {
+		return nil
+	}
 	// previous IA correct?
 	if r.CurrentStep > 0 &&
 		getNeighborIA(r.Ingress()) != r.Steps[r.CurrentStep-1].IA {
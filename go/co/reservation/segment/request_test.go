@@ -0,0 +1,136 @@
+// Copyright 2021 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segment_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	base "github.com/scionproto/scion/go/co/reservation"
+	"github.com/scionproto/scion/go/co/reservation/segment"
+	"github.com/scionproto/scion/go/co/reservation/test"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// TestSetupReqValidateAtInitiator checks the validation of the requests before sending them,
+// i.e. without checking the steps against the topology.
+func TestSetupReqValidateAtInitiator(t *testing.T) {
+	newReq := func() *segment.SetupReq {
+		steps := test.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1, "1-ff00:0:3")
+		return &segment.SetupReq{
+			Request: *base.NewRequest(util.SecsToTime(10), test.MustParseID("ff00:0:1", "00000000"),
+				0, len(steps)),
+			PathType:    reservation.UpPath,
+			PathProps:   reservation.StartLocal,
+			MinBW:       10,
+			MaxBW:       20,
+			Steps:       steps,
+			CurrentStep: 0,
+		}
+	}
+	newRenewal := func(r *segment.SetupReq) {
+		r.ID = *test.MustParseID("ff00:0:1", "beefcafe")
+		r.Reservation = segment.NewReservation(r.ID.ASID)
+		r.Reservation.ID = r.ID
+	}
+	cases := map[string]struct {
+		modify      func(*segment.SetupReq)
+		isValid     bool
+		expectedErr error
+	}{
+		"valid": {
+			modify:  func(*segment.SetupReq) {},
+			isValid: true,
+		},
+		"valid_with_transport": {
+			modify: func(r *segment.SetupReq) {
+				r.TransportPath = test.NewColPathMin(r.Steps)
+			},
+			isValid: true,
+		},
+		"min_bw_greater_than_max": {
+			modify: func(r *segment.SetupReq) {
+				r.MinBW = 21
+			},
+		},
+		"no_steps": {
+			modify: func(r *segment.SetupReq) {
+				r.Steps = nil
+			},
+		},
+		"one_step": {
+			modify: func(r *segment.SetupReq) {
+				r.Steps = r.Steps[:1]
+				r.Authenticators = nil
+			},
+			expectedErr: segment.ErrLocalReservation,
+		},
+		"src_equals_dst": {
+			modify: func(r *segment.SetupReq) {
+				r.Steps = test.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 2, "1-ff00:0:1")
+			},
+			expectedErr: segment.ErrLocalReservation,
+		},
+		"negative_current_step": {
+			modify: func(r *segment.SetupReq) {
+				r.CurrentStep = -1
+			},
+		},
+		"current_step_out_of_range": {
+			modify: func(r *segment.SetupReq) {
+				r.CurrentStep = len(r.Steps)
+			},
+		},
+		"renewal": {
+			modify: func(r *segment.SetupReq) {
+				newRenewal(r)
+				r.TransportPath = test.NewColPathMin(r.Steps)
+			},
+			isValid: true,
+		},
+		"renewal_without_transport": {
+			// the reservations set up by this AS have no transport path: renewed as setups
+			modify: func(r *segment.SetupReq) {
+				newRenewal(r)
+			},
+			isValid: true,
+		},
+		"transport_not_equivalent": {
+			modify: func(r *segment.SetupReq) {
+				r.TransportPath = test.NewColPathMin(
+					test.NewSteps("1-ff00:0:1", 3, 1, "1-ff00:0:2"))
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := newReq()
+			tc.modify(req)
+			err := req.Validate(nil)
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
@@ -15,20 +15,35 @@
 package segment
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	base "github.com/scionproto/scion/go/co/reservation"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	caddr "github.com/scionproto/scion/go/lib/slayers/path/colibri/addr"
+	"github.com/scionproto/scion/go/lib/util"
 )
 
+// ErrLocalReservation is returned when the steps of a segment reservation do not leave the
+// local AS. Segment reservations allocate bandwidth between ASes, and local QoS is out of
+// their scope: instead of deriving a degenerate colibri path, these reservations are rejected.
+var ErrLocalReservation = serrors.New("local-only segment reservations are not supported")
+
 // Reservation represents a segment reservation.
+// Indices must only be modified through the methods of the reservation, e.g. NewIndex,
+// SetIndices or MergeIndices, which hold its lock. Readers that can run concurrently with the
+// modification of the indices (e.g. reporting) must use the accessors IndicesSnapshot,
+// IndexCount, ActiveIndexNumber, ActiveIndexSnapshot and IndexSnapshot instead of reading
+// Indices directly.
 type Reservation struct {
+	mu            sync.RWMutex // protects Indices, activeIndex and observers in the methods
 	ID            reservation.ID
 	Indices       Indices                  // existing indices in this reservation
 	activeIndex   int                      // -1 <= activeIndex < len(Indices)
@@ -38,8 +53,17 @@ type Reservation struct {
 	Steps         base.PathSteps           // recovered from the pb messages
 	CurrentStep   int
 	TransportPath *colpath.ColibriPathMinimal // only used at initiator AS
+	observers     []IndexObserver             // notified when the active index changes
+	history       IndexHistory                // the last removed indices
 }
 
+// IndexObserver is called when the active index of a reservation changes, with the new active
+// index and the colibri path derived from it. Observers are called synchronously from
+// SetIndexActive, thus they must not block. If no path can be derived from the new active
+// index, e.g. its token has no hop fields yet, the observers are not notified.
+type IndexObserver func(id reservation.ID, idx reservation.IndexNumber,
+	path *colpath.ColibriPathMinimal)
+
 func NewReservation(asid addr.AS) *Reservation {
 	return &Reservation{
 		ID: reservation.ID{
@@ -50,6 +74,116 @@ func NewReservation(asid addr.AS) *Reservation {
 	}
 }
 
+// Clone returns a copy of this reservation, not sharing its indices. Observers are not copied.
+func (r *Reservation) Clone() *Reservation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &Reservation{
+		ID:            r.ID,
+		Indices:       r.Indices.copy(),
+		activeIndex:   r.activeIndex,
+		PathType:      r.PathType,
+		PathEndProps:  r.PathEndProps,
+		TrafficSplit:  r.TrafficSplit,
+		Steps:         r.Steps,
+		CurrentStep:   r.CurrentStep,
+		TransportPath: r.TransportPath,
+		history:       r.history,
+	}
+}
+
+// IndicesSnapshot returns a copy of the indices of this reservation. Modifying it does not
+// affect the reservation, and modifying the reservation does not affect it.
+func (r *Reservation) IndicesSnapshot() Indices {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.Indices.copy()
+}
+
+// History returns a copy of the last indices removed from this reservation.
+// At most MaxIndexHistory entries are kept.
+func (r *Reservation) History() IndexHistory {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.history
+}
+
+// SetHistory replaces the history of removed indices, e.g. when loading the reservation.
+func (r *Reservation) SetHistory(h IndexHistory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.history = h
+}
+
+// IndexCount returns the number of indices in this reservation.
+func (r *Reservation) IndexCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.Indices)
+}
+
+// ActiveIndexNumber returns the index number of the active index, and false if there is none.
+func (r *Reservation) ActiveIndexNumber() (reservation.IndexNumber, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.activeIndex == -1 {
+		return 0, false
+	}
+	return r.Indices[r.activeIndex].Idx, true
+}
+
+// ActiveIndexSnapshot returns a copy of the active index, and false if there is none.
+func (r *Reservation) ActiveIndexSnapshot() (Index, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.activeIndex == -1 {
+		return Index{}, false
+	}
+	return r.Indices[r.activeIndex : r.activeIndex+1].copy()[0], true
+}
+
+// IndexSnapshot returns a copy of the index with that number, and false if there is none.
+func (r *Reservation) IndexSnapshot(idx reservation.IndexNumber) (Index, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sliceIndex, err := base.FindIndex(r.Indices, idx)
+	if err != nil {
+		return Index{}, false
+	}
+	return r.Indices[sliceIndex : sliceIndex+1].copy()[0], true
+}
+
+// ValidUntil returns the time until which the reservation can be used without interruption:
+// the latest expiration of the active index and the confirmed indices switchable from it.
+// It returns the zero time if there is no active index.
+func (r *Reservation) ValidUntil() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.validUntil()
+}
+
+// CoversWindow returns true if the reservation can be used continuously during the whole
+// window from..to, i.e. if the window ends before the reservation is no longer valid.
+// Indices are usable since they are confirmed, thus the active index and the indices
+// switchable from it cover the time until ValidUntil without gaps. Indices not yet confirmed
+// do not count.
+func (r *Reservation) CoversWindow(from, to time.Time) bool {
+	if to.Before(from) {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	validUntil := r.validUntil()
+	return !validUntil.IsZero() && validUntil.After(to)
+}
+
+func (r *Reservation) validUntil() time.Time {
+	if r.activeIndex == -1 {
+		return time.Time{}
+	}
+	return r.Indices.Filter(NotSwitchableFrom(&r.Indices[r.activeIndex])).NewestExp()
+}
+
 func (r *Reservation) Ingress() uint16 {
 	return r.Steps[r.CurrentStep].Ingress
 }
@@ -63,26 +197,76 @@ func (r *Reservation) Transport() *colpath.ColibriPathMinimal {
 }
 
 // DeriveColibriPathAtSource creates the ColibriPathMinimal from the active index in this
-// reservation. If there is no active index, the path is nil. This function is expected
-// to be called by the src of the reservation. Note that the src is not necesarely the
+// reservation. If there is no active index, the path and the error are nil. This function is
+// expected to be called by the src of the reservation. Note that the src is not necesarely the
 // initator, in particular, if the Reservation is a downSegR.
-func (r *Reservation) DeriveColibriPathAtSource() *colpath.ColibriPathMinimal {
+func (r *Reservation) DeriveColibriPathAtSource() (*colpath.ColibriPathMinimal, error) {
 	return r.deriveColibriPath(false)
 }
 
 // DeriveColibriPathAtDestination creates the ColibriPath using the values of the active index in
-// this reservation, but with the hop fields in the reverse order. If there is no active index it
-// returns nil. This function is expected to be called by the dst of the reservation, which will
-// be the initiator of the reservation if the if the Reservation is a downSegR.
-func (r *Reservation) DeriveColibriPathAtDestination() *colpath.ColibriPathMinimal {
+// this reservation, but with the hop fields in the reverse order. If there is no active index the
+// path and the error are nil. This function is expected to be called by the dst of the
+// reservation, which will be the initiator of the reservation if the Reservation is a downSegR.
+func (r *Reservation) DeriveColibriPathAtDestination() (*colpath.ColibriPathMinimal, error) {
 	// because the initiator AS is actually the DstAS, reverse the path
 	return r.deriveColibriPath(true)
 }
 
-func (r *Reservation) deriveColibriPath(reverse bool) *colpath.ColibriPathMinimal {
+// DeriveColibriPath creates the ColibriPathMinimal from the active index as seen from this AS:
+// at the destination if this AS is not the first step (only for down-path SegRs), or at the
+// source otherwise. If there is no active index, there is nothing to derive and both the path
+// and the error are nil. A nil path with an error means the derivation failed.
+func (r *Reservation) DeriveColibriPath() (*colpath.ColibriPathMinimal, error) {
+	if r.CurrentStep != 0 {
+		return r.DeriveColibriPathAtDestination()
+	}
+	return r.DeriveColibriPathAtSource()
+}
+
+// PathFingerprint returns a stable identifier of the path of this reservation, derived only from
+// its ordered steps. It does not depend on the indices, thus it can be used to key data
+// related to the path across renewals and index activations.
+func (r *Reservation) PathFingerprint() string {
+	sum := sha256.Sum256(r.Steps.ToRaw())
+	return hex.EncodeToString(sum[:])
+}
+
+// AddIndexObserver registers an observer that will be notified each time the active index
+// of this reservation changes.
+func (r *Reservation) AddIndexObserver(o IndexObserver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observers = append(r.observers, o)
+}
+
+// indexActiveNotification returns a function notifying the observers about the current active
+// index. It must be called with the lock held, and the returned function without it, so that
+// observers can use the accessors of the reservation.
+func (r *Reservation) indexActiveNotification() func() {
+	if len(r.observers) == 0 {
+		return func() {}
+	}
+	observers := append([]IndexObserver{}, r.observers...)
+	idx := r.Indices[r.activeIndex].Idx
+	path, err := r.DeriveColibriPath()
+	if err != nil || path == nil {
+		log.Info("cannot derive colibri path for the index observers, not notifying them",
+			"id", r.ID.String(), "idx", idx, "err", err)
+		return func() {}
+	}
+	return func() {
+		for _, o := range observers {
+			// each observer gets its own copy of the path, as it is mutable
+			o(r.ID, idx, path.Clone())
+		}
+	}
+}
+
+func (r *Reservation) deriveColibriPath(reverse bool) (*colpath.ColibriPathMinimal, error) {
 	index := r.ActiveIndex()
 	if index == nil {
-		return nil
+		return nil, nil
 	}
 	p := &colpath.ColibriPath{
 		InfoField: r.deriveInfoField(reverse),
@@ -95,23 +279,27 @@ func (r *Reservation) deriveColibriPath(reverse bool) *colpath.ColibriPathMinima
 			Mac:       append([]byte{}, hf.Mac[:]...),
 		}
 	}
-	steps := r.Steps
-	if reverse {
-		steps = steps.Reverse()
-		// The hop fields were stacked in the reverse direction of the traffic.
-		// If reverse is true, the function was called from the destination of the traffic.
-		// E.g. for the tiny topology, a down-path initiated in 111 to 110 can be derived at
-		// 111 as destination. But the hop fields are reversed, so before calling any action
-		// on the path, we reconstruct the path 110->111 as seen from 110 by just converting
-		// the stack with 111 at the bottom (beginning of the array) to a list with 110 at
-		// beginning. This is equivalent to just reverse the array.
+	if r.PathType == reservation.DownPath {
+		// The hop fields of down-path tokens are stacked in the reverse direction of the traffic
+		// (see Token.AddNewHopField). E.g. for the tiny topology, a down-path initiated in 111
+		// to 110 has the hop field of 111 at the bottom (beginning of the array). We
+		// reconstruct the path 110->111 by converting the stack to a list with 110 at the
+		// beginning, so that the hop fields follow the steps. This is equivalent to just
+		// reverse the array.
 		hfc := len(p.HopFields)
 		for i := 0; i < hfc/2; i++ {
 			// reverse order (do not touch the ingress and egress interfaces)
 			p.HopFields[i], p.HopFields[hfc-i-1] = p.HopFields[hfc-i-1], p.HopFields[i]
 		}
+	}
+	steps := r.Steps
+	if reverse {
+		// If reverse is true, the function was called from the destination of the traffic.
+		// The path is then seen as arriving there (CurrHF is the last hop field), and
+		// reversing it yields a path that starts here and follows the reversed steps.
+		steps = steps.Reverse()
 		if _, err := p.Reverse(); err != nil {
-			return nil
+			return nil, serrors.WrapStr("reversing colibri path", err, "id", r.ID.String())
 		}
 	}
 	p.Src = caddr.NewEndpointWithAddr(steps.SrcIA(), addr.SvcCOL.Base())
@@ -126,13 +314,14 @@ func (r *Reservation) deriveColibriPath(reverse bool) *colpath.ColibriPathMinima
 	// deleteme until here
 	min, err := p.ToMinimal()
 	if err != nil {
-		return nil
+		return nil, serrors.WrapStr("converting colibri path to minimal", err,
+			"id", r.ID.String())
 	}
 	// deleteme:
 	buff := make([]byte, min.Len())
 	min.SerializeTo(buff)
 	fmt.Printf("%s -> %s\n", r.ID, hex.EncodeToString(buff))
-	return min
+	return min, nil
 }
 
 // Validate will return an error for invalid values.
@@ -161,6 +350,12 @@ func (r *Reservation) Validate() error {
 			activeIndex = i
 		}
 	}
+	if err := r.TrafficSplit.Validate(); err != nil {
+		return err
+	}
+	if err := validateNotLocal(r.Steps); err != nil {
+		return err
+	}
 	if r.Steps == nil || len(r.Steps) < 2 {
 		return serrors.New("Wrong steps state")
 	}
@@ -205,16 +400,22 @@ func (r *Reservation) NewIndex(idx reservation.IndexNumber,
 	// if len(r.Indices) > 0 {
 	// 	idx = r.Indices[len(r.Indices)-1].Idx.Add(1)
 	// }
+	expTick, err := reservation.TimeToExpTick(expTime)
+	if err != nil {
+		return 0, err
+	}
 	tok := &reservation.Token{
 		InfoField: reservation.InfoField{
 			Idx:            idx,
-			ExpirationTick: reservation.TickFromTime(expTime),
+			ExpirationTick: reservation.Tick(expTick),
 			BWCls:          allocBW,
 			RLC:            rlc,
 			PathType:       pathType,
 		},
 	}
 	index := NewIndex(idx, expTime, IndexTemporary, minBW, maxBW, allocBW, tok)
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	return r.addIndex(index)
 }
 
@@ -250,6 +451,8 @@ func (r *Reservation) NextIndexToActivate() *Index {
 // SetIndexConfirmed sets the index as IndexPending (confirmed but not active). If the requested
 // index has state active, it will emit an error.
 func (r *Reservation) SetIndexConfirmed(idx reservation.IndexNumber) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	sliceIndex, err := base.FindIndex(r.Indices, idx)
 	if err != nil {
 		return err
@@ -261,24 +464,51 @@ func (r *Reservation) SetIndexConfirmed(idx reservation.IndexNumber) error {
 	return nil
 }
 
+// SetIndexTemporary sets a confirmed index back to IndexTemporary, e.g. when its confirmation
+// failed. If the requested index has state active, it will emit an error.
+func (r *Reservation) SetIndexTemporary(idx reservation.IndexNumber) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sliceIndex, err := base.FindIndex(r.Indices, idx)
+	if err != nil {
+		return err
+	}
+	if r.Indices[sliceIndex].State == IndexActive {
+		return serrors.New("cannot revert an already active index", "index_number", idx)
+	}
+	r.Indices[sliceIndex].State = IndexTemporary
+	return nil
+}
+
 // SetIndexActive sets the index as active. If the reservation had already an active state,
 // it will remove all previous indices.
 func (r *Reservation) SetIndexActive(idx reservation.IndexNumber) error {
-	sliceIndex, err := base.FindIndex(r.Indices, idx)
+	r.mu.Lock()
+	notify, err := r.setIndexActive(idx)
+	r.mu.Unlock()
 	if err != nil {
 		return err
 	}
+	notify()
+	return nil
+}
+
+func (r *Reservation) setIndexActive(idx reservation.IndexNumber) (func(), error) {
+	sliceIndex, err := base.FindIndex(r.Indices, idx)
+	if err != nil {
+		return nil, err
+	}
 	if r.activeIndex == sliceIndex {
-		return nil // already active
+		return func() {}, nil // already active
 	}
 	// valid states are Pending (nominal) and Active (reconstructing from DB needs this)
 	if r.Indices[sliceIndex].State != IndexPending && r.Indices[sliceIndex].State != IndexActive {
-		return serrors.New("attempt to activate a non confirmed index", "index_number", idx,
+		return nil, serrors.New("attempt to activate a non confirmed index", "index_number", idx,
 			"state", r.Indices[sliceIndex].State)
 	}
 	if r.activeIndex > -1 {
 		if r.activeIndex > sliceIndex {
-			return serrors.New("activating a past index",
+			return nil, serrors.New("activating a past index",
 				"last active", r.Indices[r.activeIndex].Idx, "current", idx)
 		}
 	}
@@ -286,10 +516,12 @@ func (r *Reservation) SetIndexActive(idx reservation.IndexNumber) error {
 	r.Indices = r.Indices[sliceIndex:]
 	r.activeIndex = 0
 	r.Indices[0].State = IndexActive
-	return nil
+	return r.indexActiveNotification(), nil
 }
 
 func (r *Reservation) SetIndexInactive() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if r.activeIndex == 0 {
 		r.Indices[0].State = IndexPending
 		r.activeIndex = -1
@@ -297,11 +529,23 @@ func (r *Reservation) SetIndexInactive() {
 }
 
 // RemoveIndex removes all indices from the beginning until this one, inclusive.
+// The removed indices are recorded in the history of the reservation.
 func (r *Reservation) RemoveIndex(idx reservation.IndexNumber) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	sliceIndex, err := base.FindIndex(r.Indices, idx)
 	if err != nil {
 		return err
 	}
+	now := util.SecsToTime(util.TimeToSecs(time.Now())) // the history keeps seconds
+	for _, index := range r.Indices[:sliceIndex+1] {
+		r.history.Record(IndexHistoryEntry{
+			Idx:        index.Idx,
+			AllocBW:    index.AllocBW,
+			Expiration: index.Expiration,
+			Removed:    now,
+		})
+	}
 	r.Indices = r.Indices[sliceIndex+1:]
 
 	if r.activeIndex > sliceIndex { // if active index was not removed, adjust it
@@ -312,6 +556,101 @@ func (r *Reservation) RemoveIndex(idx reservation.IndexNumber) error {
 	return nil
 }
 
+// MergeIndices adds the indices in other to those of this reservation. Indices present in both
+// are resolved by keeping the one with the more advanced state (active, pending, temporary),
+// or the one expiring later if the states are equal. If the result has an active index,
+// the indices before it are removed, as SetIndexActive does. If the merged indices are not
+// valid, an error is returned and the reservation is not modified.
+func (r *Reservation) MergeIndices(other Indices) error {
+	r.mu.Lock()
+	byNumber := make(map[reservation.IndexNumber]Index, len(r.Indices)+len(other))
+	for _, indices := range []Indices{r.Indices, other} {
+		for _, index := range indices {
+			if existing, ok := byNumber[index.Idx]; ok && !index.preferredOver(&existing) {
+				continue
+			}
+			byNumber[index.Idx] = index
+		}
+	}
+	merged := make(Indices, 0, len(byNumber))
+	if len(byNumber) > 0 {
+		// start at the index without predecessor. If all index numbers are present,
+		// start at the one expiring first
+		var start *Index
+		for _, index := range byNumber {
+			index := index
+			if _, ok := byNumber[index.Idx.Sub(1)]; ok && len(byNumber) < 16 {
+				continue
+			}
+			if start == nil || index.Expiration.Before(start.Expiration) {
+				start = &index
+			}
+		}
+		for n := 0; n < 16; n++ {
+			if index, ok := byNumber[start.Idx.Add(reservation.IndexNumber(n))]; ok {
+				merged = append(merged, index)
+			}
+		}
+	}
+	activeIndex := -1
+	for i, index := range merged {
+		if index.State == IndexActive {
+			activeIndex = i
+		}
+	}
+	if activeIndex > 0 {
+		merged = merged[activeIndex:]
+		activeIndex = 0
+	}
+	if err := base.ValidateIndices(merged); err != nil {
+		r.mu.Unlock()
+		return serrors.WrapStr("merging indices", err)
+	}
+	notify := r.replaceIndices(merged, activeIndex)
+	r.mu.Unlock()
+	notify()
+	return nil
+}
+
+// SetIndices replaces the indices of this reservation, e.g. with those read from the DB or of
+// a copy of it modified elsewhere. The active index, if any, must be the first one, otherwise
+// an error is returned and the reservation is not modified. The indices are not validated
+// further: use Validate for that.
+func (r *Reservation) SetIndices(indices Indices) error {
+	activeIndex := -1
+	for i, index := range indices {
+		if index.State != IndexActive {
+			continue
+		}
+		if i != 0 {
+			return serrors.New("active index is not the first one", "active", index.Idx,
+				"first", indices[0].Idx)
+		}
+		activeIndex = i
+	}
+	r.mu.Lock()
+	notify := r.replaceIndices(indices, activeIndex)
+	r.mu.Unlock()
+	notify()
+	return nil
+}
+
+// replaceIndices replaces the indices and the active index of this reservation. It must be
+// called with the lock held. The returned function notifies the observers if the active index
+// changed, and must be called without the lock.
+func (r *Reservation) replaceIndices(indices Indices, activeIndex int) func() {
+	prevActive := -1
+	if r.activeIndex != -1 {
+		prevActive = int(r.Indices[r.activeIndex].Idx)
+	}
+	r.Indices = indices
+	r.activeIndex = activeIndex
+	if activeIndex != -1 && int(indices[activeIndex].Idx) != prevActive {
+		return r.indexActiveNotification()
+	}
+	return func() {}
+}
+
 func (r *Reservation) String() string {
 	return fmt.Sprintf("%s, Idxs: [%s]", r.ID.String(), r.Indices)
 }
@@ -375,8 +714,16 @@ func (r *Reservation) deriveInfoField(reverse bool) *colpath.InfoField {
 		CurrHF:  currHF,
 		// the SegR ID and then 8 zeroes:
 		ResIdSuffix: append(append(zeroBytes[:0:0], r.ID.Suffix...), zeroBytes[:]...),
-		ExpTick:     uint32(index.Token.ExpirationTick),
+		ExpTick:     index.Token.ExpirationTick.ToExpTick(),
 		BwCls:       uint8(index.AllocBW),
 		Rlc:         uint8(index.Token.RLC),
 	}
 }
+
+// validateNotLocal returns ErrLocalReservation if the steps begin and end in the same AS.
+func validateNotLocal(steps base.PathSteps) error {
+	if len(steps) > 0 && (len(steps) == 1 || steps.SrcIA() == steps.DstIA()) {
+		return serrors.WrapStr("invalid steps", ErrLocalReservation, "ia", steps.SrcIA())
+	}
+	return nil
+}
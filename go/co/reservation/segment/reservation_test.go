@@ -15,11 +15,14 @@
 package segment_test
 
 import (
+	"crypto/cipher"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	base "github.com/scionproto/scion/go/co/reservation"
 	"github.com/scionproto/scion/go/co/reservation/segment"
 	"github.com/scionproto/scion/go/co/reservation/segmenttest"
 	"github.com/scionproto/scion/go/co/reservation/test"
@@ -101,6 +104,19 @@ func TestReservationValidate(t *testing.T) {
 	r.Steps = nil
 	err = r.Validate()
 	require.Error(t, err)
+	// local-only reservation
+	r = segmenttest.NewReservation()
+	r.Steps = r.Steps[:1]
+	r.Steps[0].Egress = 0
+	err = r.Validate()
+	require.ErrorIs(t, err, segment.ErrLocalReservation)
+	// traffic split out of range
+	r = segmenttest.NewReservation()
+	r.TrafficSplit = 63
+	require.NoError(t, r.Validate())
+	r.TrafficSplit = 64
+	err = r.Validate()
+	require.Error(t, err)
 }
 
 func TestIndex(t *testing.T) {
@@ -133,6 +149,15 @@ func TestSetIndexConfirmed(t *testing.T) {
 	err = r.SetIndexConfirmed(id)
 	require.NoError(t, err)
 	require.Equal(t, segment.IndexPending, r.Indices[0].State)
+
+	// revert the confirmation
+	require.NoError(t, r.SetIndexTemporary(id))
+	require.Equal(t, segment.IndexTemporary, r.Indices[0].State)
+	require.Error(t, r.SetIndexTemporary(id.Add(1)))
+	require.NoError(t, r.SetIndexConfirmed(id))
+	require.NoError(t, r.SetIndexActive(id))
+	require.Error(t, r.SetIndexTemporary(id))
+	require.Equal(t, segment.IndexActive, r.Indices[0].State)
 }
 
 func TestSetIndexActive(t *testing.T) {
@@ -168,6 +193,79 @@ func TestSetIndexActive(t *testing.T) {
 	require.True(t, r.Indices[0].Idx == idx)
 }
 
+func TestIndexObserver(t *testing.T) {
+	r := segmenttest.NewReservation()
+	expTime := util.SecsToTime(1)
+	type notification struct {
+		id   reservation.ID
+		idx  reservation.IndexNumber
+		path *colpath.ColibriPathMinimal
+	}
+	var notified []notification
+	r.AddIndexObserver(func(id reservation.ID, idx reservation.IndexNumber,
+		path *colpath.ColibriPathMinimal) {
+
+		notified = append(notified, notification{id: id, idx: idx, path: path})
+	})
+	// newIndex adds an index whose token has the hop fields of the steps
+	newIndex := func(idx reservation.IndexNumber) reservation.IndexNumber {
+		idx, err := r.NewIndex(idx, expTime, 0, 0, 0, 0, reservation.CorePath)
+		require.NoError(t, err)
+		tok := r.Index(idx).Token
+		for i := len(r.Steps) - 1; i >= 0; i-- {
+			tok.AddNewHopField(&reservation.HopField{
+				Ingress: r.Steps[i].Ingress,
+				Egress:  r.Steps[i].Egress,
+			})
+		}
+		return idx
+	}
+
+	// confirming does not notify
+	idx := newIndex(0)
+	require.NoError(t, r.SetIndexConfirmed(idx))
+	require.Empty(t, notified)
+
+	// activation notifies the new path
+	require.NoError(t, r.SetIndexActive(idx))
+	require.Len(t, notified, 1)
+	require.Equal(t, r.ID, notified[0].id)
+	require.Equal(t, idx, notified[0].idx)
+	require.NotNil(t, notified[0].path)
+	path, err := r.DeriveColibriPath()
+	require.NoError(t, err)
+	require.Equal(t, path, notified[0].path)
+	require.Equal(t, uint8(idx), notified[0].path.InfoField.Ver)
+	require.Equal(t, uint8(len(r.Steps)), notified[0].path.InfoField.HFCount)
+
+	// already active does not notify
+	require.NoError(t, r.SetIndexActive(idx))
+	require.Len(t, notified, 1)
+
+	// a failed activation does not notify
+	idx = newIndex(1)
+	require.Error(t, r.SetIndexActive(idx))
+	require.Len(t, notified, 1)
+
+	// rollover to the next index
+	require.NoError(t, r.SetIndexConfirmed(idx))
+	require.NoError(t, r.SetIndexActive(idx))
+	require.Len(t, notified, 2)
+	require.Equal(t, idx, notified[1].idx)
+	path, err = r.DeriveColibriPath()
+	require.NoError(t, err)
+	require.Equal(t, path, notified[1].path)
+	require.Equal(t, uint8(idx), notified[1].path.InfoField.Ver)
+	require.NotEqual(t, notified[0].path.Raw, notified[1].path.Raw)
+
+	// no path can be derived from an index without hop fields: no notification
+	idx, err = r.NewIndex(2, expTime, 0, 0, 0, 0, reservation.CorePath)
+	require.NoError(t, err)
+	require.NoError(t, r.SetIndexConfirmed(idx))
+	require.NoError(t, r.SetIndexActive(idx))
+	require.Len(t, notified, 2)
+}
+
 func TestRemoveIndex(t *testing.T) {
 	r := segmenttest.NewReservation()
 	expTime := util.SecsToTime(1)
@@ -200,6 +298,484 @@ func TestRemoveIndex(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRemoveIndexHistory(t *testing.T) {
+	r := segmenttest.NewReservation()
+	require.Empty(t, r.History().Entries())
+
+	// create and remove several indices, the oldest first
+	for i := 0; i < 5; i++ {
+		_, err := r.NewIndex(reservation.IndexNumber(i), util.SecsToTime(uint32(10+i)), 0, 0,
+			reservation.BWCls(i+1), 0, reservation.CorePath)
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.RemoveIndex(1)) // removes 0 and 1
+	require.NoError(t, r.RemoveIndex(2))
+	require.NoError(t, r.RemoveIndex(4)) // removes 3 and 4
+
+	entries := r.History().Entries()
+	require.Len(t, entries, 5)
+	for i, e := range entries {
+		require.Equal(t, reservation.IndexNumber(i), e.Idx)
+		require.Equal(t, reservation.BWCls(i+1), e.AllocBW)
+		require.Equal(t, util.SecsToTime(uint32(10+i)), e.Expiration)
+		require.False(t, e.Removed.IsZero())
+	}
+
+	// the history is bounded, and keeps the newest entries
+	for i := 5; i < 5+segment.MaxIndexHistory; i++ {
+		idx := reservation.IndexNumber(i % 16)
+		_, err := r.NewIndex(idx, util.SecsToTime(uint32(10+i)), 0, 0, 0, 0,
+			reservation.CorePath)
+		require.NoError(t, err)
+		require.NoError(t, r.RemoveIndex(idx))
+	}
+	entries = r.History().Entries()
+	require.Len(t, entries, segment.MaxIndexHistory)
+	for i, e := range entries {
+		require.Equal(t, util.SecsToTime(uint32(15+i)), e.Expiration)
+	}
+
+	// the history survives serialization and cloning
+	history, err := segment.IndexHistoryFromRaw(r.History().ToRaw())
+	require.NoError(t, err)
+	require.Equal(t, entries, history.Entries())
+	require.Equal(t, entries, r.Clone().History().Entries())
+	_, err = segment.IndexHistoryFromRaw([]byte{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestIndexAccessors(t *testing.T) {
+	r := segmenttest.NewReservation()
+	expTime := util.SecsToTime(1)
+	require.Equal(t, 0, r.IndexCount())
+	require.Empty(t, r.IndicesSnapshot())
+	_, ok := r.ActiveIndexNumber()
+	require.False(t, ok)
+	_, ok = r.ActiveIndexSnapshot()
+	require.False(t, ok)
+	_, ok = r.IndexSnapshot(0)
+	require.False(t, ok)
+
+	idx, _ := r.NewIndex(0, expTime, 0, 0, 0, 0, reservation.CorePath)
+	idx2, _ := r.NewIndex(1, expTime, 0, 0, 0, 0, reservation.CorePath)
+	require.Equal(t, 2, r.IndexCount())
+	require.NoError(t, r.SetIndexConfirmed(idx))
+	require.NoError(t, r.SetIndexActive(idx))
+	active, ok := r.ActiveIndexNumber()
+	require.True(t, ok)
+	require.Equal(t, idx, active)
+	activeIndex, ok := r.ActiveIndexSnapshot()
+	require.True(t, ok)
+	require.Equal(t, r.Indices[0], activeIndex)
+	index2, ok := r.IndexSnapshot(idx2)
+	require.True(t, ok)
+	require.Equal(t, r.Indices[1], index2)
+
+	// the snapshots do not alias the reservation
+	activeIndex.Token.BWCls = 13
+	index2.State = segment.IndexActive
+	require.NotEqual(t, reservation.BWCls(13), r.Indices[0].Token.BWCls)
+	require.Equal(t, segment.IndexTemporary, r.Indices[1].State)
+	snapshot := r.IndicesSnapshot()
+	require.Equal(t, r.Indices, snapshot)
+	snapshot[0].State = segment.IndexTemporary
+	snapshot[0].Token.BWCls = 13
+	require.Equal(t, segment.IndexActive, r.Indices[0].State)
+	require.NotEqual(t, reservation.BWCls(13), r.Indices[0].Token.BWCls)
+	require.NoError(t, r.RemoveIndex(idx))
+	require.Len(t, snapshot, 2)
+	require.Equal(t, idx, snapshot[0].Idx)
+	require.Equal(t, idx2, snapshot[1].Idx)
+	require.Equal(t, 1, r.IndexCount())
+	_, ok = r.ActiveIndexNumber()
+	require.False(t, ok)
+}
+
+// TestIndexAccessorsConcurrently is meant to be run with the race detector.
+func TestIndexAccessorsConcurrently(t *testing.T) {
+	r := segmenttest.NewReservation()
+	expTime := util.SecsToTime(1)
+	const rounds = 100
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		idx := reservation.IndexNumber(0)
+		for i := 0; i < rounds; i++ {
+			_, err := r.NewIndex(idx, expTime, 0, 0, 0, 0, reservation.CorePath)
+			require.NoError(t, err)
+			require.NoError(t, r.SetIndexConfirmed(idx))
+			require.NoError(t, r.SetIndexActive(idx))
+			idx = idx.Add(1)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < rounds; i++ {
+			snapshot := r.IndicesSnapshot()
+			require.LessOrEqual(t, len(snapshot), 2)
+			for j := range snapshot {
+				snapshot[j].State = segment.IndexTemporary
+			}
+			require.LessOrEqual(t, r.IndexCount(), 2)
+			r.ActiveIndexNumber()
+			if active, ok := r.ActiveIndexSnapshot(); ok {
+				active.State = segment.IndexTemporary
+				r.IndexSnapshot(active.Idx)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		// the indices are replaced by equivalent ones, under the lock of the reservation
+		for i := 0; i < rounds; i++ {
+			require.NoError(t, r.MergeIndices(nil))
+			require.NoError(t, r.Clone().SetIndices(r.IndicesSnapshot()))
+		}
+	}()
+	wg.Wait()
+	require.Equal(t, 1, r.IndexCount())
+	active, ok := r.ActiveIndexNumber()
+	require.True(t, ok)
+	require.Equal(t, reservation.IndexNumber(0).Add(rounds-1), active)
+	require.Equal(t, segment.IndexActive, r.IndicesSnapshot()[0].State)
+}
+
+func TestMergeIndices(t *testing.T) {
+	exp := func(secs uint32) time.Time { return util.SecsToTime(secs) }
+	index := func(idx reservation.IndexNumber, state segment.IndexState,
+		expiration time.Time) segment.Index {
+
+		return segment.Index{Idx: idx, State: state, Expiration: expiration}
+	}
+	cases := map[string]struct {
+		local       segment.Indices
+		localActive int
+		other       segment.Indices
+		expected    segment.Indices
+		expActive   int // index number of the active index, or -1
+		expErr      bool
+	}{
+		"disjoint": {
+			local:       segment.Indices{index(0, segment.IndexPending, exp(1))},
+			localActive: -1,
+			other:       segment.Indices{index(1, segment.IndexTemporary, exp(2))},
+			expected: segment.Indices{
+				index(0, segment.IndexPending, exp(1)),
+				index(1, segment.IndexTemporary, exp(2)),
+			},
+			expActive: -1,
+		},
+		"overlapping": {
+			local: segment.Indices{
+				index(0, segment.IndexPending, exp(1)),
+				index(1, segment.IndexTemporary, exp(2)),
+			},
+			localActive: -1,
+			other: segment.Indices{
+				index(1, segment.IndexPending, exp(2)),
+				index(2, segment.IndexTemporary, exp(3)),
+			},
+			expected: segment.Indices{
+				index(0, segment.IndexPending, exp(1)),
+				index(1, segment.IndexPending, exp(2)),
+				index(2, segment.IndexTemporary, exp(3)),
+			},
+			expActive: -1,
+		},
+		"same_state_later_expiration": {
+			local:       segment.Indices{index(3, segment.IndexTemporary, exp(1))},
+			localActive: -1,
+			other:       segment.Indices{index(3, segment.IndexTemporary, exp(2))},
+			expected:    segment.Indices{index(3, segment.IndexTemporary, exp(2))},
+			expActive:   -1,
+		},
+		"conflicting_state": {
+			local: segment.Indices{
+				index(0, segment.IndexActive, exp(1)),
+				index(1, segment.IndexPending, exp(2)),
+			},
+			localActive: 0,
+			other: segment.Indices{
+				index(1, segment.IndexActive, exp(2)),
+				index(2, segment.IndexTemporary, exp(3)),
+			},
+			// only one active index, the previous ones are removed
+			expected: segment.Indices{
+				index(1, segment.IndexActive, exp(2)),
+				index(2, segment.IndexTemporary, exp(3)),
+			},
+			expActive: 1,
+		},
+		"keeps_local_active": {
+			local: segment.Indices{
+				index(4, segment.IndexActive, exp(1)),
+			},
+			localActive: 0,
+			other: segment.Indices{
+				index(4, segment.IndexPending, exp(1)),
+				index(5, segment.IndexPending, exp(2)),
+			},
+			expected: segment.Indices{
+				index(4, segment.IndexActive, exp(1)),
+				index(5, segment.IndexPending, exp(2)),
+			},
+			expActive: 4,
+		},
+		"wrap_around": {
+			local:       segment.Indices{index(0, segment.IndexTemporary, exp(2))},
+			localActive: -1,
+			other:       segment.Indices{index(15, segment.IndexPending, exp(1))},
+			expected: segment.Indices{
+				index(15, segment.IndexPending, exp(1)),
+				index(0, segment.IndexTemporary, exp(2)),
+			},
+			expActive: -1,
+		},
+		"non_consecutive": {
+			local:       segment.Indices{index(0, segment.IndexPending, exp(1))},
+			localActive: -1,
+			other:       segment.Indices{index(2, segment.IndexTemporary, exp(2))},
+			expErr:      true,
+		},
+		"expiration_order": {
+			local:       segment.Indices{index(0, segment.IndexPending, exp(2))},
+			localActive: -1,
+			other:       segment.Indices{index(1, segment.IndexTemporary, exp(1))},
+			expErr:      true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := segmenttest.NewReservation()
+			r.Indices = tc.local
+			r.SetActiveIndexForTesting(tc.localActive)
+			err := r.MergeIndices(tc.other)
+			if tc.expErr {
+				require.Error(t, err)
+				require.Equal(t, tc.local, r.Indices)
+				require.Equal(t, tc.localActive, r.GetActiveIndexForTesting())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, r.Indices)
+			active, ok := r.ActiveIndexNumber()
+			if tc.expActive == -1 {
+				require.False(t, ok)
+			} else {
+				require.True(t, ok)
+				require.Equal(t, reservation.IndexNumber(tc.expActive), active)
+				require.Equal(t, segment.IndexActive, r.ActiveIndex().State)
+			}
+		})
+	}
+}
+
+func TestSetIndices(t *testing.T) {
+	exp := func(secs uint32) time.Time { return util.SecsToTime(secs) }
+	index := func(idx reservation.IndexNumber, state segment.IndexState,
+		expiration time.Time) segment.Index {
+
+		return segment.Index{Idx: idx, State: state, Expiration: expiration}
+	}
+	cases := map[string]struct {
+		indices   segment.Indices
+		expActive int // index number of the active index, or -1
+		expErr    bool
+	}{
+		"empty": {
+			indices:   segment.Indices{},
+			expActive: -1,
+		},
+		"no_active": {
+			indices: segment.Indices{
+				index(1, segment.IndexPending, exp(1)),
+				index(2, segment.IndexTemporary, exp(2)),
+			},
+			expActive: -1,
+		},
+		"active_first": {
+			indices: segment.Indices{
+				index(1, segment.IndexActive, exp(1)),
+				index(2, segment.IndexPending, exp(2)),
+			},
+			expActive: 1,
+		},
+		"active_not_first": {
+			indices: segment.Indices{
+				index(1, segment.IndexPending, exp(1)),
+				index(2, segment.IndexActive, exp(2)),
+			},
+			expErr: true,
+		},
+		"two_active": {
+			indices: segment.Indices{
+				index(1, segment.IndexActive, exp(1)),
+				index(2, segment.IndexActive, exp(2)),
+			},
+			expErr: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := segmenttest.NewReservation()
+			local := segment.Indices{index(0, segment.IndexActive, exp(1))}
+			r.Indices = local
+			r.SetActiveIndexForTesting(0)
+			err := r.SetIndices(tc.indices)
+			if tc.expErr {
+				require.Error(t, err)
+				require.Equal(t, local, r.Indices)
+				require.Equal(t, 0, r.GetActiveIndexForTesting())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.indices, r.Indices)
+			active, ok := r.ActiveIndexNumber()
+			if tc.expActive == -1 {
+				require.False(t, ok)
+			} else {
+				require.True(t, ok)
+				require.Equal(t, reservation.IndexNumber(tc.expActive), active)
+			}
+		})
+	}
+}
+
+func TestPathFingerprint(t *testing.T) {
+	r := segmenttest.NewRsv(segmenttest.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1,
+		"1-ff00:0:3"))
+	fingerprint := r.PathFingerprint()
+	require.NotEmpty(t, fingerprint)
+
+	// the indices do not affect the fingerprint
+	other := segmenttest.NewRsv(segmenttest.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1,
+		"1-ff00:0:3"),
+		segmenttest.AddIndex(0),
+		segmenttest.AddIndex(1))
+	require.Equal(t, fingerprint, other.PathFingerprint())
+
+	cases := map[string]*segment.Reservation{
+		"different interface": segmenttest.NewRsv(segmenttest.WithPath(
+			"1-ff00:0:1", 1, 1, "1-ff00:0:2", 3, 1, "1-ff00:0:3")),
+		"different AS": segmenttest.NewRsv(segmenttest.WithPath(
+			"1-ff00:0:1", 1, 1, "1-ff00:0:4", 2, 1, "1-ff00:0:3")),
+		"shorter": segmenttest.NewRsv(segmenttest.WithPath(
+			"1-ff00:0:1", 1, 1, "1-ff00:0:2")),
+		"reversed": func() *segment.Reservation {
+			rsv := r.Clone()
+			rsv.Steps = rsv.Steps.Reverse()
+			return rsv
+		}(),
+	}
+	for name, rsv := range cases {
+		name, rsv := name, rsv
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.NotEqual(t, fingerprint, rsv.PathFingerprint())
+		})
+	}
+}
+
+func TestCoversWindow(t *testing.T) {
+	now := util.SecsToTime(1000)
+	at := func(secs int) time.Time {
+		return now.Add(time.Duration(secs) * time.Second)
+	}
+	exp := segmenttest.WithExpiration
+	cases := map[string]struct {
+		rsv                *segment.Reservation
+		from               time.Time
+		to                 time.Time
+		expectedValidUntil time.Time
+		expected           bool
+	}{
+		"active_covers": {
+			rsv: segmenttest.NewRsv(
+				segmenttest.AddIndex(0, exp(at(100))),
+				segmenttest.WithActiveIndex(0)),
+			from:               now,
+			to:                 at(50),
+			expectedValidUntil: at(100),
+			expected:           true,
+		},
+		"successor_covers": {
+			rsv: segmenttest.NewRsv(
+				segmenttest.AddIndex(0, exp(at(100))),
+				segmenttest.AddIndex(1, exp(at(200))),
+				segmenttest.WithActiveIndex(0),
+				segmenttest.ConfirmAllIndices()),
+			from:               at(50),
+			to:                 at(150),
+			expectedValidUntil: at(200),
+			expected:           true,
+		},
+		"partially_covered": {
+			rsv: segmenttest.NewRsv(
+				segmenttest.AddIndex(0, exp(at(100))),
+				segmenttest.AddIndex(1, exp(at(200))),
+				segmenttest.WithActiveIndex(0),
+				segmenttest.ConfirmAllIndices()),
+			from:               at(50),
+			to:                 at(250),
+			expectedValidUntil: at(200),
+		},
+		"ends_at_expiration": {
+			rsv: segmenttest.NewRsv(
+				segmenttest.AddIndex(0, exp(at(100))),
+				segmenttest.WithActiveIndex(0)),
+			from:               now,
+			to:                 at(100),
+			expectedValidUntil: at(100),
+		},
+		"gap_unconfirmed_successor": {
+			rsv: segmenttest.NewRsv(
+				segmenttest.AddIndex(0, exp(at(100))),
+				segmenttest.AddIndex(1, exp(at(200))), // temporary, not usable
+				segmenttest.WithActiveIndex(0)),
+			from:               at(50),
+			to:                 at(150),
+			expectedValidUntil: at(100),
+		},
+		"uncovered_no_active": {
+			rsv: segmenttest.NewRsv(
+				segmenttest.AddIndex(0, exp(at(100))),
+				segmenttest.ConfirmAllIndices()),
+			from: now,
+			to:   at(50),
+		},
+		"uncovered_after_expiration": {
+			rsv: segmenttest.NewRsv(
+				segmenttest.AddIndex(0, exp(at(100))),
+				segmenttest.WithActiveIndex(0)),
+			from:               at(150),
+			to:                 at(180),
+			expectedValidUntil: at(100),
+		},
+		"inverted_window": {
+			rsv: segmenttest.NewRsv(
+				segmenttest.AddIndex(0, exp(at(100))),
+				segmenttest.WithActiveIndex(0)),
+			from:               at(50),
+			to:                 at(10),
+			expectedValidUntil: at(100),
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expectedValidUntil, tc.rsv.ValidUntil())
+			require.Equal(t, tc.expected, tc.rsv.CoversWindow(tc.from, tc.to))
+		})
+	}
+}
+
 func TestMaxBlockedBW(t *testing.T) {
 	r := segmenttest.NewReservation()
 	r.Indices = r.Indices[:0]
@@ -248,6 +824,37 @@ func TestDeriveColibriPathAtSource(t *testing.T) {
 				}},
 			},
 		},
+		"core": {
+			SegR: &segment.Reservation{
+				PathType:    reservation.CorePath,
+				Steps:       test.NewSteps("1-ff00:0:1", 1, 2, "1-ff00:0:2", 3, 4, "2-ff00:0:3"),
+				CurrentStep: 0,
+				ID:          *test.MustParseID("ff00:0:1", "01234567"),
+				Indices: segment.Indices{segment.Index{
+					Token: &reservation.Token{
+						InfoField: reservation.InfoField{
+							Idx:            1,
+							BWCls:          3,
+							ExpirationTick: reservation.TickFromTime(util.SecsToTime(1000)),
+						},
+						HopFields: []reservation.HopField{
+							{
+								Ingress: 0,
+								Egress:  1,
+							},
+							{
+								Ingress: 2,
+								Egress:  3,
+							},
+							{
+								Ingress: 4,
+								Egress:  0,
+							},
+						},
+					},
+				}},
+			},
+		},
 		"down": {
 			SegR: &segment.Reservation{
 				PathType:    reservation.DownPath,
@@ -261,18 +868,19 @@ func TestDeriveColibriPathAtSource(t *testing.T) {
 							BWCls:          3,
 							ExpirationTick: reservation.TickFromTime(util.SecsToTime(1000)),
 						},
+						// stacked in the reverse direction of the traffic
 						HopFields: []reservation.HopField{
 							{
-								Ingress: 0,
-								Egress:  4,
+								Ingress: 1,
+								Egress:  0,
 							},
 							{
 								Ingress: 3,
 								Egress:  2,
 							},
 							{
-								Ingress: 1,
-								Egress:  0,
+								Ingress: 0,
+								Egress:  4,
 							},
 						},
 					},
@@ -288,7 +896,9 @@ func TestDeriveColibriPathAtSource(t *testing.T) {
 			srcAS := tc.SegR.Steps.SrcIA().AS()
 			dstAS := tc.SegR.Steps.DstIA().AS()
 			test.TraverseASesAndStampMACs(t, tc.SegR, colibriKeys, srcAS, dstAS)
-			colPath := colibriMinimalToRegular(t, tc.SegR.DeriveColibriPathAtSource())
+			min, err := tc.SegR.DeriveColibriPathAtSource()
+			require.NoError(t, err)
+			colPath := colibriMinimalToRegular(t, min)
 			test.VerifyMACs(t, colPath, colibriKeys, srcAS, dstAS)
 		})
 	}
@@ -312,18 +922,19 @@ func TestDeriveColibriPathAtDestination(t *testing.T) {
 							BWCls:          3,
 							ExpirationTick: reservation.TickFromTime(util.SecsToTime(1000)),
 						},
+						// stacked in the reverse direction of the traffic
 						HopFields: []reservation.HopField{
 							{
-								Ingress: 0,
-								Egress:  1,
+								Ingress: 4,
+								Egress:  0,
 							},
 							{
 								Ingress: 2,
 								Egress:  3,
 							},
 							{
-								Ingress: 4,
-								Egress:  0,
+								Ingress: 0,
+								Egress:  1,
 							},
 						},
 					},
@@ -345,18 +956,19 @@ func TestDeriveColibriPathAtDestination(t *testing.T) {
 							BWCls:          3,
 							ExpirationTick: reservation.TickFromTime(util.SecsToTime(1000)),
 						},
+						// stacked in the reverse direction of the traffic
 						HopFields: []reservation.HopField{
-							{ // 110
-								Ingress: 0,
-								Egress:  1,
+							{ // 113
+								Ingress: 1,
+								Egress:  0,
 							},
 							{ // 111
 								Ingress: 41,
 								Egress:  2,
 							},
-							{ // 113
-								Ingress: 1,
-								Egress:  0,
+							{ // 110
+								Ingress: 0,
+								Egress:  1,
 							},
 						},
 					},
@@ -372,10 +984,142 @@ func TestDeriveColibriPathAtDestination(t *testing.T) {
 			srcAS := tc.SegR.Steps.SrcIA().AS() // e.g. 110 in the "longlegs"
 			dstAS := tc.SegR.Steps.DstIA().AS() // e.g. 113 in the "longlegs"
 			test.TraverseASesAndStampMACs(t, tc.SegR, colibriKeys, srcAS, dstAS)
-			colPath := colibriMinimalToRegular(t, tc.SegR.DeriveColibriPathAtDestination())
+			min, err := tc.SegR.DeriveColibriPathAtDestination()
+			require.NoError(t, err)
+			colPath := colibriMinimalToRegular(t, min)
 			// Because the SCION layer reverses the src and dst ASes, simulate it here:
 			srcAS, dstAS = dstAS, srcAS
-			test.VerifyMACs(t, colPath, colibriKeys, srcAS, dstAS)
+			// and the hop fields of the path follow the reversed steps, as do the keys:
+			reversedKeys := make([]cipher.Block, len(colibriKeys))
+			for i, key := range colibriKeys {
+				reversedKeys[len(colibriKeys)-1-i] = key
+			}
+			test.VerifyMACs(t, colPath, reversedKeys, srcAS, dstAS)
+		})
+	}
+}
+
+// TestDeriveColibriPathFollowsSteps stacks the hop fields in the token as the admission does, and
+// checks that the decoded colibri path follows the steps of the reservation, or the reversed steps
+// (those of the SCION path used by the initiator) when derived at the destination of a down-path.
+func TestDeriveColibriPathFollowsSteps(t *testing.T) {
+	downSteps := test.NewSteps("1-ff00:0:110", 1, 41, "1-ff00:0:111", 2, 1, "1-ff00:0:113")
+	cases := map[string]struct {
+		pathType      reservation.PathType
+		steps         base.PathSteps
+		atDestination bool
+	}{
+		"up": {
+			pathType: reservation.UpPath,
+			steps:    test.NewSteps("1-ff00:0:113", 1, 2, "1-ff00:0:111", 41, 1, "1-ff00:0:110"),
+		},
+		"core": {
+			pathType: reservation.CorePath,
+			steps:    test.NewSteps("1-ff00:0:110", 2, 1, "1-ff00:0:120", 3, 4, "2-ff00:0:210"),
+		},
+		"down_at_source": {
+			pathType: reservation.DownPath,
+			steps:    downSteps,
+		},
+		"down_at_destination": {
+			pathType:      reservation.DownPath,
+			steps:         downSteps,
+			atDestination: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rsv := &segment.Reservation{
+				PathType: tc.pathType,
+				Steps:    tc.steps,
+				ID:       *test.MustParseID("ff00:0:110", "01234567"),
+				Indices: segment.Indices{segment.Index{
+					Token: &reservation.Token{
+						InfoField: reservation.InfoField{
+							Idx:            1,
+							BWCls:          3,
+							ExpirationTick: reservation.TickFromTime(util.SecsToTime(1000)),
+							PathType:       tc.pathType,
+						},
+					},
+				}},
+			}
+			// the response of the admission travels from the last step to the first one
+			tok := rsv.Indices[0].Token
+			for i := len(tc.steps) - 1; i >= 0; i-- {
+				tok.AddNewHopField(&reservation.HopField{
+					Ingress: tc.steps[i].Ingress,
+					Egress:  tc.steps[i].Egress,
+				})
+			}
+			derive := rsv.DeriveColibriPathAtSource
+			expected := tc.steps
+			if tc.atDestination {
+				rsv.CurrentStep = len(tc.steps) - 1
+				derive = rsv.DeriveColibriPathAtDestination
+				expected = tc.steps.Reverse()
+			}
+			min, err := derive()
+			require.NoError(t, err)
+			require.Equal(t, expected.SrcIA(), min.Src.IA)
+			require.Equal(t, expected.DstIA(), min.Dst.IA)
+
+			buff := make([]byte, min.Len())
+			require.NoError(t, min.SerializeTo(buff))
+			decoded := &colpath.ColibriPath{}
+			require.NoError(t, decoded.DecodeFromBytes(buff))
+			require.Equal(t, uint8(0), decoded.InfoField.CurrHF)
+			require.Equal(t, tc.atDestination, decoded.InfoField.R)
+			require.Len(t, decoded.HopFields, len(expected))
+			for i, step := range expected {
+				require.Equal(t, step.Ingress, decoded.HopFields[i].IngressId, "hop %d", i)
+				require.Equal(t, step.Egress, decoded.HopFields[i].EgressId, "hop %d", i)
+			}
+		})
+	}
+}
+
+func TestDeriveColibriPathNil(t *testing.T) {
+	cases := map[string]struct {
+		SegR        *segment.Reservation
+		expectError bool
+	}{
+		"no_indices": {
+			SegR: segmenttest.NewRsv(segmenttest.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2")),
+		},
+		"no_active_index": {
+			SegR: segmenttest.NewRsv(segmenttest.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				segmenttest.AddIndex(0),
+				segmenttest.ConfirmAllIndices()),
+		},
+		"no_hop_fields": {
+			// the token of the active index has no hop fields: invalid colibri path
+			SegR: segmenttest.NewRsv(segmenttest.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				segmenttest.AddIndex(0),
+				segmenttest.WithActiveIndex(0)),
+			expectError: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			derivations := map[string]func() (*colpath.ColibriPathMinimal, error){
+				"path":        tc.SegR.DeriveColibriPath,
+				"source":      tc.SegR.DeriveColibriPathAtSource,
+				"destination": tc.SegR.DeriveColibriPathAtDestination,
+			}
+			for kind, derive := range derivations {
+				p, err := derive()
+				require.Nil(t, p, kind)
+				if tc.expectError {
+					require.Error(t, err, kind)
+				} else {
+					require.NoError(t, err, kind)
+				}
+			}
 		})
 	}
 }
@@ -154,7 +154,10 @@ func WithNoActiveIndex() ReservationMod {
 				Token:      act.Token,
 			}
 			// the active reservation is always the first one: place newIdx there
-			rsv.Indices = append(append(rsv.Indices[:0:0], newIdx), rsv.Indices...)
+			indices := append(append(rsv.Indices[:0:0], newIdx), rsv.Indices...)
+			if err := rsv.SetIndices(indices); err != nil {
+				panic(err)
+			}
 			rsv.SetIndexConfirmed(newIdx.Idx)
 		}
 		return rsv
@@ -215,7 +218,8 @@ func ModIndex(idx reservation.IndexNumber, mods ...IndexMod) ReservationMod {
 	}
 }
 
-// WithBW changes the min, max and/or alloc BW if their values are > 0.
+// WithBW changes the min, max and/or alloc BW if their values are > 0. The alloc BW is also
+// set to the token of the index, if any.
 func WithBW(min, max, alloc int) IndexMod {
 	return func(index *segment.Index) {
 		if min > 0 {
@@ -226,6 +230,9 @@ func WithBW(min, max, alloc int) IndexMod {
 		}
 		if alloc > 0 {
 			index.AllocBW = reservation.BWCls(alloc)
+			if index.Token != nil {
+				index.Token.BWCls = index.AllocBW
+			}
 		}
 	}
 }
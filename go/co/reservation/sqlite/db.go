@@ -46,11 +46,16 @@ type Backend struct {
 }
 
 var _ backend.DB = (*Backend)(nil)
+var _ backend.RawDumper = (*Backend)(nil)
 
 // New returns a new SQLite backend opening a database at the given path. If
-// no database exists a new database is be created. If the schema version of the
-// stored database is different from the one in schema.go, an error is returned.
+// no database exists a new database is be created. A database with an older schema version
+// is migrated if possible. If the schema version of the stored database is still different
+// from the one in schema.go, an error is returned.
 func New(path string) (*Backend, error) {
+	if err := migrate(path); err != nil {
+		return nil, err
+	}
 	db, err := db.NewSqlite(path, Schema, SchemaVersion)
 	if err != nil {
 		return nil, err
@@ -63,6 +68,46 @@ func New(path string) (*Backend, error) {
 	}, nil
 }
 
+// migrate upgrades the schema of an existing database at path, one version at a time, for as
+// long as there is a migration for its version. Each migration is applied in a transaction
+// together with the new version number.
+func migrate(path string) error {
+	sqlDB, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return serrors.WrapStr("opening database to migrate", err, "path", path)
+	}
+	defer sqlDB.Close()
+	var version int
+	if err := sqlDB.QueryRow("PRAGMA user_version;").Scan(&version); err != nil {
+		return serrors.WrapStr("checking schema version", err, "path", path)
+	}
+	for version != 0 && version < SchemaVersion {
+		migration, ok := migrations[version]
+		if !ok {
+			// the version mismatch is reported when opening the database
+			return nil
+		}
+		tx, err := sqlDB.Begin()
+		if err != nil {
+			return serrors.WrapStr("migrating database", err, "path", path)
+		}
+		if _, err := tx.Exec(migration); err != nil {
+			tx.Rollback()
+			return serrors.WrapStr("migrating database", err, "path", path, "from", version)
+		}
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", version+1)); err != nil {
+			tx.Rollback()
+			return serrors.WrapStr("writing schema version", err, "path", path)
+		}
+		if err := tx.Commit(); err != nil {
+			return serrors.WrapStr("migrating database", err, "path", path, "from", version)
+		}
+		log.Info("COLIBRI database migrated", "path", path, "from", version, "to", version+1)
+		version++
+	}
+	return nil
+}
+
 // SetMaxOpenConns sets the maximum number of open connections.
 func (b *Backend) SetMaxOpenConns(maxOpenConns int) {
 	b.db.SetMaxOpenConns(maxOpenConns)
@@ -632,6 +677,53 @@ func (x *executor) DebugCountE2ERsvs(ctx context.Context) (int, error) {
 	return count, nil
 }
 
+// DebugSegmentRsvRow returns the fields of the segment reservation and its indices as stored,
+// without building the reservation from them. It returns nil if there is no such reservation.
+func (x *executor) DebugSegmentRsvRow(ctx context.Context, ID *reservation.ID) (
+	*backend.SegmentRsvRow, error) {
+
+	if !ID.IsSegmentID() {
+		return nil, serrors.New("wrong suffix", "suffix", hex.EncodeToString(ID.Suffix))
+	}
+	const query = `SELECT ROWID,id_as,id_suffix,ingress,egress,path_type,steps,current_step,
+		transportPath,end_props,traffic_split,src_ia,dst_ia,active_index,index_history
+		FROM seg_reservation WHERE id_as = ? AND id_suffix = ?`
+	var row backend.SegmentRsvRow
+	var srcIA, dstIA sql.NullInt64
+	err := x.db.QueryRowContext(ctx, query, ID.ASID, binary.BigEndian.Uint32(ID.Suffix)).Scan(
+		&row.RowID, &row.IDAS, &row.IDSuffix, &row.Ingress, &row.Egress, &row.PathType,
+		&row.Steps, &row.CurrentStep, &row.TransportPath, &row.EndProps, &row.TrafficSplit,
+		&srcIA, &dstIA, &row.ActiveIndex, &row.IndexHistory)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, db.NewReadError("cannot read segment reservation", err)
+	}
+	row.SrcIA, row.DstIA = uint64(srcIA.Int64), uint64(dstIA.Int64)
+
+	const indexQuery = `SELECT index_number,expiration,state,min_bw,max_bw,alloc_bw,token
+		FROM seg_index WHERE reservation = ? ORDER BY index_number`
+	rows, err := x.db.QueryContext(ctx, indexQuery, row.RowID)
+	if err != nil {
+		return nil, db.NewReadError("cannot list indices", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var index backend.SegmentIndexRow
+		err := rows.Scan(&index.IndexNumber, &index.Expiration, &index.State, &index.MinBW,
+			&index.MaxBW, &index.AllocBW, &index.Token)
+		if err != nil {
+			return nil, db.NewReadError("could not get index values", err)
+		}
+		row.Indices = append(row.Indices, index)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
 // newSegSuffix finds a segment reservation ID suffix not being used at the moment. Should be called
 // inside a transaction so the suffix is not used in the meantime, or fail.
 func newSegSuffix(ctx context.Context, x db.Sqler, ASID addr.AS) (uint32, error) {
@@ -666,18 +758,21 @@ func upsertNewSegReservation(ctx context.Context, x db.Sqler, rsv *segment.Reser
 	if err != nil {
 		return err
 	}
+	rawHistory := rsv.History().ToRaw()
 	const query = `INSERT INTO seg_reservation (id_as, id_suffix,
 		ingress, egress, path_type, steps, current_step, transportPath, end_props,
-		traffic_split, src_ia, dst_ia, active_index)
-		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)
+		traffic_split, src_ia, dst_ia, active_index, index_history)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)
 		ON CONFLICT(id_as,id_suffix) DO UPDATE
 		SET ingress = ?, egress = ?, path_type = ?, steps = ?, current_step = ?, transportPath = ?,
-		end_props = ?, traffic_split = ?, src_ia = ?, dst_ia = ?, active_index = ?`
+		end_props = ?, traffic_split = ?, src_ia = ?, dst_ia = ?, active_index = ?,
+		index_history = ?`
 	_, err = x.ExecContext(
 		ctx, query, rsv.ID.ASID, binary.BigEndian.Uint32(rsv.ID.Suffix), rsv.Ingress(), rsv.Egress(),
 		rsv.PathType, rawSteps, rsv.CurrentStep, transportPath, rsv.PathEndProps, rsv.TrafficSplit, rsv.Steps.SrcIA(),
-		rsv.Steps.DstIA(), activeIndex, rsv.Ingress(), rsv.Egress(), rsv.PathType, rawSteps, rsv.CurrentStep, transportPath,
-		rsv.PathEndProps, rsv.TrafficSplit, rsv.Steps.SrcIA(), rsv.Steps.DstIA(), activeIndex)
+		rsv.Steps.DstIA(), activeIndex, rawHistory, rsv.Ingress(), rsv.Egress(), rsv.PathType,
+		rawSteps, rsv.CurrentStep, transportPath, rsv.PathEndProps, rsv.TrafficSplit,
+		rsv.Steps.SrcIA(), rsv.Steps.DstIA(), activeIndex, rawHistory)
 	if err != nil {
 		return err
 	}
@@ -735,13 +830,14 @@ type rsvFields struct {
 	EndProps     int
 	TrafficSplit int
 	ActiveIndex  int
+	IndexHistory []byte
 }
 
 func getSegReservations(ctx context.Context, x db.Sqler, condition string, params ...interface{}) (
 	[]*segment.Reservation, error) {
 
 	const queryTmpl = `SELECT ROWID,id_as,id_suffix,ingress,egress,path_type,steps,current_step,
-		transportPath,end_props,traffic_split,active_index FROM seg_reservation %s`
+		transportPath,end_props,traffic_split,active_index,index_history FROM seg_reservation %s`
 	query := fmt.Sprintf(queryTmpl, condition)
 
 	rows, err := x.QueryContext(ctx, query, params...)
@@ -754,7 +850,7 @@ func getSegReservations(ctx context.Context, x db.Sqler, condition string, param
 	for rows.Next() {
 		var f rsvFields
 		err := rows.Scan(&f.RowID, &f.AsID, &f.Suffix, &f.Ingress, &f.Egress, &f.PathType, &f.Steps, &f.CurrentStep,
-			&f.TrasportPath, &f.EndProps, &f.TrafficSplit, &f.ActiveIndex)
+			&f.TrasportPath, &f.EndProps, &f.TrafficSplit, &f.ActiveIndex, &f.IndexHistory)
 		if err != nil {
 			return nil, err
 		}
@@ -804,7 +900,14 @@ func buildSegRsvFromFields(ctx context.Context, x db.Sqler, fields *rsvFields) (
 	rsv.TransportPath = transportPath
 	rsv.PathEndProps = reservation.PathEndProps(fields.EndProps)
 	rsv.TrafficSplit = reservation.SplitCls(fields.TrafficSplit)
-	rsv.Indices = indices
+	if err := rsv.SetIndices(indices); err != nil {
+		return nil, err
+	}
+	history, err := segment.IndexHistoryFromRaw(fields.IndexHistory)
+	if err != nil {
+		return nil, err
+	}
+	rsv.SetHistory(history)
 	if fields.ActiveIndex != -1 {
 		if err := rsv.SetIndexActive(reservation.IndexNumber(fields.ActiveIndex)); err != nil {
 			return nil, err
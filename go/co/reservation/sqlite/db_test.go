@@ -16,14 +16,18 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"encoding/binary"
 	"io/ioutil"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	base "github.com/scionproto/scion/go/co/reservation"
 	"github.com/scionproto/scion/go/co/reservation/reservationdbtest"
 	"github.com/scionproto/scion/go/co/reservation/segment"
 	"github.com/scionproto/scion/go/co/reservation/test"
@@ -39,6 +43,59 @@ func TestReservationDBSuite(t *testing.T) {
 	reservationdbtest.TestDB(t, func() backend.DB { return newDB(t) })
 }
 
+func TestMigrateFromVersion1(t *testing.T) {
+	f, err := ioutil.TempFile("", "colibri_db_test.*.db")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	defer func() {
+		os.Remove(f.Name())
+		os.Remove(f.Name() + "-shm")
+		os.Remove(f.Name() + "-wal")
+	}()
+	path := "file:" + f.Name()
+
+	// a database created with version 1 of the schema, which had no index history
+	oldSchema := strings.Replace(Schema, "index_history BLOB,", "", 1)
+	require.NotEqual(t, Schema, oldSchema)
+	old, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	_, err = old.Exec(oldSchema)
+	require.NoError(t, err)
+	_, err = old.Exec("PRAGMA user_version = 1")
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	db, err := New(path)
+	require.NoError(t, err)
+	defer db.Close()
+	var version int
+	err = db.db.QueryRow("PRAGMA user_version;").Scan(&version)
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, version)
+
+	// the migrated database stores the reservations with their history
+	ctx := context.Background()
+	rsv := segment.NewReservation(xtest.MustParseAS("ff00:0:111"))
+	rsv.PathType = reservation.UpPath
+	rsv.Steps = test.NewSteps("1-ff00:0:111", 1, 1, "1-ff00:0:2")
+	rsv.TrafficSplit = 2
+	rsv.PathEndProps = reservation.StartLocal
+	_, err = rsv.NewIndex(0, util.SecsToTime(100), 1, 3, 2, 0, reservation.UpPath)
+	require.NoError(t, err)
+	err = db.NewSegmentRsv(ctx, rsv)
+	require.NoError(t, err)
+	rsvs, err := db.GetAllSegmentRsvs(ctx)
+	require.NoError(t, err)
+	require.Len(t, rsvs, 1)
+
+	// versions without a migration are still rejected
+	_, err = db.db.Exec("PRAGMA user_version = 42")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	_, err = New(path)
+	require.Error(t, err)
+}
+
 func TestNewSegSuffix(t *testing.T) {
 	ctx := context.Background()
 	asid := xtest.MustParseAS("ff00:0:1")
@@ -57,6 +114,75 @@ func TestNewSegSuffix(t *testing.T) {
 	require.False(t, isSuffixInDB(t, db, asid, suffix))
 }
 
+func TestDebugSegmentRsvRow(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+
+	rsv := segment.NewReservation(xtest.MustParseAS("ff00:0:111"))
+	rsv.PathType = reservation.UpPath
+	rsv.Steps = test.NewSteps("1-ff00:0:111", 1, 1, "1-ff00:0:2")
+	rsv.TransportPath = test.NewColPathMin(rsv.Steps)
+	rsv.TrafficSplit = 2
+	rsv.PathEndProps = reservation.StartLocal
+	_, err := rsv.NewIndex(0, util.SecsToTime(100), 1, 3, 2, 0, reservation.UpPath)
+	require.NoError(t, err)
+	_, err = rsv.NewIndex(1, util.SecsToTime(200), 1, 5, 4, 0, reservation.UpPath)
+	require.NoError(t, err)
+	require.NoError(t, rsv.SetIndexConfirmed(0))
+	require.NoError(t, rsv.SetIndexActive(0))
+	err = db.NewSegmentRsv(ctx, rsv)
+	require.NoError(t, err)
+
+	row, err := db.DebugSegmentRsvRow(ctx, &rsv.ID)
+	require.NoError(t, err)
+	require.NotNil(t, row)
+	require.Equal(t, uint64(rsv.ID.ASID), row.IDAS)
+	require.Equal(t, binary.BigEndian.Uint32(rsv.ID.Suffix), row.IDSuffix)
+	require.Equal(t, uint16(0), row.Ingress)
+	require.Equal(t, uint16(1), row.Egress)
+	require.Equal(t, int(reservation.UpPath), row.PathType)
+	require.Equal(t, rsv.Steps.ToRaw(), row.Steps)
+	require.Equal(t, 0, row.CurrentStep)
+	transport, err := base.ColPathToRaw(rsv.TransportPath)
+	require.NoError(t, err)
+	require.Equal(t, transport, row.TransportPath)
+	require.Equal(t, int(reservation.StartLocal), row.EndProps)
+	require.Equal(t, 2, row.TrafficSplit)
+	require.Equal(t, uint64(xtest.MustParseIA("1-ff00:0:111")), row.SrcIA)
+	require.Equal(t, uint64(xtest.MustParseIA("1-ff00:0:2")), row.DstIA)
+	require.Equal(t, 0, row.ActiveIndex)
+	require.Len(t, row.Indices, 2)
+	for i, index := range rsv.Indices {
+		require.Equal(t, uint32(index.Idx), row.Indices[i].IndexNumber)
+		require.Equal(t, util.TimeToSecs(index.Expiration), row.Indices[i].Expiration)
+		require.Equal(t, uint32(index.State), row.Indices[i].State)
+		require.Equal(t, uint32(index.MinBW), row.Indices[i].MinBW)
+		require.Equal(t, uint32(index.MaxBW), row.Indices[i].MaxBW)
+		require.Equal(t, uint32(index.AllocBW), row.Indices[i].AllocBW)
+		require.Equal(t, index.Token.ToRaw(), row.Indices[i].Token)
+	}
+
+	// the rows are returned even if they cannot be used to build a reservation
+	asid := xtest.MustParseAS("ff00:0:222")
+	addSegRsvRows(t, db, asid, 1, 1)
+	id, err := reservation.NewID(asid, []byte{0, 0, 0, 1})
+	require.NoError(t, err)
+	row, err = db.DebugSegmentRsvRow(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, row)
+	require.Empty(t, row.Steps)
+	require.Zero(t, row.SrcIA)
+	require.Equal(t, -1, row.ActiveIndex)
+	require.Empty(t, row.Indices)
+
+	// not found
+	id, err = reservation.NewID(asid, []byte{0, 0, 0, 2})
+	require.NoError(t, err)
+	row, err = db.DebugSegmentRsvRow(ctx, id)
+	require.NoError(t, err)
+	require.Nil(t, row)
+}
+
 // TestTransactions checks that the read transactions have an independent and consistent view
 // of the database, as if it had taken a snapshot of it when the transaction started.
 // A read transaction is one that only performs SELECT operations.
@@ -18,7 +18,8 @@ const (
 	// SchemaVersion is the version of the SQLite schema understood by this backend.
 	// Whenever changes to the schema are made, this version number should be increased
 	// to prevent data corruption between incompatible database schemas.
-	SchemaVersion = 1
+	// Whenever possible, add a migration from the previous version to migrations.
+	SchemaVersion = 2
 	// Schema is the SQLite database layout.
 	Schema = `CREATE TABLE seg_reservation (
 		ROWID	INTEGER,
@@ -35,6 +36,7 @@ const (
 		src_ia INTEGER,
 		dst_ia INTEGER,
 		active_index	INTEGER NOT NULL,
+		index_history BLOB,
 		PRIMARY KEY(ROWID),
 		UNIQUE(id_as,id_suffix)
 	);
@@ -176,3 +178,9 @@ const (
 		"valid_until"
 	);`
 )
+
+// migrations upgrade a database with the schema version of the key to the next version.
+var migrations = map[int]string{
+	// version 2 keeps the history of the indices of the segment reservations
+	1: `ALTER TABLE seg_reservation ADD COLUMN index_history BLOB;`,
+}
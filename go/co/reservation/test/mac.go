@@ -90,6 +90,8 @@ func VerifyMACs(t *testing.T, path *colpath.ColibriPath, colibriKeysPerAS []ciph
 }
 
 // TraverseASesAndStampMACs computes the MACs and writes them into each one of the hop fields.
+// colibriKeysPerAS follows the order of the ASes in the SegR, thus the hop fields of down-path
+// SegRs, stacked in the reverse direction of the traffic, use the keys in reverse order.
 func TraverseASesAndStampMACs(t *testing.T, r *segment.Reservation, colibriKeysPerAS []cipher.Block,
 	srcAS, dstAS addr.AS) {
 
@@ -102,8 +104,12 @@ func TraverseASesAndStampMACs(t *testing.T, r *segment.Reservation, colibriKeysP
 	require.LessOrEqual(t, r.CurrentStep, N)
 
 	for i := 0; i < N; i++ {
+		step := i
+		if r.PathType == reservation.DownPath {
+			step = N - 1 - i
+		}
 		index.Token.HopFields[i].Mac = computeMAC(t,
-			colibriKeysPerAS[i],
+			colibriKeysPerAS[step],
 			r.ID.Suffix, index.Token.ExpirationTick,
 			index.Idx,
 			index.AllocBW,
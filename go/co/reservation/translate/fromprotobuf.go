@@ -291,7 +291,7 @@ func SplitCls(msg uint32) (col.SplitCls, error) {
 	if uint32(sc) != msg {
 		return 0, serrors.New("split class is out of range", "class", msg)
 	}
-	return sc, nil
+	return sc, sc.Validate()
 }
 
 func ID(msg *colpb.ReservationID) *col.ID {
@@ -149,6 +149,45 @@ type Transaction interface {
 	Rollback() error
 }
 
+// RawDumper is implemented by the backends that can return the stored fields of a segment
+// reservation without interpreting them, to debug inconsistencies between the DB and memory.
+type RawDumper interface {
+	// DebugSegmentRsvRow returns the stored fields of the segment reservation and its indices,
+	// or nil if there is no such reservation.
+	DebugSegmentRsvRow(ctx context.Context, ID *reservation.ID) (*SegmentRsvRow, error)
+}
+
+// SegmentRsvRow contains the fields of a segment reservation as stored.
+type SegmentRsvRow struct {
+	RowID         int64
+	IDAS          uint64
+	IDSuffix      uint32
+	Ingress       uint16
+	Egress        uint16
+	PathType      int
+	Steps         []byte // serialized steps
+	CurrentStep   int
+	TransportPath []byte // serialized transport path, empty if none
+	EndProps      int
+	TrafficSplit  int
+	SrcIA         uint64
+	DstIA         uint64
+	ActiveIndex   int // -1 if there is no active index
+	IndexHistory  []byte
+	Indices       []SegmentIndexRow
+}
+
+// SegmentIndexRow contains the fields of an index of a segment reservation as stored.
+type SegmentIndexRow struct {
+	IndexNumber uint32
+	Expiration  uint32 // in seconds since the epoch
+	State       uint32
+	MinBW       uint32
+	MaxBW       uint32
+	AllocBW     uint32
+	Token       []byte // serialized token
+}
+
 // DB is the interface for any reservation backend.
 type DB interface {
 	BeginTransaction(ctx context.Context, opts *sql.TxOptions) (Transaction, error)
@@ -179,6 +179,21 @@ func (mr *MockStoreMockRecorder) DeleteExpiredIndices(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIndices", reflect.TypeOf((*MockStore)(nil).DeleteExpiredIndices), arg0, arg1)
 }
 
+// DryRunSegmentReservation mocks base method.
+func (m *MockStore) DryRunSegmentReservation(arg0 context.Context, arg1 *segment.SetupReq) (reservation0.BWCls, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DryRunSegmentReservation", arg0, arg1)
+	ret0, _ := ret[0].(reservation0.BWCls)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DryRunSegmentReservation indicates an expected call of DryRunSegmentReservation.
+func (mr *MockStoreMockRecorder) DryRunSegmentReservation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DryRunSegmentReservation", reflect.TypeOf((*MockStore)(nil).DryRunSegmentReservation), arg0, arg1)
+}
+
 // GetReservationsAtSource mocks base method.
 func (m *MockStore) GetReservationsAtSource(arg0 context.Context) ([]*segment.Reservation, error) {
 	m.ctrl.T.Helper()
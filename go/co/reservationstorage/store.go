@@ -83,6 +83,9 @@ type Store interface {
 	ListStitchableSegments(ctx context.Context, dst addr.IA) (*colibri.StitchableSegments, error)
 	// InitSegmentReservation starts a new segment reservation.
 	InitSegmentReservation(ctx context.Context, req *sgt.SetupReq) error
+	// DryRunSegmentReservation computes the admission of a renewal at this AS and returns the
+	// bandwidth that would be allocated, without persisting anything.
+	DryRunSegmentReservation(ctx context.Context, req *sgt.SetupReq) (reservation.BWCls, error)
 	// InitConfirmSegmentReservation initiates a confirm request.
 	InitConfirmSegmentReservation(ctx context.Context, req *base.Request,
 		steps base.PathSteps, transport *colpath.ColibriPathMinimal) (
@@ -16,9 +16,16 @@ package reservationstore
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	base "github.com/scionproto/scion/go/co/reservation"
 	"github.com/scionproto/scion/go/co/reservation/conf"
@@ -27,9 +34,11 @@ import (
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/periodic"
 	"github.com/scionproto/scion/go/lib/serrors"
 	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
 )
 
 // sleepAtLeast is the time duration that the keeper will sleep at a minimum, even
@@ -38,6 +47,29 @@ const sleepAtLeast = 4 * time.Second
 
 const sleepAtMost = 5 * time.Minute
 
+// sleepStoreUnavailable is the time the keeper waits before retrying a run deferred because
+// the store was unavailable.
+const sleepStoreUnavailable = 2 * time.Second
+
+// maxKeepWorkers is the maximum number of entries the keeper processes concurrently.
+const maxKeepWorkers = 16
+
+// activationRetries is the number of times the keeper retries an index activation that
+// failed without being rejected, before waiting for its next run.
+const activationRetries = 2
+
+// activationRetryBackoff is the wait before the first activation retry. It doubles with
+// each subsequent retry.
+const activationRetryBackoff = 100 * time.Millisecond
+
+// errRequestRejected indicates that the request reached the path and was rejected, as opposed
+// to failing to reach it.
+var errRequestRejected = serrors.New("request rejected")
+
+// errIndexNotCompliant indicates that the index to activate does not satisfy the bandwidth
+// of the configuration, e.g. because the configuration changed after creating the index.
+var errIndexNotCompliant = serrors.New("index not compliant with the configuration")
+
 // min validity in the future for the reservations when checking their compliance,
 // the bigger the value, the more probable it is not to break continuity.
 // Typically this value would be twice the max. sleep period, to ensure no index would
@@ -49,6 +81,21 @@ const minDuration = 2 * sleepAtMost
 // This value would typically be equal to twice minDuration.
 const newIndexMinDuration = 2 * minDuration
 
+// maxWakeupJitter bounds the random amount by which the wakeup of each entry is brought forward,
+// so that reservations created at the same time are not renewed all in the same run.
+// It must be smaller than newIndexMinDuration - minDuration, so that the entries still wake up
+// while they are compliant.
+const maxWakeupJitter = minDuration / 4
+
+// defaultSetupBurst and defaultSetupInterval limit the setups of new reservations to the same
+// destination, unless configured otherwise.
+const defaultSetupBurst = 3
+const defaultSetupInterval = time.Minute
+
+// defaultPathRefreshInterval is how often the paths of the existing reservations are checked,
+// unless configured otherwise.
+const defaultPathRefreshInterval = 5 * time.Minute
+
 // ServiceFacilitator defines a minimal interface that has to be implemented to be
 // usable by the keeper.
 type ServiceFacilitator interface {
@@ -61,6 +108,13 @@ type ServiceFacilitator interface {
 		*colpath.ColibriPathMinimal,
 		bool,
 	) error
+	TeardownRequest(
+		context.Context,
+		*base.Request,
+		base.PathSteps,
+		*colpath.ColibriPathMinimal,
+		bool,
+	) error
 	GetReservationsAtSource(ctx context.Context) ([]*segment.Reservation, error)
 	DeleteExpiredIndices(ctx context.Context) error
 }
@@ -70,41 +124,96 @@ type ServiceFacilitator interface {
 // The keeper tries to match existing reservations with configured entries.
 // If no match is found, a new reservation will be created.
 type keeper struct {
+	runMu sync.Mutex // serializes the runs of OneShot, and the imports replacing their entries
+	// mu guards entries, sleepUntil, keptCount and the keptSeq of the entries. It is never
+	// held across remote calls: the entries are kept holding their own lock.
+	mu         sync.Mutex
 	now        func() time.Time
 	localIA    addr.IA
-	sleepUntil time.Time // nothing to do in the keeper until this time
+	sleepUntil time.Time // nothing to do in the keeper until this time (as of the last run)
+	keptCount  uint64    // number of times the keeper ran for any entry, to order the entries
 	provider   ServiceFacilitator
 	entries    []*entry
+	maxWorkers int                                   // if not zero, replaces maxKeepWorkers
+	maxEntries int                                   // if not zero, entries kept per run
+	jitter     func(max time.Duration) time.Duration // if nil, uniformly random in [0,max)
+	setups     *setupLimiter                         // if nil, setups are not limited
+	// pathRefresh, if not zero, is the interval between the checks of the paths of an entry.
+	pathRefresh time.Duration
+	// logFullPaths logs the paths of the reservations without anonymizing them.
+	logFullPaths bool
+	// cleanupDone is closed once the deletion of expired indices at startup has finished.
+	cleanupDone chan struct{}
+	// AdmissionHook, if not nil, is consulted before each attempt to set up a new reservation.
+	AdmissionHook AdmissionHook
+}
+
+// AdmissionHook lets an external policy (e.g. a quota service) decide whether the keeper can
+// request a new reservation for the configuration over the path. Returning false skips the
+// path, and returning an error aborts the setup of the reservation.
+type AdmissionHook func(ctx context.Context, conf *configuration, p snet.Path) (bool, error)
+
+// wakeupJitter returns the amount of time to bring the wakeup of an entry forward.
+func (k *keeper) wakeupJitter() time.Duration {
+	if k.jitter != nil {
+		return k.jitter(maxWakeupJitter)
+	}
+	return time.Duration(rand.Int63n(int64(maxWakeupJitter)))
+}
+
+// workers returns the maximum number of entries kept concurrently.
+func (k *keeper) workers() int {
+	if k.maxWorkers > 0 {
+		return k.maxWorkers
+	}
+	return maxKeepWorkers
 }
 
 type entry struct {
-	conf *configuration
-	rsv  *segment.Reservation
+	mu       sync.Mutex // held while keeping or modifying the entry, also across remote calls
+	conf     *configuration
+	rsv      *segment.Reservation
+	maxBW    reservation.BWCls    // if not zero, lowers the configured max. bw (after a downgrade)
+	replaced *segment.Reservation // if not nil, to be torn down once rsv is active (migration)
+	// pathsChecked is the last time the paths to the destination were checked for this entry.
+	pathsChecked time.Time
+	// keptSeq is the value of keeper.keptCount the last time the keeper ran for this entry,
+	// or zero if it never did. Guarded by keeper.mu.
+	keptSeq uint64
+}
+
+// MaxBW returns the maximum bandwidth to request for this entry: the configured one, or
+// the lower one set by a downgrade.
+func (e *entry) MaxBW() reservation.BWCls {
+	if e.maxBW != 0 && e.maxBW < e.conf.maxBW {
+		return e.maxBW
+	}
+	return e.conf.maxBW
 }
 
 // PrepareSetupRequest creates a valid setup request with the steps always in the direction of
 // the traffic of the SegR, and the transport path always in the direction of the next
 // colibri service (thus for down-path SegRs the transport will be in the reverse wrt the steps).
+// Only down-path SegRs are initiated at their last step: core and up-path SegRs carry traffic
+// away from this AS, and start at their first step.
+// The pathSteps are the steps of the SCION path of the SegR, as returned by StepsFromSnetPaths.
 func (e *entry) PrepareSetupRequest(now, expTime time.Time, localAS addr.AS,
-	p snet.Path) *segment.SetupReq {
+	pathSteps base.PathSteps) (*segment.SetupReq, error) {
 
-	steps, err := base.StepsFromSnet(p)
-	if err != nil {
-		log.Info("error in SCION path, cannot convert to steps", "err", err, "path", p)
-		panic(err)
+	if len(pathSteps) < 2 {
+		return nil, serrors.New("path too short for a reservation", "steps", pathSteps)
 	}
+	steps := e.segmentSteps(pathSteps)
 	currentStep := 0
-
-	// if the SegR is of down-path type, reverse the steps
 	if e.conf.pathType == reservation.DownPath {
-		steps = steps.Reverse()
 		currentStep = len(steps) - 1
 	}
 
 	id, _ := reservation.NewID(localAS, make([]byte, reservation.IDSuffixSegLen))
 	return &segment.SetupReq{
 		Request:        *base.NewRequest(now, id, 0, len(steps)),
-		ExpirationTime: expTime,
+		ExpirationTime: e.conf.clampExpiration(now, expTime),
+		RLC:            e.conf.rlc,
 		PathType:       e.conf.pathType,
 		MinBW:          e.conf.minBW,
 		MaxBW:          e.conf.maxBW,
@@ -114,79 +223,295 @@ func (e *entry) PrepareSetupRequest(now, expTime time.Time, localAS addr.AS,
 		Steps:          steps,
 		CurrentStep:    currentStep,
 		TransportPath:  nil, // new setups are not transported in colibri paths
+	}, nil
+}
+
+// segmentSteps returns the steps that a SegR of this entry would have over a SCION path with
+// the steps pathSteps, i.e. pathSteps reversed if the SegR is of down-path type. Core-path
+// SegRs are not reversed.
+func (e *entry) segmentSteps(pathSteps base.PathSteps) base.PathSteps {
+	if e.conf.pathType == reservation.DownPath {
+		return pathSteps.Reverse()
 	}
+	return pathSteps
+}
+
+// UsesAnyOf returns true if the steps of the reservation of this entry are the ones of any of
+// the SCION paths with the steps pathSteps.
+func (e *entry) UsesAnyOf(pathSteps []base.PathSteps) bool {
+	for _, steps := range pathSteps {
+		if e.segmentSteps(steps).Equal(e.rsv.Steps) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertiblePaths returns the paths that can be converted to steps, and their steps, at the
+// same positions. The other paths are logged and discarded.
+func (e *entry) convertiblePaths(paths []snet.Path) ([]snet.Path, []base.PathSteps) {
+	steps, errs := StepsFromSnetPaths(paths)
+	convertible := make([]snet.Path, 0, len(paths))
+	convertibleSteps := make([]base.PathSteps, 0, len(paths))
+	for i, p := range paths {
+		if errs[i] != nil {
+			log.Info("error in SCION path, cannot convert to steps", "err", errs[i], "path", p,
+				"labels", e.conf.labels)
+			continue
+		}
+		convertible = append(convertible, p)
+		convertibleSteps = append(convertibleSteps, steps[i])
+	}
+	return convertible, convertibleSteps
 }
 
 func (e *entry) PrepareRenewalRequest(now, expTime time.Time) *segment.SetupReq {
 	return &segment.SetupReq{
 		Request: *base.NewRequest(
 			now, &e.rsv.ID, e.rsv.NextIndexToRenew(), len(e.rsv.Steps)),
-		ExpirationTime: expTime,
+		ExpirationTime: e.conf.clampExpiration(now, expTime),
+		RLC:            e.conf.rlc,
 		PathType:       e.conf.pathType,
 		MinBW:          e.conf.minBW,
-		MaxBW:          e.conf.maxBW,
+		MaxBW:          e.MaxBW(),
 		SplitCls:       e.rsv.TrafficSplit,
 		PathProps:      e.rsv.PathEndProps,
 		AllocTrail:     reservation.AllocationBeads{},
 		Steps:          e.rsv.Steps.Copy(),
 		CurrentStep:    e.rsv.CurrentStep,
-		TransportPath:  e.rsv.TransportPath,
+		TransportPath:  e.renewalTransport(),
 		Reservation:    e.rsv,
 	}
 }
 
+// renewalTransport returns the transport path of the reservation, oriented in the direction
+// the renewal travels. Renewals always depart from this AS, thus for down-path reservations
+// the transport must travel against the direction of the steps (R flag set), and for any
+// other type along them. A transport stored in the wrong direction is reversed; if that
+// is not possible, no transport is used and the renewal travels like a setup, as do the
+// renewals of the reservations set up by this AS, which are stored without transport.
+func (e *entry) renewalTransport() *colpath.ColibriPathMinimal {
+	transport := e.rsv.TransportPath
+	if transport == nil || transport.InfoField == nil {
+		return transport
+	}
+	inReverse := e.rsv.PathType == reservation.DownPath
+	if transport.InfoField.R == inReverse {
+		return transport
+	}
+	log.Info("transport path direction does not match the steps, reversing it",
+		"id", e.rsv.ID.String(), "path_type", e.rsv.PathType,
+		"transport_reversed", transport.InfoField.R)
+	reversed, err := transport.Clone().ReverseAsColibri()
+	if err != nil {
+		log.Info("cannot reverse transport path, renewing without it",
+			"id", e.rsv.ID.String(), "err", err)
+		return nil
+	}
+	return reversed
+}
+
+// NewKeeper creates a keeper for the configured reservations. It also returns the orphans,
+// i.e. those existing reservations that match no configuration. If so configured, the orphans
+// are torn down.
 func NewKeeper(
 	ctx context.Context,
 	provider ServiceFacilitator,
 	conf *conf.Reservations,
 	localIA addr.IA,
-) (*keeper, error) {
+) (*keeper, []*segment.Reservation, error) {
 
 	// load configuration
-	reqs, err := parseInitial(conf)
+	reqs, err := parseInitial(conf, localIA)
 	if err != nil {
-		return nil, err
-	}
-	// cleanup expired indices before reading reservations
-	if err := provider.DeleteExpiredIndices(ctx); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// get existing reservations
 	rsvs, err := provider.GetReservationsAtSource(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	rsvs = dedupReservations(rsvs)
 	entries := matchRsvsWithConfiguration(rsvs, reqs)
 
-	log.Debug("colibri keeper", "reservations", len(entries))
-	return &keeper{
-		now:        time.Now,
-		localIA:    localIA,
-		sleepUntil: time.Now().Add(-time.Nanosecond),
-		provider:   provider,
-		entries:    entries,
-	}, nil
+	k := &keeper{
+		now:         time.Now,
+		localIA:     localIA,
+		sleepUntil:  time.Now().Add(-time.Nanosecond),
+		provider:    provider,
+		entries:     entries,
+		setups:      newSetupLimiter(conf),
+		pathRefresh: defaultPathRefreshInterval,
+	}
+	if conf != nil {
+		if conf.PathRefreshInterval != nil && conf.PathRefreshInterval.Duration > 0 {
+			k.pathRefresh = conf.PathRefreshInterval.Duration
+		}
+		k.maxEntries = conf.MaxEntriesPerRun
+	}
+	k.cleanupDone = make(chan struct{})
+	if conf != nil && conf.SkipStartupCleanup {
+		close(k.cleanupDone)
+	} else {
+		// delete the expired indices without delaying the startup. The expired indices of the
+		// reservations already read are not considered when keeping them.
+		go func() {
+			defer log.HandlePanic()
+			defer close(k.cleanupDone)
+			if err := provider.DeleteExpiredIndices(ctx); err != nil {
+				log.Info("error deleting expired indices at startup", "err", err)
+			}
+		}()
+	}
+	orphans := findOrphans(rsvs, entries)
+	if conf != nil && conf.TeardownOrphans {
+		k.teardownOrphans(ctx, orphans)
+	}
+
+	log.Debug("colibri keeper", "reservations", len(entries), "orphans", len(orphans))
+	return k, orphans, nil
+}
+
+// KeeperTask runs a keeper as a periodic.Task, without the rest of the manager duties.
+// The periodic runner can call Run often; OneShot is only invoked once the wakeup time
+// returned by the previous call has been reached.
+type KeeperTask struct {
+	now    func() time.Time // replace in tests
+	keeper *keeper
+	wakeup time.Time // no need to run the keeper until this time
+}
+
+var _ periodic.Task = (*KeeperTask)(nil)
+
+// NewKeeperTask returns a periodic.Task that runs the keeper.
+func NewKeeperTask(k *keeper) *KeeperTask {
+	return &KeeperTask{
+		now:    time.Now,
+		keeper: k,
+	}
+}
+
+func (t *KeeperTask) Name() string {
+	return "colibri.keeper"
+}
+
+func (t *KeeperTask) Run(ctx context.Context) {
+	if t.now().Before(t.wakeup) {
+		return
+	}
+	res := t.keeper.OneShot(ctx)
+	if err := res.Err(); err != nil {
+		log.FromCtx(ctx).Info("error while keeping the reservations", "err", err)
+	}
+	t.wakeup = res.Wakeup
 }
 
 // OneShot keeps all reservations healthy. Those that need renewal are renewed, those
 // that still have no reservation ID for its config will request a new one.
-// The function returns the time when it should be called next.
-func (k *keeper) OneShot(ctx context.Context) (time.Time, error) {
+// If the entries kept per run are limited, those kept least recently go first, and the rest
+// are left for the next run, which is then scheduled as soon as possible.
+// If the store becomes unavailable, the entries not kept yet are deferred instead of failed,
+// and the run is retried after a short and uniform backoff.
+// The result contains the time when it should be called next, and what was done for each
+// configured reservation.
+// Only the entry being kept is locked during the remote calls, thus the other operations of
+// the keeper do not wait for the whole run.
+func (k *keeper) OneShot(ctx context.Context) OneShotResult {
+	k.runMu.Lock()
+	defer k.runMu.Unlock()
+
+	k.mu.Lock()
+	now := k.now()
+	entries := append([]*entry{}, k.entries...)
+	times := make([]time.Time, len(entries))
+	kept := make([]KeptEntry, len(entries))
+	keptSeq := make([]uint64, len(entries))
+	for i, e := range entries {
+		kept[i] = KeptEntry{
+			Dst:    e.conf.dst,
+			Labels: e.conf.labels,
+		}
+		// entries left for a later run
+		times[i] = now
+		keptSeq[i] = e.keptSeq
+	}
+	order := k.keepOrder()
+	for _, i := range order {
+		k.keptCount++
+		entries[i].keptSeq = k.keptCount
+	}
+	k.mu.Unlock()
+
 	wg := sync.WaitGroup{}
-	times := make([]time.Time, len(k.entries))
-	errs := make(serrors.List, len(k.entries))
-	wg.Add(len(k.entries))
-	for i, e := range k.entries {
-		i, e := i, e
+	// keep the entries using a bounded number of goroutines
+	indices := make(chan int)
+	workers := k.workers()
+	if workers > len(order) {
+		workers = len(order)
+	}
+	var storeMu sync.Mutex
+	var storeErr error // the first error caused by the store being unavailable
+	storeUnavailable := func() bool {
+		storeMu.Lock()
+		defer storeMu.Unlock()
+		return storeErr != nil
+	}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
 		go func() {
 			defer log.HandlePanic()
 			defer wg.Done()
-			times[i], errs[i] = k.keepReservation(ctx, e)
+			for i := range indices {
+				if storeUnavailable() {
+					kept[i].Action = KeptDeferred
+					continue
+				}
+				entries[i].mu.Lock()
+				t, action, err := k.keepReservation(ctx, entries[i])
+				entries[i].mu.Unlock()
+				if errors.Is(err, ErrStoreUnavailable) {
+					storeMu.Lock()
+					if storeErr == nil {
+						storeErr = err
+					}
+					storeMu.Unlock()
+					kept[i].Action = KeptDeferred
+					continue
+				}
+				times[i], kept[i].Action, kept[i].Err = t, action, err
+			}
 		}()
 	}
+	for _, i := range order {
+		indices <- i
+	}
+	close(indices)
 	wg.Wait()
-	if err := errs.Coalesce(); err != nil {
-		return k.now().Add(sleepAtLeast), err
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	res := OneShotResult{
+		Entries:          kept,
+		Counts:           make(map[KeepAction]int),
+		StoreUnavailable: storeErr,
+	}
+	for i, e := range kept {
+		res.Counts[e.Action]++
+		if e.Action == KeptDeferred {
+			// deferred entries go first again in the next run
+			entries[i].keptSeq = keptSeq[i]
+		}
+	}
+	if res.StoreUnavailable != nil {
+		k.sleepUntil = k.now().Add(sleepStoreUnavailable)
+		res.Wakeup = k.sleepUntil
+		return res
+	}
+	if res.Err() != nil {
+		k.sleepUntil = k.now().Add(sleepAtLeast)
+		res.Wakeup = k.sleepUntil
+		return res
 	}
 	// wakeupAtLatest is the maximum to wake up the keeper
 	wakeupAtLatest := k.now().Add(sleepAtMost)
@@ -199,32 +524,463 @@ func (k *keeper) OneShot(ctx context.Context) (time.Time, error) {
 	if wakeupAtLatest.Sub(k.now()) < sleepAtLeast {
 		wakeupAtLatest = k.now().Add(sleepAtLeast)
 	}
-	return wakeupAtLatest, nil
+	k.sleepUntil = wakeupAtLatest
+	res.Wakeup = wakeupAtLatest
+	return res
+}
+
+// keepOrder returns the positions of the entries to keep in this run, those kept least
+// recently first. Entries never kept keep their configured order.
+// It must be called holding k.mu.
+func (k *keeper) keepOrder() []int {
+	order := make([]int, len(k.entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return k.entries[order[a]].keptSeq < k.entries[order[b]].keptSeq
+	})
+	if k.maxEntries > 0 && len(order) > k.maxEntries {
+		order = order[:k.maxEntries]
+	}
+	return order
+}
+
+// Plan returns, for each configured reservation, what the keeper would do if it ran now,
+// and the time the keeper is scheduled to run next. Nothing is modified.
+// Plan waits for the entries being kept at the moment, but not for the whole run of the keeper.
+func (k *keeper) Plan() ([]PlanEntry, time.Time) {
+	k.mu.Lock()
+	entries := append([]*entry{}, k.entries...)
+	sleepUntil := k.sleepUntil
+	k.mu.Unlock()
+
+	now := k.now()
+	plan := make([]PlanEntry, len(entries))
+	for i, e := range entries {
+		plan[i] = k.planEntry(e, now)
+	}
+	return plan, sleepUntil
+}
+
+// planEntry returns what the keeper would do for the entry if it ran now.
+func (k *keeper) planEntry(e *entry, now time.Time) PlanEntry {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	plan := PlanEntry{
+		Dst:       e.conf.dst,
+		PathType:  e.conf.pathType,
+		Predicate: e.conf.predicateStr,
+		MinBW:     e.conf.minBW,
+		MaxBW:     e.MaxBW(),
+		Action:    ActionCreate,
+		Labels:    e.conf.labels,
+	}
+	if e.rsv == nil {
+		return plan
+	}
+	id := e.rsv.ID
+	plan.ID = &id
+	plan.Compliance = compliance(e, now.Add(minDuration))
+	switch plan.Compliance {
+	case Compliant:
+		plan.Action = ActionNothing
+	case NeedsIndices:
+		plan.Action = ActionRenew
+		if findPendingIndex(e, now) != nil {
+			plan.Action = ActionActivate
+		}
+	case NeedsActivation:
+		plan.Action = ActionActivate
+	}
+	return plan
+}
+
+// Unmatched returns the configured reservations for which no path to their destination
+// satisfies their predicate, in the order of the configuration. The keeper cannot create a
+// reservation for them until the paths change. Nothing is modified.
+func (k *keeper) Unmatched(ctx context.Context) []UnmatchedEntry {
+	k.mu.Lock()
+	confs := make([]*configuration, len(k.entries))
+	for i, e := range k.entries {
+		confs[i] = e.conf
+	}
+	k.mu.Unlock()
+
+	type lookup struct {
+		paths []snet.Path
+		err   error
+	}
+	lookups := make(map[addr.IA]lookup) // paths per destination
+	unmatched := make([]UnmatchedEntry, 0)
+	for _, c := range confs {
+		l, ok := lookups[c.dst]
+		if !ok {
+			l.paths, l.err = k.provider.PathsTo(ctx, c.dst)
+			lookups[c.dst] = l
+		}
+		if l.err == nil && len(c.predicate.Filter(l.paths)) > 0 {
+			continue
+		}
+		unmatched = append(unmatched, UnmatchedEntry{
+			Dst:       c.dst,
+			PathType:  c.pathType,
+			Predicate: c.predicateStr,
+			Paths:     len(l.paths),
+			Err:       l.err,
+			Labels:    c.labels,
+		})
+	}
+	return unmatched
+}
+
+// Downgrade lowers the bandwidth of the kept reservation with the given ID, without tearing
+// it down: a new index is requested with bw as its maximum bandwidth, and activated.
+// Subsequent renewals of the reservation will not request more than bw.
+// It returns the number of the new active index.
+func (k *keeper) Downgrade(ctx context.Context, id reservation.ID, bw reservation.BWCls) (
+	reservation.IndexNumber, error) {
+
+	e := k.findEntry(id)
+	if e == nil {
+		return 0, serrors.New("reservation not kept by this AS", "id", id.String())
+	}
+	defer e.mu.Unlock()
+	active := e.rsv.ActiveIndex()
+	if active == nil {
+		return 0, serrors.New("reservation has no active index", "id", id.String())
+	}
+	if bw >= active.AllocBW {
+		return 0, serrors.New("downgrade must request less than the allocated bw",
+			"alloc_bw", active.AllocBW, "requested_bw", bw)
+	}
+	if bw < e.conf.minBW {
+		return 0, serrors.New("downgrade cannot request less than the configured min bw",
+			"min_bw", e.conf.minBW, "requested_bw", bw)
+	}
+
+	now := k.now()
+	req := e.PrepareRenewalRequest(now, now.Add(newIndexMinDuration))
+	req.MaxBW = bw
+	if err := k.provider.SetupRequest(ctx, req); err != nil {
+		return 0, err
+	}
+	if err := e.rsv.SetIndices(req.Reservation.IndicesSnapshot()); err != nil {
+		return 0, err
+	}
+
+	actReq := base.NewRequest(k.now(), &e.rsv.ID, req.Index, len(e.rsv.Steps))
+	inReverse := e.rsv.PathType == reservation.DownPath
+	err := k.provider.ActivateRequest(ctx, actReq, e.rsv.Steps.Copy(), e.rsv.TransportPath,
+		inReverse)
+	if err != nil {
+		return 0, err
+	}
+	if err := e.rsv.SetIndexActive(req.Index); err != nil {
+		return 0, err
+	}
+	e.maxBW = bw
+	return req.Index, nil
+}
+
+// Forget drops the reservation with the given ID from its entry, if any. The keeper will
+// request a new reservation for that entry on its next run.
+func (k *keeper) Forget(id reservation.ID) {
+	if e := k.findEntry(id); e != nil {
+		e.rsv = nil
+		e.maxBW = 0
+		e.mu.Unlock()
+	}
+}
+
+// findEntry returns the entry whose reservation has the given ID, or nil if none.
+// The entry is returned locked, and the caller must unlock it.
+func (k *keeper) findEntry(id reservation.ID) *entry {
+	k.mu.Lock()
+	entries := append([]*entry{}, k.entries...)
+	k.mu.Unlock()
+
+	for _, e := range entries {
+		e.mu.Lock()
+		if e.rsv != nil && e.rsv.ID.Equal(&id) {
+			return e
+		}
+		e.mu.Unlock()
+	}
+	return nil
+}
+
+// keeperState is the serialized matching of the keeper entries with their reservations.
+type keeperState struct {
+	Entries []entryState `json:"entries"`
+}
+
+// entryState identifies the reservations of the entry of the configuration at position Conf.
+type entryState struct {
+	Conf     int               `json:"conf"`
+	ID       string            `json:"id,omitempty"`
+	Replaced string            `json:"replaced,omitempty"`
+	MaxBW    reservation.BWCls `json:"max_bw,omitempty"`
+}
+
+// ExportState serializes the matching of the configured entries with their reservations.
+// Only the reservation IDs are exported; the reservations themselves are read again from
+// the store by ImportState.
+func (k *keeper) ExportState() []byte {
+	k.mu.Lock()
+	entries := append([]*entry{}, k.entries...)
+	k.mu.Unlock()
+
+	state := keeperState{Entries: make([]entryState, 0, len(entries))}
+	for _, e := range entries {
+		e.mu.Lock()
+		s := entryState{
+			Conf:  e.conf.index,
+			MaxBW: e.maxBW,
+		}
+		if e.rsv != nil {
+			s.ID = e.rsv.ID.String()
+		}
+		if e.replaced != nil {
+			s.Replaced = e.replaced.ID.String()
+		}
+		e.mu.Unlock()
+		state.Entries = append(state.Entries, s)
+	}
+	buff, err := json.Marshal(state)
+	if err != nil {
+		// only basic types are marshaled, this cannot fail
+		panic(err)
+	}
+	return buff
+}
+
+// ImportState restores the matching exported by ExportState, possibly by a previous process.
+// Entries are matched with the exported reservations if these still exist in the store and
+// are compatible with the configuration at the same position. The remaining entries and
+// reservations are matched as in NewKeeper.
+// The import waits for the current run of the keeper, which would otherwise keep the entries
+// being replaced.
+func (k *keeper) ImportState(ctx context.Context, buff []byte) error {
+	k.runMu.Lock()
+	defer k.runMu.Unlock()
+
+	var state keeperState
+	if err := json.Unmarshal(buff, &state); err != nil {
+		return serrors.WrapStr("decoding keeper state", err)
+	}
+	rsvs, err := k.provider.GetReservationsAtSource(ctx)
+	if err != nil {
+		return err
+	}
+	rsvs = dedupReservations(rsvs)
+	byID := make(map[string]*segment.Reservation, len(rsvs))
+	for _, r := range rsvs {
+		byID[r.ID.String()] = r
+	}
+	byConf := make(map[int]entryState, len(state.Entries))
+	for _, s := range state.Entries {
+		byConf[s.Conf] = s
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	entries := make([]*entry, 0, len(k.entries))
+	used := make(map[*segment.Reservation]struct{})
+	pending := make([]*configuration, 0)
+	for _, e := range k.entries {
+		s, ok := byConf[e.conf.index]
+		r := byID[s.ID]
+		if _, taken := used[r]; !ok || r == nil || taken ||
+			findCompatibleConfiguration(r, []*configuration{e.conf}) != 0 {
+
+			pending = append(pending, e.conf)
+			continue
+		}
+		used[r] = struct{}{}
+		restored := &entry{
+			conf:  e.conf,
+			rsv:   r,
+			maxBW: s.MaxBW,
+		}
+		if replaced := byID[s.Replaced]; replaced != nil {
+			restored.replaced = replaced
+			used[replaced] = struct{}{}
+		}
+		entries = append(entries, restored)
+	}
+	remaining := make([]*segment.Reservation, 0, len(rsvs))
+	for _, r := range rsvs {
+		if _, ok := used[r]; !ok {
+			remaining = append(remaining, r)
+		}
+	}
+	entries = append(entries, matchRsvsWithConfiguration(remaining, pending)...)
+	k.entries = entries
+
+	log.Debug("colibri keeper state imported", "restored", len(entries)-len(pending),
+		"reconciled", len(pending))
+	return nil
 }
 
 // keepReservation will ensure that the reservation exists or a request is created.
-func (k *keeper) keepReservation(ctx context.Context, e *entry) (time.Time, error) {
+// If the path of the reservation is no longer available, the reservation is migrated to a
+// new one, and the old one is torn down once the new one is active.
+func (k *keeper) keepReservation(ctx context.Context, e *entry) (
+	time.Time, KeepAction, error) {
+
 	now := k.now()
+	action := KeptNothing
+	var migrationRetry time.Time
 	var err error
 	if e.rsv == nil {
+		if next, ok := k.setups.allow(e.conf.dst, now); !ok {
+			log.Debug("throttling setup of new reservation", "dst", e.conf.dst,
+				"predicate", e.conf.predicateStr, "until", next, "labels", e.conf.labels)
+			return next, KeptNothing, nil
+		}
 		e.rsv, err = k.askNewReservation(ctx, e)
 		if err != nil {
-			return time.Time{}, err
+			return time.Time{}, KeptFailed, err
+		}
+		e.pathsChecked = now
+		action = KeptCreated
+	} else if e.replaced == nil && k.pathRefreshDue(e, now) {
+		e.pathsChecked = now
+		// keep the current reservation if the migration is not possible
+		retry, err := k.migrateIfPathGone(ctx, e, now)
+		if err != nil {
+			log.Info("error migrating reservation to a new path", "id", e.rsv.ID.String(),
+				"labels", e.conf.labels, "err", err)
+		}
+		if !retry.IsZero() {
+			// check the paths again as soon as the setup is allowed
+			e.pathsChecked = retry.Add(-k.pathRefresh)
+			migrationRetry = retry
+		}
+		if e.replaced != nil {
+			action = KeptCreated
 		}
 	}
 
+	// a new reservation needs an activation, but is reported as created
 	switch compliance(e, k.now().Add(minDuration)) {
 	case Compliant:
 	case NeedsIndices:
-		err = k.askNewIndices(ctx, e)
+		// prefer activating an existing pending index to creating yet another one
+		if idx := findPendingIndex(e, now); idx != nil {
+			err = k.activateIndex(ctx, e, idx.Idx)
+			action = orAction(action, KeptActivated)
+		} else {
+			err = k.askNewIndices(ctx, e)
+			action = orAction(action, KeptRenewed)
+		}
 	case NeedsActivation:
-		err = k.activateIndex(ctx, e)
+		err = k.activateIndex(ctx, e, e.rsv.NextIndexToActivate().Idx)
+		if !errors.Is(err, errIndexNotCompliant) {
+			action = orAction(action, KeptActivated)
+			break
+		}
+		// skip the next index, activating a compliant one or creating it
+		log.Info("COLIBRI not activating next index", "id", e.rsv.ID.String(),
+			"labels", e.conf.labels, "err", err)
+		if idx := findPendingIndex(e, now); idx != nil {
+			err = k.activateIndex(ctx, e, idx.Idx)
+			action = orAction(action, KeptActivated)
+		} else {
+			err = k.askNewIndices(ctx, e)
+			action = orAction(action, KeptRenewed)
+		}
+	}
+
+	if err != nil {
+		return time.Time{}, KeptFailed, err
+	}
+	k.teardownReplaced(ctx, e)
+	// the entry is compliant for at least newIndexMinDuration - minDuration. Wake up before
+	// that, with a jitter to spread the renewals of entries kept at the same time.
+	wakeup := now.Add(newIndexMinDuration - minDuration - k.wakeupJitter())
+	// but also in time to check its paths again
+	if k.pathRefresh > 0 {
+		if refresh := e.pathsChecked.Add(k.pathRefresh); refresh.Before(wakeup) {
+			wakeup = refresh
+		}
+	}
+	if !migrationRetry.IsZero() && migrationRetry.Before(wakeup) {
+		wakeup = migrationRetry
+	}
+	return wakeup, action, nil
+}
+
+// orAction returns the action already taken, or the new one if nothing was done yet.
+func orAction(taken, a KeepAction) KeepAction {
+	if taken != KeptNothing {
+		return taken
 	}
+	return a
+}
+
+// pathRefreshDue returns true if the paths of the entry must be checked at now. With a zero
+// refresh interval, they are checked every time the entry is kept.
+func (k *keeper) pathRefreshDue(e *entry, now time.Time) bool {
+	return k.pathRefresh <= 0 || !now.Before(e.pathsChecked.Add(k.pathRefresh))
+}
+
+// migrateIfPathGone sets up a new reservation for the entry if the steps of its current one
+// are not present in the paths to the destination anymore. The current reservation is then
+// kept as replaced, until the new one is active.
+// The migration counts as a setup towards the destination: if too many were already done,
+// it is not attempted and the time when it would be allowed is returned.
+func (k *keeper) migrateIfPathGone(ctx context.Context, e *entry, now time.Time) (
+	time.Time, error) {
 
+	paths, err := k.provider.PathsTo(ctx, e.conf.dst)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return now.Add(newIndexMinDuration), nil
+	paths, steps := e.convertiblePaths(e.conf.predicate.Filter(paths))
+	if len(paths) == 0 || e.UsesAnyOf(steps) {
+		// nothing better to migrate to, or the path is still there
+		return time.Time{}, nil
+	}
+	if next, ok := k.setups.allow(e.conf.dst, now); !ok {
+		log.Debug("throttling migration of reservation", "id", e.rsv.ID.String(),
+			"dst", e.conf.dst, "until", next, "labels", e.conf.labels)
+		return next, nil
+	}
+	log.Info("COLIBRI path of reservation not available anymore, migrating",
+		"id", e.rsv.ID.String(), "path", describeSteps(e.rsv.Steps, k.logFullPaths),
+		"predicate", e.conf.predicateStr,
+		"labels", e.conf.labels)
+	rsv, err := k.setupOnPaths(ctx, e, paths, steps)
+	if err != nil {
+		return time.Time{}, err
+	}
+	e.replaced = e.rsv
+	e.rsv = rsv
+	return time.Time{}, nil
+}
+
+// teardownReplaced tears down the reservation replaced by a migration, if any, once the new
+// one is active. Failing to tear it down is logged, and the replaced reservation forgotten.
+func (k *keeper) teardownReplaced(ctx context.Context, e *entry) {
+	if e.replaced == nil || e.rsv.ActiveIndex() == nil {
+		return
+	}
+	r := e.replaced
+	e.replaced = nil
+	req := base.NewRequest(k.now(), &r.ID, 0, len(r.Steps))
+	inReverse := r.PathType == reservation.DownPath
+	err := k.provider.TeardownRequest(ctx, req, r.Steps.Copy(), r.TransportPath, inReverse)
+	if err != nil {
+		log.Info("error tearing down replaced reservation", "id", r.ID.String(),
+			"labels", e.conf.labels, "err", err)
+		return
+	}
+	log.Info("torn down replaced reservation", "id", r.ID.String(), "new_id", e.rsv.ID.String(),
+		"labels", e.conf.labels)
 }
 
 // matchRsvsWithConfiguration matches existing reservations with configuration.
@@ -255,12 +1011,68 @@ func matchRsvsWithConfiguration(rsvs []*segment.Reservation, conf []*configurati
 	return entries
 }
 
+// dedupReservations returns the reservations with unique IDs, keeping the first occurrence of
+// each one. Duplicates would otherwise be matched to different entries, or be considered
+// orphans, and thus be renewed or torn down twice.
+func dedupReservations(rsvs []*segment.Reservation) []*segment.Reservation {
+	seen := make(map[string]struct{}, len(rsvs))
+	unique := make([]*segment.Reservation, 0, len(rsvs))
+	for _, r := range rsvs {
+		id := r.ID.String()
+		if _, ok := seen[id]; ok {
+			log.Info("dropping duplicated reservation returned by the store", "id", id)
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, r)
+	}
+	return unique
+}
+
+// findOrphans returns those reservations not present in any entry.
+func findOrphans(rsvs []*segment.Reservation, entries []*entry) []*segment.Reservation {
+	matched := make(map[*segment.Reservation]struct{}, len(entries))
+	for _, e := range entries {
+		if e.rsv != nil {
+			matched[e.rsv] = struct{}{}
+		}
+	}
+	orphans := make([]*segment.Reservation, 0)
+	for _, r := range rsvs {
+		if _, ok := matched[r]; !ok {
+			orphans = append(orphans, r)
+		}
+	}
+	return orphans
+}
+
+// teardownOrphans tears down the orphaned reservations. Failing to tear down one of them
+// is logged, but does not prevent the others from being torn down.
+func (k *keeper) teardownOrphans(ctx context.Context, orphans []*segment.Reservation) {
+	for _, r := range orphans {
+		req := base.NewRequest(k.now(), &r.ID, 0, len(r.Steps))
+		inReverse := r.PathType == reservation.DownPath
+		err := k.provider.TeardownRequest(ctx, req, r.Steps.Copy(), r.TransportPath, inReverse)
+		if err != nil {
+			log.Info("error tearing down orphaned reservation", "id", r.ID.String(), "err", err)
+			continue
+		}
+		log.Info("torn down orphaned reservation", "id", r.ID.String())
+	}
+}
+
 // findCompatibleConfiguration finds the first compatible configuration with the reservation.
 // It returns the index of the configuration in the slice, or -1 if no valid one is found.
+// The destination and predicate of the configurations refer to the SCION path from this AS,
+// thus the steps of down-path reservations are reversed before comparing them.
 func findCompatibleConfiguration(r *segment.Reservation, conf []*configuration) int {
+	steps := r.Steps
+	if r.PathType == reservation.DownPath {
+		steps = steps.Reverse()
+	}
 	for i, c := range conf {
 		switch {
-		case r.Steps.DstIA() != c.dst:
+		case steps.DstIA() != c.dst:
 			continue
 		case r.PathType != c.pathType:
 			continue
@@ -268,7 +1080,7 @@ func findCompatibleConfiguration(r *segment.Reservation, conf []*configuration)
 			continue
 		case r.PathEndProps != c.endProps:
 			continue
-		case !c.predicate.EvalInterfaces(r.Steps.Interfaces()):
+		case !evalInterfaces(c.predicate, steps.Interfaces()):
 			continue
 		}
 		return i
@@ -276,15 +1088,54 @@ func findCompatibleConfiguration(r *segment.Reservation, conf []*configuration)
 	return -1
 }
 
-func (k *keeper) activateIndex(ctx context.Context, e *entry) error {
-	req := base.NewRequest(k.now(), &e.rsv.ID, e.rsv.NextIndexToActivate().Idx,
-		len(e.rsv.Steps))
+// evalInterfaces returns true if a path with the interfaces satisfies the predicate.
+func evalInterfaces(predicate *pathpol.Policy, ifaces []snet.PathInterface) bool {
+	p := snetpath.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: ifaces,
+		},
+	}
+	return len(predicate.Filter([]snet.Path{p})) > 0
+}
+
+func (k *keeper) activateIndex(ctx context.Context, e *entry, idx reservation.IndexNumber) error {
+	// the configuration could have changed since the index was created. The bounds of the
+	// index are checked as for the compliance, and the allocated bandwidth must not exceed them
+	index := e.rsv.Index(idx)
+	if index == nil {
+		return serrors.New("index to activate not found", "id", e.rsv.ID.String(), "idx", idx)
+	}
+	if index.MinBW < e.conf.minBW || index.MaxBW > e.MaxBW() || index.AllocBW > e.MaxBW() {
+		return serrors.WrapStr("refusing to activate index", errIndexNotCompliant,
+			"id", e.rsv.ID.String(), "idx", idx, "index_min_bw", index.MinBW,
+			"index_max_bw", index.MaxBW, "alloc_bw", index.AllocBW,
+			"min_bw", e.conf.minBW, "max_bw", e.MaxBW())
+	}
 	inReverse := e.rsv.PathType == reservation.DownPath
-	err := k.provider.ActivateRequest(ctx, req, e.rsv.Steps.Copy(), e.rsv.TransportPath, inReverse)
-	if err == nil {
-		err = e.rsv.SetIndexActive(req.Index)
+	backoff := activationRetryBackoff
+	for attempt := 0; ; attempt++ {
+		req := base.NewRequest(k.now(), &e.rsv.ID, idx, len(e.rsv.Steps))
+		err := k.provider.ActivateRequest(ctx, req, e.rsv.Steps.Copy(), e.rsv.TransportPath,
+			inReverse)
+		if err == nil {
+			return e.rsv.SetIndexActive(req.Index)
+		}
+		// a rejection (e.g. activating a past index) will not succeed by retrying, and
+		// neither will it while the store is unavailable
+		if errors.Is(err, errRequestRejected) || errors.Is(err, ErrStoreUnavailable) ||
+			attempt >= activationRetries {
+
+			return err
+		}
+		log.FromCtx(ctx).Debug("retrying index activation", "id", e.rsv.ID.String(),
+			"idx", idx, "attempt", attempt+1, "err", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
 	}
-	return err
 }
 
 // askNewIndices requests a renewal
@@ -298,21 +1149,59 @@ func (k *keeper) askNewIndices(ctx context.Context, e *entry) error {
 	// otherwise the entry reservation is not updated with the new indices
 	// TODO(JordiSubira): Check whether we are missing else from the updated reservation
 	// after confirming indices.
-	e.rsv.Indices = req.Reservation.Indices
-	return nil
+	return e.rsv.SetIndices(req.Reservation.IndicesSnapshot())
 }
 
 func (k *keeper) askNewReservation(ctx context.Context, e *entry) (*segment.Reservation, error) {
-	now := k.now()
 	paths, err := k.provider.PathsTo(ctx, e.conf.dst)
 	if err != nil {
 		return nil, err
 	}
+	// discard up front the paths that cannot be converted to steps
+	paths, steps := e.convertiblePaths(e.conf.predicate.Filter(paths))
+	return k.setupOnPaths(ctx, e, paths, steps)
+}
+
+// StepsFromSnetPaths converts each of the paths to steps. The steps and the error of each path
+// are at the same position in the returned slices; a path that cannot be converted has nil
+// steps and a non nil error.
+func StepsFromSnetPaths(paths []snet.Path) ([]base.PathSteps, []error) {
+	steps := make([]base.PathSteps, len(paths))
+	errs := make([]error, len(paths))
+	for i, p := range paths {
+		steps[i], errs[i] = base.StepsFromSnet(p)
+	}
+	return steps, errs
+}
+
+// setupOnPaths requests a new reservation for the entry over the first possible path. The steps
+// of each path are at the same position in pathSteps.
+func (k *keeper) setupOnPaths(ctx context.Context, e *entry, paths []snet.Path,
+	pathSteps []base.PathSteps) (*segment.Reservation, error) {
+
+	now := k.now()
 	// try with each possible path
-	paths = e.conf.predicate.Eval(paths)
-	for _, p := range paths {
-		req := e.PrepareSetupRequest(now, now.Add(newIndexMinDuration), k.localIA.AS(), p)
-		err := k.provider.SetupRequest(ctx, req)
+	for i, p := range paths {
+		if k.AdmissionHook != nil {
+			admitted, err := k.AdmissionHook(ctx, e.conf, p)
+			if err != nil {
+				return nil, serrors.WrapStr("admission hook", err, "dst", e.conf.dst,
+					"labels", e.conf.labels)
+			}
+			if !admitted {
+				log.Debug("admission hook skipped path for new reservation", "path", p,
+					"labels", e.conf.labels)
+				continue
+			}
+		}
+		req, err := e.PrepareSetupRequest(now, now.Add(newIndexMinDuration), k.localIA.AS(),
+			pathSteps[i])
+		if err != nil {
+			log.Info("cannot prepare new reservation on path", "path", p,
+				"labels", e.conf.labels, "err", err)
+			continue
+		}
+		err = k.provider.SetupRequest(ctx, req)
 		if err == nil {
 			if req.Reservation == nil {
 				panic("logic error, reservation after new request is empty")
@@ -321,20 +1210,122 @@ func (k *keeper) askNewReservation(ctx context.Context, e *entry) (*segment.Rese
 		if req.Reservation != nil {
 			return req.Reservation, err
 		}
-		log.Info("error creating new reservation from best effort path", "path", p, "err", err)
+		if errors.Is(err, ErrStoreUnavailable) {
+			// the other paths would fail the same way
+			return nil, err
+		}
+		log.Info("error creating new reservation from best effort path", "path", p,
+			"labels", e.conf.labels, "err", err)
+	}
+	return nil, serrors.New("no more best effort paths to create reservation", "dst", e.conf.dst,
+		"predicate", e.conf.predicateStr, "labels", e.conf.labels)
+}
+
+// setupLimiter limits the setups of new reservations per destination with a token bucket.
+// The bucket of each destination is kept as the time when it will be full again, so that the
+// limits are computed without rounding errors: each setup moves that time an interval
+// forward, and a setup is allowed while it lies less than burst intervals after now.
+type setupLimiter struct {
+	mu       sync.Mutex
+	burst    int
+	interval time.Duration         // to regain one token
+	full     map[addr.IA]time.Time // when the bucket of the destination is full again
+}
+
+// newSetupLimiter returns the limiter configured in conf, or the default one.
+func newSetupLimiter(conf *conf.Reservations) *setupLimiter {
+	l := &setupLimiter{
+		burst:    defaultSetupBurst,
+		interval: defaultSetupInterval,
+		full:     make(map[addr.IA]time.Time),
+	}
+	if conf != nil && conf.SetupRateLimit != nil {
+		l.burst = conf.SetupRateLimit.Burst
+		l.interval = conf.SetupRateLimit.Interval.Duration
+	}
+	return l
+}
+
+// allow consumes a token of the destination and returns true if a setup can be attempted at
+// now. Otherwise it returns false and the time when the next token will be available, which
+// is always after now.
+// A nil limiter allows all setups.
+func (l *setupLimiter) allow(dst addr.IA, now time.Time) (time.Time, bool) {
+	if l == nil || l.burst <= 0 || l.interval <= 0 {
+		return now, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	full, ok := l.full[dst]
+	if !ok || full.Before(now) {
+		full = now
 	}
-	return nil, serrors.New("no more best effort paths to create reservation", "dst", e.conf.dst)
+	// the next token is available once the bucket lacks less than burst tokens
+	next := full.Add(-time.Duration(l.burst-1) * l.interval)
+	if now.Before(next) {
+		return next, false
+	}
+	l.full[dst] = full.Add(l.interval)
+	return now, true
 }
 
 // configuration is a 1 to 1 association to a conf.ReservationEntry
 type configuration struct {
-	dst       addr.IA
-	pathType  reservation.PathType
-	predicate *pathpol.Sequence
-	minBW     reservation.BWCls
-	maxBW     reservation.BWCls
-	splitCls  reservation.SplitCls
-	endProps  reservation.PathEndProps
+	dst          addr.IA
+	pathType     reservation.PathType
+	predicate    *pathpol.Policy
+	predicateStr string // the path predicate and policy as configured, before being compiled
+	minBW        reservation.BWCls
+	maxBW        reservation.BWCls
+	splitCls     reservation.SplitCls
+	endProps     reservation.PathEndProps
+	rlc          reservation.RLC
+	labels       map[string]string
+	index        int           // position in the configured reservations
+	maxLifetime  time.Duration // if not zero, the maximum validity of a new index
+}
+
+// clampExpiration returns expTime, or the latest expiration allowed for an index requested
+// at now if expTime is beyond it.
+func (c *configuration) clampExpiration(now, expTime time.Time) time.Time {
+	if c.maxLifetime > 0 {
+		if latest := now.Add(c.maxLifetime); expTime.After(latest) {
+			return latest
+		}
+	}
+	return expTime
+}
+
+// Equal returns true if both configurations request the same reservation. Their positions in
+// the configured reservations are not compared. The predicates are compared as configured,
+// ignoring white space: differently written but equivalent policies are considered different.
+func (c *configuration) Equal(other *configuration) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	if c.dst != other.dst ||
+		c.pathType != other.pathType ||
+		normalizePredicate(c.predicateStr) != normalizePredicate(other.predicateStr) ||
+		c.minBW != other.minBW ||
+		c.maxBW != other.maxBW ||
+		c.splitCls != other.splitCls ||
+		c.endProps != other.endProps ||
+		c.rlc != other.rlc ||
+		len(c.labels) != len(other.labels) {
+
+		return false
+	}
+	for k, v := range c.labels {
+		if value, ok := other.labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizePredicate returns the predicate with its white space normalized.
+func normalizePredicate(predicate string) string {
+	return strings.Join(strings.Fields(predicate), " ")
 }
 
 type Compliance int
@@ -358,6 +1349,133 @@ func (c Compliance) String() string {
 	}
 }
 
+// PlanAction is the next action of the keeper for a configured reservation.
+type PlanAction int
+
+const (
+	ActionNothing  = PlanAction(iota) // the reservation is compliant
+	ActionCreate                      // set up a new reservation
+	ActionRenew                       // ask for a new index
+	ActionActivate                    // activate an existing index
+)
+
+func (a PlanAction) String() string {
+	switch a {
+	case ActionNothing:
+		return "nothing"
+	case ActionCreate:
+		return "create"
+	case ActionRenew:
+		return "renew"
+	case ActionActivate:
+		return "activate"
+	default:
+		panic(fmt.Errorf("unknown value for plan action %d", a))
+	}
+}
+
+// KeepAction is what the keeper did for a configured reservation in one run.
+type KeepAction int
+
+const (
+	KeptNothing   = KeepAction(iota) // the reservation was compliant, or its setup throttled
+	KeptCreated                      // a new reservation was set up
+	KeptRenewed                      // a new index was requested
+	KeptActivated                    // an existing index was activated
+	KeptFailed                       // the reservation could not be kept
+	KeptDeferred                     // not kept, as the store was unavailable
+)
+
+func (a KeepAction) String() string {
+	switch a {
+	case KeptNothing:
+		return "nothing"
+	case KeptCreated:
+		return "created"
+	case KeptRenewed:
+		return "renewed"
+	case KeptActivated:
+		return "activated"
+	case KeptFailed:
+		return "failed"
+	case KeptDeferred:
+		return "deferred"
+	default:
+		panic(fmt.Errorf("unknown value for keep action %d", a))
+	}
+}
+
+// KeptEntry is the outcome of keeping a configured reservation.
+type KeptEntry struct {
+	Dst    addr.IA
+	Labels map[string]string
+	Action KeepAction
+	Err    error // not nil iff Action is KeptFailed
+}
+
+// OneShotResult is the outcome of a run of the keeper.
+type OneShotResult struct {
+	Wakeup  time.Time          // when the keeper should run next
+	Entries []KeptEntry        // one per configured reservation
+	Counts  map[KeepAction]int // number of entries per action
+	// StoreUnavailable is the error that deferred the run, if the store became unavailable.
+	StoreUnavailable error
+}
+
+// Err returns the errors of all the entries coalesced, and the one that deferred the run if
+// any, or nil if none failed.
+func (r OneShotResult) Err() error {
+	errs := make(serrors.List, len(r.Entries), len(r.Entries)+1)
+	for i, e := range r.Entries {
+		errs[i] = e.Err
+	}
+	errs = append(errs, r.StoreUnavailable)
+	return errs.Coalesce()
+}
+
+// PlanEntry describes the state of a configured reservation, and the next action of the keeper.
+type PlanEntry struct {
+	Dst        addr.IA
+	PathType   reservation.PathType
+	Predicate  string // the path predicate as configured
+	MinBW      reservation.BWCls
+	MaxBW      reservation.BWCls // the configured one, or lower after a downgrade
+	ID         *reservation.ID   // nil if no reservation matches the configuration
+	Compliance Compliance        // only meaningful if ID is not nil
+	Action     PlanAction
+	Labels     map[string]string
+}
+
+// UnmatchedEntry is a configured reservation without any path satisfying its predicate.
+type UnmatchedEntry struct {
+	Dst       addr.IA
+	PathType  reservation.PathType
+	Predicate string // the path predicate as configured
+	Paths     int    // the number of paths to the destination, regardless of the predicate
+	Err       error  // the error obtaining the paths to the destination, if any
+	Labels    map[string]string
+}
+
+// HasLabels returns true if the entry has all the labels with the same values.
+func (e PlanEntry) HasLabels(labels map[string]string) bool {
+	return hasLabels(e.Labels, labels)
+}
+
+// HasLabels returns true if the entry has all the labels with the same values.
+func (e UnmatchedEntry) HasLabels(labels map[string]string) bool {
+	return hasLabels(e.Labels, labels)
+}
+
+// hasLabels returns true if all the labels are present in set with the same values.
+func hasLabels(set, labels map[string]string) bool {
+	for k, v := range labels {
+		if value, ok := set[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
 // compliance finds the status of the reservation in regard with the configuration.
 // It returns Compliant if it contains active indices compatible with the configuration,
 // NeedsActivation if the compatible index(es) exist but need to be activated, or
@@ -366,7 +1484,7 @@ func (c Compliance) String() string {
 func compliance(e *entry, until time.Time) Compliance {
 	idxs := e.rsv.Indices.Filter(
 		segment.ByMinBW(e.conf.minBW),
-		segment.ByMaxBW(e.conf.maxBW),
+		segment.ByMaxBW(e.MaxBW()),
 		segment.NotConfirmed(),
 		segment.ByExpiration(until),
 	)
@@ -380,33 +1498,146 @@ func compliance(e *entry, until time.Time) Compliance {
 	}
 }
 
-func parseInitial(conf *conf.Reservations) ([]*configuration, error) {
+// findPendingIndex returns the pending (confirmed but not active) index that satisfies the
+// bandwidth requirements of the entry, is still valid at the given time, and expires later
+// than the active index. If there are several, the one expiring the latest is returned.
+// It returns nil if there is no such index.
+func findPendingIndex(e *entry, now time.Time) *segment.Index {
+	validUntil := now
+	if active := e.rsv.ActiveIndex(); active != nil {
+		validUntil = active.Expiration
+	}
+	idxs := e.rsv.Indices.Filter(
+		segment.ByMinBW(e.conf.minBW),
+		segment.ByMaxBW(e.MaxBW()),
+		func(index segment.Index) bool { return index.State == segment.IndexPending },
+		segment.ByExpiration(validUntil),
+	)
+	var found *segment.Index
+	for i := range idxs {
+		if found == nil || idxs[i].Expiration.After(found.Expiration) {
+			found = &idxs[i]
+		}
+	}
+	return found
+}
+
+func parseInitial(conf *conf.Reservations, localIA addr.IA) ([]*configuration, error) {
 	if conf == nil {
 		log.Info("COLIBRI not keeping any reservations")
 		return nil, nil
 	}
-	log.Info("COLIBRI will keep reservations", "count", len(conf.Rsvs))
-	initial := make([]*configuration, len(conf.Rsvs))
-	for i, r := range conf.Rsvs {
-		seq, err := pathpol.NewSequence(r.PathPredicate)
+	rsvs := conf.Rsvs
+	if conf.MaxReservations > 0 && len(rsvs) > conf.MaxReservations {
+		if !conf.TruncateExcess {
+			return nil, serrors.New("too many reservations configured",
+				"count", len(rsvs), "max", conf.MaxReservations)
+		}
+		log.Info("COLIBRI ignoring reservations above the maximum",
+			"count", len(rsvs), "max", conf.MaxReservations)
+		rsvs = rsvs[:conf.MaxReservations]
+	}
+	var maxLifetime time.Duration
+	if conf.MaxIndexLifetime != nil {
+		maxLifetime = conf.MaxIndexLifetime.Duration
+	}
+	if maxLifetime != 0 && maxLifetime < newIndexMinDuration {
+		return nil, serrors.New("max index lifetime shorter than the min validity of an index",
+			"max_index_lifetime", maxLifetime, "min", newIndexMinDuration)
+	}
+	log.Info("COLIBRI will keep reservations", "count", len(rsvs))
+	initial := make([]*configuration, len(rsvs))
+	for i, r := range rsvs {
+		predicate, predicateStr, err := newPredicate(r.PathPredicate, r.PathPolicy)
 		if err != nil {
-			return nil, err
+			return nil, serrors.WrapStr("invalid path predicate", err, "entry", i)
 		}
 
+		if r.DstAS == localIA {
+			return nil, serrors.New("reservation destination is the local AS",
+				"entry", i, "dst", r.DstAS)
+		}
 		if r.MinSize > r.MaxSize {
 			return nil, serrors.New("min bw must be less or equal than max bw",
 				"min_bw", r.MinSize, "max_bw", r.MaxSize)
 		}
+		if floor := conf.MinSizeFloors.Floor(r.PathType); r.MinSize < floor {
+			return nil, serrors.New("min bw below the floor for its path type",
+				"entry", i, "path_type", r.PathType, "min_bw", r.MinSize, "floor", floor)
+		}
+		if err := r.SplitCls.Validate(); err != nil {
+			return nil, serrors.WrapStr("invalid split class", err, "entry", i)
+		}
+		if err := r.RLC.Validate(); err != nil {
+			return nil, serrors.WrapStr("invalid rlc", err, "entry", i)
+		}
+		if err := validateLabels(r.Labels); err != nil {
+			return nil, serrors.WrapStr("invalid labels", err, "entry", i)
+		}
 
 		initial[i] = &configuration{
-			dst:       r.DstAS,
-			pathType:  r.PathType,
-			predicate: seq,
-			minBW:     r.MinSize,
-			maxBW:     r.MaxSize,
-			splitCls:  r.SplitCls,
-			endProps:  reservation.PathEndProps(r.EndProps),
+			dst:          r.DstAS,
+			pathType:     r.PathType,
+			predicate:    predicate,
+			predicateStr: predicateStr,
+			minBW:        r.MinSize,
+			maxBW:        r.MaxSize,
+			splitCls:     r.SplitCls,
+			endProps:     reservation.PathEndProps(r.EndProps),
+			rlc:          r.RLC,
+			labels:       r.Labels,
+			index:        i,
+			maxLifetime:  maxLifetime,
 		}
 	}
 	return initial, nil
 }
+
+// newPredicate compiles the configured path predicate, a sequence, and the optional path policy
+// into one policy. The sequence of the policy replaces the predicate, thus only one of them can
+// be configured. It also returns the description of the predicate used to compare and print it:
+// the sequence as configured, or the JSON form of the policy if there is one.
+func newPredicate(sequence string, policy *pathpol.Policy) (*pathpol.Policy, string, error) {
+	if policy == nil {
+		seq, err := pathpol.NewSequence(sequence)
+		if err != nil {
+			return nil, "", err
+		}
+		return pathpol.NewPolicy("", nil, seq, nil), sequence, nil
+	}
+	p := *policy
+	if strings.TrimSpace(sequence) != "" {
+		if p.Sequence != nil {
+			return nil, "", serrors.New("both path predicate and policy sequence configured",
+				"predicate", sequence, "sequence", p.Sequence.String())
+		}
+		seq, err := pathpol.NewSequence(sequence)
+		if err != nil {
+			return nil, "", err
+		}
+		p.Sequence = seq
+	}
+	desc, err := json.Marshal(&p)
+	if err != nil {
+		return nil, "", serrors.WrapStr("describing path policy", err)
+	}
+	return &p, string(desc), nil
+}
+
+// labelNameRegexp matches the valid metric label names.
+var labelNameRegexp = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// validateLabels checks that the labels can be used as metric labels: the keys must be valid
+// label names not reserved for internal use (starting with "__"), and the values non empty
+// UTF-8 strings.
+func validateLabels(labels map[string]string) error {
+	for k, v := range labels {
+		if !labelNameRegexp.MatchString(k) || strings.HasPrefix(k, "__") {
+			return serrors.New("invalid label name", "name", k)
+		}
+		if v == "" || !utf8.ValidString(v) {
+			return serrors.New("invalid label value", "name", k, "value", v)
+		}
+	}
+	return nil
+}
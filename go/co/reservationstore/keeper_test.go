@@ -16,6 +16,9 @@ package reservationstore
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -23,15 +26,19 @@ import (
 	"github.com/stretchr/testify/require"
 
 	base "github.com/scionproto/scion/go/co/reservation"
+	"github.com/scionproto/scion/go/co/reservation/conf"
 	seg "github.com/scionproto/scion/go/co/reservation/segment"
 	st "github.com/scionproto/scion/go/co/reservation/segmenttest"
 	te "github.com/scionproto/scion/go/co/reservation/test"
+	"github.com/scionproto/scion/go/co/reservationstorage/mock_reservationstorage"
 	mockmanager "github.com/scionproto/scion/go/co/reservationstore/mock_reservationstore"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
 	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
@@ -83,7 +90,7 @@ func TestKeepOneShot(t *testing.T) {
 		splitCls:  2,
 		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
 	}
-	r1 := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+	r1 := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 2, "1-ff00:0:2"),
 		st.AddIndex(0, st.WithBW(12, 42, 0),
 			st.WithExpiration(tomorrow)),
 		st.AddIndex(1, st.WithBW(12, 24, 0),
@@ -92,7 +99,7 @@ func TestKeepOneShot(t *testing.T) {
 		st.WithActiveIndex(0),
 		st.WithTrafficSplit(2),
 		st.WithEndProps(reservation.StartLocal|reservation.EndLocal|reservation.EndTransfer))
-	r2 := st.ModRsv(cloneR(r1), st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:3"))
+	r2 := st.ModRsv(cloneR(r1), st.WithPath("1-ff00:0:1", 1, 2, "1-ff00:0:3"))
 	r3 := st.ModRsv(cloneR(r1), st.WithPath("1-ff00:0:1", 1, 8, "1-ff00:0:2", 9, 1, "1-ff00:0:4"),
 		st.WithNoActiveIndex())
 	cases := map[string]struct {
@@ -203,231 +210,3358 @@ func TestKeepOneShot(t *testing.T) {
 					return nil
 				})
 
-			wakeupTime, err := keeper.OneShot(ctx)
+			res := keeper.OneShot(ctx)
 			if tc.expectError {
-				require.Error(t, err)
+				require.Error(t, res.Err())
 			} else {
-				require.NoError(t, err)
+				require.NoError(t, res.Err())
 			}
-			require.Equal(t, tc.expectedWakeupTime, wakeupTime)
+			require.Equal(t, tc.expectedWakeupTime, res.Wakeup)
 		})
 	}
 }
 
-func TestRequirementsCompliance(t *testing.T) {
-	now := util.SecsToTime(0)
-	tomorrow := now.Add(3600 * 24 * time.Second)
-	reqs := &configuration{
-		pathType:  reservation.UpPath,
+func TestOneShotResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	newConf := func(dst string) *configuration {
+		return &configuration{
+			dst:       xtest.MustParseIA(dst),
+			predicate: newSequence(t, "1-ff00:0:1 "+dst), // direct
+			minBW:     10,
+			maxBW:     42,
+			splitCls:  2,
+		}
+	}
+	newRsv := func(dst string, opts ...st.ReservationMod) *seg.Reservation {
+		opts = append([]st.ReservationMod{
+			st.WithPath("1-ff00:0:1", 1, 2, dst),
+			st.WithTrafficSplit(2),
+		}, opts...)
+		return st.NewRsv(opts...)
+	}
+	confs := []*configuration{
+		newConf("1-ff00:0:2"), // compliant
+		newConf("1-ff00:0:3"), // no reservation
+		newConf("1-ff00:0:4"), // index about to expire
+		newConf("1-ff00:0:5"), // no active index
+		newConf("1-ff00:0:6"), // no reservation and no paths
+	}
+	rsvs := []*seg.Reservation{
+		newRsv("1-ff00:0:2",
+			st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+			st.ConfirmAllIndices(), st.WithActiveIndex(0)),
+		newRsv("1-ff00:0:4",
+			st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(now.Add(time.Minute))),
+			st.ConfirmAllIndices(), st.WithActiveIndex(0)),
+		newRsv("1-ff00:0:5",
+			st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+			st.ConfirmAllIndices()),
+	}
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now: func() time.Time {
+			return now
+		},
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: provider,
+		entries:  matchRsvsWithConfiguration(rsvs, confs),
+	}
+	provider.EXPECT().PathsTo(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, dst addr.IA) ([]snet.Path, error) {
+			if dst == xtest.MustParseIA("1-ff00:0:6") {
+				return nil, nil
+			}
+			return []snet.Path{te.NewSnetPath("1-ff00:0:1", 1, 2, dst.String())}, nil
+		})
+	provider.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, req *seg.SetupReq) error {
+			if req.Reservation != nil { // renewal
+				return nil
+			}
+			req.Reservation = st.NewRsv(st.WithPath("1-ff00:0:1", 1, 2, "1-ff00:0:3"),
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices())
+			return nil
+		})
+	provider.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	res := k.OneShot(context.Background())
+	require.Error(t, res.Err())
+	require.Equal(t, now.Add(sleepAtLeast), res.Wakeup)
+	actions := make(map[string]KeepAction)
+	for _, e := range res.Entries {
+		actions[e.Dst.String()] = e.Action
+		if e.Action == KeptFailed {
+			require.Error(t, e.Err)
+		} else {
+			require.NoError(t, e.Err)
+		}
+	}
+	require.Equal(t, map[string]KeepAction{
+		"1-ff00:0:2": KeptNothing,
+		"1-ff00:0:3": KeptCreated,
+		"1-ff00:0:4": KeptRenewed,
+		"1-ff00:0:5": KeptActivated,
+		"1-ff00:0:6": KeptFailed,
+	}, actions)
+	require.Equal(t, map[KeepAction]int{
+		KeptNothing:   1,
+		KeptCreated:   1,
+		KeptRenewed:   1,
+		KeptActivated: 1,
+		KeptFailed:    1,
+	}, res.Counts)
+}
+
+func TestKeepReservationReusesPendingIndex(t *testing.T) {
+	now := util.SecsToTime(10)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
 		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
 		minBW:     10,
 		maxBW:     42,
 		splitCls:  2,
 		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
 	}
+	// both indices expire too soon to be compliant, thus the reservation needs indices
+	activeExp := now.Add(minDuration / 4)
+	pendingExp := now.Add(minDuration / 2)
 	cases := map[string]struct {
-		conf               *configuration
-		rsv                *seg.Reservation
-		atLeastUntil       time.Time
-		expectedCompliance Compliance
+		rsv                      *seg.Reservation
+		expectedSetupRequests    int
+		expectedActivateRequests int
 	}{
-		"compliant, one index": {
-			conf: reqs,
-			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(tomorrow)),
-				st.WithPathType(reservation.UpPath),
-				st.WithActiveIndex(0),
-				st.WithTrafficSplit(2),
-				st.WithEndProps(reqs.endProps)),
-			atLeastUntil:       now,
-			expectedCompliance: Compliant,
-		},
-		"one non compliant index, minbw": {
-			conf: reqs,
-			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-				st.WithPathType(reservation.UpPath),
-				st.AddIndex(0, st.WithBW(1, 24, 0), st.WithExpiration(tomorrow)),
-				st.WithActiveIndex(0),
-				st.WithTrafficSplit(2),
-				st.WithEndProps(reqs.endProps)),
-			atLeastUntil:       now,
-			expectedCompliance: NeedsIndices,
-		},
-		"one non compliant index, maxbw": {
-			conf: reqs,
+		"pending_index": {
 			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-				st.WithPathType(reservation.UpPath),
-				st.AddIndex(0, st.WithBW(12, 44, 0), st.WithExpiration(tomorrow)),
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(activeExp)),
+				st.AddIndex(1, st.WithBW(12, 42, 0), st.WithExpiration(pendingExp)),
+				st.ConfirmAllIndices(),
 				st.WithActiveIndex(0),
 				st.WithTrafficSplit(2),
-				st.WithEndProps(reqs.endProps)),
-			atLeastUntil:       now,
-			expectedCompliance: NeedsIndices,
+				st.WithEndProps(conf.endProps)),
+			expectedSetupRequests:    0,
+			expectedActivateRequests: 1,
 		},
-		"one non compliant index, expired": {
-			conf: reqs,
+		"pending_index_not_compliant": {
 			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-				st.WithPathType(reservation.UpPath),
-				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(now)),
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(activeExp)),
+				st.AddIndex(1, st.WithBW(12, 63, 0), st.WithExpiration(pendingExp)),
+				st.ConfirmAllIndices(),
 				st.WithActiveIndex(0),
 				st.WithTrafficSplit(2),
-				st.WithEndProps(reqs.endProps)),
-			atLeastUntil:       now,
-			expectedCompliance: NeedsIndices,
+				st.WithEndProps(conf.endProps)),
+			expectedSetupRequests:    1,
+			expectedActivateRequests: 0,
 		},
-		"no active indices": {
-			conf: reqs,
+		"no_pending_index": {
 			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-				st.WithPathType(reservation.UpPath),
-				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(tomorrow)),
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(activeExp)),
 				st.ConfirmAllIndices(),
+				st.WithActiveIndex(0),
 				st.WithTrafficSplit(2),
-				st.WithEndProps(reqs.endProps)),
-			atLeastUntil:       now,
-			expectedCompliance: NeedsActivation,
-		},
-		"no indices": {
-			conf: reqs,
-			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-				st.WithPathType(reservation.UpPath),
-				st.WithTrafficSplit(2),
-				st.WithEndProps(reqs.endProps)),
-			atLeastUntil:       now,
-			expectedCompliance: NeedsIndices,
-		},
-		"compliant in the past, not now": {
-			conf: reqs,
-			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-				st.WithPathType(reservation.UpPath),
-				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(tomorrow)),
-				st.AddIndex(1, st.WithBW(1, 24, 0), st.WithExpiration(tomorrow)),
-				st.WithActiveIndex(1), // will destroy index 0
-				st.WithTrafficSplit(2),
-				st.WithEndProps(reqs.endProps)),
-			atLeastUntil:       now,
-			expectedCompliance: NeedsIndices,
+				st.WithEndProps(conf.endProps)),
+			expectedSetupRequests:    1,
+			expectedActivateRequests: 0,
 		},
 	}
 	for name, tc := range cases {
 		name, tc := name, tc
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
-			entry := &entry{
-				conf: tc.conf,
-				rsv:  tc.rsv,
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			entries := matchRsvsWithConfiguration([]*seg.Reservation{tc.rsv},
+				[]*configuration{conf})
+			keeper := keeper{
+				now: func() time.Time {
+					return now
+				},
+				localIA:  xtest.MustParseIA("1-ff00:0:1"),
+				provider: manager,
+				entries:  entries,
+			}
+			manager.EXPECT().PathsTo(gomock.Any(), conf.dst).Return(
+				[]snet.Path{te.NewSnetPath("1-ff00:0:1", 1, 1, "1-ff00:0:2")}, nil)
+			manager.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).
+				Times(tc.expectedSetupRequests).Return(nil)
+			manager.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(tc.expectedActivateRequests).DoAndReturn(
+				func(_ context.Context, req *base.Request, _ base.PathSteps,
+					_ *colpath.ColibriPathMinimal, _ bool) error {
+
+					require.Equal(t, reservation.IndexNumber(1), req.Index)
+					return nil
+				})
+
+			_, _, err := keeper.keepReservation(ctx, entries[0])
+			require.NoError(t, err)
+			if tc.expectedActivateRequests > 0 {
+				require.Equal(t, reservation.IndexNumber(1), tc.rsv.ActiveIndex().Idx)
 			}
-			c := compliance(entry, tc.atLeastUntil)
-			require.Equal(t, tc.expectedCompliance, c,
-				"expected %s got %s", tc.expectedCompliance, c)
 		})
 	}
 }
 
-func TestMatchRsvsWithConfiguration(t *testing.T) {
-	r1 := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-		st.WithPathType(reservation.UpPath),
-		st.WithTrafficSplit(1), // 1
-		st.WithEndProps(reservation.StartLocal))
-	r2 := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-		st.WithPathType(reservation.UpPath),
-		st.WithTrafficSplit(2), // 2
-		st.WithEndProps(reservation.StartLocal))
-	c1 := &configuration{
-		dst:       xtest.MustParseIA("1-ff00:0:2"),
-		pathType:  reservation.UpPath,
-		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
-		splitCls:  1,
-		endProps:  reservation.StartLocal,
-	}
-	c2 := &configuration{
+func TestKeepReservationMigratesFromGonePath(t *testing.T) {
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	conf := &configuration{
 		dst:       xtest.MustParseIA("1-ff00:0:2"),
-		pathType:  reservation.UpPath,
-		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+		minBW:     10,
+		maxBW:     42,
 		splitCls:  2,
-		endProps:  reservation.StartLocal,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	newRsv := func() *seg.Reservation {
+		return st.NewRsv(st.WithID("ff00:0:1", "beefcafe"),
+			st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+			st.ConfirmAllIndices(),
+			st.WithActiveIndex(0),
+			st.WithTrafficSplit(2),
+			st.WithEndProps(conf.endProps))
+	}
+	// a path with an odd number of interfaces cannot be converted to steps
+	bad := snetpath.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: xtest.MustParseIA("1-ff00:0:1"), ID: 2},
+				{IA: xtest.MustParseIA("1-ff00:0:2"), ID: 3},
+				{IA: xtest.MustParseIA("1-ff00:0:2"), ID: 4},
+			},
+		},
 	}
-	c1_copy := func() *configuration { a := *c1; return &a }()
 	cases := map[string]struct {
-		rsvs              []*seg.Reservation
-		confs             []*configuration
-		expectedConfToRsv []int // configuration i paired with rsvs[ expected[i] ]
+		paths             []snet.Path
+		activationErr     error
+		throttled         bool
+		expectedMigration bool
+		expectedTeardown  bool
 	}{
-		"both": {
-			rsvs:              []*seg.Reservation{r1, r2},
-			confs:             []*configuration{c1, c2},
-			expectedConfToRsv: []int{0, 1},
+		"path_present": {
+			paths: []snet.Path{
+				te.NewSnetPath("1-ff00:0:1", 2, 3, "1-ff00:0:2"),
+				te.NewSnetPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			},
 		},
-		"unordered": {
-			rsvs:              []*seg.Reservation{r1, r2},
-			confs:             []*configuration{c2, c1},
-			expectedConfToRsv: []int{1, 0},
+		"no_paths": {
+			paths: nil,
 		},
-		"only_one": {
-			rsvs:              []*seg.Reservation{r2},
-			confs:             []*configuration{c1, c2},
-			expectedConfToRsv: []int{-1, 0},
+		"path_gone": {
+			paths:             []snet.Path{te.NewSnetPath("1-ff00:0:1", 2, 3, "1-ff00:0:2")},
+			expectedMigration: true,
+			expectedTeardown:  true,
 		},
-		"none": {
-			rsvs:              []*seg.Reservation{},
-			confs:             []*configuration{c2, c1},
-			expectedConfToRsv: []int{-1, -1},
+		"path_gone_activation_fails": {
+			paths:             []snet.Path{te.NewSnetPath("1-ff00:0:1", 2, 3, "1-ff00:0:2")},
+			activationErr:     serrors.New("activation failed"),
+			expectedMigration: true,
 		},
-		"same_config": {
-			rsvs:              []*seg.Reservation{r1, r2},
-			confs:             []*configuration{c1, c1_copy},
-			expectedConfToRsv: []int{0, -1},
+		"path_gone_unconvertible_path": {
+			paths: []snet.Path{
+				bad,
+				te.NewSnetPath("1-ff00:0:1", 2, 3, "1-ff00:0:2"),
+			},
+			expectedMigration: true,
+			expectedTeardown:  true,
 		},
-		"no_config": {
-			rsvs:              []*seg.Reservation{r1, r2},
-			confs:             []*configuration{},
-			expectedConfToRsv: []int{},
+		"path_gone_only_unconvertible_paths": {
+			paths: []snet.Path{bad},
+		},
+		"path_gone_throttled": {
+			paths:     []snet.Path{te.NewSnetPath("1-ff00:0:1", 2, 3, "1-ff00:0:2")},
+			throttled: true,
 		},
 	}
 	for name, tc := range cases {
 		name, tc := name, tc
 		t.Run(name, func(t *testing.T) {
-			// check that the test case is well formed
-			require.Equal(t, len(tc.confs), len(tc.expectedConfToRsv),
-				"wrong use case: expected matches must have the same length as confs")
-
-			entries := matchRsvsWithConfiguration(tc.rsvs, tc.confs)
-			require.Len(t, entries, len(tc.confs))
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
 
-			confToReservation := make(map[*configuration]*seg.Reservation)
-			for i, e := range tc.expectedConfToRsv {
-				var r *seg.Reservation
-				if e >= 0 {
-					r = tc.rsvs[e]
+			oldRsv := newRsv()
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			entries := matchRsvsWithConfiguration([]*seg.Reservation{oldRsv},
+				[]*configuration{conf})
+			keeper := keeper{
+				now: func() time.Time {
+					return now
+				},
+				localIA:  xtest.MustParseIA("1-ff00:0:1"),
+				provider: manager,
+				entries:  entries,
+				setups:   newSetupLimiter(nil),
+			}
+			if tc.throttled {
+				// all the setups allowed to the destination were already done
+				for i := 0; i < defaultSetupBurst; i++ {
+					_, ok := keeper.setups.allow(conf.dst, now)
+					require.True(t, ok)
 				}
-				_, ok := confToReservation[tc.confs[i]]
-				require.False(t, ok)
-				confToReservation[tc.confs[i]] = r
 			}
-			for i, e := range entries {
-				require.Contains(t, confToReservation, e.conf)
-				require.Same(t, confToReservation[e.conf], e.rsv,
-					"entry %d has unexpected reservation", i)
-				delete(confToReservation, e.conf)
+			var migrations, activations int
+			if tc.expectedMigration {
+				migrations = 1
+				activations = activationRetries + 1
+				if tc.activationErr == nil {
+					activations = 1
+				}
+			}
+			manager.EXPECT().PathsTo(gomock.Any(), conf.dst).Return(tc.paths, nil)
+			manager.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).
+				Times(migrations).DoAndReturn(
+				func(_ context.Context, req *seg.SetupReq) error {
+					require.Equal(t, te.NewSteps("1-ff00:0:1", 2, 3, "1-ff00:0:2"), req.Steps)
+					req.Reservation = st.NewRsv(st.WithID("ff00:0:1", "deadbeef"),
+						st.WithPath("1-ff00:0:1", 2, 3, "1-ff00:0:2"),
+						st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+						st.ConfirmAllIndices())
+					return nil
+				})
+			manager.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(activations).DoAndReturn(
+				func(_ context.Context, req *base.Request, _ base.PathSteps,
+					_ *colpath.ColibriPathMinimal, _ bool) error {
+
+					require.Equal(t, "ff00:0:1-deadbeef", req.ID.String())
+					return tc.activationErr
+				})
+			teardowns := 0
+			if tc.expectedTeardown {
+				teardowns = 1
+			}
+			manager.EXPECT().TeardownRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(teardowns).DoAndReturn(
+				func(_ context.Context, req *base.Request, _ base.PathSteps,
+					_ *colpath.ColibriPathMinimal, _ bool) error {
+
+					require.Equal(t, oldRsv.ID, req.ID)
+					return nil
+				})
+
+			wakeup, _, err := keeper.keepReservation(ctx, entries[0])
+			if tc.activationErr != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.throttled {
+				// wake up to migrate once the setup is allowed
+				require.Equal(t, now.Add(defaultSetupInterval), wakeup)
+			}
+			e := entries[0]
+			if !tc.expectedMigration {
+				require.Same(t, oldRsv, e.rsv)
+				require.Nil(t, e.replaced)
+				return
+			}
+			require.Equal(t, "ff00:0:1-deadbeef", e.rsv.ID.String())
+			if tc.expectedTeardown {
+				require.Nil(t, e.replaced)
+			} else {
+				// the old reservation is kept until the new one is active
+				require.Same(t, oldRsv, e.replaced)
 			}
 		})
 	}
 }
 
-func TestFindCompatibleConfiguration(t *testing.T) {
-	cases := map[string]struct {
-		rsv      *seg.Reservation
-		confs    []*configuration
-		expected int // index of match on `confs`, or -1
-	}{
-		"ok": {
-			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
-				st.WithPathType(reservation.UpPath),
-				st.WithTrafficSplit(2),
-				st.WithEndProps(reservation.StartLocal)),
-			confs: []*configuration{
-				{
-					dst:       xtest.MustParseIA("1-ff00:0:2"),
-					pathType:  reservation.UpPath,
+func TestKeepReservationPathRefresh(t *testing.T) {
+	const refresh = 2 * time.Minute
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+	}
+	oldRsv := st.NewRsv(st.WithID("ff00:0:1", "beefcafe"),
+		st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+		st.ConfirmAllIndices(),
+		st.WithActiveIndex(0),
+		st.WithTrafficSplit(2))
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	entries := matchRsvsWithConfiguration([]*seg.Reservation{oldRsv}, []*configuration{conf})
+	k := &keeper{
+		now: func() time.Time {
+			return now
+		},
+		localIA:     xtest.MustParseIA("1-ff00:0:1"),
+		provider:    provider,
+		entries:     entries,
+		pathRefresh: refresh,
+	}
+	e := entries[0]
+
+	// the reservation is healthy, and its path present
+	paths := []snet.Path{te.NewSnetPath("1-ff00:0:1", 1, 1, "1-ff00:0:2")}
+	refreshes := 0
+	provider.EXPECT().PathsTo(gomock.Any(), conf.dst).AnyTimes().DoAndReturn(
+		func(context.Context, addr.IA) ([]snet.Path, error) {
+			refreshes++
+			return paths, nil
+		})
+	wakeup, _, err := k.keepReservation(context.Background(), e)
+	require.NoError(t, err)
+	require.Equal(t, 1, refreshes)
+	require.Same(t, oldRsv, e.rsv)
+	// the keeper wakes up to refresh the paths, long before the renewal is needed
+	require.Equal(t, now.Add(refresh), wakeup)
+
+	// the paths change, but are not checked again before the interval elapses
+	paths = []snet.Path{te.NewSnetPath("1-ff00:0:1", 2, 3, "1-ff00:0:2")}
+	now = now.Add(refresh / 2)
+	_, _, err = k.keepReservation(context.Background(), e)
+	require.NoError(t, err)
+	require.Equal(t, 1, refreshes)
+	require.Same(t, oldRsv, e.rsv)
+
+	// the refresh detects the change and migrates the reservation
+	provider.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *seg.SetupReq) error {
+			require.Equal(t, te.NewSteps("1-ff00:0:1", 2, 3, "1-ff00:0:2"), req.Steps)
+			req.Reservation = st.NewRsv(st.WithID("ff00:0:1", "deadbeef"),
+				st.WithPath("1-ff00:0:1", 2, 3, "1-ff00:0:2"),
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices())
+			return nil
+		})
+	provider.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Return(nil)
+	provider.EXPECT().TeardownRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).Return(nil)
+	now = now.Add(refresh / 2)
+	wakeup, _, err = k.keepReservation(context.Background(), e)
+	require.NoError(t, err)
+	require.Equal(t, 2, refreshes)
+	require.Equal(t, "ff00:0:1-deadbeef", e.rsv.ID.String())
+	require.Nil(t, e.replaced)
+	require.Equal(t, now.Add(refresh), wakeup)
+}
+
+func TestAskNewReservationAdmissionHook(t *testing.T) {
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+	}
+	paths := []snet.Path{
+		te.NewSnetPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		te.NewSnetPath("1-ff00:0:1", 2, 3, "1-ff00:0:2"),
+	}
+	vetoFirst := func(_ context.Context, c *configuration, p snet.Path) (bool, error) {
+		require.Same(t, conf, c)
+		// veto the path leaving through interface 1
+		return p.Metadata().Interfaces[0].ID != 1, nil
+	}
+	cases := map[string]struct {
+		hook          AdmissionHook
+		expectedSteps base.PathSteps // nil if no setup is expected
+		expectError   bool
+	}{
+		"no_hook": {
+			expectedSteps: te.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		},
+		"admit_all": {
+			hook: func(context.Context, *configuration, snet.Path) (bool, error) {
+				return true, nil
+			},
+			expectedSteps: te.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		},
+		"veto_first": {
+			hook:          vetoFirst,
+			expectedSteps: te.NewSteps("1-ff00:0:1", 2, 3, "1-ff00:0:2"),
+		},
+		"veto_all": {
+			hook: func(context.Context, *configuration, snet.Path) (bool, error) {
+				return false, nil
+			},
+			expectError: true,
+		},
+		"hook_error": {
+			hook: func(context.Context, *configuration, snet.Path) (bool, error) {
+				return true, serrors.New("quota service unavailable")
+			},
+			expectError: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			entries := matchRsvsWithConfiguration(nil, []*configuration{conf})
+			keeper := keeper{
+				now: func() time.Time {
+					return now
+				},
+				localIA:       xtest.MustParseIA("1-ff00:0:1"),
+				provider:      manager,
+				entries:       entries,
+				AdmissionHook: tc.hook,
+			}
+			setups := 0
+			if tc.expectedSteps != nil {
+				setups = 1
+			}
+			manager.EXPECT().PathsTo(gomock.Any(), conf.dst).Return(paths, nil)
+			manager.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).
+				Times(setups).DoAndReturn(
+				func(_ context.Context, req *seg.SetupReq) error {
+					require.Equal(t, tc.expectedSteps, req.Steps)
+					req.Reservation = st.NewRsv(st.WithID("ff00:0:1", "deadbeef"),
+						st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)))
+					return nil
+				})
+
+			rsv, err := keeper.askNewReservation(context.Background(), entries[0])
+			if tc.expectError {
+				require.Error(t, err)
+				require.Nil(t, rsv)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "ff00:0:1-deadbeef", rsv.ID.String())
+		})
+	}
+}
+
+func TestStepsFromSnetPaths(t *testing.T) {
+	// a path with an odd number of interfaces cannot be converted to steps
+	bad := snetpath.Path{
+		Meta: snet.PathMetadata{
+			Interfaces: []snet.PathInterface{
+				{IA: xtest.MustParseIA("1-ff00:0:1"), ID: 1},
+				{IA: xtest.MustParseIA("1-ff00:0:2"), ID: 2},
+				{IA: xtest.MustParseIA("1-ff00:0:2"), ID: 3},
+			},
+		},
+	}
+	paths := []snet.Path{
+		te.NewSnetPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		bad,
+		te.NewSnetPath("1-ff00:0:1", 2, 3, "1-ff00:0:2"),
+		bad,
+	}
+	steps, errs := StepsFromSnetPaths(paths)
+	require.Len(t, steps, len(paths))
+	require.Len(t, errs, len(paths))
+	require.NoError(t, errs[0])
+	require.Equal(t, te.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2"), steps[0])
+	require.Error(t, errs[1])
+	require.Nil(t, steps[1])
+	require.NoError(t, errs[2])
+	require.Equal(t, te.NewSteps("1-ff00:0:1", 2, 3, "1-ff00:0:2"), steps[2])
+	require.Error(t, errs[3])
+	require.Nil(t, steps[3])
+
+	steps, errs = StepsFromSnetPaths(nil)
+	require.Empty(t, steps)
+	require.Empty(t, errs)
+
+	// the keeper skips the unconvertible paths instead of panicking
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := util.SecsToTime(10)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: &pathpol.Policy{}, // accepts all paths
+		minBW:     10,
+		maxBW:     42,
+	}
+	manager := mockmanager.NewMockServiceFacilitator(ctrl)
+	entries := matchRsvsWithConfiguration(nil, []*configuration{conf})
+	keeper := keeper{
+		now:      func() time.Time { return now },
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: manager,
+		entries:  entries,
+	}
+	manager.EXPECT().PathsTo(gomock.Any(), conf.dst).
+		Return([]snet.Path{bad, paths[2]}, nil)
+	manager.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *seg.SetupReq) error {
+			require.Equal(t, te.NewSteps("1-ff00:0:1", 2, 3, "1-ff00:0:2"), req.Steps)
+			req.Reservation = st.NewRsv(st.WithID("ff00:0:1", "deadbeef"),
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(now.AddDate(0, 0, 1))))
+			return nil
+		})
+	rsv, err := keeper.askNewReservation(context.Background(), entries[0])
+	require.NoError(t, err)
+	require.NotNil(t, rsv)
+}
+
+func TestActivateIndexRetries(t *testing.T) {
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	transient := fmt.Errorf("connection refused")
+	cases := map[string]struct {
+		errs          []error // returned by the successive activation requests
+		expectActive  bool
+		expectedCalls int
+	}{
+		"first_try": {
+			errs:          []error{nil},
+			expectActive:  true,
+			expectedCalls: 1,
+		},
+		"transient_then_ok": {
+			errs:          []error{transient, nil},
+			expectActive:  true,
+			expectedCalls: 2,
+		},
+		"always_transient": {
+			errs:          []error{transient, transient, transient},
+			expectedCalls: activationRetries + 1,
+		},
+		"rejected": {
+			errs:          []error{serrors.WrapStr("activating", errRequestRejected), nil},
+			expectedCalls: 1,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rsv := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices(),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(conf.endProps))
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			entries := matchRsvsWithConfiguration([]*seg.Reservation{rsv},
+				[]*configuration{conf})
+			keeper := keeper{
+				now: func() time.Time {
+					return now
+				},
+				localIA:  xtest.MustParseIA("1-ff00:0:1"),
+				provider: manager,
+				entries:  entries,
+			}
+			calls := 0
+			manager.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(tc.expectedCalls).DoAndReturn(
+				func(context.Context, *base.Request, base.PathSteps,
+					*colpath.ColibriPathMinimal, bool) error {
+
+					calls++
+					return tc.errs[calls-1]
+				})
+
+			err := keeper.activateIndex(ctx, entries[0], 0)
+			if tc.expectActive {
+				require.NoError(t, err)
+				require.NotNil(t, rsv.ActiveIndex())
+				require.Equal(t, reservation.IndexNumber(0), rsv.ActiveIndex().Idx)
+			} else {
+				require.Error(t, err)
+				require.Nil(t, rsv.ActiveIndex())
+			}
+		})
+	}
+}
+
+func TestActivateIndexCompliance(t *testing.T) {
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	cases := map[string]struct {
+		minBW, maxBW, allocBW int // of the index to activate
+		expectActive          bool
+	}{
+		"compliant": {
+			minBW:        12,
+			maxBW:        42,
+			allocBW:      30,
+			expectActive: true,
+		},
+		"over_max": {
+			minBW:   12,
+			maxBW:   63,
+			allocBW: 50,
+		},
+		"alloc_over_max": {
+			minBW:   12,
+			maxBW:   42,
+			allocBW: 50,
+		},
+		"below_min": {
+			minBW:   5,
+			maxBW:   42,
+			allocBW: 20,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rsv := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.AddIndex(0, st.WithBW(tc.minBW, tc.maxBW, tc.allocBW),
+					st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices(),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(conf.endProps))
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			entries := matchRsvsWithConfiguration([]*seg.Reservation{rsv},
+				[]*configuration{conf})
+			keeper := keeper{
+				now: func() time.Time {
+					return now
+				},
+				localIA:  xtest.MustParseIA("1-ff00:0:1"),
+				provider: manager,
+				entries:  entries,
+			}
+			activations := 0
+			if tc.expectActive {
+				activations = 1
+			}
+			manager.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(activations).DoAndReturn(
+				func(_ context.Context, req *base.Request, _ base.PathSteps,
+					_ *colpath.ColibriPathMinimal, _ bool) error {
+
+					require.Equal(t, rsv.ID, req.ID)
+					require.Equal(t, reservation.IndexNumber(0), req.Index)
+					return nil
+				})
+
+			err := keeper.activateIndex(context.Background(), entries[0], 0)
+			if tc.expectActive {
+				require.NoError(t, err)
+				active := rsv.ActiveIndex()
+				require.NotNil(t, active)
+				require.Equal(t, reservation.IndexNumber(0), active.Idx)
+				require.Equal(t, reservation.BWCls(tc.allocBW), active.AllocBW)
+				require.Equal(t, active.AllocBW, active.Token.BWCls)
+				return
+			}
+			require.Error(t, err)
+			require.ErrorIs(t, err, errIndexNotCompliant)
+			require.Nil(t, rsv.ActiveIndex())
+			// the refused index is left as it was
+			require.Equal(t, seg.IndexPending, rsv.Index(0).State)
+		})
+	}
+
+	// when kept, the reservation skips the over-max index and activates a compliant one
+	t.Run("keep_skips_over_max", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		rsv := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			st.AddIndex(0, st.WithBW(12, 42, 30), st.WithExpiration(tomorrow)),
+			st.AddIndex(1, st.WithBW(12, 63, 50), st.WithExpiration(tomorrow)),
+			st.ConfirmAllIndices(),
+			st.WithNoActiveIndex(),
+			st.WithTrafficSplit(2),
+			st.WithEndProps(conf.endProps))
+		manager := mockmanager.NewMockServiceFacilitator(ctrl)
+		entries := matchRsvsWithConfiguration([]*seg.Reservation{rsv},
+			[]*configuration{conf})
+		keeper := keeper{
+			now: func() time.Time {
+				return now
+			},
+			localIA:  xtest.MustParseIA("1-ff00:0:1"),
+			provider: manager,
+			entries:  entries,
+		}
+		manager.EXPECT().PathsTo(gomock.Any(), conf.dst).Return(
+			[]snet.Path{te.NewSnetPath("1-ff00:0:1", 1, 1, "1-ff00:0:2")}, nil)
+		manager.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, req *base.Request, _ base.PathSteps,
+				_ *colpath.ColibriPathMinimal, _ bool) error {
+
+				require.Equal(t, reservation.IndexNumber(0), req.Index)
+				return nil
+			})
+
+		_, action, err := keeper.keepReservation(context.Background(), entries[0])
+		require.NoError(t, err)
+		require.Equal(t, KeptActivated, action)
+		require.Equal(t, reservation.IndexNumber(0), rsv.ActiveIndex().Idx)
+		require.Equal(t, reservation.BWCls(30), rsv.ActiveIndex().AllocBW)
+		// the over-max index is not activated
+		require.Equal(t, seg.IndexPending, rsv.Index(1).State)
+	})
+}
+
+func TestDowngrade(t *testing.T) {
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	newRsv := func() *seg.Reservation {
+		return st.NewRsv(st.WithID("ff00:0:1", "beefcafe"),
+			st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			st.AddIndex(0, st.WithBW(12, 42, 30),
+				st.WithExpiration(tomorrow)),
+			st.WithActiveIndex(0),
+			st.WithTrafficSplit(2),
+			st.WithEndProps(conf.endProps))
+	}
+	cases := map[string]struct {
+		id               string
+		bw               reservation.BWCls
+		expectedRequests int
+		expectError      bool
+	}{
+		"ok": {
+			id:               "ff00:0:1-beefcafe",
+			bw:               20,
+			expectedRequests: 1,
+		},
+		"unknown_id": {
+			id:          "ff00:0:1-deadbeef",
+			bw:          20,
+			expectError: true,
+		},
+		"not_lower": {
+			id:          "ff00:0:1-beefcafe",
+			bw:          30,
+			expectError: true,
+		},
+		"below_min": {
+			id:          "ff00:0:1-beefcafe",
+			bw:          9,
+			expectError: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rsv := newRsv()
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			entries := matchRsvsWithConfiguration([]*seg.Reservation{rsv}, []*configuration{conf})
+			keeper := keeper{
+				now: func() time.Time {
+					return now
+				},
+				localIA:  xtest.MustParseIA("1-ff00:0:1"),
+				provider: manager,
+				entries:  entries,
+			}
+			manager.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).
+				Times(tc.expectedRequests).DoAndReturn(
+				func(_ context.Context, req *seg.SetupReq) error {
+					require.Equal(t, tc.bw, req.MaxBW)
+					_, err := req.Reservation.NewIndex(req.Index, tomorrow, req.MinBW, req.MaxBW,
+						req.MaxBW, 0, req.PathType)
+					require.NoError(t, err)
+					return req.Reservation.SetIndexConfirmed(req.Index)
+				})
+			manager.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(tc.expectedRequests).DoAndReturn(
+				func(_ context.Context, req *base.Request, _ base.PathSteps,
+					_ *colpath.ColibriPathMinimal, _ bool) error {
+
+					// the downgraded index is activated, and not the previously active one
+					require.Equal(t, rsv.ID, req.ID)
+					require.Equal(t, reservation.IndexNumber(1), req.Index)
+					require.Equal(t, tc.bw, rsv.Index(req.Index).AllocBW)
+					return nil
+				})
+
+			id, err := reservation.IDFromString(tc.id)
+			require.NoError(t, err)
+			idx, err := keeper.Downgrade(ctx, *id, tc.bw)
+			if tc.expectError {
+				require.Error(t, err)
+				require.Equal(t, reservation.IndexNumber(0), rsv.ActiveIndex().Idx)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, reservation.IndexNumber(1), idx)
+			require.Equal(t, idx, rsv.ActiveIndex().Idx)
+			require.Equal(t, tc.bw, rsv.ActiveIndex().AllocBW)
+			// renewals must keep the lower bandwidth
+			renewal := keeper.entries[0].PrepareRenewalRequest(now, tomorrow)
+			require.Equal(t, tc.bw, renewal.MaxBW)
+		})
+	}
+}
+
+func TestNewKeeperOrphans(t *testing.T) {
+	matched := st.NewRsv(st.WithID("ff00:0:1", "beefcafe"),
+		st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		st.WithPathType(reservation.UpPath),
+		st.WithTrafficSplit(1),
+		st.WithEndProps(reservation.StartLocal))
+	orphan := st.NewRsv(st.WithID("ff00:0:1", "deadbeef"),
+		st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		st.WithPathType(reservation.UpPath),
+		st.WithTrafficSplit(2),
+		st.WithEndProps(reservation.StartLocal))
+	newConf := func(teardown bool) *conf.Reservations {
+		return &conf.Reservations{
+			Rsvs: []conf.ReservationEntry{{
+				DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+				PathType:      reservation.UpPath,
+				PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+				MinSize:       10,
+				MaxSize:       42,
+				SplitCls:      1,
+				EndProps:      conf.EndProps(reservation.StartLocal),
+			}},
+			TeardownOrphans: teardown,
+		}
+	}
+	cases := map[string]struct {
+		teardown          bool
+		expectedTeardowns int
+	}{
+		"report_only": {
+			teardown:          false,
+			expectedTeardowns: 0,
+		},
+		"teardown": {
+			teardown:          true,
+			expectedTeardowns: 1,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			manager.EXPECT().DeleteExpiredIndices(gomock.Any()).Return(nil)
+			manager.EXPECT().GetReservationsAtSource(gomock.Any()).
+				Return([]*seg.Reservation{matched, orphan}, nil)
+			manager.EXPECT().TeardownRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(tc.expectedTeardowns).DoAndReturn(
+				func(_ context.Context, req *base.Request, _ base.PathSteps,
+					_ *colpath.ColibriPathMinimal, _ bool) error {
+					require.Equal(t, orphan.ID, req.ID)
+					return nil
+				})
+
+			keeper, orphans, err := NewKeeper(ctx, manager, newConf(tc.teardown),
+				xtest.MustParseIA("1-ff00:0:1"))
+			require.NoError(t, err)
+			<-keeper.cleanupDone
+			require.Len(t, keeper.entries, 1)
+			require.Same(t, matched, keeper.entries[0].rsv)
+			require.Len(t, orphans, 1)
+			require.Same(t, orphan, orphans[0])
+		})
+	}
+}
+
+func TestNewKeeperStartupCleanup(t *testing.T) {
+	cases := map[string]struct {
+		conf             *conf.Reservations
+		expectedCleanups int
+	}{
+		"no_configuration": {
+			expectedCleanups: 1,
+		},
+		"default": {
+			conf:             &conf.Reservations{},
+			expectedCleanups: 1,
+		},
+		"skipped": {
+			conf: &conf.Reservations{
+				SkipStartupCleanup: true,
+			},
+			expectedCleanups: 0,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			read := manager.EXPECT().GetReservationsAtSource(gomock.Any()).Return(nil, nil)
+			// the cleanup does not delay reading the reservations
+			manager.EXPECT().DeleteExpiredIndices(gomock.Any()).After(read).
+				Times(tc.expectedCleanups).Return(nil)
+
+			k, _, err := NewKeeper(ctx, manager, tc.conf, xtest.MustParseIA("1-ff00:0:1"))
+			require.NoError(t, err)
+			<-k.cleanupDone
+		})
+	}
+}
+
+func TestNewKeeperPathRefresh(t *testing.T) {
+	cases := map[string]struct {
+		conf            *conf.Reservations
+		expectedRefresh time.Duration
+	}{
+		"no_configuration": {
+			expectedRefresh: defaultPathRefreshInterval,
+		},
+		"default": {
+			conf:            &conf.Reservations{},
+			expectedRefresh: defaultPathRefreshInterval,
+		},
+		"zero": {
+			conf: &conf.Reservations{
+				PathRefreshInterval: &util.DurWrap{},
+			},
+			expectedRefresh: defaultPathRefreshInterval,
+		},
+		"configured": {
+			conf: &conf.Reservations{
+				PathRefreshInterval: &util.DurWrap{Duration: time.Minute},
+			},
+			expectedRefresh: time.Minute,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			manager.EXPECT().GetReservationsAtSource(gomock.Any()).Return(nil, nil)
+			manager.EXPECT().DeleteExpiredIndices(gomock.Any()).AnyTimes().Return(nil)
+
+			k, _, err := NewKeeper(ctx, manager, tc.conf, xtest.MustParseIA("1-ff00:0:1"))
+			require.NoError(t, err)
+			<-k.cleanupDone
+			require.Equal(t, tc.expectedRefresh, k.pathRefresh)
+
+			// the paths are not checked again until the interval elapses
+			now := util.SecsToTime(10)
+			e := &entry{pathsChecked: now}
+			require.False(t, k.pathRefreshDue(e, now))
+			require.False(t, k.pathRefreshDue(e, now.Add(tc.expectedRefresh-time.Second)))
+			require.True(t, k.pathRefreshDue(e, now.Add(tc.expectedRefresh)))
+		})
+	}
+}
+
+func TestNewKeeperDuplicates(t *testing.T) {
+	newRsv := func(suffix string, split int) *seg.Reservation {
+		return st.NewRsv(st.WithID("ff00:0:1", suffix),
+			st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			st.WithPathType(reservation.UpPath),
+			st.WithTrafficSplit(split),
+			st.WithEndProps(reservation.StartLocal))
+	}
+	entry := conf.ReservationEntry{
+		DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+		PathType:      reservation.UpPath,
+		PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+		MinSize:       10,
+		MaxSize:       42,
+		SplitCls:      1,
+		EndProps:      conf.EndProps(reservation.StartLocal),
+	}
+	cases := map[string]struct {
+		rsvs            []*seg.Reservation
+		confCount       int
+		expectedEntries int // entries with a reservation
+		expectedOrphans int
+	}{
+		"no_duplicates": {
+			rsvs:            []*seg.Reservation{newRsv("beefcafe", 1), newRsv("deadbeef", 1)},
+			confCount:       2,
+			expectedEntries: 2,
+		},
+		"duplicated": {
+			rsvs:            []*seg.Reservation{newRsv("beefcafe", 1), newRsv("beefcafe", 1)},
+			confCount:       2,
+			expectedEntries: 1,
+		},
+		"duplicated_not_orphan": {
+			rsvs:            []*seg.Reservation{newRsv("beefcafe", 1), newRsv("beefcafe", 1)},
+			confCount:       1,
+			expectedEntries: 1,
+		},
+		"duplicated_orphan": {
+			rsvs: []*seg.Reservation{
+				newRsv("beefcafe", 1),
+				newRsv("deadbeef", 2),
+				newRsv("deadbeef", 2),
+				newRsv("beefcafe", 1),
+			},
+			confCount:       1,
+			expectedEntries: 1,
+			expectedOrphans: 1,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			manager.EXPECT().DeleteExpiredIndices(gomock.Any()).Return(nil)
+			manager.EXPECT().GetReservationsAtSource(gomock.Any()).Return(tc.rsvs, nil)
+			cfg := &conf.Reservations{}
+			for i := 0; i < tc.confCount; i++ {
+				cfg.Rsvs = append(cfg.Rsvs, entry)
+			}
+
+			keeper, orphans, err := NewKeeper(ctx, manager, cfg, xtest.MustParseIA("1-ff00:0:1"))
+			require.NoError(t, err)
+			<-keeper.cleanupDone
+			require.Len(t, orphans, tc.expectedOrphans)
+			ids := make(map[string]struct{})
+			for _, e := range keeper.entries {
+				if e.rsv == nil {
+					continue
+				}
+				ids[e.rsv.ID.String()] = struct{}{}
+			}
+			require.Len(t, ids, tc.expectedEntries)
+			// one entry per unique ID
+			require.Len(t, keeper.entries, tc.confCount)
+			for _, e := range keeper.entries[tc.expectedEntries:] {
+				require.Nil(t, e.rsv)
+			}
+		})
+	}
+}
+
+func TestKeeperExportImportState(t *testing.T) {
+	newRsv := func(suffix string) *seg.Reservation {
+		return st.NewRsv(st.WithID("ff00:0:1", suffix),
+			st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			st.WithPathType(reservation.UpPath),
+			st.WithTrafficSplit(1),
+			st.WithEndProps(reservation.StartLocal))
+	}
+	entry := conf.ReservationEntry{
+		DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+		PathType:      reservation.UpPath,
+		PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+		MinSize:       10,
+		MaxSize:       42,
+		SplitCls:      1,
+		EndProps:      conf.EndProps(reservation.StartLocal),
+	}
+	cfg := &conf.Reservations{
+		Rsvs: []conf.ReservationEntry{entry, entry, entry},
+	}
+	// the exported keeper has a matching that NewKeeper would not produce
+	r1, r2, r3 := newRsv("00000001"), newRsv("00000002"), newRsv("00000003")
+	cases := map[string]struct {
+		stored    []*seg.Reservation // in the store when importing
+		expected  []string           // IDs of the entries per configuration, after importing
+		sameState bool               // nothing to reconcile, the state exports back unchanged
+	}{
+		"all_present": {
+			stored:    []*seg.Reservation{r1, r2, r3},
+			expected:  []string{r3.ID.String(), r1.ID.String(), ""},
+			sameState: true,
+		},
+		"one_gone": {
+			stored:   []*seg.Reservation{r2, r3},
+			expected: []string{r3.ID.String(), r2.ID.String(), ""},
+		},
+		"one_new": {
+			stored:   []*seg.Reservation{r1, r3, newRsv("00000004")},
+			expected: []string{r3.ID.String(), r1.ID.String(), "ff00:0:1-00000004"},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			manager.EXPECT().DeleteExpiredIndices(gomock.Any()).Return(nil).Times(2)
+			manager.EXPECT().GetReservationsAtSource(gomock.Any()).
+				Return([]*seg.Reservation{cloneR(r1), cloneR(r3)}, nil)
+			exporting, _, err := NewKeeper(ctx, manager, cfg, xtest.MustParseIA("1-ff00:0:1"))
+			require.NoError(t, err)
+			<-exporting.cleanupDone
+			require.Len(t, exporting.entries, 3)
+			// entry for conf 0 keeps r3 after a downgrade, entry for conf 1 migrates to r1
+			exporting.entries[0].rsv = cloneR(r3)
+			exporting.entries[0].maxBW = 13
+			exporting.entries[1].rsv = cloneR(r1)
+			exporting.entries[1].replaced = cloneR(r2)
+			exporting.entries[2].rsv = nil
+			state := exporting.ExportState()
+
+			manager.EXPECT().GetReservationsAtSource(gomock.Any()).
+				Return([]*seg.Reservation{cloneR(r1), cloneR(r2), cloneR(r3)}, nil)
+			k, _, err := NewKeeper(ctx, manager, cfg, xtest.MustParseIA("1-ff00:0:1"))
+			require.NoError(t, err)
+			<-k.cleanupDone
+			stored := make([]*seg.Reservation, len(tc.stored))
+			for i, r := range tc.stored {
+				stored[i] = cloneR(r)
+			}
+			manager.EXPECT().GetReservationsAtSource(gomock.Any()).Return(stored, nil)
+			err = k.ImportState(ctx, state)
+			require.NoError(t, err)
+
+			require.Len(t, k.entries, len(tc.expected))
+			got := make([]string, len(k.entries))
+			for _, e := range k.entries {
+				if e.rsv != nil {
+					got[e.conf.index] = e.rsv.ID.String()
+				}
+			}
+			require.Equal(t, tc.expected, got)
+
+			// restored entries keep their downgrade and migration
+			for _, e := range k.entries {
+				switch e.conf.index {
+				case 0:
+					require.Equal(t, reservation.BWCls(13), e.maxBW)
+				case 1:
+					if e.rsv == nil || !e.rsv.ID.Equal(&r1.ID) {
+						continue
+					}
+					if containsRsv(tc.stored, r2) {
+						require.NotNil(t, e.replaced)
+						require.Equal(t, r2.ID, e.replaced.ID)
+					} else {
+						require.Nil(t, e.replaced)
+					}
+				}
+			}
+
+			if tc.sameState {
+				require.JSONEq(t, string(state), string(k.ExportState()))
+			}
+		})
+	}
+}
+
+func TestKeeperImportStateInvalid(t *testing.T) {
+	k := &keeper{}
+	require.Error(t, k.ImportState(context.Background(), []byte("not json")))
+}
+
+func TestKeeperImportStateWaitsForRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	rsv := st.NewRsv(st.WithID("ff00:0:1", "beefcafe"),
+		st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		st.WithTrafficSplit(2),
+		st.WithEndProps(cfg.endProps))
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now:      time.Now,
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: provider,
+		entries:  matchRsvsWithConfiguration(nil, []*configuration{cfg}),
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	provider.EXPECT().PathsTo(gomock.Any(), cfg.dst).DoAndReturn(
+		func(context.Context, addr.IA) ([]snet.Path, error) {
+			close(started)
+			<-release
+			return nil, fmt.Errorf("no paths")
+		})
+	provider.EXPECT().GetReservationsAtSource(gomock.Any()).Return([]*seg.Reservation{rsv}, nil)
+
+	done := make(chan OneShotResult)
+	go func() {
+		done <- k.OneShot(context.Background())
+	}()
+	<-started
+	imported := make(chan error)
+	go func() {
+		imported <- k.ImportState(context.Background(), []byte(`{"entries":[]}`))
+	}()
+	select {
+	case <-imported:
+		t.Fatal("importing the state does not wait for the keeper run")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	res := <-done
+	require.Equal(t, 1, res.Counts[KeptFailed])
+	require.NoError(t, <-imported)
+	// the entries replaced by the import are the ones kept from now on
+	require.Len(t, k.entries, 1)
+	require.Same(t, rsv, k.entries[0].rsv)
+}
+
+func containsRsv(rsvs []*seg.Reservation, r *seg.Reservation) bool {
+	for _, other := range rsvs {
+		if other.ID.Equal(&r.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestForget(t *testing.T) {
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	rsv := st.NewRsv(st.WithID("ff00:0:1", "beefcafe"),
+		st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		st.WithTrafficSplit(2),
+		st.WithEndProps(conf.endProps))
+	keeper := keeper{
+		entries: matchRsvsWithConfiguration([]*seg.Reservation{rsv}, []*configuration{conf}),
+	}
+	require.Len(t, keeper.entries, 1)
+	require.NotNil(t, keeper.entries[0].rsv)
+
+	other, err := reservation.IDFromString("ff00:0:1-deadbeef")
+	require.NoError(t, err)
+	keeper.Forget(*other)
+	require.NotNil(t, keeper.entries[0].rsv)
+
+	keeper.Forget(rsv.ID)
+	require.Nil(t, keeper.entries[0].rsv)
+	require.Same(t, conf, keeper.entries[0].conf)
+}
+
+func TestPlan(t *testing.T) {
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	// too soon to be compliant
+	soon := now.Add(minDuration / 4)
+	later := now.Add(minDuration / 2)
+	newRsv := func(mods ...st.ReservationMod) *seg.Reservation {
+		mods = append([]st.ReservationMod{
+			st.WithID("ff00:0:1", "beefcafe"),
+			st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			st.WithTrafficSplit(2),
+			st.WithEndProps(conf.endProps),
+		}, mods...)
+		return st.NewRsv(mods...)
+	}
+	cases := map[string]struct {
+		rsv                *seg.Reservation
+		expectedCompliance Compliance
+		expectedAction     PlanAction
+	}{
+		"no_reservation": {
+			expectedAction: ActionCreate,
+		},
+		"compliant": {
+			rsv: newRsv(
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+				st.WithActiveIndex(0)),
+			expectedCompliance: Compliant,
+			expectedAction:     ActionNothing,
+		},
+		"needs_activation": {
+			rsv: newRsv(
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices()),
+			expectedCompliance: NeedsActivation,
+			expectedAction:     ActionActivate,
+		},
+		"needs_indices": {
+			rsv: newRsv(
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(soon)),
+				st.WithActiveIndex(0)),
+			expectedCompliance: NeedsIndices,
+			expectedAction:     ActionRenew,
+		},
+		"needs_indices_pending": {
+			rsv: newRsv(
+				st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(soon)),
+				st.AddIndex(1, st.WithBW(12, 42, 0), st.WithExpiration(later)),
+				st.ConfirmAllIndices(),
+				st.WithActiveIndex(0)),
+			expectedCompliance: NeedsIndices,
+			expectedAction:     ActionActivate,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			rsvs := []*seg.Reservation{}
+			if tc.rsv != nil {
+				rsvs = append(rsvs, tc.rsv)
+			}
+			wakeup := now.Add(time.Minute)
+			keeper := keeper{
+				now: func() time.Time {
+					return now
+				},
+				sleepUntil: wakeup,
+				entries:    matchRsvsWithConfiguration(rsvs, []*configuration{conf}),
+			}
+			var indices int
+			if tc.rsv != nil {
+				indices = len(tc.rsv.Indices)
+			}
+
+			plan, gotWakeup := keeper.Plan()
+			require.Equal(t, wakeup, gotWakeup)
+			require.Len(t, plan, 1)
+			e := plan[0]
+			require.Equal(t, conf.dst, e.Dst)
+			require.Equal(t, conf.minBW, e.MinBW)
+			require.Equal(t, conf.maxBW, e.MaxBW)
+			require.Equal(t, tc.expectedAction, e.Action)
+			if tc.rsv == nil {
+				require.Nil(t, e.ID)
+				return
+			}
+			require.Equal(t, tc.rsv.ID, *e.ID)
+			require.Equal(t, tc.expectedCompliance, e.Compliance)
+			// computing the plan modifies nothing
+			require.Len(t, tc.rsv.Indices, indices)
+		})
+	}
+}
+
+func TestUnmatched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newConf := func(dst, predicate string) *configuration {
+		return &configuration{
+			dst:          xtest.MustParseIA(dst),
+			pathType:     reservation.UpPath,
+			predicate:    newSequence(t, predicate),
+			predicateStr: predicate,
+			minBW:        10,
+			maxBW:        42,
+			splitCls:     2,
+			labels:       map[string]string{"dst": dst},
+		}
+	}
+	confs := []*configuration{
+		newConf("1-ff00:0:2", "1-ff00:0:1 1-ff00:0:2"),             // direct, matched
+		newConf("1-ff00:0:2", "1-ff00:0:1 1-ff00:0:88 1-ff00:0:2"), // link removed
+		newConf("1-ff00:0:3", "1-ff00:0:1 0* 1-ff00:0:3"),          // no paths at all
+		newConf("1-ff00:0:4", "1-ff00:0:1 0* 1-ff00:0:4"),          // error looking up
+		newConf("1-ff00:0:2", "1-ff00:0:1 1-ff00:0:77 1-ff00:0:2"), // matched
+	}
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now:      func() time.Time { return util.SecsToTime(10) },
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: provider,
+		entries:  matchRsvsWithConfiguration(nil, confs),
+	}
+	// the paths to each destination are looked up only once
+	provider.EXPECT().PathsTo(gomock.Any(), xtest.MustParseIA("1-ff00:0:2")).Times(1).Return(
+		[]snet.Path{
+			te.NewSnetPath("1-ff00:0:1", 1, 2, "1-ff00:0:2"),
+			te.NewSnetPath("1-ff00:0:1", 3, 1, "1-ff00:0:77", 2, 4, "1-ff00:0:2"),
+		}, nil)
+	provider.EXPECT().PathsTo(gomock.Any(), xtest.MustParseIA("1-ff00:0:3")).Times(1).Return(
+		nil, nil)
+	provider.EXPECT().PathsTo(gomock.Any(), xtest.MustParseIA("1-ff00:0:4")).Times(1).Return(
+		nil, fmt.Errorf("no route"))
+
+	unmatched := k.Unmatched(context.Background())
+	require.Len(t, unmatched, 3)
+	require.Equal(t, confs[1].dst, unmatched[0].Dst)
+	require.Equal(t, confs[1].predicateStr, unmatched[0].Predicate)
+	require.Equal(t, 2, unmatched[0].Paths)
+	require.NoError(t, unmatched[0].Err)
+	require.Equal(t, confs[2].dst, unmatched[1].Dst)
+	require.Equal(t, 0, unmatched[1].Paths)
+	require.NoError(t, unmatched[1].Err)
+	require.Equal(t, confs[3].dst, unmatched[2].Dst)
+	require.Error(t, unmatched[2].Err)
+	require.True(t, unmatched[2].HasLabels(map[string]string{"dst": "1-ff00:0:4"}))
+	require.False(t, unmatched[2].HasLabels(map[string]string{"dst": "1-ff00:0:3"}))
+}
+
+func TestKeeperTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := util.SecsToTime(0)
+	clock := func() time.Time {
+		return now
+	}
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now:      clock,
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: provider,
+		entries:  matchRsvsWithConfiguration(nil, []*configuration{conf}),
+	}
+	task := NewKeeperTask(k)
+	task.now = clock
+
+	// every invocation of OneShot will try to obtain a new reservation for the entry, and
+	// fail. The keeper then asks to be woken up after sleepAtLeast.
+	var invocations []time.Time
+	provider.EXPECT().PathsTo(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(context.Context, addr.IA) ([]snet.Path, error) {
+			invocations = append(invocations, now)
+			return nil, fmt.Errorf("no paths")
+		})
+
+	// emulate the periodic runner, calling Run every second
+	for i := 0; i <= 10; i++ {
+		now = util.SecsToTime(uint32(i))
+		task.Run(context.Background())
+	}
+	require.Equal(t, []time.Time{
+		util.SecsToTime(0),
+		util.SecsToTime(4),
+		util.SecsToTime(8),
+	}, invocations)
+}
+
+func TestRequirementsCompliance(t *testing.T) {
+	now := util.SecsToTime(0)
+	tomorrow := now.Add(3600 * 24 * time.Second)
+	reqs := &configuration{
+		pathType:  reservation.UpPath,
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	cases := map[string]struct {
+		conf               *configuration
+		rsv                *seg.Reservation
+		atLeastUntil       time.Time
+		expectedCompliance Compliance
+	}{
+		"compliant, one index": {
+			conf: reqs,
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(tomorrow)),
+				st.WithPathType(reservation.UpPath),
+				st.WithActiveIndex(0),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reqs.endProps)),
+			atLeastUntil:       now,
+			expectedCompliance: Compliant,
+		},
+		"one non compliant index, minbw": {
+			conf: reqs,
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.AddIndex(0, st.WithBW(1, 24, 0), st.WithExpiration(tomorrow)),
+				st.WithActiveIndex(0),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reqs.endProps)),
+			atLeastUntil:       now,
+			expectedCompliance: NeedsIndices,
+		},
+		"one non compliant index, maxbw": {
+			conf: reqs,
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.AddIndex(0, st.WithBW(12, 44, 0), st.WithExpiration(tomorrow)),
+				st.WithActiveIndex(0),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reqs.endProps)),
+			atLeastUntil:       now,
+			expectedCompliance: NeedsIndices,
+		},
+		"one non compliant index, expired": {
+			conf: reqs,
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(now)),
+				st.WithActiveIndex(0),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reqs.endProps)),
+			atLeastUntil:       now,
+			expectedCompliance: NeedsIndices,
+		},
+		"no active indices": {
+			conf: reqs,
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices(),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reqs.endProps)),
+			atLeastUntil:       now,
+			expectedCompliance: NeedsActivation,
+		},
+		"no indices": {
+			conf: reqs,
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reqs.endProps)),
+			atLeastUntil:       now,
+			expectedCompliance: NeedsIndices,
+		},
+		"compliant, core": {
+			conf: &configuration{
+				pathType:  reservation.CorePath,
+				predicate: newSequence(t, "1-ff00:0:1 0* 2-ff00:0:3"),
+				minBW:     10,
+				maxBW:     42,
+				splitCls:  2,
+				endProps:  reservation.StartLocal | reservation.EndTransfer,
+			},
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1, "2-ff00:0:3"),
+				st.WithPathType(reservation.CorePath),
+				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(tomorrow)),
+				st.WithActiveIndex(0),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reservation.StartLocal|reservation.EndTransfer)),
+			atLeastUntil:       now,
+			expectedCompliance: Compliant,
+		},
+		"core, needs activation": {
+			conf: &configuration{
+				pathType:  reservation.CorePath,
+				predicate: newSequence(t, "1-ff00:0:1 0* 2-ff00:0:3"),
+				minBW:     10,
+				maxBW:     42,
+				splitCls:  2,
+				endProps:  reservation.StartLocal | reservation.EndTransfer,
+			},
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1, "2-ff00:0:3"),
+				st.WithPathType(reservation.CorePath),
+				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices(),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reservation.StartLocal|reservation.EndTransfer)),
+			atLeastUntil:       now,
+			expectedCompliance: NeedsActivation,
+		},
+		"compliant in the past, not now": {
+			conf: reqs,
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(tomorrow)),
+				st.AddIndex(1, st.WithBW(1, 24, 0), st.WithExpiration(tomorrow)),
+				st.WithActiveIndex(1), // will destroy index 0
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reqs.endProps)),
+			atLeastUntil:       now,
+			expectedCompliance: NeedsIndices,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			entry := &entry{
+				conf: tc.conf,
+				rsv:  tc.rsv,
+			}
+			c := compliance(entry, tc.atLeastUntil)
+			require.Equal(t, tc.expectedCompliance, c,
+				"expected %s got %s", tc.expectedCompliance, c)
+		})
+	}
+}
+
+func TestPrepareSetupRequestPathType(t *testing.T) {
+	now := util.SecsToTime(0)
+	tomorrow := now.Add(3600 * 24 * time.Second)
+	// the SCION path always goes from the local AS to the destination
+	path := te.NewSnetPath("1-ff00:0:1", 1, 2, "1-ff00:0:2", 3, 4, "1-ff00:0:3")
+	pathSteps, err := base.StepsFromSnet(path)
+	require.NoError(t, err)
+	cases := map[string]struct {
+		pathType            reservation.PathType
+		endProps            reservation.PathEndProps
+		expectedSteps       base.PathSteps
+		expectedCurrentStep int
+	}{
+		"core": {
+			pathType:            reservation.CorePath,
+			endProps:            reservation.StartLocal,
+			expectedSteps:       te.NewSteps("1-ff00:0:1", 1, 2, "1-ff00:0:2", 3, 4, "1-ff00:0:3"),
+			expectedCurrentStep: 0,
+		},
+		"up": {
+			pathType:            reservation.UpPath,
+			endProps:            reservation.StartLocal,
+			expectedSteps:       te.NewSteps("1-ff00:0:1", 1, 2, "1-ff00:0:2", 3, 4, "1-ff00:0:3"),
+			expectedCurrentStep: 0,
+		},
+		"down": {
+			pathType:            reservation.DownPath,
+			endProps:            reservation.EndLocal,
+			expectedSteps:       te.NewSteps("1-ff00:0:3", 4, 3, "1-ff00:0:2", 2, 1, "1-ff00:0:1"),
+			expectedCurrentStep: 2,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			e := &entry{
+				conf: &configuration{
+					dst:       xtest.MustParseIA("1-ff00:0:3"),
+					pathType:  tc.pathType,
+					predicate: newSequence(t, "1-ff00:0:1 0* 1-ff00:0:3"),
+					minBW:     10,
+					maxBW:     42,
+					splitCls:  2,
+					endProps:  tc.endProps,
+				},
+			}
+			req, err := e.PrepareSetupRequest(now, tomorrow, xtest.MustParseAS("ff00:0:1"),
+				pathSteps)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSteps, req.Steps)
+			require.Equal(t, tc.expectedCurrentStep, req.CurrentStep)
+			require.Equal(t, tc.pathType, req.PathType)
+			require.NoError(t, req.Validate(nil))
+
+			e.rsv = st.NewRsv(st.WithPathType(tc.pathType))
+			e.rsv.Steps = req.Steps
+			require.True(t, e.UsesAnyOf([]base.PathSteps{pathSteps}))
+		})
+	}
+
+	// a reservation needs at least two steps
+	e := &entry{conf: &configuration{pathType: reservation.CorePath}}
+	_, err = e.PrepareSetupRequest(now, tomorrow, xtest.MustParseAS("ff00:0:1"), pathSteps[:1])
+	require.Error(t, err)
+}
+
+func TestParseInitialFloors(t *testing.T) {
+	newConf := func(pathType reservation.PathType, minSize reservation.BWCls,
+		floors *conf.MinSizeFloors) *conf.Reservations {
+
+		return &conf.Reservations{
+			Rsvs: []conf.ReservationEntry{{
+				DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+				PathType:      pathType,
+				PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+				MinSize:       minSize,
+				MaxSize:       42,
+				SplitCls:      1,
+			}},
+			MinSizeFloors: floors,
+		}
+	}
+	cases := map[string]struct {
+		conf    *conf.Reservations
+		isValid bool
+	}{
+		"no_floors": {
+			conf:    newConf(reservation.CorePath, 1, nil),
+			isValid: true,
+		},
+		"above_floor": {
+			conf:    newConf(reservation.CorePath, 10, &conf.MinSizeFloors{Core: 10}),
+			isValid: true,
+		},
+		"below_floor": {
+			conf:    newConf(reservation.CorePath, 9, &conf.MinSizeFloors{Core: 10}),
+			isValid: false,
+		},
+		"floor_other_path_type": {
+			conf:    newConf(reservation.DownPath, 1, &conf.MinSizeFloors{Core: 10}),
+			isValid: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseInitial(tc.conf, xtest.MustParseIA("1-ff00:0:1"))
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestParseInitialLocalDestination(t *testing.T) {
+	localIA := xtest.MustParseIA("1-ff00:0:1")
+	newEntry := func(dst string) conf.ReservationEntry {
+		return conf.ReservationEntry{
+			DstAS:         xtest.MustParseIA(dst),
+			PathType:      reservation.CorePath,
+			PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+			MinSize:       1,
+			MaxSize:       42,
+			SplitCls:      1,
+		}
+	}
+	cases := map[string]struct {
+		conf    *conf.Reservations
+		isValid bool
+	}{
+		"remote": {
+			conf: &conf.Reservations{
+				Rsvs: []conf.ReservationEntry{newEntry("1-ff00:0:2")},
+			},
+			isValid: true,
+		},
+		"local": {
+			conf: &conf.Reservations{
+				Rsvs: []conf.ReservationEntry{newEntry("1-ff00:0:1")},
+			},
+		},
+		"local_second_entry": {
+			conf: &conf.Reservations{
+				Rsvs: []conf.ReservationEntry{newEntry("1-ff00:0:2"), newEntry("1-ff00:0:1")},
+			},
+		},
+		"same_as_other_isd": {
+			conf: &conf.Reservations{
+				Rsvs: []conf.ReservationEntry{newEntry("2-ff00:0:1")},
+			},
+			isValid: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := parseInitial(tc.conf, localIA)
+			if tc.isValid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "local AS")
+			}
+		})
+	}
+}
+
+func TestParseInitialRLC(t *testing.T) {
+	cases := map[string]struct {
+		rlc     reservation.RLC
+		isValid bool
+	}{
+		"default": {
+			rlc:     0,
+			isValid: true,
+		},
+		"low_latency": {
+			rlc:     3,
+			isValid: true,
+		},
+		"max": {
+			rlc:     63,
+			isValid: true,
+		},
+		"out_of_range": {
+			rlc:     64,
+			isValid: false,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			now := util.SecsToTime(10)
+			tomorrow := now.AddDate(0, 0, 1)
+			confs, err := parseInitial(&conf.Reservations{
+				Rsvs: []conf.ReservationEntry{{
+					DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+					PathType:      reservation.CorePath,
+					PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+					MinSize:       1,
+					MaxSize:       42,
+					SplitCls:      1,
+					RLC:           tc.rlc,
+				}},
+			}, xtest.MustParseIA("1-ff00:0:1"))
+			if !tc.isValid {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "rlc")
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, confs, 1)
+			e := &entry{conf: confs[0]}
+			req, err := e.PrepareSetupRequest(now, tomorrow, xtest.MustParseAS("ff00:0:1"),
+				te.NewSteps("1-ff00:0:1", 1, 2, "1-ff00:0:2"))
+			require.NoError(t, err)
+			require.Equal(t, tc.rlc, req.RLC)
+
+			e.rsv = st.NewRsv(st.WithPath("1-ff00:0:1", 1, 2, "1-ff00:0:2"),
+				st.AddIndex(0, st.WithBW(1, 42, 0), st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices())
+			req = e.PrepareRenewalRequest(now, tomorrow)
+			require.Equal(t, tc.rlc, req.RLC)
+		})
+	}
+}
+
+func TestPrepareRenewalTransportDirection(t *testing.T) {
+	cases := map[string]struct {
+		rsv              *seg.Reservation
+		transportReverse bool // R flag of the stored transport path
+		expectedReverse  bool // R flag of the transport in the renewal
+	}{
+		"up": {
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath)),
+			transportReverse: false,
+			expectedReverse:  false,
+		},
+		"up_reversed": {
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath)),
+			transportReverse: true,
+			expectedReverse:  false,
+		},
+		"down": {
+			// the steps of down-path reservations go from the destination to the local AS
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:2", 1, 1, "1-ff00:0:1"),
+				st.WithPathType(reservation.DownPath)),
+			transportReverse: true,
+			expectedReverse:  true,
+		},
+		"down_along_steps": {
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:2", 1, 1, "1-ff00:0:1"),
+				st.WithPathType(reservation.DownPath)),
+			transportReverse: false,
+			expectedReverse:  true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			now := util.SecsToTime(10)
+			tomorrow := now.AddDate(0, 0, 1)
+			transport := te.NewColPathMin(tc.rsv.Steps)
+			if tc.transportReverse {
+				var err error
+				transport, err = transport.ReverseAsColibri()
+				require.NoError(t, err)
+			}
+			tc.rsv.TransportPath = transport
+			e := &entry{
+				conf: &configuration{pathType: tc.rsv.PathType, minBW: 1, maxBW: 42},
+				rsv:  tc.rsv,
+			}
+
+			req := e.PrepareRenewalRequest(now, tomorrow)
+			require.NotNil(t, req.TransportPath)
+			require.Equal(t, tc.expectedReverse, req.TransportPath.InfoField.R)
+			// the transport path departs from this AS
+			expectedCurrHF := uint8(0)
+			if tc.expectedReverse {
+				expectedCurrHF = req.TransportPath.InfoField.HFCount - 1
+			}
+			require.Equal(t, expectedCurrHF, req.TransportPath.InfoField.CurrHF)
+			// the transport path of the reservation is not modified
+			require.Equal(t, tc.transportReverse, tc.rsv.TransportPath.InfoField.R)
+		})
+	}
+
+	// a transport that cannot be reversed is not used, and the renewal travels like a setup
+	t.Run("not_reversible", func(t *testing.T) {
+		t.Parallel()
+		now := util.SecsToTime(10)
+		rsv := st.NewRsv(st.WithID("ff00:0:1", "01234567"),
+			st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			st.WithPathType(reservation.CorePath),
+			st.WithEndProps(reservation.StartLocal|reservation.EndLocal|
+				reservation.EndTransfer))
+		transport, err := te.NewColPathMin(rsv.Steps).ReverseAsColibri()
+		require.NoError(t, err)
+		transport.Raw = transport.Raw[:len(transport.Raw)-1] // truncated hop fields
+		rsv.TransportPath = transport
+		e := &entry{
+			conf: &configuration{pathType: rsv.PathType, minBW: 1, maxBW: 42},
+			rsv:  rsv,
+		}
+
+		req := e.PrepareRenewalRequest(now, now.AddDate(0, 0, 1))
+		require.Nil(t, req.TransportPath)
+		require.NoError(t, req.Validate(nil))
+	})
+}
+
+// TestKeepRenewsOwnReservation sets up a reservation and renews it through the manager. As the
+// reservations set up by this AS have no transport path, the renewal travels like a setup.
+func TestKeepRenewsOwnReservation(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := util.SecsToTime(10)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		pathType:  reservation.CorePath,
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	// the store creates the reservation as it does for the local setups: with the transport
+	// path of the request
+	store.EXPECT().InitSegmentReservation(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(_ context.Context, req *seg.SetupReq) error {
+			if req.Reservation == nil {
+				rsv := seg.NewReservation(req.ID.ASID)
+				rsv.ID = *te.MustParseID("ff00:0:1", "01234567")
+				rsv.PathType = req.PathType
+				rsv.PathEndProps = req.PathProps
+				rsv.TrafficSplit = req.SplitCls
+				rsv.Steps = req.Steps
+				rsv.CurrentStep = req.CurrentStep
+				rsv.TransportPath = req.TransportPath
+				req.ID = rsv.ID
+				req.Reservation = rsv
+			}
+			_, err := req.Reservation.NewIndex(req.Index, req.ExpirationTime, req.MinBW,
+				req.MaxBW, req.MaxBW, req.RLC, req.PathType)
+			return err
+		})
+	store.EXPECT().InitConfirmSegmentReservation(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Times(2).Return(&base.ResponseSuccess{}, nil)
+	m := &manager{
+		now:   func() time.Time { return now },
+		store: store,
+	}
+
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	provider.EXPECT().PathsTo(gomock.Any(), conf.dst).AnyTimes().Return(
+		[]snet.Path{te.NewSnetPath("1-ff00:0:1", 1, 1, "1-ff00:0:2")}, nil)
+	provider.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		m.SetupRequest)
+	provider.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	k := &keeper{
+		now:      func() time.Time { return now },
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: provider,
+		entries:  matchRsvsWithConfiguration(nil, []*configuration{conf}),
+	}
+	e := k.entries[0]
+
+	_, action, err := k.keepReservation(ctx, e)
+	require.NoError(t, err)
+	require.Equal(t, KeptCreated, action)
+	require.NotNil(t, e.rsv)
+	require.Nil(t, e.rsv.TransportPath)
+	require.NotNil(t, e.rsv.ActiveIndex())
+
+	// shortly before the index expires, the reservation is renewed
+	now = e.rsv.ActiveIndex().Expiration.Add(-minDuration / 2)
+	_, action, err = k.keepReservation(ctx, e)
+	require.NoError(t, err)
+	require.Equal(t, KeptRenewed, action)
+	require.Equal(t, 2, e.rsv.Indices.Len())
+}
+
+func TestParseInitialSplitCls(t *testing.T) {
+	cases := map[string]struct {
+		splitCls reservation.SplitCls
+		isValid  bool
+	}{
+		"zero": {
+			splitCls: 0,
+			isValid:  true,
+		},
+		"max": {
+			splitCls: 63,
+			isValid:  true,
+		},
+		"out_of_range": {
+			splitCls: 64,
+			isValid:  false,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			confs, err := parseInitial(&conf.Reservations{
+				Rsvs: []conf.ReservationEntry{
+					{
+						DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+						PathType:      reservation.CorePath,
+						PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+						MinSize:       1,
+						MaxSize:       42,
+						SplitCls:      1,
+					},
+					{
+						DstAS:         xtest.MustParseIA("1-ff00:0:3"),
+						PathType:      reservation.CorePath,
+						PathPredicate: "1-ff00:0:1 1-ff00:0:3",
+						MinSize:       1,
+						MaxSize:       42,
+						SplitCls:      tc.splitCls,
+					},
+				},
+			}, xtest.MustParseIA("1-ff00:0:1"))
+			if !tc.isValid {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "split")
+				require.Contains(t, err.Error(), "entry=1")
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, confs, 2)
+			require.Equal(t, tc.splitCls, confs[1].splitCls)
+		})
+	}
+}
+
+func TestParseInitialPredicate(t *testing.T) {
+	predicates := []string{
+		"",
+		"1-ff00:0:1 1-ff00:0:2",
+		"  1-ff00:0:1#0,1 0*   1-ff00:0:2 ",
+	}
+	entries := make([]conf.ReservationEntry, len(predicates))
+	for i, p := range predicates {
+		entries[i] = conf.ReservationEntry{
+			DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+			PathType:      reservation.CorePath,
+			PathPredicate: p,
+			MinSize:       1,
+			MaxSize:       42,
+			SplitCls:      1,
+		}
+	}
+	confs, err := parseInitial(&conf.Reservations{Rsvs: entries}, xtest.MustParseIA("1-ff00:0:1"))
+	require.NoError(t, err)
+	require.Len(t, confs, len(predicates))
+	for i, c := range confs {
+		// the predicate is kept as written
+		require.Equal(t, predicates[i], c.predicateStr)
+		require.NotNil(t, c.predicate)
+	}
+	plan, _ := (&keeper{
+		now:     time.Now,
+		entries: matchRsvsWithConfiguration(nil, confs),
+	}).Plan()
+	for i, e := range plan {
+		require.Equal(t, predicates[i], e.Predicate)
+	}
+}
+
+func TestParseInitialPathPolicy(t *testing.T) {
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	paths := []snet.Path{
+		te.NewSnetPath("1-ff00:0:1", 1, 1, "1-ff00:0:3", 2, 1, "1-ff00:0:2"),
+		te.NewSnetPath("1-ff00:0:1", 2, 1, "1-ff00:0:4", 2, 2, "1-ff00:0:2"),
+		te.NewSnetPath("1-ff00:0:1", 3, 3, "1-ff00:0:2"), // direct
+	}
+	cases := map[string]struct {
+		entry         string         // JSON of the configured entry
+		expectedSteps base.PathSteps // nil if an error is expected
+	}{
+		"bare_sequence": {
+			entry: `{"destination": "1-ff00:0:2", "path_type": "core",
+				"path_predicate": "1-ff00:0:1 0+ 1-ff00:0:2"}`,
+			expectedSteps: te.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:3", 2, 1, "1-ff00:0:2"),
+		},
+		"acl_excludes_transit": {
+			entry: `{"destination": "1-ff00:0:2", "path_type": "core",
+				"path_predicate": "1-ff00:0:1 0+ 1-ff00:0:2",
+				"path_policy": {"acl": ["- 1-ff00:0:3", "+"]}}`,
+			expectedSteps: te.NewSteps("1-ff00:0:1", 2, 1, "1-ff00:0:4", 2, 2, "1-ff00:0:2"),
+		},
+		"acl_excludes_all_transit": {
+			entry: `{"destination": "1-ff00:0:2", "path_type": "core",
+				"path_policy": {"acl": ["- 1-ff00:0:3", "- 1-ff00:0:4", "+"]}}`,
+			expectedSteps: te.NewSteps("1-ff00:0:1", 3, 3, "1-ff00:0:2"),
+		},
+		"policy_sequence": {
+			entry: `{"destination": "1-ff00:0:2", "path_type": "core",
+				"path_policy": {"acl": ["- 1-ff00:0:3", "+"],
+					"sequence": "1-ff00:0:1 1-ff00:0:2"}}`,
+			expectedSteps: te.NewSteps("1-ff00:0:1", 3, 3, "1-ff00:0:2"),
+		},
+		"both_sequences": {
+			entry: `{"destination": "1-ff00:0:2", "path_type": "core",
+				"path_predicate": "1-ff00:0:1 0+ 1-ff00:0:2",
+				"path_policy": {"sequence": "1-ff00:0:1 1-ff00:0:2"}}`,
+		},
+		"bad_sequence": {
+			entry: `{"destination": "1-ff00:0:2", "path_type": "core",
+				"path_predicate": "1-ff00:0:1 ((",
+				"path_policy": {"acl": ["- 1-ff00:0:3", "+"]}}`,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			entry := conf.ReservationEntry{}
+			require.NoError(t, json.Unmarshal([]byte(tc.entry), &entry))
+			entry.MinSize = 1
+			entry.MaxSize = 42
+			confs, err := parseInitial(&conf.Reservations{
+				Rsvs: []conf.ReservationEntry{entry},
+			}, xtest.MustParseIA("1-ff00:0:1"))
+			if tc.expectedSteps == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, confs, 1)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			manager := mockmanager.NewMockServiceFacilitator(ctrl)
+			entries := matchRsvsWithConfiguration(nil, confs)
+			keeper := keeper{
+				now: func() time.Time {
+					return now
+				},
+				localIA:  xtest.MustParseIA("1-ff00:0:1"),
+				provider: manager,
+				entries:  entries,
+			}
+			manager.EXPECT().PathsTo(gomock.Any(), confs[0].dst).Return(paths, nil)
+			manager.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *seg.SetupReq) error {
+					require.Equal(t, tc.expectedSteps, req.Steps)
+					req.Reservation = st.NewRsv(st.WithID("ff00:0:1", "deadbeef"),
+						st.WithPathType(reservation.CorePath),
+						st.AddIndex(0, st.WithBW(12, 42, 0), st.WithExpiration(tomorrow)))
+					req.Reservation.Steps = req.Steps.Copy()
+					return nil
+				})
+			rsv, err := keeper.askNewReservation(context.Background(), entries[0])
+			require.NoError(t, err)
+
+			// the existing reservation matches the configuration with the policy
+			require.Equal(t, 0, findCompatibleConfiguration(rsv, confs))
+		})
+	}
+}
+
+func TestParseInitialLabels(t *testing.T) {
+	cases := map[string]struct {
+		labels  map[string]string
+		isValid bool
+	}{
+		"no_labels": {
+			isValid: true,
+		},
+		"valid": {
+			labels:  map[string]string{"tenant": "X", "role": "backup", "_zone2": "a b"},
+			isValid: true,
+		},
+		"empty_name": {
+			labels:  map[string]string{"": "X"},
+			isValid: false,
+		},
+		"name_starts_with_digit": {
+			labels:  map[string]string{"1tenant": "X"},
+			isValid: false,
+		},
+		"name_with_dash": {
+			labels:  map[string]string{"ten-ant": "X"},
+			isValid: false,
+		},
+		"reserved_name": {
+			labels:  map[string]string{"__name__": "X"},
+			isValid: false,
+		},
+		"empty_value": {
+			labels:  map[string]string{"tenant": ""},
+			isValid: false,
+		},
+		"invalid_utf8_value": {
+			labels:  map[string]string{"tenant": "\xff"},
+			isValid: false,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			confs, err := parseInitial(&conf.Reservations{
+				Rsvs: []conf.ReservationEntry{{
+					DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+					PathType:      reservation.CorePath,
+					PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+					MinSize:       1,
+					MaxSize:       42,
+					SplitCls:      1,
+					Labels:        tc.labels,
+				}},
+			}, xtest.MustParseIA("1-ff00:0:1"))
+			if !tc.isValid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, confs, 1)
+			require.Equal(t, tc.labels, confs[0].labels)
+		})
+	}
+}
+
+func TestPlanEntryHasLabels(t *testing.T) {
+	entry := PlanEntry{
+		Labels: map[string]string{"tenant": "X", "role": "backup"},
+	}
+	cases := map[string]struct {
+		labels   map[string]string
+		expected bool
+	}{
+		"no_filter": {
+			expected: true,
+		},
+		"one_label": {
+			labels:   map[string]string{"tenant": "X"},
+			expected: true,
+		},
+		"all_labels": {
+			labels:   map[string]string{"tenant": "X", "role": "backup"},
+			expected: true,
+		},
+		"different_value": {
+			labels:   map[string]string{"tenant": "Y"},
+			expected: false,
+		},
+		"missing_label": {
+			labels:   map[string]string{"tenant": "X", "zone": "a"},
+			expected: false,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, entry.HasLabels(tc.labels))
+		})
+	}
+	// entries without labels only pass an empty filter
+	require.True(t, PlanEntry{}.HasLabels(nil))
+	require.False(t, PlanEntry{}.HasLabels(map[string]string{"tenant": "X"}))
+}
+
+func TestMaxIndexLifetime(t *testing.T) {
+	now := util.SecsToTime(0)
+	pathSteps := te.NewSteps("1-ff00:0:1", 1, 2, "1-ff00:0:2")
+	cases := map[string]struct {
+		maxLifetime        time.Duration
+		expTime            time.Time
+		expectedExpiration time.Time
+	}{
+		"no_limit": {
+			expTime:            now.Add(24 * time.Hour),
+			expectedExpiration: now.Add(24 * time.Hour),
+		},
+		"below_limit": {
+			maxLifetime:        time.Hour,
+			expTime:            now.Add(time.Minute),
+			expectedExpiration: now.Add(time.Minute),
+		},
+		"at_limit": {
+			maxLifetime:        time.Hour,
+			expTime:            now.Add(time.Hour),
+			expectedExpiration: now.Add(time.Hour),
+		},
+		"clamped": {
+			maxLifetime:        time.Hour,
+			expTime:            now.Add(24 * time.Hour),
+			expectedExpiration: now.Add(time.Hour),
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			e := &entry{
+				conf: &configuration{
+					dst:         xtest.MustParseIA("1-ff00:0:2"),
+					pathType:    reservation.UpPath,
+					predicate:   newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+					minBW:       10,
+					maxBW:       42,
+					splitCls:    2,
+					maxLifetime: tc.maxLifetime,
+				},
+			}
+			req, err := e.PrepareSetupRequest(now, tc.expTime, xtest.MustParseAS("ff00:0:1"),
+				pathSteps)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedExpiration, req.ExpirationTime)
+
+			e.rsv = st.NewRsv(st.WithPath("1-ff00:0:1", 1, 2, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.AddIndex(0, st.WithBW(12, 24, 0), st.WithExpiration(now.Add(time.Minute))))
+			req = e.PrepareRenewalRequest(now, tc.expTime)
+			require.Equal(t, tc.expectedExpiration, req.ExpirationTime)
+		})
+	}
+
+	// the limit cannot be below the minimum validity of the new indices
+	newConf := func(lifetime time.Duration) *conf.Reservations {
+		return &conf.Reservations{
+			Rsvs: []conf.ReservationEntry{{
+				DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+				PathType:      reservation.CorePath,
+				PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+				MinSize:       1,
+				MaxSize:       42,
+				SplitCls:      1,
+			}},
+			MaxIndexLifetime: &util.DurWrap{Duration: lifetime},
+		}
+	}
+	_, err := parseInitial(newConf(newIndexMinDuration-time.Second),
+		xtest.MustParseIA("1-ff00:0:1"))
+	require.Error(t, err)
+	initial, err := parseInitial(newConf(newIndexMinDuration), xtest.MustParseIA("1-ff00:0:1"))
+	require.NoError(t, err)
+	require.Len(t, initial, 1)
+	require.Equal(t, newIndexMinDuration, initial[0].maxLifetime)
+}
+
+func TestParseInitialMaxReservations(t *testing.T) {
+	newConf := func(count, max int, truncate bool) *conf.Reservations {
+		rsvs := make([]conf.ReservationEntry, count)
+		for i := range rsvs {
+			rsvs[i] = conf.ReservationEntry{
+				DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+				PathType:      reservation.CorePath,
+				PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+				MinSize:       1,
+				MaxSize:       42,
+				SplitCls:      1,
+			}
+		}
+		return &conf.Reservations{
+			Rsvs:            rsvs,
+			MaxReservations: max,
+			TruncateExcess:  truncate,
+		}
+	}
+	cases := map[string]struct {
+		conf          *conf.Reservations
+		isValid       bool
+		expectedCount int
+	}{
+		"no_limit": {
+			conf:          newConf(5, 0, false),
+			isValid:       true,
+			expectedCount: 5,
+		},
+		"at_limit": {
+			conf:          newConf(5, 5, false),
+			isValid:       true,
+			expectedCount: 5,
+		},
+		"above_limit": {
+			conf: newConf(6, 5, false),
+		},
+		"above_limit_truncated": {
+			conf:          newConf(6, 5, true),
+			isValid:       true,
+			expectedCount: 5,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			initial, err := parseInitial(tc.conf, xtest.MustParseIA("1-ff00:0:1"))
+			if tc.isValid {
+				require.NoError(t, err)
+				require.Len(t, initial, tc.expectedCount)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestOneShotBoundedConcurrency(t *testing.T) {
+	const entryCount = 20
+	const maxWorkers = 3
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	confs := make([]*configuration, entryCount)
+	for i := range confs {
+		confs[i] = &configuration{
+			dst:       xtest.MustParseIA(fmt.Sprintf("1-ff00:0:%d", i+2)),
+			predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+			minBW:     10,
+			maxBW:     42,
+			splitCls:  2,
+		}
+	}
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now:        time.Now,
+		localIA:    xtest.MustParseIA("1-ff00:0:1"),
+		provider:   provider,
+		entries:    matchRsvsWithConfiguration(nil, confs),
+		maxWorkers: maxWorkers,
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	provider.EXPECT().PathsTo(gomock.Any(), gomock.Any()).Times(entryCount).DoAndReturn(
+		func(context.Context, addr.IA) ([]snet.Path, error) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil, fmt.Errorf("no paths")
+		})
+
+	res := k.OneShot(context.Background())
+	require.Error(t, res.Err())
+	require.LessOrEqual(t, maxRunning, maxWorkers)
+	require.Greater(t, maxRunning, 0)
+}
+
+// TestOneShotDoesNotBlockOtherEntries checks that, while the keeper waits for a remote call
+// for one entry, the operations on other entries can proceed.
+func TestOneShotDoesNotBlockOtherEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	confA := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	confB := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:3"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:3"),
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	rsvB := st.NewRsv(st.WithID("ff00:0:1", "beefcafe"),
+		st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:3"),
+		st.WithTrafficSplit(2),
+		st.WithEndProps(confB.endProps))
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now:      time.Now,
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: provider,
+		entries: matchRsvsWithConfiguration([]*seg.Reservation{rsvB},
+			[]*configuration{confA, confB}),
+		maxWorkers: 1,
+	}
+	entryB := k.entries[0]
+	require.Same(t, confB, entryB.conf)
+	// A is kept first, as the entry of B was kept more recently
+	entryB.keptSeq = 1
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	provider.EXPECT().PathsTo(gomock.Any(), confA.dst).DoAndReturn(
+		func(context.Context, addr.IA) ([]snet.Path, error) {
+			close(started)
+			<-release
+			return nil, fmt.Errorf("no paths")
+		})
+	// B is kept after being forgotten: a new reservation is requested for it
+	provider.EXPECT().PathsTo(gomock.Any(), confB.dst).Return(nil, fmt.Errorf("no paths"))
+
+	done := make(chan OneShotResult)
+	go func() {
+		done <- k.OneShot(context.Background())
+	}()
+	<-started
+	forgotten := make(chan struct{})
+	go func() {
+		k.Forget(rsvB.ID)
+		close(forgotten)
+	}()
+	select {
+	case <-forgotten:
+	case <-time.After(time.Second):
+		t.Fatal("forgetting a reservation waits for the keeper run")
+	}
+	close(release)
+	res := <-done
+	require.Equal(t, 2, res.Counts[KeptFailed])
+	require.Nil(t, entryB.rsv)
+}
+
+func TestOneShotMaxEntries(t *testing.T) {
+	const entryCount = 7
+	const maxEntries = 3
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	confs := make([]*configuration, entryCount)
+	for i := range confs {
+		confs[i] = &configuration{
+			dst:       xtest.MustParseIA(fmt.Sprintf("1-ff00:0:%d", i+2)),
+			predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+			minBW:     10,
+			maxBW:     42,
+			splitCls:  2,
+		}
+	}
+	now := util.SecsToTime(10)
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now:        func() time.Time { return now },
+		localIA:    xtest.MustParseIA("1-ff00:0:1"),
+		provider:   provider,
+		entries:    matchRsvsWithConfiguration(nil, confs),
+		maxEntries: maxEntries,
+	}
+
+	var mu sync.Mutex
+	var kept []addr.IA // the destinations kept in the current run
+	provider.EXPECT().PathsTo(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, dst addr.IA) ([]snet.Path, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			kept = append(kept, dst)
+			return nil, fmt.Errorf("no paths")
+		})
+
+	keptCount := make(map[addr.IA]int)
+	for run := 0; run < 2*entryCount; run++ {
+		kept = nil
+		res := k.OneShot(context.Background())
+		require.Len(t, res.Entries, entryCount)
+		require.Len(t, kept, maxEntries)
+		// the wakeup is as soon as possible, as there are always entries left
+		require.Equal(t, now.Add(sleepAtLeast), res.Wakeup)
+		for _, dst := range kept {
+			keptCount[dst]++
+		}
+		// no entry is kept twice before all the others are kept again
+		least, most := 2*entryCount, 0
+		for _, c := range confs {
+			if keptCount[c.dst] < least {
+				least = keptCount[c.dst]
+			}
+			if keptCount[c.dst] > most {
+				most = keptCount[c.dst]
+			}
+		}
+		require.LessOrEqual(t, most-least, 1, "run %d: %v", run, keptCount)
+		now = now.Add(time.Minute)
+	}
+	// all the entries were kept, the same number of times
+	for _, c := range confs {
+		require.Equal(t, 2*maxEntries, keptCount[c.dst], c.dst)
+	}
+}
+
+func TestKeepUpAndDownToSameDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	cUp := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		pathType:  reservation.UpPath,
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal,
+	}
+	cDown := func() *configuration { a := *cUp; a.pathType = reservation.DownPath; return &a }()
+	confs := []*configuration{cUp, cDown}
+
+	manager := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now:      func() time.Time { return now },
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: manager,
+		entries:  matchRsvsWithConfiguration(nil, confs),
+	}
+	manager.EXPECT().PathsTo(gomock.Any(), gomock.Any()).AnyTimes().Return(
+		[]snet.Path{te.NewSnetPath("1-ff00:0:1", 1, 2, "1-ff00:0:2")}, nil)
+	// only one setup per configuration: the second run keeps the reservations of the first
+	manager.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).Times(len(confs)).DoAndReturn(
+		func(_ context.Context, req *seg.SetupReq) error {
+			req.Reservation = st.NewRsv(st.WithPathType(req.PathType),
+				st.AddIndex(0, st.WithBW(10, 42, 0), st.WithExpiration(tomorrow)),
+				st.ConfirmAllIndices(),
+				st.WithTrafficSplit(int(req.SplitCls)),
+				st.WithEndProps(req.PathProps))
+			req.Reservation.Steps = req.Steps
+			return nil
+		})
+	manager.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	for run := 0; run < 2; run++ {
+		res := k.OneShot(context.Background())
+		require.NoError(t, res.Err(), "run %d", run)
+	}
+	require.Len(t, k.entries, len(confs))
+	rsvs := make([]*seg.Reservation, len(k.entries))
+	for i, e := range k.entries {
+		require.NotNil(t, e.rsv)
+		require.Equal(t, e.conf.pathType, e.rsv.PathType)
+		rsvs[i] = e.rsv
+	}
+	require.NotSame(t, rsvs[0], rsvs[1])
+
+	// after a restart, each configuration is paired again with its own reservation
+	entries := matchRsvsWithConfiguration(rsvs, confs)
+	require.Len(t, entries, len(confs))
+	for _, e := range entries {
+		require.NotNil(t, e.rsv)
+		require.Equal(t, e.conf.pathType, e.rsv.PathType)
+	}
+}
+
+func TestOneShotStoreUnavailable(t *testing.T) {
+	const entryCount = 4
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	confs := make([]*configuration, entryCount)
+	for i := range confs {
+		confs[i] = &configuration{
+			dst:       xtest.MustParseIA(fmt.Sprintf("1-ff00:0:%d", i+2)),
+			predicate: newSequence(t, "0*"),
+			minBW:     10,
+			maxBW:     42,
+			splitCls:  2,
+		}
+	}
+	now := util.SecsToTime(10)
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now:        func() time.Time { return now },
+		localIA:    xtest.MustParseIA("1-ff00:0:1"),
+		provider:   provider,
+		entries:    matchRsvsWithConfiguration(nil, confs),
+		maxWorkers: 1,
+	}
+	provider.EXPECT().PathsTo(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, dst addr.IA) ([]snet.Path, error) {
+			return []snet.Path{
+				te.NewSnetPath("1-ff00:0:1", 1, 1, dst.String()),
+				te.NewSnetPath("1-ff00:0:1", 2, 2, dst.String()),
+			}, nil
+		})
+	// the first failure defers the rest of the run: no other path nor entry is tried
+	provider.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).Times(1).Return(
+		serrors.Wrap(ErrStoreUnavailable, serrors.New("connection lost")))
+
+	res := k.OneShot(context.Background())
+	require.Error(t, res.Err())
+	require.ErrorIs(t, res.StoreUnavailable, ErrStoreUnavailable)
+	require.Equal(t, now.Add(sleepStoreUnavailable), res.Wakeup)
+	require.Equal(t, map[KeepAction]int{KeptDeferred: entryCount}, res.Counts)
+	for i, e := range res.Entries {
+		require.NoError(t, e.Err, "entry %d", i)
+	}
+	// the deferred entries are not considered kept
+	for i, e := range k.entries {
+		require.Zero(t, e.keptSeq, "entry %d", i)
+	}
+}
+
+func TestKeepReservationWakeupJitter(t *testing.T) {
+	const entryCount = 50
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := util.SecsToTime(10)
+	conf := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		minBW:     10,
+		maxBW:     42,
+		splitCls:  2,
+		endProps:  reservation.StartLocal | reservation.EndLocal | reservation.EndTransfer,
+	}
+	confs := make([]*configuration, entryCount)
+	for i := range confs {
+		confs[i] = conf
+	}
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now: func() time.Time {
+			return now
+		},
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: provider,
+		entries:  matchRsvsWithConfiguration(nil, confs),
+	}
+	provider.EXPECT().PathsTo(gomock.Any(), conf.dst).AnyTimes().Return(
+		[]snet.Path{te.NewSnetPath("1-ff00:0:1", 1, 2, "1-ff00:0:2")}, nil)
+	// all reservations are created at the same instant
+	provider.EXPECT().SetupRequest(gomock.Any(), gomock.Any()).Times(entryCount).DoAndReturn(
+		func(_ context.Context, req *seg.SetupReq) error {
+			req.Reservation = &seg.Reservation{
+				Indices: seg.Indices{
+					{
+						Idx:        0,
+						Expiration: req.ExpirationTime,
+						MinBW:      10,
+						MaxBW:      42,
+					},
+				},
+			}
+			return req.Reservation.SetIndexConfirmed(0)
+		})
+	provider.EXPECT().ActivateRequest(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+
+	latest := now.Add(newIndexMinDuration - minDuration)
+	earliest := latest.Add(-maxWakeupJitter)
+	wakeups := make(map[time.Time]struct{})
+	for _, e := range k.entries {
+		wakeup, _, err := k.keepReservation(context.Background(), e)
+		require.NoError(t, err)
+		// never past the time the entry stops being compliant
+		require.False(t, wakeup.After(latest), "wakeup %s after %s", wakeup, latest)
+		require.True(t, wakeup.After(earliest), "wakeup %s before %s", wakeup, earliest)
+		wakeups[wakeup] = struct{}{}
+	}
+	// the wakeups are spread, not identical
+	require.Greater(t, len(wakeups), entryCount/2)
+}
+
+func TestKeepReservationSetupRateLimit(t *testing.T) {
+	const burst = 2
+	const interval = time.Minute
+	const duration = 10 * time.Minute
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := util.SecsToTime(10)
+	dst := xtest.MustParseIA("1-ff00:0:2")
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	k := &keeper{
+		now: func() time.Time {
+			return now
+		},
+		localIA:  xtest.MustParseIA("1-ff00:0:1"),
+		provider: provider,
+		entries: matchRsvsWithConfiguration(nil, []*configuration{{
+			dst:       dst,
+			predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+			minBW:     10,
+			maxBW:     42,
+			splitCls:  2,
+		}}),
+		setups: newSetupLimiter(&conf.Reservations{
+			SetupRateLimit: &conf.SetupRateLimit{
+				Burst:    burst,
+				Interval: util.DurWrap{Duration: interval},
+			},
+		}),
+	}
+	// the setups always fail
+	attempts := 0
+	provider.EXPECT().PathsTo(gomock.Any(), dst).AnyTimes().DoAndReturn(
+		func(context.Context, addr.IA) ([]snet.Path, error) {
+			attempts++
+			return nil, fmt.Errorf("no paths")
+		})
+
+	start := now
+	for ; now.Sub(start) < duration; now = now.Add(sleepAtLeast) {
+		prev := attempts
+		wakeup, _, err := k.keepReservation(context.Background(), k.entries[0])
+		if attempts > prev {
+			require.Error(t, err)
+			continue
+		}
+		// throttled: no error and a later wakeup
+		require.NoError(t, err)
+		require.True(t, wakeup.After(now))
+		require.False(t, wakeup.After(now.Add(interval)))
+	}
+	require.LessOrEqual(t, attempts, burst+int(duration/interval))
+	require.GreaterOrEqual(t, attempts, int(duration/interval))
+}
+
+func TestSetupLimiter(t *testing.T) {
+	start := util.SecsToTime(10)
+	at := func(secs int) time.Time { return start.Add(time.Duration(secs) * time.Second) }
+	dst := xtest.MustParseIA("1-ff00:0:2")
+	other := xtest.MustParseIA("1-ff00:0:3")
+	l := newSetupLimiter(&conf.Reservations{
+		SetupRateLimit: &conf.SetupRateLimit{
+			Burst:    2,
+			Interval: util.DurWrap{Duration: time.Minute},
+		},
+	})
+	steps := []struct {
+		dst          addr.IA
+		now          time.Time
+		allowed      bool
+		expectedNext time.Time // only if not allowed
+	}{
+		// the burst is available at once
+		{dst: dst, now: at(0), allowed: true},
+		{dst: dst, now: at(0), allowed: true},
+		{dst: dst, now: at(0), allowed: false, expectedNext: at(60)},
+		{dst: dst, now: at(30), allowed: false, expectedNext: at(60)},
+		// other destinations have their own bucket
+		{dst: other, now: at(30), allowed: true},
+		// one token regained per interval, exactly
+		{dst: dst, now: at(60), allowed: true},
+		{dst: dst, now: at(90), allowed: false, expectedNext: at(120)},
+		{dst: dst, now: at(120), allowed: true},
+		// half an interval, twice, is one token
+		{dst: dst, now: at(150), allowed: false, expectedNext: at(180)},
+		{dst: dst, now: at(180), allowed: true},
+		// the bucket fills up to the burst only
+		{dst: dst, now: at(1000), allowed: true},
+		{dst: dst, now: at(1000), allowed: true},
+		{dst: dst, now: at(1000), allowed: false, expectedNext: at(1060)},
+	}
+	for i, s := range steps {
+		next, ok := l.allow(s.dst, s.now)
+		require.Equal(t, s.allowed, ok, "step %d", i)
+		if s.allowed {
+			require.Equal(t, s.now, next, "step %d", i)
+		} else {
+			require.Equal(t, s.expectedNext, next, "step %d", i)
+		}
+	}
+
+	// a nil limiter allows everything
+	var nilLimiter *setupLimiter
+	next, ok := nilLimiter.allow(dst, start)
+	require.True(t, ok)
+	require.Equal(t, start, next)
+}
+
+func TestMatchRsvsWithConfiguration(t *testing.T) {
+	r1 := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		st.WithPathType(reservation.UpPath),
+		st.WithTrafficSplit(1), // 1
+		st.WithEndProps(reservation.StartLocal))
+	r2 := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		st.WithPathType(reservation.UpPath),
+		st.WithTrafficSplit(2), // 2
+		st.WithEndProps(reservation.StartLocal))
+	c1 := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		pathType:  reservation.UpPath,
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		splitCls:  1,
+		endProps:  reservation.StartLocal,
+	}
+	c2 := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		pathType:  reservation.UpPath,
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		splitCls:  2,
+		endProps:  reservation.StartLocal,
+	}
+	c1_copy := func() *configuration { a := *c1; return &a }()
+	// an up and a down reservation between the same ASes, with the same properties
+	rUp := st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+		st.WithPathType(reservation.UpPath),
+		st.WithTrafficSplit(1),
+		st.WithEndProps(reservation.StartLocal))
+	rDown := st.NewRsv(st.WithPath("1-ff00:0:2", 1, 1, "1-ff00:0:1"),
+		st.WithPathType(reservation.DownPath),
+		st.WithTrafficSplit(1),
+		st.WithEndProps(reservation.StartLocal))
+	cUp := &configuration{
+		dst:       xtest.MustParseIA("1-ff00:0:2"),
+		pathType:  reservation.UpPath,
+		predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"),
+		splitCls:  1,
+		endProps:  reservation.StartLocal,
+	}
+	cDown := func() *configuration { a := *cUp; a.pathType = reservation.DownPath; return &a }()
+	cases := map[string]struct {
+		rsvs              []*seg.Reservation
+		confs             []*configuration
+		expectedConfToRsv []int // configuration i paired with rsvs[ expected[i] ]
+	}{
+		"both": {
+			rsvs:              []*seg.Reservation{r1, r2},
+			confs:             []*configuration{c1, c2},
+			expectedConfToRsv: []int{0, 1},
+		},
+		"unordered": {
+			rsvs:              []*seg.Reservation{r1, r2},
+			confs:             []*configuration{c2, c1},
+			expectedConfToRsv: []int{1, 0},
+		},
+		"only_one": {
+			rsvs:              []*seg.Reservation{r2},
+			confs:             []*configuration{c1, c2},
+			expectedConfToRsv: []int{-1, 0},
+		},
+		"none": {
+			rsvs:              []*seg.Reservation{},
+			confs:             []*configuration{c2, c1},
+			expectedConfToRsv: []int{-1, -1},
+		},
+		"same_config": {
+			rsvs:              []*seg.Reservation{r1, r2},
+			confs:             []*configuration{c1, c1_copy},
+			expectedConfToRsv: []int{0, -1},
+		},
+		"no_config": {
+			rsvs:              []*seg.Reservation{r1, r2},
+			confs:             []*configuration{},
+			expectedConfToRsv: []int{},
+		},
+		"up_and_down": {
+			rsvs:              []*seg.Reservation{rUp, rDown},
+			confs:             []*configuration{cUp, cDown},
+			expectedConfToRsv: []int{0, 1},
+		},
+		"up_and_down_unordered": {
+			rsvs:              []*seg.Reservation{rDown, rUp},
+			confs:             []*configuration{cUp, cDown},
+			expectedConfToRsv: []int{1, 0},
+		},
+		"only_down": {
+			rsvs:              []*seg.Reservation{rDown},
+			confs:             []*configuration{cUp, cDown},
+			expectedConfToRsv: []int{-1, 0},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			// check that the test case is well formed
+			require.Equal(t, len(tc.confs), len(tc.expectedConfToRsv),
+				"wrong use case: expected matches must have the same length as confs")
+
+			entries := matchRsvsWithConfiguration(tc.rsvs, tc.confs)
+			require.Len(t, entries, len(tc.confs))
+
+			confToReservation := make(map[*configuration]*seg.Reservation)
+			for i, e := range tc.expectedConfToRsv {
+				var r *seg.Reservation
+				if e >= 0 {
+					r = tc.rsvs[e]
+				}
+				_, ok := confToReservation[tc.confs[i]]
+				require.False(t, ok)
+				confToReservation[tc.confs[i]] = r
+			}
+			for i, e := range entries {
+				require.Contains(t, confToReservation, e.conf)
+				require.Same(t, confToReservation[e.conf], e.rsv,
+					"entry %d has unexpected reservation", i)
+				delete(confToReservation, e.conf)
+			}
+		})
+	}
+}
+
+func TestConfigurationEqual(t *testing.T) {
+	newConf := func() *configuration {
+		return &configuration{
+			dst:          xtest.MustParseIA("1-ff00:0:2"),
+			pathType:     reservation.UpPath,
+			predicate:    newSequence(t, "1-ff00:0:1 0* 1-ff00:0:2"),
+			predicateStr: "1-ff00:0:1 0* 1-ff00:0:2",
+			minBW:        10,
+			maxBW:        42,
+			splitCls:     2,
+			endProps:     reservation.StartLocal,
+			labels:       map[string]string{"tenant": "a"},
+			index:        0,
+		}
+	}
+	withPredicate := func(c *configuration, predicate string) {
+		c.predicate = newSequence(t, predicate)
+		c.predicateStr = predicate
+	}
+	cases := map[string]struct {
+		modify   func(c *configuration)
+		expected bool
+	}{
+		"same": {
+			modify:   func(c *configuration) {},
+			expected: true,
+		},
+		"other_index": {
+			modify: func(c *configuration) {
+				c.index = 3
+			},
+			expected: true,
+		},
+		"predicate_other_spacing": {
+			modify: func(c *configuration) {
+				withPredicate(c, " 1-ff00:0:1  0*\t1-ff00:0:2 ")
+			},
+			expected: true,
+		},
+		"predicate_equivalent_differently_written": {
+			modify: func(c *configuration) {
+				withPredicate(c, "1-ff00:0:1 0-0* 1-ff00:0:2")
+			},
+		},
+		"predicate_different": {
+			modify: func(c *configuration) {
+				withPredicate(c, "1-ff00:0:1 1-ff00:0:2")
+			},
+		},
+		"predicate_empty": {
+			modify: func(c *configuration) {
+				withPredicate(c, "")
+			},
+		},
+		"dst": {
+			modify: func(c *configuration) {
+				c.dst = xtest.MustParseIA("1-ff00:0:3")
+			},
+		},
+		"path_type": {
+			modify: func(c *configuration) {
+				c.pathType = reservation.DownPath
+			},
+		},
+		"min_bw": {
+			modify: func(c *configuration) {
+				c.minBW++
+			},
+		},
+		"max_bw": {
+			modify: func(c *configuration) {
+				c.maxBW++
+			},
+		},
+		"split_cls": {
+			modify: func(c *configuration) {
+				c.splitCls++
+			},
+		},
+		"end_props": {
+			modify: func(c *configuration) {
+				c.endProps = reservation.StartLocal | reservation.EndLocal
+			},
+		},
+		"rlc": {
+			modify: func(c *configuration) {
+				c.rlc = 4
+			},
+		},
+		"label_value": {
+			modify: func(c *configuration) {
+				c.labels = map[string]string{"tenant": "b"}
+			},
+		},
+		"label_added": {
+			modify: func(c *configuration) {
+				c.labels = map[string]string{"tenant": "a", "tier": "gold"}
+			},
+		},
+		"no_labels": {
+			modify: func(c *configuration) {
+				c.labels = nil
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c := newConf()
+			other := newConf()
+			tc.modify(other)
+			require.Equal(t, tc.expected, c.Equal(other))
+			require.Equal(t, tc.expected, other.Equal(c))
+		})
+	}
+	require.True(t, (*configuration)(nil).Equal(nil))
+	require.False(t, newConf().Equal(nil))
+}
+
+func TestFindCompatibleConfiguration(t *testing.T) {
+	cases := map[string]struct {
+		rsv      *seg.Reservation
+		confs    []*configuration
+		expected int // index of match on `confs`, or -1
+	}{
+		"ok": {
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reservation.StartLocal)),
+			confs: []*configuration{
+				{
+					dst:       xtest.MustParseIA("1-ff00:0:2"),
+					pathType:  reservation.UpPath,
+					predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
+					minBW:     10,
+					maxBW:     42,
+					splitCls:  2,
+					endProps:  reservation.StartLocal,
+				},
+			},
+			expected: 0,
+		},
+		"core": {
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1, "2-ff00:0:3"),
+				st.WithPathType(reservation.CorePath),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reservation.StartLocal|reservation.EndTransfer)),
+			confs: []*configuration{
+				{
+					dst:       xtest.MustParseIA("2-ff00:0:3"),
+					pathType:  reservation.UpPath,
+					predicate: newSequence(t, "1-ff00:0:1 0* 2-ff00:0:3"),
+					minBW:     10,
+					maxBW:     42,
+					splitCls:  2,
+					endProps:  reservation.StartLocal | reservation.EndTransfer,
+				},
+				{
+					dst:       xtest.MustParseIA("2-ff00:0:3"),
+					pathType:  reservation.CorePath,
+					predicate: newSequence(t, "1-ff00:0:1 0* 2-ff00:0:3"),
+					minBW:     10,
+					maxBW:     42,
+					splitCls:  2,
+					endProps:  reservation.StartLocal | reservation.EndTransfer,
+				},
+			},
+			expected: 1,
+		},
+		"core_reversed_path": {
+			rsv: st.NewRsv(st.WithPath("2-ff00:0:3", 1, 2, "1-ff00:0:2", 1, 1, "1-ff00:0:1"),
+				st.WithPathType(reservation.CorePath),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reservation.StartLocal|reservation.EndTransfer)),
+			confs: []*configuration{
+				{
+					dst:       xtest.MustParseIA("2-ff00:0:3"),
+					pathType:  reservation.CorePath,
+					predicate: newSequence(t, "1-ff00:0:1 0* 2-ff00:0:3"),
+					minBW:     10,
+					maxBW:     42,
+					splitCls:  2,
+					endProps:  reservation.StartLocal | reservation.EndTransfer,
+				},
+			},
+			expected: -1,
+		},
+		"down": {
+			// the steps of down-path reservations go from the destination to the local AS
+			rsv: st.NewRsv(st.WithPath("1-ff00:0:2", 1, 1, "1-ff00:0:1"),
+				st.WithPathType(reservation.DownPath),
+				st.WithTrafficSplit(2),
+				st.WithEndProps(reservation.StartLocal)),
+			confs: []*configuration{
+				{
+					dst:       xtest.MustParseIA("1-ff00:0:2"),
+					pathType:  reservation.DownPath,
 					predicate: newSequence(t, "1-ff00:0:1 1-ff00:0:2"), // direct
 					minBW:     10,
 					maxBW:     42,
@@ -520,14 +3654,14 @@ func TestFindCompatibleConfiguration(t *testing.T) {
 	}
 }
 
-func newSequence(t *testing.T, str string) *pathpol.Sequence {
+// newSequence returns a predicate with only the sequence.
+func newSequence(t *testing.T, str string) *pathpol.Policy {
 	t.Helper()
 	seq, err := pathpol.NewSequence(str)
 	xtest.FailOnErr(t, err)
-	return seq
+	return pathpol.NewPolicy("", nil, seq, nil)
 }
 
 func cloneR(r *seg.Reservation) *seg.Reservation {
-	c := *r
-	return &c
+	return r.Clone()
 }
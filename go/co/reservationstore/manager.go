@@ -16,7 +16,9 @@ package reservationstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,11 +26,13 @@ import (
 
 	base "github.com/scionproto/scion/go/co/reservation"
 	"github.com/scionproto/scion/go/co/reservation/conf"
+	"github.com/scionproto/scion/go/co/reservation/e2e"
 	"github.com/scionproto/scion/go/co/reservation/segment"
 	"github.com/scionproto/scion/go/co/reservationstorage"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	caddr "github.com/scionproto/scion/go/lib/slayers/path/colibri/addr"
@@ -37,6 +41,9 @@ import (
 
 // manager takes care of the health of the segment reservations.
 type manager struct {
+	mu                  sync.Mutex       // protects the wakeup times and keeping
+	keeping             bool             // a keeper OneShot is running
+	firstCycleDone      bool             // a keeper OneShot finished without a fatal error
 	now                 func() time.Time // replace in tests
 	wakeupTime          time.Time        // no need to do anything until this time
 	wakeupListSegs      time.Time
@@ -44,185 +51,445 @@ type manager struct {
 	wakeupKeeper        time.Time // wake up the keeper (new rsvs/indices)
 	wakeupExpirer       time.Time // wake up the colibri reservation expire routine
 	wakeupAdmissionList time.Time
-	keeper              *keeper // handles new rsvs/indices
+	reports             ReportIntervals  // intervals of the periodic DB reports
+	readOnly            bool             // never modify the reservations, only report them
+	logFullPaths        bool             // log the paths without anonymizing them
+	keeper              *keeper          // handles new rsvs/indices
+	orphans             []reservation.ID // rsvs matching no configuration at startup
 	localIA             addr.IA
-	store               reservationstorage.Store // TODO(juagargi) this should be an InitialStore
+	store               reservationstorage.Store   // TODO(juagargi) this should be an InitialStore
+	shards              []reservationstorage.Store // stores besides store, if sharded
+	partition           PartitionFunc              // selects the store per destination
 	router              snet.Router
+	metrics             Metrics
+	teardowns           sync.WaitGroup // teardowns of abandoned setups, in the background
+}
+
+// abandonedTeardownTimeout bounds the teardown of a reservation whose setup was cancelled.
+const abandonedTeardownTimeout = 5 * time.Second
+
+// ErrReadOnly is returned by the requests that would modify the reservations of a read-only
+// manager.
+var ErrReadOnly = serrors.New("read-only instance")
+
+// ErrStoreUnavailable is returned by the requests that failed because the store became
+// unavailable, e.g. after losing its DB connection. The keeper then defers the whole run
+// instead of failing each configured reservation.
+var ErrStoreUnavailable = serrors.New("colibri store unavailable")
+
+// ManagerConfig configures the periodic duties of the manager.
+type ManagerConfig struct {
+	Reports ReportIntervals
+	// ReadOnly makes the manager only report the reservations in the DB: neither the keeper
+	// nor the expirers run, and the requests that would modify the reservations fail.
+	ReadOnly bool
+	// Shards optionally spreads the reservations initiated by this AS over more stores than
+	// the main one, e.g. in very large ASes. The main store is the shard zero.
+	Shards []reservationstorage.Store
+	// Partition selects the shard of the reservations to a destination. If nil, the shard is
+	// selected with PartitionByDst.
+	Partition PartitionFunc
+	// LogFullPaths logs the paths of the reservations with all their details. Otherwise, the
+	// paths in the reports and the keeper logs are anonymized. Only meant for debugging.
+	LogFullPaths bool
+}
+
+// describeSteps returns the steps to be logged: anonymized unless full is set.
+func describeSteps(steps base.PathSteps, full bool) string {
+	if full {
+		return steps.String()
+	}
+	return steps.AnonymizedString(true)
+}
+
+// PartitionFunc returns the shard, between 0 and shards-1, of the reservations to dst.
+type PartitionFunc func(dst addr.IA, shards int) int
+
+// PartitionByDst selects the shard with a hash of the destination IA.
+func PartitionByDst(dst addr.IA, shards int) int {
+	h := fnv.New32a()
+	h.Write([]byte(dst.String()))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// ReportIntervals are the intervals between the periodic reports of the reservations in the DB.
+// A zero interval disables the corresponding report.
+type ReportIntervals struct {
+	Segments time.Duration
+	E2Es     time.Duration
 }
 
 func NewColibriManager(ctx context.Context, localIA addr.IA, router snet.Router,
-	store reservationstorage.Store, initial *conf.Reservations) (*manager, error) {
+	store reservationstorage.Store, initial *conf.Reservations, cfg ManagerConfig,
+	metrics Metrics) (*manager, error) {
 
 	m := &manager{
-		now:        time.Now,
-		wakeupTime: time.Now().Add(-time.Nanosecond),
-		localIA:    localIA,
-		store:      store,
-		router:     router,
+		now:          time.Now,
+		wakeupTime:   time.Now().Add(-time.Nanosecond),
+		localIA:      localIA,
+		store:        store,
+		shards:       cfg.Shards,
+		partition:    cfg.Partition,
+		router:       router,
+		reports:      cfg.Reports,
+		readOnly:     cfg.ReadOnly,
+		logFullPaths: cfg.LogFullPaths,
+		metrics:      metrics,
+	}
+	if cfg.ReadOnly && initial != nil {
+		if initial.TeardownOrphans {
+			log.Info("colibri manager is read-only, the orphans will not be torn down")
+		}
+		c := *initial
+		c.TeardownOrphans = false
+		c.SkipStartupCleanup = true
+		initial = &c
 	}
 
-	keeper, err := NewKeeper(ctx, m, initial, localIA)
+	keeper, orphans, err := NewKeeper(ctx, m, initial, localIA)
 	if err != nil {
 		return nil, err
 	}
+	keeper.logFullPaths = cfg.LogFullPaths
 	m.keeper = keeper
+	m.orphans = make([]reservation.ID, len(orphans))
+	for i, r := range orphans {
+		m.orphans[i] = r.ID
+	}
+	if len(orphans) > 0 {
+		ids := make([]string, len(orphans))
+		for i, id := range m.orphans {
+			ids[i] = id.String()
+		}
+		log.Info("colibri manager found reservations matching no configuration",
+			"count", len(orphans), "ids", strings.Join(ids, ","),
+			"torn_down", initial != nil && initial.TeardownOrphans)
+	}
+	if cfg.ReadOnly {
+		log.Info("colibri manager is read-only, reservations will only be reported")
+	}
 	return m, nil
 }
 
+// Orphans returns the IDs of the reservations that matched no configuration at startup.
+func (m *manager) Orphans() []reservation.ID {
+	return m.orphans
+}
+
 func (m *manager) Name() string {
 	return "colibri.manager"
 }
 
-func (m *manager) Run(ctx context.Context) {
-	logger := log.FromCtx(ctx)
+// managerTask is a periodic subtask of the manager. The task runs only if its wakeup time
+// has passed, and returns its next wakeup time.
+type managerTask struct {
+	wakeup *time.Time
+	run    func(ctx context.Context, now time.Time) time.Time
+}
+
+// tasks returns the enabled periodic subtasks of the manager. A read-only manager only
+// runs the reports.
+func (m *manager) tasks() []managerTask {
+	var tasks []managerTask
+	if !m.readOnly {
+		tasks = append(tasks,
+			managerTask{wakeup: &m.wakeupKeeper, run: m.keepReservations},
+			managerTask{wakeup: &m.wakeupExpirer, run: m.deleteExpiredIndices},
+			managerTask{wakeup: &m.wakeupAdmissionList, run: m.deleteExpiredAdmissionEntries},
+		)
+	}
+	if m.reports.Segments > 0 {
+		tasks = append(tasks, managerTask{wakeup: &m.wakeupListSegs, run: m.reportSegments})
+	}
+	if m.reports.E2Es > 0 {
+		tasks = append(tasks, managerTask{wakeup: &m.wakeupListE2Es, run: m.reportE2Es})
+	}
+	return tasks
+}
 
+func (m *manager) Run(ctx context.Context) {
 	now := time.Now()
+	m.mu.Lock()
 	if now.Before(m.wakeupTime) {
+		m.mu.Unlock()
 		return
 	}
-	if !m.store.Ready() {
+	if !m.storesReady() {
 		log.Info("colibri store not yet ready")
 		m.wakeupTime = m.now().Add(2 * time.Second)
+		m.mu.Unlock()
 		return
 	}
+	tasks := m.tasks()
+	due := make([]bool, len(tasks))
+	for i, t := range tasks {
+		due[i] = !now.Before(*t.wakeup)
+	}
+	m.mu.Unlock()
+
+	// Run can be called again while the tasks of a previous call are still running. Only
+	// the keeper is guarded against running concurrently, see keepReservations.
+	wakeups := make([]time.Time, len(tasks))
 	wg := sync.WaitGroup{}
-	wg.Add(5)
-	go func() { // periodic report of segment reservations
-		defer log.HandlePanic()
-		defer wg.Done()
-		defer func() {
-			m.wakeupListSegs = time.Now().Add(10 * time.Minute)
-		}()
-		// list segments
-		rsvs, err := m.store.ReportSegmentReservationsInDB(ctx)
-		if err != nil {
-			log.Info("error reporting segment reservations in db", "err", err)
-			return
-		}
-		table := make([]string, 0, len(rsvs)+1)
-		table = append(table, fmt.Sprintf("%24s %4s %15s %4s %4s %20s %11s %s",
-			"id", "dir", "dst", "|i|", "act", "exp", "rawpath_type", "path"))
-		for _, r := range rsvs {
-			var idx int = -1
-			if active := r.ActiveIndex(); active != nil {
-				idx = int(active.Idx)
+	wg.Add(len(tasks))
+	for i, t := range tasks {
+		i, t := i, t
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			if !due[i] {
+				return
 			}
-			table = append(table, fmt.Sprintf("%24s %4s %15s %4d %4d %20s %11s %s",
-				r.ID.String(),
-				r.PathType,
-				r.Steps.DstIA(),
-				r.Indices.Len(),
-				// len(r.Indices.Filter(segment.NotActive())),
-				idx,
-				r.Indices.NewestExp().Format(time.Stamp),
-				r.TransportPath.Type(),
-				r.Steps))
-		}
-		if len(rsvs) > 0 {
-			log.Debug("----------- colibri segments ------------\n" + strings.Join(table, "\n") +
-				"\n" + strings.Repeat("-", 150))
-		}
-	}()
-	go func() { // periodic report of e2e reservations
-		defer log.HandlePanic()
-		defer wg.Done()
-		defer func() {
-			m.wakeupListE2Es = time.Now().Add(5 * time.Minute)
+			wakeups[i] = t.run(ctx, now)
 		}()
-		// list e2e reservations
-		rsvs, err := m.store.ReportE2EReservationsInDB(ctx)
-		if err != nil {
-			log.Info("error reporting e2e reservations in db", "err", err)
-			return
-		}
-		table := make([]string, 0, len(rsvs)+1)
-		table = append(table, fmt.Sprintf("%38s %8s %3s %3s %12s",
-			"id", "alloc", "idx", "bw", "exptime"))
-		for _, r := range rsvs {
-			args := []interface{}{
-				r.ID.String(),
-				r.AllocResv(),
-			}
-			if len(r.Indices) > 0 {
-				index := r.Indices[len(r.Indices)-1]
-				args = append(args,
-					strconv.Itoa(int(index.Idx)),
-					strconv.Itoa(int(index.AllocBW)),
-					index.Expiration.Format(time.StampMilli),
-				)
-			} else {
-				args = append(args, "--", "---", "-------")
-			}
-			table = append(table, fmt.Sprintf("%38s %8d %3s %3s %12s", args...))
-		}
-		if len(rsvs) > 0 {
-			log.Debug("___________ colibri e2e's now ___________\n" + strings.Join(table, "\n"))
-		}
-	}()
-	go func() { // keep segment reservations (new setups and renewals)
-		defer log.HandlePanic()
-		defer wg.Done()
-		if now.Before(m.wakeupKeeper) {
-			return
-		}
-		logger.Debug("Reservation manager starting")
-		defer logger.Debug("Reservation manager finished")
+	}
+	wg.Wait()
 
-		wakeupTime, err := m.keeper.OneShot(ctx)
-		if err != nil {
-			logger.Info("error while keeping the reservations", "err", err)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, t := range tasks {
+		if due[i] {
+			*t.wakeup = wakeups[i]
 		}
-		logger.Info("will wait until the specified time", "wakeup_time", wakeupTime)
-		m.wakeupKeeper = wakeupTime
-	}()
-	go func() { // periodic removal of expired indices (both segment & e2e)
-		defer log.HandlePanic()
-		defer wg.Done()
-		if now.Before(m.wakeupExpirer) {
-			return
-		}
-		n, wakeupTime, err := m.store.DeleteExpiredIndices(ctx, m.now())
-		if err != nil {
-			logger.Info("error deleting expired indices", "deleted_count", n, "err", err)
-		}
-		if n > 0 {
-			logger.Debug("deleted expired indices", "count", n)
+		wakeups[i] = *t.wakeup
+	}
+	m.wakeupTime = findEarliest(wakeups...)
+}
+
+// keepReservations keeps the segment reservations (new setups and renewals).
+// If the keeper is still running from a previous invocation, it skips this one and returns
+// a wakeup time after the minimum keeper sleep.
+func (m *manager) keepReservations(ctx context.Context, now time.Time) time.Time {
+	logger := log.FromCtx(ctx)
+	if !m.startKeeping() {
+		logger.Info("colibri keeper still running, skipping this run")
+		return now.Add(sleepAtLeast)
+	}
+	defer m.stopKeeping()
+	logger.Debug("Reservation manager starting")
+	defer logger.Debug("Reservation manager finished")
+
+	res := m.keeper.OneShot(ctx)
+	for _, e := range res.Entries {
+		metrics.CounterInc(metrics.CounterWith(m.metrics.KeeperActions,
+			"action", e.Action.String()))
+		if e.Err != nil {
+			logger.Info("error while keeping a reservation", "dst", e.Dst,
+				"labels", e.Labels, "err", e.Err)
 		}
-		if wakeupTime.IsZero() {
-			wakeupTime = now.Add(8 * time.Second)
+	}
+	if res.StoreUnavailable != nil {
+		logger.Info("colibri store unavailable, deferring the keeper run",
+			"deferred", res.Counts[KeptDeferred], "err", res.StoreUnavailable)
+	} else {
+		m.markFirstCycleDone()
+	}
+	logger.Info("will wait until the specified time", "wakeup_time", res.Wakeup,
+		"created", res.Counts[KeptCreated], "renewed", res.Counts[KeptRenewed],
+		"activated", res.Counts[KeptActivated], "failed", res.Counts[KeptFailed])
+	return res.Wakeup
+}
+
+// markFirstCycleDone records that the keeper has completed a cycle, which makes the manager
+// ready if the stores are.
+func (m *manager) markFirstCycleDone() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.firstCycleDone {
+		log.Info("colibri keeper completed its first cycle")
+	}
+	m.firstCycleDone = true
+}
+
+// Ready returns true if the manager can serve requests: the stores are ready, and the keeper
+// has already completed a cycle (unless the manager is read-only, as the keeper never runs).
+func (m *manager) Ready() bool {
+	ready, _ := m.Health()
+	return ready
+}
+
+// Health returns whether the manager is ready, as in Ready, and a detail of why it is not.
+func (m *manager) Health() (bool, string) {
+	if !m.storesReady() {
+		return false, "store not ready"
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.readOnly && !m.firstCycleDone {
+		return false, "first keeper cycle pending"
+	}
+	return true, "ready"
+}
+
+// startKeeping marks the keeper as running, and returns false if it was already running.
+func (m *manager) startKeeping() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.keeping {
+		return false
+	}
+	m.keeping = true
+	return true
+}
+
+func (m *manager) stopKeeping() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keeping = false
+}
+
+// deleteExpiredIndices periodically removes the expired indices (both segment & e2e).
+func (m *manager) deleteExpiredIndices(ctx context.Context, now time.Time) time.Time {
+	logger := log.FromCtx(ctx)
+	n, wakeupTime, err := m.forEachStore(func(s reservationstorage.Store) (int, time.Time, error) {
+		return s.DeleteExpiredIndices(ctx, m.now())
+	})
+	if err != nil {
+		logger.Info("error deleting expired indices", "deleted_count", n, "err", err)
+	}
+	if n > 0 {
+		logger.Debug("deleted expired indices", "count", n)
+	}
+	if wakeupTime.IsZero() {
+		wakeupTime = now.Add(8 * time.Second)
+	}
+	return wakeupTime
+}
+
+// deleteExpiredAdmissionEntries periodically removes the expired admission entries
+// (white/black lists).
+func (m *manager) deleteExpiredAdmissionEntries(ctx context.Context, now time.Time) time.Time {
+	logger := log.FromCtx(ctx)
+	n, wakeupTime, err := m.forEachStore(func(s reservationstorage.Store) (int, time.Time, error) {
+		return s.DeleteExpiredAdmissionEntries(ctx, m.now())
+	})
+	if err != nil {
+		logger.Info("error deleting expired admission list entries", "err", err)
+	}
+	if n > 0 {
+		logger.Debug("deleted expired indices", "count", n)
+	}
+	if wakeupTime.IsZero() {
+		wakeupTime = now.Add(8 * time.Second)
+	}
+	return wakeupTime
+}
+
+// reportSegments periodically logs the segment reservations in the DB.
+func (m *manager) reportSegments(ctx context.Context, now time.Time) time.Time {
+	// list segments
+	var rsvs []*segment.Reservation
+	var err error
+	for _, s := range m.stores() {
+		var shardRsvs []*segment.Reservation
+		if shardRsvs, err = s.ReportSegmentReservationsInDB(ctx); err != nil {
+			break
 		}
-		m.wakeupExpirer = wakeupTime
-	}()
-	go func() { // periodic removal of expired admission entries (white/black lists)
-		defer log.HandlePanic()
-		defer wg.Done()
-		if now.Before(m.wakeupAdmissionList) {
-			return
+		rsvs = append(rsvs, shardRsvs...)
+	}
+	if err != nil {
+		log.Info("error reporting segment reservations in db", "err", err)
+		return time.Now().Add(m.reports.Segments)
+	}
+	table := make([]string, 0, len(rsvs)+1)
+	table = append(table, fmt.Sprintf("%24s %4s %15s %4s %4s %20s %11s %s",
+		"id", "dir", "dst", "|i|", "act", "exp", "rawpath_type", "path"))
+	for _, r := range rsvs {
+		var idx int = -1
+		if active, ok := r.ActiveIndexNumber(); ok {
+			idx = int(active)
 		}
-		n, wakeupTime, err := m.store.DeleteExpiredAdmissionEntries(ctx, m.now())
-		if err != nil {
-			logger.Info("error deleting expired admission list entries", "err", err)
+		indices := r.IndicesSnapshot()
+		table = append(table, fmt.Sprintf("%24s %4s %15s %4d %4d %20s %11s %s",
+			r.ID.String(),
+			r.PathType,
+			r.Steps.DstIA(),
+			indices.Len(),
+			// len(r.Indices.Filter(segment.NotActive())),
+			idx,
+			indices.NewestExp().Format(time.Stamp),
+			r.TransportPath.Type(),
+			describeSteps(r.Steps, m.logFullPaths)))
+	}
+	if len(rsvs) > 0 {
+		log.Debug("----------- colibri segments ------------\n" + strings.Join(table, "\n") +
+			"\n" + strings.Repeat("-", 150))
+	}
+	return time.Now().Add(m.reports.Segments)
+}
+
+// reportE2Es periodically logs the e2e reservations in the DB.
+func (m *manager) reportE2Es(ctx context.Context, now time.Time) time.Time {
+	// list e2e reservations
+	var rsvs []*e2e.Reservation
+	var err error
+	for _, s := range m.stores() {
+		var shardRsvs []*e2e.Reservation
+		if shardRsvs, err = s.ReportE2EReservationsInDB(ctx); err != nil {
+			break
 		}
-		if n > 0 {
-			logger.Debug("deleted expired indices", "count", n)
+		rsvs = append(rsvs, shardRsvs...)
+	}
+	if err != nil {
+		log.Info("error reporting e2e reservations in db", "err", err)
+		return time.Now().Add(m.reports.E2Es)
+	}
+	table := make([]string, 0, len(rsvs)+1)
+	table = append(table, fmt.Sprintf("%38s %8s %3s %3s %12s",
+		"id", "alloc", "idx", "bw", "exptime"))
+	for _, r := range rsvs {
+		args := []interface{}{
+			r.ID.String(),
+			r.AllocResv(),
 		}
-		if wakeupTime.IsZero() {
-			wakeupTime = now.Add(8 * time.Second)
+		if len(r.Indices) > 0 {
+			index := r.Indices[len(r.Indices)-1]
+			args = append(args,
+				strconv.Itoa(int(index.Idx)),
+				strconv.Itoa(int(index.AllocBW)),
+				index.Expiration.Format(time.StampMilli),
+			)
+		} else {
+			args = append(args, "--", "---", "-------")
 		}
-		m.wakeupAdmissionList = wakeupTime
-	}()
-	wg.Wait()
+		table = append(table, fmt.Sprintf("%38s %8d %3s %3s %12s", args...))
+	}
+	if len(rsvs) > 0 {
+		log.Debug("___________ colibri e2e's now ___________\n" + strings.Join(table, "\n"))
+	}
+	return time.Now().Add(m.reports.E2Es)
+}
+
+// Downgrade lowers the bandwidth of a segment reservation kept by the keeper.
+func (m *manager) Downgrade(ctx context.Context, id reservation.ID, bw reservation.BWCls) (
+	reservation.IndexNumber, error) {
+
+	return m.keeper.Downgrade(ctx, id, bw)
+}
+
+// Forget makes the keeper stop tracking a segment reservation that was removed.
+func (m *manager) Forget(id reservation.ID) {
+	m.keeper.Forget(id)
+}
+
+// Plan returns the next action of the keeper for each configured reservation, and the time
+// the keeper is scheduled to run next.
+func (m *manager) Plan() ([]PlanEntry, time.Time) {
+	return m.keeper.Plan()
+}
 
-	m.wakeupTime = findEarliest(
-		m.wakeupListSegs,
-		m.wakeupListE2Es,
-		m.wakeupKeeper,
-		m.wakeupExpirer,
-		m.wakeupAdmissionList)
+// Unmatched returns the configured reservations without any path satisfying their predicate.
+func (m *manager) Unmatched(ctx context.Context) []UnmatchedEntry {
+	return m.keeper.Unmatched(ctx)
 }
 
 func (m *manager) DeleteExpiredIndices(ctx context.Context) error {
-	_, _, err := m.store.DeleteExpiredIndices(ctx, m.now())
+	if m.readOnly {
+		return nil // the expired indices are left for the instance that keeps them
+	}
+	_, _, err := m.forEachStore(func(s reservationstorage.Store) (int, time.Time, error) {
+		return s.DeleteExpiredIndices(ctx, m.now())
+	})
 	return err
 }
 
@@ -236,16 +503,42 @@ func (m *manager) PathsTo(ctx context.Context, dst addr.IA) ([]snet.Path, error)
 func (m *manager) GetReservationsAtSource(ctx context.Context) (
 	[]*segment.Reservation, error) {
 
-	return m.store.GetReservationsAtSource(ctx)
+	var rsvs []*segment.Reservation
+	for _, s := range m.stores() {
+		shardRsvs, err := s.GetReservationsAtSource(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rsvs = append(rsvs, shardRsvs...)
+	}
+	return rsvs, nil
 }
 
 // SetupRequest expects the steps to always go from src->dst, also for down-path. E.g.
 // a down-path SegR A<-B<-C is transported with a scion path A->B, but the steps are C,B,A .
 func (m *manager) SetupRequest(ctx context.Context, req *segment.SetupReq) error {
+	if m.readOnly {
+		return ErrReadOnly
+	}
+	if err := req.Validate(nil); err != nil {
+		return serrors.WrapStr("invalid setup request", err)
+	}
+	isNew := req.Reservation == nil
+	// the destination of down-path SegRs, as seen from this AS, is their first step
+	dst := req.Steps.DstIA()
+	if req.PathType == reservation.DownPath {
+		dst = req.Steps.SrcIA()
+	}
+	store := m.storeFor(dst)
 	// setup/renew reservation (new temporary index in both cases)
-	err := m.store.InitSegmentReservation(ctx, req)
+	err := store.InitSegmentReservation(ctx, req)
 	if err != nil {
-		return err
+		return m.storeErr(store, err)
+	}
+	if isNew && ctx.Err() != nil {
+		// the transit ASes keep the new reservation until it is torn down
+		m.teardownAbandoned(req)
+		return serrors.WrapStr("setup cancelled before confirming the index", ctx.Err())
 	}
 
 	// confirm new index
@@ -260,9 +553,10 @@ func (m *manager) SetupRequest(ctx context.Context, req *segment.SetupReq) error
 		steps = steps.Reverse()
 	}
 	transport := req.Transport()
-	res, err := m.store.InitConfirmSegmentReservation(ctx, confirmReq, steps, transport)
+	res, err := store.InitConfirmSegmentReservation(ctx, confirmReq, steps, transport)
 
 	if err != nil || !res.Success() {
+		storeFailed := err != nil
 		origErr := err
 		if res != nil && !res.Success() {
 			origErr = fmt.Errorf(res.(*base.ResponseFailure).Message)
@@ -270,18 +564,129 @@ func (m *manager) SetupRequest(ctx context.Context, req *segment.SetupReq) error
 		log.Info("error confirming index", "id", req.ID, "idx", req.Index,
 			"err", origErr, "res_failure", res != nil && !res.Success())
 		// rollback the index state
-		i, err := base.FindIndex(req.Reservation.Indices, req.Index)
-		if err == nil {
-			req.Reservation.Indices[i].State = segment.IndexTemporary
+		if err := req.Reservation.SetIndexTemporary(req.Index); err != nil {
+			log.Debug("cannot roll back the index state", "id", req.ID, "idx", req.Index,
+				"err", err)
+		}
+		if isNew && ctx.Err() != nil {
+			m.teardownAbandoned(req)
+		}
+		if storeFailed {
+			return m.storeErr(store, serrors.WrapStr("failed to confirm the index", origErr))
 		}
 		return serrors.WrapStr("failed to confirm the index", origErr)
 	}
-	return err
+	m.recordSetupBW(req)
+	return nil
+}
+
+// teardownAbandoned tears down the new reservation of a setup cancelled after its admission,
+// but before its index was confirmed. The teardown runs in the background, with its own
+// context, and is best effort. The reservation is removed from the request, so that the caller
+// does not keep it.
+func (m *manager) teardownAbandoned(req *segment.SetupReq) {
+	rsv := req.Reservation
+	req.Reservation = nil
+	if rsv == nil {
+		return
+	}
+	teardownReq := base.NewRequest(m.now(), &rsv.ID, req.Index, len(rsv.Steps))
+	steps := rsv.Steps.Copy()
+	inReverse := rsv.PathType == reservation.DownPath
+	m.teardowns.Add(1)
+	go func() {
+		defer log.HandlePanic()
+		defer m.teardowns.Done()
+		ctx, cancelF := context.WithTimeout(context.Background(), abandonedTeardownTimeout)
+		defer cancelF()
+		err := m.TeardownRequest(ctx, teardownReq, steps, rsv.TransportPath, inReverse)
+		if err != nil {
+			log.Info("error tearing down reservation abandoned during its setup",
+				"id", rsv.ID.String(), "err", err)
+			return
+		}
+		log.Info("torn down reservation abandoned during its setup", "id", rsv.ID.String())
+	}()
+}
+
+// recordSetupBW logs and observes the requested and admitted bandwidth of a confirmed setup.
+// The admitted bandwidth is the one of the new index, as obtained from the response, or the
+// bottleneck of the allocation trail if the index is not there.
+func (m *manager) recordSetupBW(req *segment.SetupReq) {
+	admitted := req.AllocTrail.Bottleneck()
+	if idx, ok := req.Reservation.IndexSnapshot(req.Index); ok {
+		admitted = idx.AllocBW
+	}
+	log.Debug("COLIBRI setup admitted", "id", req.ID.String(), "idx", req.Index,
+		"requested_min", req.MinBW, "requested_max", req.MaxBW, "admitted", admitted,
+		"dst_ia", req.Steps.DstIA())
+	metrics.HistogramObserve(metrics.HistogramWith(m.metrics.SetupBW, "kind", "requested_min"),
+		float64(req.MinBW))
+	metrics.HistogramObserve(metrics.HistogramWith(m.metrics.SetupBW, "kind", "requested_max"),
+		float64(req.MaxBW))
+	metrics.HistogramObserve(metrics.HistogramWith(m.metrics.SetupBW, "kind", "admitted"),
+		float64(admitted))
+	if isRenewalDowngrade(req, admitted) {
+		active, _ := req.Reservation.ActiveIndexSnapshot()
+		log.Debug("COLIBRI renewal admitted less bandwidth than the active index",
+			"id", req.ID.String(), "idx", req.Index, "admitted", admitted,
+			"active_idx", active.Idx, "active_alloc_bw", active.AllocBW,
+			"requested_max", req.MaxBW, "dst_ia", req.Steps.DstIA())
+		metrics.CounterInc(m.metrics.RenewalDowngrades)
+	}
+}
+
+// isRenewalDowngrade returns true if the request renews a reservation with an active index,
+// and the admitted bandwidth is lower than that of the active index. Renewals that
+// requested less than the active index, e.g. after a downgrade, are not considered.
+func isRenewalDowngrade(req *segment.SetupReq, admitted reservation.BWCls) bool {
+	if req.Reservation == nil {
+		return false
+	}
+	active, ok := req.Reservation.ActiveIndexSnapshot()
+	if !ok || active.Idx == req.Index {
+		return false
+	}
+	expected := active.AllocBW
+	if req.MaxBW < expected {
+		expected = req.MaxBW
+	}
+	return admitted < expected
+}
+
+func (m *manager) TeardownRequest(ctx context.Context, req *base.Request, steps base.PathSteps,
+	transportPath *colpath.ColibriPathMinimal, reverseTraveling bool) error {
+
+	if m.readOnly {
+		return ErrReadOnly
+	}
+	if reverseTraveling {
+		steps = steps.Reverse()
+	}
+	transport := transportPath
+	if transportPath != nil {
+		transport.Src = caddr.NewEndpointWithAddr(steps.SrcIA(), addr.SvcCOL.Base())
+		transport.Dst = caddr.NewEndpointWithAddr(steps.DstIA(), addr.SvcCOL.Base())
+	}
+	// the steps go from this AS to the destination
+	store := m.storeFor(steps.DstIA())
+	res, err := store.InitTearDownSegmentReservation(ctx, req, steps, transport)
+	if err != nil {
+		return m.storeErr(store, err)
+	}
+	if !res.Success() {
+		return serrors.New("error tearing down reservation",
+			"msg", res.(*base.ResponseFailure).Message)
+	}
+	return nil
 }
 
 func (m *manager) ActivateRequest(ctx context.Context, req *base.Request, steps base.PathSteps,
 	transportPath *colpath.ColibriPathMinimal, reverseTraveling bool) error {
 
+	if m.readOnly {
+		return ErrReadOnly
+	}
 	if reverseTraveling {
 		steps = steps.Reverse()
 	}
@@ -290,16 +695,78 @@ func (m *manager) ActivateRequest(ctx context.Context, req *base.Request, steps
 		transport.Src = caddr.NewEndpointWithAddr(steps.SrcIA(), addr.SvcCOL.Base())
 		transport.Dst = caddr.NewEndpointWithAddr(steps.DstIA(), addr.SvcCOL.Base())
 	}
-	res, err := m.store.InitActivateSegmentReservation(ctx, req, steps, transport)
+	// the steps go from this AS to the destination
+	store := m.storeFor(steps.DstIA())
+	res, err := store.InitActivateSegmentReservation(ctx, req, steps, transport)
 	if err != nil {
-		return err
+		return m.storeErr(store, err)
 	}
 	if !res.Success() {
-		return serrors.New("error activating index", "msg", res.(*base.ResponseFailure).Message)
+		return serrors.WrapStr("error activating index", errRequestRejected,
+			"msg", res.(*base.ResponseFailure).Message)
 	}
 	return nil
 }
 
+// storeErr returns err wrapped in ErrStoreUnavailable if the store is not ready anymore, as
+// the error is then not specific to the request. Otherwise it returns err as is.
+// The errors of cancelled or expired requests are always returned as is.
+func (m *manager) storeErr(store reservationstorage.Store, err error) error {
+	if err == nil || errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) || store.Ready() {
+		return err
+	}
+	return serrors.Wrap(ErrStoreUnavailable, err)
+}
+
+// stores returns the main store followed by the shards, if any.
+func (m *manager) stores() []reservationstorage.Store {
+	return append([]reservationstorage.Store{m.store}, m.shards...)
+}
+
+// storeFor returns the store of the reservations initiated by this AS to dst.
+func (m *manager) storeFor(dst addr.IA) reservationstorage.Store {
+	if len(m.shards) == 0 {
+		return m.store
+	}
+	partition := m.partition
+	if partition == nil {
+		partition = PartitionByDst
+	}
+	stores := m.stores()
+	return stores[partition(dst, len(stores))]
+}
+
+// storesReady returns true if all the stores are ready.
+func (m *manager) storesReady() bool {
+	for _, s := range m.stores() {
+		if !s.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
+// forEachStore calls f with each store, adding up the counts and returning the earliest
+// non zero time. It stops at the first error.
+func (m *manager) forEachStore(f func(reservationstorage.Store) (int, time.Time, error)) (
+	int, time.Time, error) {
+
+	total := 0
+	var earliest time.Time
+	for _, s := range m.stores() {
+		n, t, err := f(s)
+		total += n
+		if err != nil {
+			return total, earliest, err
+		}
+		if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+			earliest = t
+		}
+	}
+	return total, earliest, nil
+}
+
 func findEarliest(times ...time.Time) time.Time {
 	if len(times) == 0 {
 		return time.Time{}
@@ -0,0 +1,860 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	base "github.com/scionproto/scion/go/co/reservation"
+	"github.com/scionproto/scion/go/co/reservation/conf"
+	"github.com/scionproto/scion/go/co/reservation/segment"
+	"github.com/scionproto/scion/go/co/reservation/segmenttest"
+	"github.com/scionproto/scion/go/co/reservation/test"
+	"github.com/scionproto/scion/go/co/reservationstorage"
+	"github.com/scionproto/scion/go/co/reservationstorage/mock_reservationstorage"
+	mockmanager "github.com/scionproto/scion/go/co/reservationstore/mock_reservationstore"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestManagerRunEnabledTasks(t *testing.T) {
+	cases := map[string]struct {
+		reports ReportIntervals
+	}{
+		"all_reports": {
+			reports: ReportIntervals{Segments: time.Minute, E2Es: time.Minute},
+		},
+		"no_reports": {
+			reports: ReportIntervals{},
+		},
+		"only_segments_report": {
+			reports: ReportIntervals{Segments: time.Minute},
+		},
+		"only_e2es_report": {
+			reports: ReportIntervals{E2Es: time.Minute},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			store.EXPECT().Ready().Return(true)
+			store.EXPECT().DeleteExpiredIndices(gomock.Any(), gomock.Any()).
+				Return(0, time.Time{}, nil)
+			store.EXPECT().DeleteExpiredAdmissionEntries(gomock.Any(), gomock.Any()).
+				Return(0, time.Time{}, nil)
+			segsReports, e2esReports := 0, 0
+			if tc.reports.Segments > 0 {
+				segsReports = 1
+			}
+			if tc.reports.E2Es > 0 {
+				e2esReports = 1
+			}
+			store.EXPECT().ReportSegmentReservationsInDB(gomock.Any()).
+				Times(segsReports).Return(nil, nil)
+			store.EXPECT().ReportE2EReservationsInDB(gomock.Any()).
+				Times(e2esReports).Return(nil, nil)
+
+			m := &manager{
+				now:     time.Now,
+				store:   store,
+				reports: tc.reports,
+				keeper: &keeper{
+					now:      time.Now,
+					provider: nil, // no entries, thus the provider is never used
+				},
+			}
+			m.Run(ctx)
+
+			require.False(t, m.wakeupKeeper.IsZero())
+			require.False(t, m.wakeupExpirer.IsZero())
+			require.False(t, m.wakeupAdmissionList.IsZero())
+			require.Equal(t, tc.reports.Segments > 0, !m.wakeupListSegs.IsZero())
+			require.Equal(t, tc.reports.E2Es > 0, !m.wakeupListE2Es.IsZero())
+			// disabled tasks must not be taken into account for the next wakeup
+			require.Equal(t, m.wakeupExpirer, m.wakeupTime)
+		})
+	}
+}
+
+// TestManagerRunNoOverlappingKeeper runs the manager while the keeper is blocked, and checks
+// that the concurrent runs skip the keeper instead of waiting for or overlapping with it.
+// Run it with -race.
+func TestManagerRunNoOverlappingKeeper(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	store.EXPECT().Ready().Return(true).AnyTimes()
+	store.EXPECT().DeleteExpiredIndices(gomock.Any(), gomock.Any()).
+		Return(0, time.Time{}, nil).AnyTimes()
+	store.EXPECT().DeleteExpiredAdmissionEntries(gomock.Any(), gomock.Any()).
+		Return(0, time.Time{}, nil).AnyTimes()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	// the only entry has no reservation: each OneShot looks for paths exactly once
+	provider.EXPECT().PathsTo(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(context.Context, addr.IA) ([]snet.Path, error) {
+			close(started)
+			<-release
+			return nil, serrors.New("no paths")
+		})
+
+	m := &manager{
+		now:   time.Now,
+		store: store,
+		keeper: &keeper{
+			now:      time.Now,
+			provider: provider,
+			entries: []*entry{{
+				conf: &configuration{dst: xtest.MustParseIA("1-ff00:0:2")},
+			}},
+		},
+	}
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		m.Run(ctx)
+	}()
+	<-started
+
+	// fire many runs while the keeper is blocked; none of them must wait for it
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Run(ctx)
+		}()
+	}
+	skipped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(skipped)
+	}()
+	select {
+	case <-skipped:
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "concurrent runs waited for the running keeper")
+	}
+
+	close(release)
+	<-firstDone
+	require.False(t, m.wakeupKeeper.IsZero())
+	require.False(t, m.keeping)
+}
+
+// TestManagerReadyAfterFirstCycle checks that the manager becomes ready only once the stores
+// are ready and the keeper has completed its first cycle.
+func TestManagerReadyAfterFirstCycle(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storeReady := false
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	store.EXPECT().Ready().DoAndReturn(func() bool { return storeReady }).AnyTimes()
+	store.EXPECT().DeleteExpiredIndices(gomock.Any(), gomock.Any()).
+		Return(0, time.Time{}, nil).AnyTimes()
+	store.EXPECT().DeleteExpiredAdmissionEntries(gomock.Any(), gomock.Any()).
+		Return(0, time.Time{}, nil).AnyTimes()
+	provider := mockmanager.NewMockServiceFacilitator(ctrl)
+	// failing to keep a reservation is not fatal for the cycle
+	provider.EXPECT().PathsTo(gomock.Any(), gomock.Any()).
+		Return(nil, serrors.New("no paths")).Times(1)
+
+	m := &manager{
+		now:   time.Now,
+		store: store,
+		keeper: &keeper{
+			now:      time.Now,
+			provider: provider,
+			entries: []*entry{{
+				conf: &configuration{dst: xtest.MustParseIA("1-ff00:0:2")},
+			}},
+		},
+	}
+	ready, detail := m.Health()
+	require.False(t, ready)
+	require.Equal(t, "store not ready", detail)
+
+	// the keeper does not run while the store is not ready
+	m.Run(ctx)
+	require.False(t, m.Ready())
+
+	storeReady = true
+	ready, detail = m.Health()
+	require.False(t, ready)
+	require.Equal(t, "first keeper cycle pending", detail)
+
+	m.wakeupTime = time.Time{}
+	m.Run(ctx)
+	require.True(t, m.Ready())
+
+	// a read-only manager never runs the keeper
+	ro := &manager{now: time.Now, store: store, readOnly: true}
+	require.True(t, ro.Ready())
+}
+
+// TestManagerReadOnly checks that a read-only manager only reports the reservations, and
+// never sets up, activates or tears them down.
+func TestManagerReadOnly(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	localIA := xtest.MustParseIA("1-ff00:0:1")
+	orphan := segmenttest.NewRsv(segmenttest.WithID("ff00:0:1", "beefcafe"),
+		segmenttest.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:3"))
+	store := mock_reservationstorage.NewMockStore(ctrl)
+	store.EXPECT().Ready().Return(true).AnyTimes()
+	store.EXPECT().GetReservationsAtSource(gomock.Any()).
+		Return([]*segment.Reservation{orphan}, nil)
+	store.EXPECT().ReportSegmentReservationsInDB(gomock.Any()).Return(nil, nil)
+	store.EXPECT().DeleteExpiredIndices(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().DeleteExpiredAdmissionEntries(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().InitSegmentReservation(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().InitActivateSegmentReservation(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Times(0)
+	store.EXPECT().InitTearDownSegmentReservation(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any()).Times(0)
+
+	initial := &conf.Reservations{
+		Rsvs: []conf.ReservationEntry{{
+			DstAS:         xtest.MustParseIA("1-ff00:0:2"),
+			PathType:      reservation.UpPath,
+			PathPredicate: "1-ff00:0:1 1-ff00:0:2",
+			MinSize:       10,
+			MaxSize:       42,
+			SplitCls:      1,
+		}},
+		TeardownOrphans: true,
+	}
+	m, err := NewColibriManager(ctx, localIA, nil, store, initial, ManagerConfig{
+		Reports:  ReportIntervals{Segments: time.Minute},
+		ReadOnly: true,
+	}, Metrics{})
+	require.NoError(t, err)
+	require.Equal(t, []reservation.ID{orphan.ID}, m.Orphans())
+	require.True(t, initial.TeardownOrphans) // the configuration is not modified
+
+	m.Run(ctx)
+	require.True(t, m.wakeupKeeper.IsZero())
+	require.True(t, m.wakeupExpirer.IsZero())
+	require.False(t, m.wakeupListSegs.IsZero())
+
+	// the requests of the keeper fail without reaching the store
+	req := &segment.SetupReq{
+		Request:  *base.NewRequest(time.Now(), &orphan.ID, 1, 2),
+		PathType: reservation.UpPath,
+		Steps:    orphan.Steps,
+	}
+	require.ErrorIs(t, m.SetupRequest(ctx, req), ErrReadOnly)
+	require.ErrorIs(t, m.ActivateRequest(ctx, &req.Request, orphan.Steps, nil, false),
+		ErrReadOnly)
+	require.ErrorIs(t, m.TeardownRequest(ctx, &req.Request, orphan.Steps, nil, false),
+		ErrReadOnly)
+}
+
+func TestSetupRequestRecordsAdmittedBW(t *testing.T) {
+	cases := map[string]struct {
+		minBW        reservation.BWCls
+		maxBW        reservation.BWCls
+		allocBW      reservation.BWCls
+		confirmFails bool
+		expected     map[string]float64
+	}{
+		"fully_admitted": {
+			minBW:   5,
+			maxBW:   13,
+			allocBW: 13,
+			expected: map[string]float64{
+				"requested_min": 5,
+				"requested_max": 13,
+				"admitted":      13,
+			},
+		},
+		"capped": {
+			minBW:   5,
+			maxBW:   13,
+			allocBW: 7,
+			expected: map[string]float64{
+				"requested_min": 5,
+				"requested_max": 13,
+				"admitted":      7,
+			},
+		},
+		"not_confirmed": {
+			minBW:        5,
+			maxBW:        13,
+			allocBW:      13,
+			confirmFails: true,
+			expected:     map[string]float64{},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := &segment.SetupReq{
+				Request: *base.NewRequest(time.Now(), test.MustParseID("ff00:0:1", "01234567"),
+					1, 3),
+				MinBW:       tc.minBW,
+				MaxBW:       tc.maxBW,
+				Steps:       test.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1, "1-ff00:0:3"),
+				CurrentStep: 0,
+			}
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			store.EXPECT().InitSegmentReservation(gomock.Any(), req).DoAndReturn(
+				func(_ context.Context, req *segment.SetupReq) error {
+					// the rest of the path admitted less than requested
+					req.AllocTrail = reservation.AllocationBeads{
+						{AllocBW: tc.maxBW, MaxBW: tc.maxBW},
+						{AllocBW: tc.allocBW, MaxBW: tc.allocBW},
+					}
+					req.Reservation = segmenttest.NewRsv(
+						segmenttest.WithID("ff00:0:1", "01234567"),
+						segmenttest.AddIndex(1, segmenttest.WithBW(int(tc.minBW),
+							int(tc.maxBW), int(tc.allocBW))))
+					return nil
+				})
+			var res base.Response = &base.ResponseSuccess{}
+			if tc.confirmFails {
+				res = &base.ResponseFailure{FailedStep: 1, Message: "not confirmed"}
+			}
+			store.EXPECT().InitConfirmSegmentReservation(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Return(res, nil)
+
+			hist := &fakeHistogram{observed: map[string]float64{}}
+			m := &manager{
+				now:     time.Now,
+				store:   store,
+				metrics: Metrics{SetupBW: hist},
+			}
+			err := m.SetupRequest(ctx, req)
+			if tc.confirmFails {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expected, hist.observed)
+		})
+	}
+}
+
+func TestSetupRequestCancelledTeardown(t *testing.T) {
+	cases := map[string]struct {
+		renewal           bool
+		cancelAtInit      bool
+		cancelAtConfirm   bool
+		expectedConfirms  int
+		expectedTeardowns int
+	}{
+		"not_cancelled": {
+			expectedConfirms: 1,
+		},
+		"cancelled_after_init": {
+			cancelAtInit:      true,
+			expectedTeardowns: 1,
+		},
+		"cancelled_during_confirm": {
+			cancelAtConfirm:   true,
+			expectedConfirms:  1,
+			expectedTeardowns: 1,
+		},
+		"renewal_cancelled_after_init": {
+			renewal:          true,
+			cancelAtInit:     true,
+			expectedConfirms: 1,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancelF := context.WithCancel(context.Background())
+			defer cancelF()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rsv := segmenttest.NewRsv(segmenttest.WithID("ff00:0:1", "01234567"),
+				segmenttest.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				segmenttest.AddIndex(0, segmenttest.WithBW(5, 13, 13)))
+			req := &segment.SetupReq{
+				Request: *base.NewRequest(time.Now(), &rsv.ID, 0, 2),
+				MinBW:   5,
+				MaxBW:   13,
+				Steps:   test.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+			}
+			if tc.renewal {
+				req.Reservation = rsv
+				req.TransportPath = test.NewColPathMin(req.Steps)
+			}
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			store.EXPECT().InitSegmentReservation(gomock.Any(), req).DoAndReturn(
+				func(_ context.Context, req *segment.SetupReq) error {
+					req.Reservation = rsv
+					if tc.cancelAtInit {
+						cancelF()
+					}
+					return nil
+				})
+			store.EXPECT().InitConfirmSegmentReservation(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(tc.expectedConfirms).DoAndReturn(
+				func(ctx context.Context, _ *base.Request, _ base.PathSteps,
+					_ *colpath.ColibriPathMinimal) (base.Response, error) {
+
+					if tc.cancelAtConfirm {
+						cancelF()
+					}
+					if err := ctx.Err(); err != nil {
+						return nil, err
+					}
+					return &base.ResponseSuccess{}, nil
+				})
+			store.EXPECT().InitTearDownSegmentReservation(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Times(tc.expectedTeardowns).DoAndReturn(
+				func(ctx context.Context, req *base.Request, _ base.PathSteps,
+					_ *colpath.ColibriPathMinimal) (base.Response, error) {
+
+					// the teardown is not affected by the cancelled context
+					require.NoError(t, ctx.Err())
+					require.Equal(t, rsv.ID, req.ID)
+					return &base.ResponseSuccess{}, nil
+				})
+
+			m := &manager{
+				now:   time.Now,
+				store: store,
+			}
+			err := m.SetupRequest(ctx, req)
+			m.teardowns.Wait()
+			if tc.cancelAtInit || tc.cancelAtConfirm {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if tc.expectedTeardowns > 0 {
+				// the caller does not keep the torn down reservation
+				require.Nil(t, req.Reservation)
+			} else {
+				require.Same(t, rsv, req.Reservation)
+			}
+		})
+	}
+}
+
+func TestSetupRequestFlagsRenewalDowngrade(t *testing.T) {
+	cases := map[string]struct {
+		activeBW          int // alloc. bw of the active index 0, no active index if zero
+		maxBW             reservation.BWCls
+		allocBW           reservation.BWCls // admitted for the new index 1
+		expectedDowngrade bool
+	}{
+		"setup": {
+			maxBW:   13,
+			allocBW: 7,
+		},
+		"same_bw": {
+			activeBW: 13,
+			maxBW:    13,
+			allocBW:  13,
+		},
+		"upgrade": {
+			activeBW: 7,
+			maxBW:    13,
+			allocBW:  13,
+		},
+		"downgrade": {
+			activeBW:          13,
+			maxBW:             13,
+			allocBW:           7,
+			expectedDowngrade: true,
+		},
+		"requested_less": {
+			activeBW: 13,
+			maxBW:    7,
+			allocBW:  7,
+		},
+		"below_requested_less": {
+			activeBW:          13,
+			maxBW:             9,
+			allocBW:           7,
+			expectedDowngrade: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := &segment.SetupReq{
+				Request: *base.NewRequest(time.Now(), test.MustParseID("ff00:0:1", "01234567"),
+					1, 3),
+				MinBW:       5,
+				MaxBW:       tc.maxBW,
+				Steps:       test.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1, "1-ff00:0:3"),
+				CurrentStep: 0,
+			}
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			store.EXPECT().InitSegmentReservation(gomock.Any(), req).DoAndReturn(
+				func(_ context.Context, req *segment.SetupReq) error {
+					mods := []segmenttest.ReservationMod{
+						segmenttest.WithID("ff00:0:1", "01234567"),
+					}
+					if tc.activeBW != 0 {
+						mods = append(mods,
+							segmenttest.AddIndex(0, segmenttest.WithBW(5, 13, tc.activeBW)),
+							segmenttest.WithActiveIndex(0))
+					}
+					mods = append(mods, segmenttest.AddIndex(1,
+						segmenttest.WithBW(5, int(tc.maxBW), int(tc.allocBW))))
+					req.Reservation = segmenttest.NewRsv(mods...)
+					// the tokens must carry the allocated bandwidth of their indices
+					for _, index := range req.Reservation.IndicesSnapshot() {
+						require.NotNil(t, index.Token)
+						require.Equal(t, index.AllocBW, index.Token.BWCls)
+					}
+					return nil
+				})
+			store.EXPECT().InitConfirmSegmentReservation(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Return(&base.ResponseSuccess{}, nil)
+
+			downgrades := metrics.NewTestCounter()
+			m := &manager{
+				now:     time.Now,
+				store:   store,
+				metrics: Metrics{RenewalDowngrades: downgrades},
+			}
+			err := m.SetupRequest(ctx, req)
+			require.NoError(t, err)
+			expected := 0.0
+			if tc.expectedDowngrade {
+				expected = 1
+			}
+			require.Equal(t, expected, metrics.CounterValue(downgrades))
+		})
+	}
+}
+
+// fakeHistogram keeps the last observed value per "kind" label.
+type fakeHistogram struct {
+	kind     string
+	observed map[string]float64
+}
+
+func (h *fakeHistogram) With(labelValues ...string) metrics.Histogram {
+	return &fakeHistogram{
+		kind:     labelValues[1],
+		observed: h.observed,
+	}
+}
+
+func (h *fakeHistogram) Observe(value float64) {
+	h.observed[h.kind] = value
+}
+
+func TestTeardownRequest(t *testing.T) {
+	steps := test.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2", 2, 1, "1-ff00:0:3")
+	cases := map[string]struct {
+		reverse       bool
+		res           base.Response
+		expectedSteps base.PathSteps // as passed to the store
+		expectedErr   string         // empty if no error is expected
+	}{
+		"success": {
+			res:           &base.ResponseSuccess{},
+			expectedSteps: steps,
+		},
+		"reverse": {
+			reverse:       true,
+			res:           &base.ResponseSuccess{},
+			expectedSteps: steps.Reverse(),
+		},
+		"transit_unreachable": {
+			res: &base.ResponseFailure{
+				FailedStep: 1,
+				Message:    "cannot reach 1-ff00:0:3",
+			},
+			expectedSteps: steps,
+			expectedErr:   "cannot reach 1-ff00:0:3",
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			req := base.NewRequest(time.Now(), test.MustParseID("ff00:0:1", "01234567"), 0,
+				len(steps))
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			store.EXPECT().InitTearDownSegmentReservation(gomock.Any(), req,
+				tc.expectedSteps, nil).Return(tc.res, nil)
+			m := &manager{
+				now:   time.Now,
+				store: store,
+			}
+			err := m.TeardownRequest(ctx, req, steps.Copy(), nil, tc.reverse)
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
+
+func TestRequestsStoreUnavailable(t *testing.T) {
+	cases := map[string]struct {
+		ready       bool
+		cancelled   bool // the requests fail because their context is cancelled
+		unavailable bool
+	}{
+		"ready": {
+			ready:       true,
+			unavailable: false,
+		},
+		"not_ready": {
+			ready:       false,
+			unavailable: true,
+		},
+		"cancelled_not_ready": {
+			ready:       false,
+			cancelled:   true,
+			unavailable: false,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storeErr := serrors.New("connection lost")
+			if tc.cancelled {
+				storeErr = serrors.WrapStr("request failed", context.Canceled)
+			}
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			store.EXPECT().Ready().AnyTimes().Return(tc.ready)
+			store.EXPECT().InitActivateSegmentReservation(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Return(nil, storeErr)
+			store.EXPECT().InitTearDownSegmentReservation(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).Return(nil, storeErr)
+			store.EXPECT().InitSegmentReservation(gomock.Any(), gomock.Any()).
+				Return(storeErr)
+			m := &manager{
+				now:   time.Now,
+				store: store,
+			}
+			steps := test.NewSteps("1-ff00:0:1", 1, 1, "1-ff00:0:2")
+			req := base.NewRequest(time.Now(), test.MustParseID("ff00:0:1", "01234567"), 0,
+				len(steps))
+
+			err := m.ActivateRequest(ctx, req, steps, nil, false)
+			require.Error(t, err)
+			require.Equal(t, tc.unavailable, errors.Is(err, ErrStoreUnavailable))
+
+			err = m.TeardownRequest(ctx, req, steps, nil, false)
+			require.Error(t, err)
+			require.Equal(t, tc.unavailable, errors.Is(err, ErrStoreUnavailable))
+
+			setupReq := &segment.SetupReq{
+				Request:     *req,
+				MinBW:       5,
+				MaxBW:       13,
+				Steps:       steps,
+				CurrentStep: 0,
+			}
+			err = m.SetupRequest(ctx, setupReq)
+			require.Error(t, err)
+			require.Equal(t, tc.unavailable, errors.Is(err, ErrStoreUnavailable))
+		})
+	}
+}
+
+func TestManagerShards(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	local := "1-ff00:0:1"
+	dsts := []addr.IA{xtest.MustParseIA("1-ff00:0:2"), xtest.MustParseIA("1-ff00:0:3")}
+	stores := []*mock_reservationstorage.MockStore{
+		mock_reservationstorage.NewMockStore(ctrl),
+		mock_reservationstorage.NewMockStore(ctrl),
+	}
+	m := &manager{
+		now:    time.Now,
+		store:  stores[0],
+		shards: []reservationstorage.Store{stores[1]},
+		partition: func(dst addr.IA, shards int) int {
+			require.Equal(t, 2, shards)
+			for i, ia := range dsts {
+				if ia == dst {
+					return i
+				}
+			}
+			require.FailNow(t, "unexpected destination", dst)
+			return 0
+		},
+	}
+
+	// the requests are routed to the shard of the destination, also for down-path SegRs,
+	// whose steps go from the destination to this AS
+	cases := map[string]struct {
+		pathType reservation.PathType
+		steps    base.PathSteps
+		shard    int
+	}{
+		"up_shard_0": {
+			pathType: reservation.UpPath,
+			steps:    test.NewSteps(local, 1, 1, dsts[0].String()),
+			shard:    0,
+		},
+		"up_shard_1": {
+			pathType: reservation.UpPath,
+			steps:    test.NewSteps(local, 2, 1, dsts[1].String()),
+			shard:    1,
+		},
+		"down_shard_0": {
+			pathType: reservation.DownPath,
+			steps:    test.NewSteps(dsts[0].String(), 1, 1, local),
+			shard:    0,
+		},
+		"down_shard_1": {
+			pathType: reservation.DownPath,
+			steps:    test.NewSteps(dsts[1].String(), 1, 2, local),
+			shard:    1,
+		},
+	}
+	for name, tc := range cases {
+		currentStep := 0
+		props := reservation.StartLocal
+		if tc.pathType == reservation.DownPath {
+			currentStep = len(tc.steps) - 1
+			props = reservation.EndLocal
+		}
+		req := &segment.SetupReq{
+			Request: *base.NewRequest(time.Now(), test.MustParseID("ff00:0:1", "01234567"),
+				1, len(tc.steps)),
+			PathType:    tc.pathType,
+			PathProps:   props,
+			MinBW:       5,
+			MaxBW:       13,
+			Steps:       tc.steps,
+			CurrentStep: currentStep,
+		}
+		store := stores[tc.shard]
+		store.EXPECT().InitSegmentReservation(gomock.Any(), req).DoAndReturn(
+			func(_ context.Context, req *segment.SetupReq) error {
+				req.Reservation = segmenttest.NewRsv(
+					segmenttest.WithID("ff00:0:1", "01234567"),
+					segmenttest.AddIndex(1, segmenttest.WithBW(5, 13, 13)))
+				return nil
+			})
+		store.EXPECT().InitConfirmSegmentReservation(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(&base.ResponseSuccess{}, nil)
+		require.NoError(t, m.SetupRequest(ctx, req), name)
+
+		store.EXPECT().InitActivateSegmentReservation(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(&base.ResponseSuccess{}, nil)
+		require.NoError(t, m.ActivateRequest(ctx, &req.Request, tc.steps, nil,
+			tc.pathType == reservation.DownPath), name)
+
+		store.EXPECT().InitTearDownSegmentReservation(gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).Return(&base.ResponseSuccess{}, nil)
+		require.NoError(t, m.TeardownRequest(ctx, &req.Request, tc.steps, nil,
+			tc.pathType == reservation.DownPath), name)
+	}
+
+	// the reservations of all shards are aggregated
+	rsvs := []*segment.Reservation{
+		segmenttest.NewRsv(segmenttest.WithID("ff00:0:1", "00000001"),
+			segmenttest.WithPath(local, 1, 1, dsts[0].String())),
+		segmenttest.NewRsv(segmenttest.WithID("ff00:0:1", "00000002"),
+			segmenttest.WithPath(local, 2, 1, dsts[1].String())),
+	}
+	for i, s := range stores {
+		s.EXPECT().GetReservationsAtSource(gomock.Any()).
+			Return([]*segment.Reservation{rsvs[i]}, nil)
+	}
+	got, err := m.GetReservationsAtSource(ctx)
+	require.NoError(t, err)
+	require.Equal(t, rsvs, got)
+
+	// the expired indices are deleted in all shards, waking up at the earliest of them
+	now := time.Now()
+	stores[0].EXPECT().DeleteExpiredIndices(gomock.Any(), gomock.Any()).
+		Return(2, now.Add(10*time.Second), nil)
+	stores[1].EXPECT().DeleteExpiredIndices(gomock.Any(), gomock.Any()).
+		Return(3, now.Add(5*time.Second), nil)
+	require.Equal(t, now.Add(5*time.Second), m.deleteExpiredIndices(ctx, now))
+
+	// all the shards must be ready
+	stores[0].EXPECT().Ready().Return(true).AnyTimes()
+	stores[1].EXPECT().Ready().Return(false)
+	require.False(t, m.storesReady())
+}
+
+func TestPartitionByDst(t *testing.T) {
+	ias := []addr.IA{
+		xtest.MustParseIA("1-ff00:0:1"),
+		xtest.MustParseIA("1-ff00:0:2"),
+		xtest.MustParseIA("2-ff00:0:1"),
+		xtest.MustParseIA("1-ff00:1:110"),
+	}
+	for shards := 1; shards <= 4; shards++ {
+		for _, ia := range ias {
+			shard := PartitionByDst(ia, shards)
+			require.GreaterOrEqual(t, shard, 0)
+			require.Less(t, shard, shards)
+			// the same destination is always in the same shard
+			require.Equal(t, shard, PartitionByDst(ia, shards))
+		}
+	}
+}
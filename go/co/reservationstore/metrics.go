@@ -0,0 +1,55 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reservationstore
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+)
+
+// Metrics are the metrics reported by the colibri manager. Nil fields disable the
+// corresponding metric.
+type Metrics struct {
+	// SetupBW observes the bandwidth classes of each successful setup or renewal initiated
+	// by this AS. The "kind" label is one of requested_min, requested_max or admitted.
+	SetupBW metrics.Histogram
+	// RenewalDowngrades counts the renewals initiated by this AS that were admitted with a
+	// lower bandwidth class than the active index of the reservation, and than requested.
+	RenewalDowngrades metrics.Counter
+	// KeeperActions counts the configured reservations kept in each run of the keeper. The
+	// "action" label is one of nothing, created, renewed, activated, failed or deferred.
+	KeeperActions metrics.Counter
+}
+
+// NewMetrics creates and registers the prometheus metrics of the colibri manager.
+func NewMetrics() Metrics {
+	return Metrics{
+		SetupBW: metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+			Name:    "colibri_setup_bw_class",
+			Help:    "Requested and admitted bandwidth classes of the initiated segment setups.",
+			Buckets: prometheus.LinearBuckets(0, 4, 17),
+		}, []string{"kind"}),
+		RenewalDowngrades: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+			Name: "colibri_renewal_downgrades_total",
+			Help: "Renewals of segment reservations admitted with a lower bandwidth class " +
+				"than their active index.",
+		}, nil),
+		KeeperActions: metrics.NewPromCounterFrom(prometheus.CounterOpts{
+			Name: "colibri_keeper_actions_total",
+			Help: "Configured segment reservations kept by the keeper, per action taken.",
+		}, []string{"action"}),
+	}
+}
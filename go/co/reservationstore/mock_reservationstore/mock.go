@@ -110,3 +110,17 @@ func (mr *MockServiceFacilitatorMockRecorder) SetupRequest(arg0, arg1 interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupRequest", reflect.TypeOf((*MockServiceFacilitator)(nil).SetupRequest), arg0, arg1)
 }
+
+// TeardownRequest mocks base method.
+func (m *MockServiceFacilitator) TeardownRequest(arg0 context.Context, arg1 *reservation.Request, arg2 reservation.PathSteps, arg3 *colibri.ColibriPathMinimal, arg4 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeardownRequest", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TeardownRequest indicates an expected call of TeardownRequest.
+func (mr *MockServiceFacilitatorMockRecorder) TeardownRequest(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeardownRequest", reflect.TypeOf((*MockServiceFacilitator)(nil).TeardownRequest), arg0, arg1, arg2, arg3, arg4)
+}
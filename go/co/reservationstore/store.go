@@ -46,6 +46,10 @@ import (
 
 const MaxAdmissionEntryValidity = time.Minute
 
+// ErrAdmissionDenied is returned when the setup or renewal of a segment reservation is denied
+// by some AS along its path.
+var ErrAdmissionDenied = serrors.New("segment reservation denied")
+
 // Store is the reservation store.
 type Store struct {
 	localIA       addr.IA
@@ -320,7 +324,7 @@ func (s *Store) InitSegmentReservation(ctx context.Context, req *segment.SetupRe
 	}
 	if _, ok := res.(*segment.SegmentSetupResponseSuccess); !ok {
 		rollbackChanges(res)
-		return serrors.New("error in setup", "response", res)
+		return serrors.WithCtx(ErrAdmissionDenied, "response", res)
 	}
 	rsv = req.Reservation
 
@@ -347,6 +351,43 @@ func (s *Store) InitSegmentReservation(ctx context.Context, req *segment.SetupRe
 	return nil
 }
 
+// DryRunSegmentReservation computes the admission of the renewal request at this AS, and
+// returns the bandwidth that would be allocated here. The changes of the admission are rolled
+// back and the request is not sent to the other ASes of the path, which could still grant
+// less bandwidth.
+func (s *Store) DryRunSegmentReservation(ctx context.Context, req *segment.SetupReq) (
+	reservation.BWCls, error) {
+
+	if req.ID.ASID != s.localIA.AS() || req.ID.IsEmptySuffix() {
+		return 0, s.errNew("bad reservation id for a renewal", "id", req.ID.String())
+	}
+	tx, err := s.db.BeginTransaction(ctx, nil)
+	if err != nil {
+		return 0, s.errWrapStr("cannot create transaction", err, "id", req.ID.String())
+	}
+	defer tx.Rollback() // never committed: a dry run changes nothing
+
+	rsv, err := tx.GetSegmentRsvFromID(ctx, &req.ID)
+	if err != nil {
+		return 0, s.errWrapStr("cannot obtain segment reservation", err, "id", req.ID.String())
+	}
+	if rsv == nil {
+		return 0, s.errNew("reservation not found for a renewal", "id", req.ID.String())
+	}
+	if rsv.Index(req.Index) != nil {
+		return 0, s.errNew("index from setup already in use", "id", req.ID.String(),
+			"idx", req.Index)
+	}
+	req.Reservation = rsv
+	if err := req.ValidateForReservation(rsv); err != nil {
+		return 0, s.errWrapStr("error validating request with reservation", err)
+	}
+	if err := s.admitter.AdmitRsv(ctx, tx, req); err != nil {
+		return 0, serrors.Wrap(ErrAdmissionDenied, s.err(err), "id", req.ID.String())
+	}
+	return req.AllocTrail[len(req.AllocTrail)-1].AllocBW, nil
+}
+
 // InitConfirmSegmentReservation needs the steps variable in the order of transport: this means
 // in the direction of the SegR if core or up path, but reverse direction if down path.
 func (s *Store) InitConfirmSegmentReservation(ctx context.Context, req *base.Request,
@@ -1222,14 +1263,18 @@ func (s *Store) AdmitE2EReservation(
 			if err != nil {
 				return nil, err
 			}
-			transport = r.DeriveColibriPathAtSource()
+			if transport, err = r.DeriveColibriPathAtSource(); err != nil {
+				return nil, err
+			}
 		} else if isStitchPoint {
 			req.CurrentSegmentRsvIndex++
 			rNext, err := tx.GetSegmentRsvFromID(ctx, &req.SegmentRsvs[req.CurrentSegmentRsvIndex])
 			if err != nil {
 				return nil, err
 			}
-			transport = rNext.DeriveColibriPathAtSource()
+			if transport, err = rNext.DeriveColibriPathAtSource(); err != nil {
+				return nil, err
+			}
 		}
 		ingress = rsv.Ingress()
 		egress = rsv.Egress()
@@ -1369,13 +1414,17 @@ func (s *Store) CleanupE2EReservation(
 			if err != nil {
 				return nil, err
 			}
-			transport = r.DeriveColibriPathAtSource()
+			if transport, err = r.DeriveColibriPathAtSource(); err != nil {
+				return nil, err
+			}
 		} else {
 			r, err := tx.GetSegmentRsvFromID(ctx, &rsv.SegmentReservations[1].ID)
 			if err != nil {
 				return nil, err
 			}
-			transport = r.DeriveColibriPathAtSource()
+			if transport, err = r.DeriveColibriPathAtSource(); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -1864,8 +1913,8 @@ func computeMAC(buff []byte, key cipher.Block, suffix []byte, tok *reservation.T
 	hf *reservation.HopField, srcAS, dstAS addr.AS, isE2E bool) error {
 
 	var input [libcolibri.LengthInputDataRound16]byte
-	libcolibri.MACInputStatic(input[:], suffix, uint32(tok.InfoField.ExpirationTick), tok.BWCls,
-		tok.RLC, !isE2E, false, tok.Idx, srcAS, dstAS, hf.Ingress, hf.Egress)
+	libcolibri.MACInputStatic(input[:], suffix, tok.InfoField.ExpirationTick.ToExpTick(),
+		tok.BWCls, tok.RLC, !isE2E, false, tok.Idx, srcAS, dstAS, hf.Ingress, hf.Egress)
 	return libcolibri.MACStaticFromInput(buff, key, input[:])
 }
 
@@ -2007,9 +2056,9 @@ func pathFromSegmentRsv(rsv *segment.Reservation) (*colpath.ColibriPathMinimal,
 			"current_step: %d, steps: %s",
 			rsv.ID, rsv.PathType, rsv.CurrentStep, rsv.Steps,
 		)
-		return rsv.DeriveColibriPathAtDestination(), nil
+		return rsv.DeriveColibriPathAtDestination()
 	}
-	return rsv.DeriveColibriPathAtSource(), nil
+	return rsv.DeriveColibriPathAtSource()
 }
 
 // patchColibriTransport is at temporary fix:
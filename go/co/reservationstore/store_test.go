@@ -15,12 +15,144 @@
 package reservationstore
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	base "github.com/scionproto/scion/go/co/reservation"
+	"github.com/scionproto/scion/go/co/reservation/segment"
+	st "github.com/scionproto/scion/go/co/reservation/segmenttest"
 	"github.com/scionproto/scion/go/co/reservationstorage"
+	"github.com/scionproto/scion/go/co/reservationstorage/backend"
+	"github.com/scionproto/scion/go/co/reservationstorage/backend/mock_backend"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
 )
 
 func TestStore(t *testing.T) {
 	var s reservationstorage.Store = &Store{}
 	_ = s
 }
+
+// TestDryRunSegmentReservation checks that a dry run computes the admission in a transaction
+// that is never committed, and that nothing is persisted nor sent to other ASes.
+func TestDryRunSegmentReservation(t *testing.T) {
+	now := util.SecsToTime(10)
+	tomorrow := now.AddDate(0, 0, 1)
+	cases := map[string]struct {
+		index         reservation.IndexNumber
+		admitErr      error
+		expectedBW    reservation.BWCls
+		expectedError error // nil if no error, ErrAdmissionDenied, or any error
+	}{
+		"admitted": {
+			index:      1,
+			expectedBW: 7,
+		},
+		"denied": {
+			index:         1,
+			admitErr:      serrors.New("not enough capacity"),
+			expectedError: ErrAdmissionDenied,
+		},
+		"index_in_use": {
+			index:         0,
+			expectedError: serrors.New("any"),
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rsv := st.NewRsv(st.WithID("ff00:0:1", "beefcafe"),
+				st.WithPath("1-ff00:0:1", 1, 1, "1-ff00:0:2"),
+				st.WithPathType(reservation.UpPath),
+				st.AddIndex(0, st.WithBW(5, 10, 5), st.WithExpiration(tomorrow)),
+				st.WithActiveIndex(0))
+			// no other calls are expected: nothing can be persisted
+			tx := &fakeTx{MockDB: mock_backend.NewMockDB(ctrl)}
+			tx.EXPECT().GetSegmentRsvFromID(gomock.Any(), &rsv.ID).Return(rsv, nil)
+			db := mock_backend.NewMockDB(ctrl)
+			db.EXPECT().BeginTransaction(gomock.Any(), gomock.Any()).Return(tx, nil)
+			admitter := &fakeAdmitter{bw: tc.expectedBW, err: tc.admitErr}
+			s := &Store{
+				localIA:  xtest.MustParseIA("1-ff00:0:1"),
+				db:       db,
+				admitter: admitter,
+			}
+			req := &segment.SetupReq{
+				Request:        *base.NewRequest(now, &rsv.ID, tc.index, len(rsv.Steps)),
+				ExpirationTime: tomorrow,
+				PathType:       rsv.PathType,
+				MinBW:          5,
+				MaxBW:          10,
+				Steps:          rsv.Steps.Copy(),
+			}
+			bw, err := s.DryRunSegmentReservation(ctx, req)
+			switch {
+			case tc.expectedError == nil:
+				require.NoError(t, err)
+				require.Equal(t, tc.expectedBW, bw)
+			case errors.Is(tc.expectedError, ErrAdmissionDenied):
+				require.ErrorIs(t, err, ErrAdmissionDenied)
+			default:
+				require.Error(t, err)
+			}
+			require.Equal(t, tc.index != 0, admitter.called)
+			require.False(t, tx.committed)
+			require.True(t, tx.rolledBack)
+			// the reservation is left untouched
+			require.Len(t, rsv.Indices, 1)
+		})
+	}
+}
+
+// fakeTx is a transaction that records whether it was committed or rolled back.
+type fakeTx struct {
+	*mock_backend.MockDB
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+// fakeAdmitter admits all requests with bw, or fails with err.
+type fakeAdmitter struct {
+	bw     reservation.BWCls
+	err    error
+	called bool
+}
+
+func (a *fakeAdmitter) Capacities() base.Capacities {
+	return nil
+}
+
+func (a *fakeAdmitter) AdmitRsv(ctx context.Context, x backend.ColibriStorage,
+	req *segment.SetupReq) error {
+
+	a.called = true
+	if a.err != nil {
+		return a.err
+	}
+	req.AllocTrail = append(req.AllocTrail, reservation.AllocationBead{
+		AllocBW: a.bw,
+		MaxBW:   a.bw,
+	})
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+type configFlags struct {
+	RootFlags
+	Labels map[string]string
+}
+
+func newConfig() *cobra.Command {
+	var flags configFlags
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Check the configured segment reservations",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newConfigUnmatched(&flags),
+	)
+
+	return cmd
+}
+
+func newConfigUnmatched(flags *configFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unmatched",
+		Short: "List the configured reservations without any path satisfying their predicate",
+		Long: "'unmatched' looks up the paths to the destination of each configured segment " +
+			"reservation, and prints those for which no path satisfies the predicate. " +
+			"The keeper cannot create a reservation for them until the paths change, " +
+			"e.g. after a link they depend on is removed. Nothing is modified.\n" +
+			"With --label, only the reservations configured with all the labels are printed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return configUnmatchedCmd(cmd, flags)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+	cmd.Flags().StringToStringVar(&flags.Labels, "label", nil,
+		"only show the reservations with this label, as key=value (can be repeated)")
+
+	return cmd
+}
+
+func configUnmatchedCmd(cmd *cobra.Command, flags *configFlags) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		res, err := client.CmdKeeperUnmatched(ctx, &colpb.CmdKeeperUnmatchedRequest{
+			Labels: flags.Labels,
+		})
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		if len(res.Entries) == 0 {
+			fmt.Println("All the configured reservations have matching paths.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DST\tTYPE\tPREDICATE\tPATHS\tLABELS")
+		for _, e := range res.Entries {
+			predicate := strings.Join(strings.Fields(e.Predicate), " ")
+			if predicate == "" {
+				predicate = "-"
+			}
+			paths := fmt.Sprintf("%d", e.Paths)
+			if e.PathsError != "" {
+				paths = "error: " + e.PathsError
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", addr.IA(e.DstIa),
+				reservation.PathType(e.PathType), predicate, paths, formatLabels(e.Labels))
+		}
+		return w.Flush()
+	})
+}
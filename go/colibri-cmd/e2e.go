@@ -0,0 +1,118 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/co/reservation/translate"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+type e2eFlags struct {
+	RootFlags
+	Parent string
+}
+
+func newE2E() *cobra.Command {
+	var flags e2eFlags
+
+	cmd := &cobra.Command{
+		Use:   "e2e",
+		Short: "Inspect e2e reservations",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newE2EList(&flags),
+	)
+
+	return cmd
+}
+
+func newE2EList(flags *e2eFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the e2e reservations stored in the local colibri service",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return e2eListCmd(cmd, flags)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+	cmd.Flags().StringVar(&flags.Parent, "parent", "",
+		"list only the e2e reservations on top of this segment reservation ID")
+
+	return cmd
+}
+
+func e2eListCmd(cmd *cobra.Command, flags *e2eFlags) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	req := &colpb.CmdE2EListRequest{}
+	if flags.Parent != "" {
+		id, err := reservation.SegmentIDFromString(flags.Parent)
+		if err != nil {
+			return serrors.WrapStr("parsing the parent ID", err)
+		}
+		req.Parent = translate.PBufID(id)
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		res, err := client.CmdE2EList(ctx, req)
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		// same columns as the periodic e2e report of the colibri service
+		fmt.Printf("%38s %8s %3s %3s %12s\n", "id", "alloc", "idx", "bw", "exptime")
+		if len(res.Reservations) == 0 {
+			fmt.Println("no reservations")
+			return nil
+		}
+		for _, r := range res.Reservations {
+			idx, bw, exp := "--", "---", "-------"
+			if r.HasIndex {
+				idx = strconv.Itoa(int(r.Idx))
+				bw = strconv.Itoa(int(r.Bw))
+				exp = time.UnixMilli(int64(r.Exptime)).Format(time.StampMilli)
+			}
+			fmt.Printf("%38s %8d %3s %3s %12s\n", translate.ID(r.Id), r.Alloc, idx, bw, exp)
+		}
+		return nil
+	})
+}
@@ -24,7 +24,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/serrors"
-	sgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	"github.com/scionproto/scion/go/lib/util"
 	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
 	"github.com/spf13/cobra"
 )
@@ -32,7 +32,11 @@ import (
 type indexFlags struct {
 	RootFlags
 	// DebugServerAddr string
-	Activate bool
+	Activate     bool
+	DryRun       bool
+	DstIA        string
+	Index        int
+	ShowSchedule bool
 }
 
 func newIndex() *cobra.Command {
@@ -48,7 +52,9 @@ func newIndex() *cobra.Command {
 	cmd.AddCommand(
 		newIndexCreate(&flags),
 		newIndexActivate(&flags),
+		newIndexActivateAll(&flags),
 		newIndexCleanup(&flags),
+		newIndexExpire(&flags),
 	)
 
 	return cmd
@@ -66,6 +72,9 @@ func newIndexCreate(flags *indexFlags) *cobra.Command {
 
 	addRootFlags(cmd, &flags.RootFlags)
 	cmd.PersistentFlags().BoolVar(&flags.Activate, "activate", false, "also activate the index")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false,
+		"only compute the admission of the index in the local AS, without creating it")
+	addShowScheduleFlag(cmd, flags)
 
 	return cmd
 }
@@ -81,6 +90,24 @@ func newIndexActivate(flags *indexFlags) *cobra.Command {
 	}
 
 	addRootFlags(cmd, &flags.RootFlags)
+	addShowScheduleFlag(cmd, flags)
+
+	return cmd
+}
+
+func newIndexActivateAll(flags *indexFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "activate-all",
+		Short: "Activate the next index of all segment reservations initiated here",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return indexActivateAllCmd(cmd, flags)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+	cmd.Flags().StringVar(&flags.DstIA, "dst", "",
+		"activate only the segment reservations ending at this IA")
 
 	return cmd
 }
@@ -96,49 +123,114 @@ func newIndexCleanup(flags *indexFlags) *cobra.Command {
 	}
 
 	addRootFlags(cmd, &flags.RootFlags)
+	addShowScheduleFlag(cmd, flags)
+
+	return cmd
+}
+
+func newIndexExpire(flags *indexFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expire segR_ID --index index_number",
+		Short: "Expire an existing index now, for testing",
+		Long: "'expire' sets the expiration of the index to the past and removes it, as if it " +
+			"had expired. It is meant to test how the applications react to a reservation " +
+			"dying, and requires the service to have the debug commands enabled.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return indexExpireCmd(cmd, flags, args)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+	cmd.Flags().IntVar(&flags.Index, "index", -1, "the index number to expire")
+	cmd.MarkFlagRequired("index")
+	addShowScheduleFlag(cmd, flags)
 
 	return cmd
 }
 
 func indexCreateCmd(cmd *cobra.Command, flags *indexFlags, args []string) error {
-	cliAddr, err := flags.DebugServer()
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
 	if err != nil {
 		return err
 	}
-	id, err := reservation.IDFromString(args[0])
+	id, err := reservation.SegmentIDFromString(args[0])
 	if err != nil {
 		return serrors.WrapStr("parsing the ID of the segment reservation", err)
 	}
+	if flags.DryRun && flags.Activate {
+		return serrors.New("--dry-run cannot be combined with --activate")
+	}
 	cmd.SilenceUsage = true
 
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
 	defer cancelF()
 
-	grpcDialer := sgrpc.TCPDialer{}
-	conn, err := grpcDialer.Dial(ctx, cliAddr)
-	if err != nil {
-		return serrors.WrapStr("dialing to the local debug service", err)
-	}
-	client := colpb.NewColibriDebugCommandsServiceClient(conn)
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
 
-	// new index
-	req := &colpb.CmdIndexNewRequest{
-		Id: translate.PBufID(id),
-	}
-	res, err := client.CmdIndexNew(ctx, req)
+		// new index
+		req := &colpb.CmdIndexNewRequest{
+			Id:     translate.PBufID(id),
+			DryRun: flags.DryRun,
+		}
+		res, err := client.CmdIndexNew(ctx, req)
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		if flags.DryRun {
+			fmt.Printf("Would create index %d with BW class %d (as admitted by this AS).\n",
+				res.Index, res.AllocBw)
+			return nil
+		}
+		fmt.Printf("Index with ID %d created.\n", res.Index)
+
+		if flags.Activate {
+			if err := activateIdx(ctx, client, translate.PBufID(id), res.Index); err != nil {
+				return err
+			}
+		}
+		if flags.ShowSchedule {
+			return showSchedule(ctx, client, id)
+		}
+		return nil
+	})
+}
+
+func addShowScheduleFlag(cmd *cobra.Command, flags *indexFlags) {
+	cmd.Flags().BoolVar(&flags.ShowSchedule, "show-schedule", false,
+		"after the operation, print when the keeper will next handle the reservation")
+}
+
+// showSchedule prints the next action of the keeper for the segment reservation, and when the
+// keeper runs next. Reservations not managed by the keeper are reported as such.
+func showSchedule(ctx context.Context, client colpb.ColibriDebugCommandsServiceClient,
+	id *reservation.ID) error {
+
+	res, err := client.CmdKeeperPlan(ctx, &colpb.CmdKeeperPlanRequest{})
 	if err != nil {
 		return err
 	}
 	if res.ErrorFound != nil {
-		return serrors.New(
-			fmt.Sprintf("at IA %s: %s\n", addr.IA(res.ErrorFound.Ia), res.ErrorFound.Message))
+		return errorFound(res.ErrorFound)
 	}
-	fmt.Printf("Index with ID %d created.\n", res.Index)
-
-	if flags.Activate {
-		return activateIdx(ctx, client, translate.PBufID(id), res.Index)
+	for _, e := range res.Entries {
+		if e.Id == nil || !translate.ID(e.Id).Equal(id) {
+			continue
+		}
+		wakeup := util.SecsToTime(res.Wakeup)
+		fmt.Printf("Next keeper run: %s (in %s), next action: %s.\n",
+			wakeup.Format(time.RFC3339), time.Until(wakeup).Round(time.Second), e.Action)
+		return nil
 	}
-
+	fmt.Printf("Segment reservation %s is not managed by the keeper.\n", id)
 	return nil
 }
 
@@ -155,8 +247,7 @@ func activateIdx(ctx context.Context, client colpb.ColibriDebugCommandsServiceCl
 		return err
 	}
 	if res.ErrorFound != nil {
-		return serrors.New(
-			fmt.Sprintf("at IA %s: %s\n", addr.IA(res.ErrorFound.Ia), res.ErrorFound.Message))
+		return errorFound(res.ErrorFound)
 	}
 	fmt.Printf("Index with ID %d activated.\n", idx)
 	return nil
@@ -166,6 +257,63 @@ func indexActivateCmd(cmd *cobra.Command, flags *indexFlags, args []string) erro
 	return requestWithIndex(cmd, flags, args, activateIdx)
 }
 
+func indexActivateAllCmd(cmd *cobra.Command, flags *indexFlags) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	req := &colpb.CmdIndexActivateAllRequest{}
+	if flags.DstIA != "" {
+		dstIA, err := addr.ParseIA(flags.DstIA)
+		if err != nil {
+			return serrors.WrapStr("parsing the destination IA", err)
+		}
+		req.DstIa = uint64(dstIA)
+	}
+	cmd.SilenceUsage = true
+
+	// each activation travels the path of its reservation
+	ctx, cancelF := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		res, err := client.CmdIndexActivateAll(ctx, req)
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		if len(res.Results) == 0 {
+			fmt.Println("No segment reservation with an index to activate.")
+			return nil
+		}
+		failed := 0
+		for _, r := range res.Results {
+			id := translate.ID(r.Id)
+			if r.ErrorFound != nil {
+				failed++
+				fmt.Printf("%s index %d: failed at IA %s: %s\n", id, r.Index,
+					addr.IA(r.ErrorFound.Ia), r.ErrorFound.Message)
+				continue
+			}
+			fmt.Printf("%s index %d: activated\n", id, r.Index)
+		}
+		fmt.Printf("%d succeeded, %d failed.\n", len(res.Results)-failed, failed)
+		if failed > 0 {
+			return serrors.New("some activations failed", "failed", failed,
+				"total", len(res.Results))
+		}
+		return nil
+	})
+}
+
 func indexCleanupCmd(cmd *cobra.Command, flags *indexFlags, args []string) error {
 	cleanupFcn := func(ctx context.Context, client colpb.ColibriDebugCommandsServiceClient,
 		segID *colpb.ReservationID, idx uint32) error {
@@ -180,8 +328,7 @@ func indexCleanupCmd(cmd *cobra.Command, flags *indexFlags, args []string) error
 			return err
 		}
 		if res.ErrorFound != nil {
-			return serrors.New(
-				fmt.Sprintf("at IA %s: %s\n", addr.IA(res.ErrorFound.Ia), res.ErrorFound.Message))
+			return errorFound(res.ErrorFound)
 		}
 		fmt.Printf("Index with ID %d cleaned up.\n", idx)
 		return nil
@@ -189,15 +336,42 @@ func indexCleanupCmd(cmd *cobra.Command, flags *indexFlags, args []string) error
 	return requestWithIndex(cmd, flags, args, cleanupFcn)
 }
 
+func indexExpireCmd(cmd *cobra.Command, flags *indexFlags, args []string) error {
+	expireFcn := func(ctx context.Context, client colpb.ColibriDebugCommandsServiceClient,
+		segID *colpb.ReservationID, idx uint32) error {
+
+		req := &colpb.CmdIndexExpireRequest{
+			Id:    segID,
+			Index: idx,
+		}
+		res, err := client.CmdIndexExpire(ctx, req)
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		fmt.Printf("Index with ID %d expired at %s, %d expired indices removed.\n", idx,
+			util.SecsToTime(res.Expiration).Format(time.RFC3339), res.Removed)
+		return nil
+	}
+	args = append(args, strconv.Itoa(flags.Index))
+	return requestWithIndex(cmd, flags, args, expireFcn)
+}
+
 func requestWithIndex(cmd *cobra.Command, flags *indexFlags, args []string,
 	fcn func(ctx context.Context, client colpb.ColibriDebugCommandsServiceClient,
 		segID *colpb.ReservationID, idx uint32) error,
 ) error {
-	cliAddr, err := flags.DebugServer()
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
 	if err != nil {
 		return err
 	}
-	id, err := reservation.IDFromString(args[0])
+	id, err := reservation.SegmentIDFromString(args[0])
 	if err != nil {
 		return serrors.WrapStr("parsing the ID of the segment reservation", err)
 	}
@@ -213,11 +387,15 @@ func requestWithIndex(cmd *cobra.Command, flags *indexFlags, args []string,
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
 	defer cancelF()
 
-	grpcDialer := sgrpc.TCPDialer{}
-	conn, err := grpcDialer.Dial(ctx, cliAddr)
-	if err != nil {
-		return serrors.WrapStr("dialing to the local debug service", err)
-	}
-	client := colpb.NewColibriDebugCommandsServiceClient(conn)
-	return fcn(ctx, client, translate.PBufID(id), uint32(idx))
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		if err := fcn(ctx, client, translate.PBufID(id), uint32(idx)); err != nil {
+			return err
+		}
+		if flags.ShowSchedule {
+			return showSchedule(ctx, client, id)
+		}
+		return nil
+	})
 }
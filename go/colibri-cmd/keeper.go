@@ -0,0 +1,140 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/co/reservation/translate"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/util"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+type keeperFlags struct {
+	RootFlags
+	Labels map[string]string
+}
+
+func newKeeper() *cobra.Command {
+	var flags keeperFlags
+
+	cmd := &cobra.Command{
+		Use:   "keeper",
+		Short: "Inspect the keeper of the configured segment reservations",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newKeeperPlan(&flags),
+	)
+
+	return cmd
+}
+
+func newKeeperPlan(flags *keeperFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Show what the keeper will do next for each configured reservation",
+		Long: "'plan' prints, for each configured segment reservation, the matching " +
+			"reservation, its compliance with the configuration, and the next action of the " +
+			"keeper. Nothing is modified.\n" +
+			"With --label, only the reservations configured with all the labels are printed.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return keeperPlanCmd(cmd, flags)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+	cmd.Flags().StringToStringVar(&flags.Labels, "label", nil,
+		"only show the reservations with this label, as key=value (can be repeated)")
+
+	return cmd
+}
+
+func keeperPlanCmd(cmd *cobra.Command, flags *keeperFlags) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		res, err := client.CmdKeeperPlan(ctx, &colpb.CmdKeeperPlanRequest{
+			Labels: flags.Labels,
+		})
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DST\tTYPE\tPREDICATE\tBW\tRESERVATION\tCOMPLIANCE\tNEXT ACTION\tLABELS")
+		for _, e := range res.Entries {
+			id, compliance := "none", "-"
+			if e.Id != nil {
+				id = translate.ID(e.Id).String()
+				compliance = e.Compliance
+			}
+			predicate := strings.Join(strings.Fields(e.Predicate), " ")
+			if predicate == "" {
+				predicate = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d-%d\t%s\t%s\t%s\t%s\n", addr.IA(e.DstIa),
+				reservation.PathType(e.PathType), predicate, e.MinBw, e.MaxBw, id, compliance,
+				e.Action, formatLabels(e.Labels))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		wakeup := util.SecsToTime(res.Wakeup)
+		fmt.Printf("Next keeper run: %s (in %s)\n", wakeup.Format(time.RFC3339),
+			time.Until(wakeup).Round(time.Second))
+		return nil
+	})
+}
+
+// formatLabels returns the labels as a comma separated list of key=value, sorted by key.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -15,34 +15,110 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/pkg/app"
-	"github.com/spf13/cobra"
+	sgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
 )
 
+// exitCodes are the exit codes of the commands when the debug service reports an error, per
+// error code, so that scripts can tell the classes of failures apart. Other errors exit with 2.
+var exitCodes = map[colpb.ErrorCode]int{
+	colpb.ErrorCode_ERROR_CODE_NOT_FOUND:        3,
+	colpb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT: 4,
+	colpb.ErrorCode_ERROR_CODE_ADMISSION_DENIED: 5,
+	colpb.ErrorCode_ERROR_CODE_INTERNAL:         6,
+}
+
 type RootFlags struct {
 	DebugServerAddr string
+	LocalAddr       string
+}
+
+// DebugServers returns the addresses of the debug services, in the order they were specified.
+// The flag accepts a single address or a comma separated list of them.
+func (f RootFlags) DebugServers() ([]*net.TCPAddr, error) {
+	addrs := make([]*net.TCPAddr, 0)
+	for _, a := range strings.Split(f.DebugServerAddr, ",") {
+		addr, err := net.ResolveTCPAddr("tcp", strings.TrimSpace(a))
+		if err != nil {
+			return nil, serrors.WrapStr("parsing TCP address of the local debug service", err,
+				"addr", a)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
 }
 
-func (f RootFlags) DebugServer() (*net.TCPAddr, error) {
-	addr, err := net.ResolveTCPAddr("tcp", f.DebugServerAddr)
+// Dialer returns the dialer to connect to the debug services. If a local address was specified,
+// the connections originate from it. It must be an address of this host, with or without port.
+func (f RootFlags) Dialer() (*sgrpc.TCPDialer, error) {
+	if f.LocalAddr == "" {
+		return &sgrpc.TCPDialer{}, nil
+	}
+	local := &net.TCPAddr{IP: net.ParseIP(f.LocalAddr)}
+	if local.IP == nil {
+		var err error
+		if local, err = net.ResolveTCPAddr("tcp", f.LocalAddr); err != nil {
+			return nil, serrors.WrapStr("parsing the local address", err, "addr", f.LocalAddr)
+		}
+	}
+	// check that the address can be used as the source of the connections
+	l, err := net.ListenTCP("tcp", local)
 	if err != nil {
-		return nil, serrors.WrapStr("parsing TCP address of the local debug service", err)
+		return nil, serrors.WrapStr("local address not usable", err, "addr", f.LocalAddr)
 	}
-	return addr, nil
+	l.Close()
+	return &sgrpc.TCPDialer{LocalAddr: local}, nil
+}
+
+// withDebugService calls fcn with a client connected to each of the debug services in order,
+// until one of them is reachable. Only if a debug service is unreachable the next one is tried,
+// so that a request is never processed by more than one of them.
+// If no debug service is reachable, the errors from all of them are returned.
+func withDebugService(ctx context.Context, dialer *sgrpc.TCPDialer, addrs []*net.TCPAddr,
+	fcn func(context.Context, colpb.ColibriDebugCommandsServiceClient) error) error {
+
+	errs := serrors.List{}
+	for _, addr := range addrs {
+		conn, err := dialer.Dial(ctx, addr)
+		if err != nil {
+			errs = append(errs, serrors.WrapStr("dialing to the local debug service", err,
+				"addr", addr))
+			continue
+		}
+		err = fcn(ctx, colpb.NewColibriDebugCommandsServiceClient(conn))
+		conn.Close()
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		errs = append(errs, serrors.WrapStr("debug service unavailable", err, "addr", addr))
+	}
+	return errs.ToError()
 }
 
 func main() {
 	// Note: code setting up cobra command, etc based on the "scion" command.
 	executable := filepath.Base(os.Args[0])
 	cmd := &cobra.Command{
-		Use:           executable,
-		Short:         "COLIBRI CLI to debug services",
+		Use:   executable,
+		Short: "COLIBRI CLI to debug services",
+		Long: "COLIBRI CLI to debug services.\n\n" +
+			"Exit codes when the debug service reports an error: 3 not found, " +
+			"4 invalid argument, 5 admission denied, 6 internal error. " +
+			"Any other error exits with 2.",
 		Args:          cobra.NoArgs,
 		SilenceErrors: true,
 	}
@@ -50,6 +126,12 @@ func main() {
 	cmd.AddCommand(
 		newTraceroute(cmd),
 		newIndex(),
+		newReservation(),
+		newKeeper(),
+		newConfig(),
+		newE2E(),
+		newPath(),
+		newNeighbors(),
 	)
 
 	if err := cmd.Execute(); err != nil {
@@ -61,7 +143,24 @@ func main() {
 	}
 }
 
+// errorFound returns the error reported by the debug service, with the exit code of its class.
+func errorFound(errFound *colpb.ErrorInIA) error {
+	return withErrorCode(serrors.New(
+		fmt.Sprintf("at IA %s: %s\n", addr.IA(errFound.Ia), errFound.Message)), errFound.Code)
+}
+
+// withErrorCode sets on err the exit code corresponding to the error code.
+func withErrorCode(err error, code colpb.ErrorCode) error {
+	if exitCode, ok := exitCodes[code]; ok {
+		return app.WithExitCode(err, exitCode)
+	}
+	return err
+}
+
 func addRootFlags(cmd *cobra.Command, flags *RootFlags) {
 	cmd.Flags().StringVar(&flags.DebugServerAddr, "dbgsrv", "",
-		"TCP address of the local debug service")
+		"TCP address of the local debug service, or a comma separated list of addresses "+
+			"tried in order until one is reachable")
+	cmd.Flags().StringVar(&flags.LocalAddr, "local-addr", "",
+		"local IP address (optionally with port) to connect from to the debug service")
 }
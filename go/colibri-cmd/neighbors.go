@@ -0,0 +1,116 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+type neighborsFlags struct {
+	RootFlags
+}
+
+func newNeighbors() *cobra.Command {
+	var flags neighborsFlags
+
+	cmd := &cobra.Command{
+		Use:   "neighbors",
+		Short: "List the neighbors of the colibri service and their session status",
+		Long: "'neighbors' prints, for each interface of the AS, the neighboring IA, the " +
+			"address of its colibri service, whether a QUIC session to it is open, " +
+			"the last time a session to it was used, and the state of its circuit breaker " +
+			"with the consecutive failures to reach it.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return neighborsCmd(cmd, &flags)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+
+	return cmd
+}
+
+func neighborsCmd(cmd *cobra.Command, flags *neighborsFlags) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		res, err := client.CmdNeighbors(ctx, &colpb.CmdNeighborsRequest{})
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "IFID\tIA\tADDRESS\tSESSION\tLAST USE\tBREAKER")
+		for _, n := range res.Neighbors {
+			address := n.Address
+			if address == "" {
+				address = "unresolved"
+			}
+			session := "closed"
+			if n.SessionOpen {
+				session = "open"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", n.InterfaceId, addr.IA(n.Ia),
+				address, session, formatLastUse(n.LastUse),
+				formatBreaker(n.Breaker, n.ConsecutiveFailures))
+		}
+		return w.Flush()
+	})
+}
+
+// formatBreaker returns the state of the circuit breaker and the consecutive failures.
+func formatBreaker(state string, failures uint32) string {
+	if state == "" {
+		state = "unknown"
+	}
+	if failures == 0 {
+		return state
+	}
+	return fmt.Sprintf("%s (%d failures)", state, failures)
+}
+
+// formatLastUse returns the time in milliseconds since the epoch, and how long ago it was.
+func formatLastUse(millis uint64) string {
+	if millis == 0 {
+		return "never"
+	}
+	t := time.UnixMilli(int64(millis))
+	return fmt.Sprintf("%s (%s ago)", t.Format(time.RFC3339), time.Since(t).Round(time.Second))
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
+)
+
+func newPath() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "path",
+		Short: "Inspect colibri paths",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newPathDescribe(),
+	)
+
+	return cmd
+}
+
+func newPathDescribe() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe hex_path",
+		Short: "Decode a serialized colibri path and print its fields",
+		Long: "'describe' decodes the colibri path passed as hexadecimal, e.g. as captured " +
+			"from a packet, and prints its info and hop fields. It does not contact any " +
+			"service.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := decodeHexColibriPath(args[0])
+			if err != nil {
+				return err
+			}
+			describeColibriPath(os.Stdout, p)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// decodeHexColibriPath decodes a colibri path from its hexadecimal serialization.
+// An optional "0x" prefix and white space are allowed.
+func decodeHexColibriPath(s string) (*colpath.ColibriPath, error) {
+	s = strings.Join(strings.Fields(s), "")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, serrors.WrapStr("parsing the hexadecimal path", err)
+	}
+	p := &colpath.ColibriPath{}
+	if err := p.DecodeFromBytes(raw); err != nil {
+		return nil, serrors.WrapStr("decoding the colibri path", err)
+	}
+	return p, nil
+}
+
+// describeColibriPath writes the fields of the colibri path, one line per info or hop field.
+func describeColibriPath(w io.Writer, p *colpath.ColibriPath) {
+	inf := p.InfoField
+	exp := reservation.ExpTickToTime(inf.ExpTick).UTC().Format(time.RFC3339)
+	fmt.Fprintf(w, "  Timestamp:  %s\n", hex.EncodeToString(p.PacketTimestamp[:]))
+	fmt.Fprintf(w, "  Info field: suffix: %s, idx: %d, C: %v, R: %v, S: %v, "+
+		"#HFs: %d, CurrHF: %d, exp. tick: %d (%s), bw: %d, rlc: %d\n",
+		hex.EncodeToString(inf.ResIdSuffix), inf.Ver, inf.C, inf.R, inf.S,
+		inf.HFCount, inf.CurrHF, inf.ExpTick, exp, inf.BwCls, inf.Rlc)
+	for i, hf := range p.HopFields {
+		fmt.Fprintf(w, "  Hop field %d: %d -> %d, MAC: %s\n",
+			i, hf.IngressId, hf.EgressId, hex.EncodeToString(hf.Mac))
+	}
+}
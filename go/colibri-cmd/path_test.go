@@ -0,0 +1,135 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestDecodeHexColibriPath(t *testing.T) {
+	p := newTestColibriPath()
+	raw := make([]byte, p.Len())
+	require.NoError(t, p.SerializeTo(raw))
+	hexPath := hex.EncodeToString(raw)
+
+	cases := map[string]struct {
+		hex         string
+		expectError bool
+	}{
+		"plain": {
+			hex: hexPath,
+		},
+		"prefixed": {
+			hex: "0x" + hexPath,
+		},
+		"spaced": {
+			hex: hexPath[:16] + " " + hexPath[16:48] + "\n" + hexPath[48:],
+		},
+		"upper_case": {
+			hex: strings.ToUpper(hexPath),
+		},
+		"not_hex": {
+			hex:         "beefcafez",
+			expectError: true,
+		},
+		"truncated": {
+			hex:         hexPath[:len(hexPath)-8],
+			expectError: true,
+		},
+		"empty": {
+			hex:         "",
+			expectError: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := decodeHexColibriPath(tc.hex)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, p.PacketTimestamp, got.PacketTimestamp)
+			require.Equal(t, p.InfoField, got.InfoField)
+			require.Equal(t, p.HopFields, got.HopFields)
+		})
+	}
+}
+
+func TestDescribeColibriPath(t *testing.T) {
+	expected := "" +
+		"  Timestamp:  0100000000000000\n" +
+		"  Info field: suffix: beefcafe0000000000000000, idx: 1, C: true, R: false, S: true, " +
+		"#HFs: 3, CurrHF: 0, exp. tick: 1893452400 (2210-01-02T20:00:00Z), bw: 7, rlc: 7\n" +
+		"  Hop field 0: 0 -> 41, MAC: 8c5f66be\n" +
+		"  Hop field 1: 1 -> 2, MAC: 003d42a4\n" +
+		"  Hop field 2: 1 -> 0, MAC: 00000000\n"
+
+	p := newTestColibriPath()
+	raw := make([]byte, p.Len())
+	require.NoError(t, p.SerializeTo(raw))
+	decoded, err := decodeHexColibriPath(hex.EncodeToString(raw))
+	require.NoError(t, err)
+
+	buff := &bytes.Buffer{}
+	describeColibriPath(buff, decoded)
+	require.Equal(t, expected, buff.String())
+}
+
+func newTestColibriPath() *colpath.ColibriPath {
+	return &colpath.ColibriPath{
+		PacketTimestamp: colpath.Timestamp{1},
+		InfoField: &colpath.InfoField{
+			C:           true,
+			R:           false,
+			S:           true,
+			Ver:         1,
+			CurrHF:      0,
+			HFCount:     3,
+			ResIdSuffix: xtest.MustParseHexString("beefcafe0000000000000000"),
+			ExpTick:     1893452400,
+			BwCls:       7,
+			Rlc:         7,
+			OrigPayLen:  1208,
+		},
+		HopFields: []*colpath.HopField{
+			{
+				IngressId: 0,
+				EgressId:  41,
+				Mac:       []byte{140, 95, 102, 190},
+			},
+			{
+				IngressId: 1,
+				EgressId:  2,
+				Mac:       []byte{0, 61, 66, 164},
+			},
+			{
+				IngressId: 1,
+				EgressId:  0,
+				Mac:       xtest.MustParseHexString("00000000"),
+			},
+		},
+	}
+}
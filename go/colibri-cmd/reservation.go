@@ -0,0 +1,534 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	base "github.com/scionproto/scion/go/co/reservation"
+	"github.com/scionproto/scion/go/co/reservation/segment"
+	"github.com/scionproto/scion/go/co/reservation/translate"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/util"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+	"github.com/spf13/cobra"
+)
+
+type reservationFlags struct {
+	RootFlags
+	BW       uint8
+	Force    bool
+	DumpPath bool
+}
+
+func newReservation() *cobra.Command {
+	var flags reservationFlags
+
+	cmd := &cobra.Command{
+		Use:   "reservation",
+		Short: "Manipulate segment reservations",
+		Long:  "'reservation' allows the manipulation of segment reservations.",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newReservationResize(&flags),
+		newReservationDelete(&flags),
+		newReservationShow(&flags),
+		newReservationDump(&flags),
+		newReservationTimeline(&flags),
+	)
+
+	return cmd
+}
+
+func newReservationResize(flags *reservationFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resize segR_ID",
+		Short: "Lower the bandwidth of a segment reservation without tearing it down",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reservationResizeCmd(cmd, flags, args)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+	cmd.Flags().Uint8Var(&flags.BW, "bw", 0, "the new bandwidth class of the reservation")
+	cmd.MarkFlagRequired("bw")
+
+	return cmd
+}
+
+func reservationResizeCmd(cmd *cobra.Command, flags *reservationFlags, args []string) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	id, err := reservation.SegmentIDFromString(args[0])
+	if err != nil {
+		return serrors.WrapStr("parsing the ID of the segment reservation", err)
+	}
+	if err := reservation.BWCls(flags.BW).Validate(); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		req := &colpb.CmdReservationResizeRequest{
+			Id: translate.PBufID(id),
+			Bw: uint32(flags.BW),
+		}
+		res, err := client.CmdReservationResize(ctx, req)
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		fmt.Printf("Reservation resized to bw class %d, new active index %d.\n",
+			flags.BW, res.Index)
+		return nil
+	})
+}
+
+func newReservationDelete(flags *reservationFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete segR_ID",
+		Short: "Tear down a whole segment reservation and remove it",
+		Long: "'delete' tears down the segment reservation in every AS along its path, and " +
+			"removes it from the local DB. Unlike index cleanup, all indices are removed.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reservationDeleteCmd(cmd, flags, args)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+	cmd.Flags().BoolVar(&flags.Force, "force", false,
+		"remove the local reservation even if the teardown fails along the path")
+
+	return cmd
+}
+
+func reservationDeleteCmd(cmd *cobra.Command, flags *reservationFlags, args []string) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	id, err := reservation.SegmentIDFromString(args[0])
+	if err != nil {
+		return serrors.WrapStr("parsing the ID of the segment reservation", err)
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		req := &colpb.CmdReservationDeleteRequest{
+			Id:    translate.PBufID(id),
+			Force: flags.Force,
+		}
+		res, err := client.CmdReservationDelete(ctx, req)
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound == nil {
+			fmt.Printf("Reservation %s deleted.\n", id)
+			return nil
+		}
+		failure := fmt.Sprintf("at IA %s: %s", addr.IA(res.ErrorFound.Ia), res.ErrorFound.Message)
+		if res.DeletedLocally {
+			return withErrorCode(serrors.New(
+				"reservation removed locally, but teardown failed "+failure), res.ErrorFound.Code)
+		}
+		return withErrorCode(serrors.New("reservation not deleted, teardown failed "+failure),
+			res.ErrorFound.Code)
+	})
+}
+
+func newReservationShow(flags *reservationFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show segR_ID",
+		Short: "Show the details of a segment reservation",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reservationShowCmd(cmd, flags, args)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+	cmd.Flags().BoolVar(&flags.DumpPath, "dump-path", false,
+		"print the raw bytes of the transport path of the reservation")
+
+	return cmd
+}
+
+func reservationShowCmd(cmd *cobra.Command, flags *reservationFlags, args []string) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	id, err := reservation.SegmentIDFromString(args[0])
+	if err != nil {
+		return serrors.WrapStr("parsing the ID of the segment reservation", err)
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		res, err := client.CmdReservationShow(ctx, &colpb.CmdReservationShowRequest{
+			Id: translate.PBufID(id),
+		})
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		fmt.Printf("Reservation:  %s\n", id)
+		fmt.Printf("Path type:    %s\n", reservation.PathType(res.PathType))
+		fmt.Printf("Steps:        %s\n", translate.PathSteps(res.Steps))
+		fmt.Printf("Current step: %d\n", res.CurrentStep)
+		fmt.Printf("Indices:      %v\n", res.Indices)
+		if res.ActiveIndex < 0 {
+			fmt.Println("Active index: none")
+		} else {
+			fmt.Printf("Active index: %d\n", res.ActiveIndex)
+		}
+		if len(res.History) == 0 {
+			fmt.Println("Removed indices: none")
+		} else {
+			fmt.Println("Removed indices:")
+		}
+		for _, e := range res.History {
+			fmt.Printf("  %d: bw: %d, expiration: %s, removed: %s\n", e.Index, e.AllocBw,
+				util.SecsToTime(e.Expiration).Format(time.RFC3339),
+				util.SecsToTime(e.Removed).Format(time.RFC3339))
+		}
+		if flags.DumpPath {
+			return dumpTransportPath(res.TransportPath)
+		}
+		return nil
+	})
+}
+
+// dumpTransportPath prints the serialized transport path as hex, followed by its decoded
+// fields.
+func dumpTransportPath(raw []byte) error {
+	if len(raw) == 0 {
+		fmt.Println("Transport path: none")
+		return nil
+	}
+	fmt.Printf("Transport path (%d bytes): %s\n", len(raw), hex.EncodeToString(raw))
+	min := &colpath.ColibriPathMinimal{}
+	if err := min.DecodeFromBytes(raw); err != nil {
+		return serrors.WrapStr("decoding the transport path", err)
+	}
+	p, err := min.ToFull()
+	if err != nil {
+		return serrors.WrapStr("reconstructing the full transport path", err)
+	}
+	describeColibriPath(os.Stdout, p)
+	return nil
+}
+
+func newReservationDump(flags *reservationFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump segR_ID",
+		Short: "Dump the stored fields of a segment reservation",
+		Long: "'dump' prints the fields of the segment reservation and its indices exactly as " +
+			"stored in the DB, decoded when possible, to compare them with 'show'. It requires " +
+			"the service to have the debug commands enabled.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reservationDumpCmd(cmd, flags, args)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+
+	return cmd
+}
+
+func reservationDumpCmd(cmd *cobra.Command, flags *reservationFlags, args []string) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	id, err := reservation.SegmentIDFromString(args[0])
+	if err != nil {
+		return serrors.WrapStr("parsing the ID of the segment reservation", err)
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		res, err := client.CmdReservationDBDump(ctx, &colpb.CmdReservationDBDumpRequest{
+			Id: translate.PBufID(id),
+		})
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		fmt.Printf("Reservation:   %s\n", id)
+		fmt.Printf("Row ID:        %d\n", res.RowId)
+		fmt.Printf("Path type:     %s\n", reservation.PathType(res.PathType))
+		fmt.Printf("Ingress:       %d\n", res.Ingress)
+		fmt.Printf("Egress:        %d\n", res.Egress)
+		fmt.Printf("Current step:  %d\n", res.CurrentStep)
+		fmt.Printf("End props:     %#02x\n", res.EndProps)
+		fmt.Printf("Traffic split: %d\n", res.TrafficSplit)
+		fmt.Printf("Source IA:     %s\n", addr.IA(res.SrcIa))
+		fmt.Printf("Dest. IA:      %s\n", addr.IA(res.DstIa))
+		fmt.Printf("Active index:  %d\n", res.ActiveIndex)
+		fmt.Printf("Steps (%d bytes): %s\n", len(res.Steps), hex.EncodeToString(res.Steps))
+		if steps, err := decodeSteps(res.Steps); err != nil {
+			fmt.Printf("  cannot decode: %s\n", err)
+		} else {
+			fmt.Printf("  %s\n", steps)
+		}
+		if err := dumpTransportPath(res.TransportPath); err != nil {
+			fmt.Printf("  cannot decode: %s\n", err)
+		}
+		fmt.Printf("Index history (%d bytes): %s\n", len(res.IndexHistory),
+			hex.EncodeToString(res.IndexHistory))
+		if history, err := segment.IndexHistoryFromRaw(res.IndexHistory); err != nil {
+			fmt.Printf("  cannot decode: %s\n", err)
+		} else {
+			for _, e := range history.Entries() {
+				fmt.Printf("  %d: bw: %d, expiration: %s, removed: %s\n", e.Idx, e.AllocBW,
+					e.Expiration.Format(time.RFC3339), e.Removed.Format(time.RFC3339))
+			}
+		}
+		fmt.Printf("Indices: %d\n", len(res.Indices))
+		for _, index := range res.Indices {
+			fmt.Printf("  %d: state: %s, expiration: %s, bw: min %d max %d alloc %d\n",
+				index.Index, indexStateName(index.State),
+				util.SecsToTime(index.Expiration).Format(time.RFC3339),
+				index.MinBw, index.MaxBw, index.AllocBw)
+			fmt.Printf("    token (%d bytes): %s\n", len(index.Token),
+				hex.EncodeToString(index.Token))
+			if tok, err := reservation.TokenFromRaw(index.Token); err != nil {
+				fmt.Printf("    cannot decode: %s\n", err)
+			} else if tok != nil {
+				fmt.Printf("    %s\n", tok)
+			}
+		}
+		return nil
+	})
+}
+
+func newReservationTimeline(flags *reservationFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "timeline segR_ID",
+		Short: "Show the validity windows of the indices of a segment reservation",
+		Long: "'timeline' prints the indices of the segment reservation sorted by expiration, " +
+			"with the window each one covers after the previous one, to find gaps or " +
+			"overlaps in the coverage of the reservation.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reservationTimelineCmd(cmd, flags, args)
+		},
+	}
+
+	addRootFlags(cmd, &flags.RootFlags)
+
+	return cmd
+}
+
+func reservationTimelineCmd(cmd *cobra.Command, flags *reservationFlags, args []string) error {
+	cliAddrs, err := flags.DebugServers()
+	if err != nil {
+		return err
+	}
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	id, err := reservation.SegmentIDFromString(args[0])
+	if err != nil {
+		return serrors.WrapStr("parsing the ID of the segment reservation", err)
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		res, err := client.CmdReservationShow(ctx, &colpb.CmdReservationShowRequest{
+			Id: translate.PBufID(id),
+		})
+		if err != nil {
+			return err
+		}
+		if res.ErrorFound != nil {
+			return errorFound(res.ErrorFound)
+		}
+		var validUntil time.Time
+		if res.ValidUntil != 0 {
+			validUntil = util.SecsToTime(res.ValidUntil)
+		}
+		fmt.Printf("Reservation: %s\n", id)
+		printTimeline(os.Stdout, newTimeline(time.Now(), validUntil, res.IndexDetails),
+			validUntil)
+		return nil
+	})
+}
+
+// timelineRow is the window of time covered by one index of a segment reservation.
+type timelineRow struct {
+	Index uint32
+	State uint32
+	From  time.Time
+	To    time.Time
+	Notes []string
+}
+
+// newTimeline sorts the indices by expiration and assigns to each one the window it covers
+// after the previous one: from the expiration of the previous index (or now, for the first
+// one) until its own expiration. The notes of each row point out what breaks the coverage:
+// expired indices, indices expiring at the same time as the previous one (thus overlapping
+// it), temporary indices (not usable), and indices expiring after validUntil, the end of the
+// uninterrupted coverage of the reservation.
+func newTimeline(now, validUntil time.Time, indices []*colpb.IndexSummary) []timelineRow {
+	sorted := append([]*colpb.IndexSummary{}, indices...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Expiration < sorted[j].Expiration
+	})
+	rows := make([]timelineRow, len(sorted))
+	from := now
+	for i, index := range sorted {
+		to := util.SecsToTime(index.Expiration)
+		row := timelineRow{
+			Index: index.Index,
+			State: index.State,
+			From:  from,
+			To:    to,
+		}
+		if to.Before(now) {
+			row.From = to
+			row.Notes = append(row.Notes, "expired")
+		}
+		if i > 0 && index.Expiration == sorted[i-1].Expiration {
+			row.Notes = append(row.Notes, fmt.Sprintf("overlaps index %d", sorted[i-1].Index))
+		}
+		if segment.IndexState(index.State) == segment.IndexTemporary {
+			row.Notes = append(row.Notes, "not usable")
+		}
+		if to.After(now) && (validUntil.IsZero() || to.After(validUntil)) {
+			row.Notes = append(row.Notes, "not covered")
+		}
+		if to.After(from) {
+			from = to
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// printTimeline writes the rows of the timeline, followed by the end of the coverage.
+func printTimeline(w io.Writer, rows []timelineRow, validUntil time.Time) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "No indices")
+	}
+	for _, row := range rows {
+		fmt.Fprintf(w, "  %3d  %s .. %s  %-9s", row.Index, row.From.Format(time.RFC3339),
+			row.To.Format(time.RFC3339), indexStateName(row.State))
+		if len(row.Notes) > 0 {
+			fmt.Fprintf(w, "  (%s)", strings.Join(row.Notes, ", "))
+		}
+		fmt.Fprintln(w)
+	}
+	if validUntil.IsZero() {
+		fmt.Fprintln(w, "Not covered: there is no active index")
+	} else {
+		fmt.Fprintf(w, "Covered until %s\n", validUntil.Format(time.RFC3339))
+	}
+}
+
+// decodeSteps deserializes the steps as stored in the DB, checking their length first.
+func decodeSteps(raw []byte) (base.PathSteps, error) {
+	const stepLen = 12 // ingress, egress and IA
+	if len(raw) < 2 {
+		return nil, serrors.New("too short for the steps", "len", len(raw))
+	}
+	count := int(binary.BigEndian.Uint16(raw))
+	if len(raw) != 2+count*stepLen {
+		return nil, serrors.New("length does not match the step count", "len", len(raw),
+			"steps", count)
+	}
+	return base.PathStepsFromRaw(raw), nil
+}
+
+// indexStateName returns the name of a stored segment index state.
+func indexStateName(state uint32) string {
+	switch segment.IndexState(state) {
+	case segment.IndexTemporary:
+		return "temporary"
+	case segment.IndexPending:
+		return "pending"
+	case segment.IndexActive:
+		return "active"
+	default:
+		return fmt.Sprintf("unknown (%d)", state)
+	}
+}
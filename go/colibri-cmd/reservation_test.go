@@ -0,0 +1,120 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/co/reservation/segment"
+	"github.com/scionproto/scion/go/lib/util"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+func TestNewTimeline(t *testing.T) {
+	now := util.SecsToTime(100)
+	index := func(idx, exp uint32, state segment.IndexState) *colpb.IndexSummary {
+		return &colpb.IndexSummary{Index: idx, Expiration: exp, State: uint32(state)}
+	}
+	type row struct {
+		index    uint32
+		from, to uint32
+		notes    []string
+	}
+	cases := map[string]struct {
+		validUntil uint32
+		indices    []*colpb.IndexSummary
+		expected   []row
+	}{
+		"empty": {
+			indices:  nil,
+			expected: []row{},
+		},
+		"contiguous": {
+			validUntil: 300,
+			indices: []*colpb.IndexSummary{
+				index(1, 300, segment.IndexPending),
+				index(0, 200, segment.IndexActive),
+			},
+			expected: []row{
+				{index: 0, from: 100, to: 200},
+				{index: 1, from: 200, to: 300},
+			},
+		},
+		"overlapping": {
+			validUntil: 200,
+			indices: []*colpb.IndexSummary{
+				index(0, 200, segment.IndexActive),
+				index(1, 200, segment.IndexPending),
+			},
+			expected: []row{
+				{index: 0, from: 100, to: 200},
+				{index: 1, from: 200, to: 200, notes: []string{"overlaps index 0"}},
+			},
+		},
+		"temporary_gap": {
+			validUntil: 200,
+			indices: []*colpb.IndexSummary{
+				index(0, 200, segment.IndexActive),
+				index(1, 300, segment.IndexTemporary),
+			},
+			expected: []row{
+				{index: 0, from: 100, to: 200},
+				{index: 1, from: 200, to: 300, notes: []string{"not usable", "not covered"}},
+			},
+		},
+		"expired": {
+			validUntil: 0,
+			indices: []*colpb.IndexSummary{
+				index(0, 50, segment.IndexActive),
+				index(1, 200, segment.IndexPending),
+			},
+			expected: []row{
+				{index: 0, from: 50, to: 50, notes: []string{"expired"}},
+				{index: 1, from: 100, to: 200, notes: []string{"not covered"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var validUntil time.Time
+			if tc.validUntil != 0 {
+				validUntil = util.SecsToTime(tc.validUntil)
+			}
+			rows := newTimeline(now, validUntil, tc.indices)
+			require.Len(t, rows, len(tc.expected))
+			for i, exp := range tc.expected {
+				require.Equal(t, exp.index, rows[i].Index, "row %d", i)
+				require.Equal(t, util.SecsToTime(exp.from), rows[i].From, "row %d", i)
+				require.Equal(t, util.SecsToTime(exp.to), rows[i].To, "row %d", i)
+				require.Equal(t, exp.notes, rows[i].Notes, "row %d", i)
+			}
+
+			buf := &bytes.Buffer{}
+			printTimeline(buf, rows, validUntil)
+			if len(tc.indices) == 0 {
+				require.Contains(t, buf.String(), "No indices")
+			}
+			if validUntil.IsZero() {
+				require.Contains(t, buf.String(), "no active index")
+			}
+		})
+	}
+}
@@ -24,7 +24,6 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/serrors"
-	sgrpc "github.com/scionproto/scion/go/pkg/grpc"
 	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
 	"github.com/spf13/cobra"
 )
@@ -55,11 +54,15 @@ func newTraceroute(parent *cobra.Command) *cobra.Command {
 }
 
 func tracerouteCmd(cmd *cobra.Command, flags *traceRouteFlags, args []string) error {
-	cliAddr, err := flags.DebugServer()
+	cliAddrs, err := flags.DebugServers()
 	if err != nil {
 		return err
 	}
-	id, err := reservation.IDFromString(args[0])
+	dialer, err := flags.Dialer()
+	if err != nil {
+		return err
+	}
+	id, err := reservation.SegmentIDFromString(args[0])
 	if err != nil {
 		return serrors.WrapStr("parsing the ID of the segment reservation", err)
 	}
@@ -68,20 +71,17 @@ func tracerouteCmd(cmd *cobra.Command, flags *traceRouteFlags, args []string) er
 	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
 	defer cancelF()
 
-	grpcDialer := sgrpc.TCPDialer{}
-	conn, err := grpcDialer.Dial(ctx, cliAddr)
-	if err != nil {
-		return serrors.WrapStr("dialing to the local debug service", err)
-	}
-	client := colpb.NewColibriDebugCommandsServiceClient(conn)
-
 	req := &colpb.CmdTracerouteRequest{
 		Id:         translate.PBufID(id),
 		UseColibri: true,
 	}
 
-	return traceroute(func() (*colpb.CmdTracerouteResponse, error) {
-		return client.CmdTraceroute(ctx, req)
+	return withDebugService(ctx, dialer, cliAddrs, func(ctx context.Context,
+		client colpb.ColibriDebugCommandsServiceClient) error {
+
+		return traceroute(func() (*colpb.CmdTracerouteResponse, error) {
+			return client.CmdTraceroute(ctx, req)
+		})
 	})
 }
 
@@ -101,7 +101,7 @@ func traceroute(fcn func() (*colpb.CmdTracerouteResponse, error)) error {
 		if len(res.IaStamp) > 0 {
 			msg += fmt.Sprintf("IAs: %v\n", res.IaStamp)
 		}
-		return serrors.New(msg)
+		return withErrorCode(serrors.New(msg), res.ErrorFound.Code)
 	}
 
 	ias := make([]addr.IA, 0, len(res.IaStamp))
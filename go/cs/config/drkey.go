@@ -47,6 +47,8 @@ type DRKeyConfig struct {
 	Delegation SVHostList `toml:"delegation,omitempty"`
 	// PrefetchEntries is the number of lvl1 keys to be prefetched
 	PrefetchEntries int `toml:"prefetch_entries,omitempty"`
+	// RequireColibri restricts the exchange of lvl1 keys with remote CSes to COLIBRI paths.
+	RequireColibri bool `toml:"require_colibri,omitempty"`
 }
 
 // InitDefaults initializes values of unset keys and determines if the configuration enables DRKey.
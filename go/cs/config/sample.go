@@ -54,6 +54,10 @@ mode = "in-process"
 const drkeySample = `
 # Number of distinct Lvl1Keys to be prefetched.
 prefetch_entries = 10000
+
+# Whether the lvl1 keys are requested from, and served to, remote CSes only over
+# COLIBRI paths. (default false)
+require_colibri = false
 `
 const drkeySVHostListSample = `
 # The list of hosts authorized to get a SV per protocol.
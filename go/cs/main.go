@@ -28,6 +28,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
 	promgrpc "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -46,6 +47,7 @@ import (
 	"github.com/scionproto/scion/go/cs/segreq"
 	segreqgrpc "github.com/scionproto/scion/go/cs/segreq/grpc"
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/coliquic"
 	"github.com/scionproto/scion/go/lib/common"
 	libdrkey "github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/infra/infraenv"
@@ -546,19 +548,39 @@ func realMain(ctx context.Context) error {
 			},
 		}
 		tlsMgr := trust.NewTLSCryptoManager(loader, trustDB)
+		var drkeyConnDialer libgrpc.ConnDialer = quicStack.TLSDialer
+		var drkeyRouter snet.Router = segreq.NewRouter(fetcherCfg)
+		if globalCfg.DRKey.RequireColibri {
+			// QUIC sessions over COLIBRI paths, as used by the COLIBRI service itself.
+			drkeyConnDialer = coliquic.NewPersistentQUIC(quicStack.TLSDialer.Conn,
+				quicStack.TLSDialer.TLSConfig, nil)
+			// the paths of the segment reservations, obtained from the COLIBRI service.
+			drkeyRouter = drkeygrpc.NewColibriRouter(topo)
+			log.Info("DRKey lvl1 keys are exchanged only over COLIBRI paths")
+		}
 		drkeyFetcher := drkeygrpc.Fetcher{
 			Dialer: &libgrpc.TLSQUICDialer{
 				Rewriter: nc.AddressRewriter(nil),
-				Dialer:   quicStack.TLSDialer,
-				Credentials: credentials.NewTLS(&tls.Config{
+				Dialer:   drkeyConnDialer,
+				Credentials: credentials.NewTLS(drkeygrpc.ClientTLSConfig(&tls.Config{
 					InsecureSkipVerify:    true,
 					GetClientCertificate:  tlsMgr.GetClientCertificate,
 					VerifyPeerCertificate: tlsMgr.VerifyServerCertificate,
 					VerifyConnection:      tlsMgr.VerifyConnection,
-				}),
+				})),
 			},
-			Router:     segreq.NewRouter(fetcherCfg),
+			Router:     drkeyRouter,
 			MaxRetries: 20,
+			Latency: libmetrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+				Name:    "control_drkey_lvl1_fetch_duration_seconds",
+				Help:    "Time to fetch a level 1 DRKey from a remote CS, including retries.",
+				Buckets: prom.DefaultLatencyBuckets,
+			}, drkeygrpc.Lvl1LatencyLabels),
+			Retries: libmetrics.NewPromCounterFrom(prometheus.CounterOpts{
+				Name: "control_drkey_lvl1_fetch_retries_total",
+				Help: "Total number of retried level 1 DRKey fetches from remote CSes.",
+			}, drkeygrpc.Lvl1RetriesLabels),
+			RequireColibri: globalCfg.DRKey.RequireColibri,
 		}
 		drkeyEngine, err = drkey.NewServiceEngine(topo.IA(), svDB, masterKey.Key0,
 			epochDuration, drkeyDB, drkeyFetcher, globalCfg.DRKey.PrefetchEntries)
@@ -569,6 +591,7 @@ func realMain(ctx context.Context) error {
 			LocalIA:            topo.IA(),
 			Engine:             drkeyEngine,
 			AllowedSVHostProto: globalCfg.DRKey.Delegation.ToAllowedSet(),
+			RequireColibri:     globalCfg.DRKey.RequireColibri,
 		}
 		srvConfig := &tls.Config{
 			InsecureSkipVerify:    true,
@@ -0,0 +1,151 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coliquic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/lucas-clemente/quic-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DefaultALPN is the application protocol (ALPN) negotiated by the QUIC sessions between
+// COLIBRI services, if no other one is configured. It is the one negotiated before it could be
+// configured, so that upgraded instances still talk to the older ones. Instances running
+// different versions of the protocol can configure several ones, in order of preference.
+const DefaultALPN = "SCION"
+
+// ErrUnknownALPN is returned for the QUIC sessions that negotiated an application protocol other
+// than the recognized ones.
+var ErrUnknownALPN = serrors.New("unrecognized negotiated application protocol")
+
+// unknownALPNErrorCode is the application error code used to close the QUIC sessions that
+// negotiated an application protocol other than the recognized ones. It does not collide with
+// the error codes of squic.
+const unknownALPNErrorCode quic.ApplicationErrorCode = 0x200
+
+// WithNextProtos returns a copy of the TLS configuration that negotiates the application
+// protocols protos, in order of preference. If protos is empty, DefaultALPN is used.
+func WithNextProtos(tlsConfig *tls.Config, protos ...string) *tls.Config {
+	tlsConfig = tlsConfig.Clone()
+	if len(protos) == 0 {
+		protos = []string{DefaultALPN}
+	}
+	tlsConfig.NextProtos = append([]string{}, protos...)
+	return tlsConfig
+}
+
+// checkALPN returns ErrUnknownALPN if the negotiated application protocol of the QUIC session is
+// not one of protos. If protos is empty, any application protocol is recognized.
+func checkALPN(state quic.ConnectionState, protos []string) error {
+	if len(protos) == 0 {
+		return nil
+	}
+	negotiated := state.TLS.NegotiatedProtocol
+	for _, p := range protos {
+		if p == negotiated {
+			return nil
+		}
+	}
+	return serrors.WithCtx(ErrUnknownALPN, "negotiated", negotiated, "recognized", protos)
+}
+
+// rejectUnknownALPN closes the session and returns an error if its negotiated application
+// protocol is not one of protos.
+func rejectUnknownALPN(sess quic.Session, protos []string) error {
+	err := checkALPN(sess.ConnectionState(), protos)
+	if err != nil {
+		if errClose := sess.CloseWithError(unknownALPNErrorCode, "unknown ALPN"); errClose != nil {
+			log.Debug("error closing session with unknown ALPN", "err", errClose)
+		}
+	}
+	return err
+}
+
+// alpnListener is a quic.Listener that only accepts the sessions that negotiated one of the
+// recognized application protocols. The other ones are closed.
+type alpnListener struct {
+	quic.Listener
+	protos []string
+}
+
+func (l alpnListener) Accept(ctx context.Context) (quic.Session, error) {
+	for {
+		sess, err := l.Listener.Accept(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := rejectUnknownALPN(sess, l.protos); err != nil {
+			log.Debug("rejected QUIC session", "remote", sess.RemoteAddr(), "err", err)
+			continue
+		}
+		return sess, nil
+	}
+}
+
+// quicConn is implemented by the connections over a QUIC session.
+type quicConn interface {
+	ConnectionState() quic.ConnectionState
+}
+
+// alpnOption is the option returned by WithALPN.
+type alpnOption struct {
+	grpc.EmptyServerOption
+	protos []string
+}
+
+// WithALPN returns an option for NewGrpcServer that rejects the connections over QUIC sessions
+// that negotiated an application protocol other than protos. If protos is empty, DefaultALPN is
+// the only one recognized. Connections not over QUIC, e.g. over TCP, are not affected.
+func WithALPN(protos ...string) grpc.ServerOption {
+	if len(protos) == 0 {
+		protos = []string{DefaultALPN}
+	}
+	return alpnOption{protos: append([]string{}, protos...)}
+}
+
+// alpnCredentials are insecure transport credentials that reject the connections over QUIC
+// sessions that negotiated an application protocol other than the recognized ones.
+type alpnCredentials struct {
+	credentials.TransportCredentials
+	protos []string
+}
+
+func newALPNCredentials(protos []string) credentials.TransportCredentials {
+	return alpnCredentials{
+		TransportCredentials: insecure.NewCredentials(),
+		protos:               protos,
+	}
+}
+
+func (c alpnCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if qc, ok := conn.(quicConn); ok {
+		if err := checkALPN(qc.ConnectionState(), c.protos); err != nil {
+			return nil, nil, err
+		}
+	}
+	return c.TransportCredentials.ServerHandshake(conn)
+}
+
+func (c alpnCredentials) Clone() credentials.TransportCredentials {
+	return newALPNCredentials(append([]string{}, c.protos...))
+}
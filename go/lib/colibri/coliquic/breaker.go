@@ -0,0 +1,173 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coliquic
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ErrBreakerOpen is returned instead of dialing a neighbor whose circuit breaker is open.
+var ErrBreakerOpen = serrors.New("circuit breaker open for colibri neighbor")
+
+// defaultBreakerThreshold is the number of consecutive failures to reach a neighbor that
+// opens its circuit breaker.
+const defaultBreakerThreshold = 5
+
+// defaultBreakerCooldown is the time a circuit breaker stays open before allowing a probe.
+const defaultBreakerCooldown = 30 * time.Second
+
+// BreakerState is the state of the circuit breaker of a neighbor.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // the neighbor is reachable
+	BreakerOpen                         // the calls to the neighbor fail fast
+	BreakerHalfOpen                     // a single probe to the neighbor is allowed
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// breaker is the circuit breaker of a neighbor. After threshold consecutive failures it opens,
+// and the calls fail fast during the cooldown. After it, a single probe is allowed: if it
+// succeeds the breaker closes again, otherwise it opens for another cooldown.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+	failures  int // consecutive
+	state     BreakerState
+	since     time.Time // when the breaker opened, or when the probe was allowed
+	probing   bool      // the probe of a half-open breaker is in flight
+}
+
+func newBreaker(threshold int, cooldown time.Duration, now func() time.Time) *breaker {
+	if threshold <= 0 {
+		threshold = defaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	if now == nil {
+		now = time.Now
+	}
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       now,
+	}
+}
+
+// allow returns true if a call to the neighbor can be attempted.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	switch b.state {
+	case BreakerOpen:
+		if now.Before(b.since.Add(b.cooldown)) {
+			return false
+		}
+		b.state = BreakerHalfOpen
+	case BreakerHalfOpen:
+		// a probe whose outcome was never recorded does not block the neighbor forever
+		if b.probing && now.Before(b.since.Add(b.cooldown)) {
+			return false
+		}
+	default:
+		return true
+	}
+	b.probing = true
+	b.since = now
+	return true
+}
+
+// record accounts for the outcome of a call to the neighbor. A nil error is a success.
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		b.state = BreakerClosed
+		b.probing = false
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.since = b.now()
+		b.probing = false
+	}
+}
+
+// status returns the state of the breaker and the number of consecutive failures.
+func (b *breaker) status() (BreakerState, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state := b.state
+	if state == BreakerOpen && !b.now().Before(b.since.Add(b.cooldown)) {
+		state = BreakerHalfOpen // the next call will be allowed as a probe
+	}
+	return state, b.failures
+}
+
+// isNeighborFailure returns true if the error of a call means that the neighbor could not be
+// reached, as opposed to the neighbor answering with an error.
+func isNeighborFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// breakerConn records the outcome of the unary calls made on the connection it wraps in the
+// circuit breaker of the neighbor.
+type breakerConn struct {
+	grpc.ClientConnInterface
+	breaker *breaker
+}
+
+func (c *breakerConn) Invoke(ctx context.Context, method string, args, reply interface{},
+	opts ...grpc.CallOption) error {
+
+	err := c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	if isNeighborFailure(err) {
+		c.breaker.record(err)
+	} else {
+		c.breaker.record(nil)
+	}
+	return err
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -28,6 +29,7 @@ import (
 	"github.com/scionproto/scion/go/lib/infra/infraenv"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -54,21 +56,34 @@ type TopoLoader interface {
 type ServiceClientOperator struct {
 	initialized          bool
 	gRPCDialer           grpc.Dialer
+	connDialer           *PersistentQUIC          // the QUIC sessions underneath gRPCDialer
 	neighboringColSvcs   map[uint16]*snet.UDPAddr // SvcCOL addr per egress interface ID
 	neighboringColSvcsMu sync.Mutex
 	neighboringIAs       map[uint16]addr.IA
 	srvResolver          ColSrvResolver
 	colServices          map[addr.IA]*snet.UDPAddr // cached discovered addresses
 	colServicesMutex     sync.Mutex
+	payloadSizes         metrics.Histogram   // nil if the payload sizes are not observed
+	breakers             map[uint16]*breaker // per egress interface ID, created on first use
+	breakersMu           sync.Mutex
+	breakerThreshold     int              // if zero, defaultBreakerThreshold
+	breakerCooldown      time.Duration    // if zero, defaultBreakerCooldown
+	now                  func() time.Time // if nil, time.Now. Replace in tests
 }
 
+// NewServiceClientOperator creates an operator whose clients dial over pconn. If payloadSizes
+// is not nil, it observes the serialized sizes of the requests and responses of the RPCs issued
+// with those clients, as created by NewPayloadSizesHistogram. The QUIC sessions negotiate the
+// application protocols (ALPN) alpn, in order of preference, or DefaultALPN if none are given.
 func NewServiceClientOperator(topo TopoLoader, pconn net.PacketConn, router snet.Router,
-	resolver messenger.Resolver) (*ServiceClientOperator, error) {
+	resolver messenger.Resolver, payloadSizes metrics.Histogram, alpn ...string) (
+	*ServiceClientOperator, error) {
 
 	tlsConfig, err := infraenv.GenerateTLSConfig()
 	if err != nil {
 		return nil, err
 	}
+	tlsConfig = WithNextProtos(tlsConfig, alpn...)
 	connDialer := NewPersistentQUIC(pconn, tlsConfig, nil)
 	gRPCDialer := &grpc.QUICDialer{
 		Dialer: connDialer,
@@ -83,12 +98,14 @@ func NewServiceClientOperator(topo TopoLoader, pconn net.PacketConn, router snet
 
 	operator := &ServiceClientOperator{
 		gRPCDialer:         gRPCDialer, // persistent dialer
+		connDialer:         connDialer,
 		neighboringColSvcs: make(map[uint16]*snet.UDPAddr, len(topo.InterfaceIDs())),
 		srvResolver: &DiscoveryColSrvRes{
 			Router:     router,
 			GRPCDialer: gRPCDialer, // persistent dialer
 		},
-		colServices: make(map[addr.IA]*snet.UDPAddr),
+		colServices:  make(map[addr.IA]*snet.UDPAddr),
+		payloadSizes: payloadSizes,
 	}
 	operator.initialize(topo)
 
@@ -100,6 +117,58 @@ func (o *ServiceClientOperator) Neighbor(interfaceID uint16) addr.IA {
 	return o.neighboringIAs[interfaceID]
 }
 
+// NeighborInfo describes a neighboring colibri service as known by the operator.
+type NeighborInfo struct {
+	InterfaceID uint16
+	IA          addr.IA
+	Addr        *snet.UDPAddr // nil if the address is not yet resolved
+	SessionOpen bool          // true if a QUIC session to the neighbor is open
+	LastUse     time.Time     // zero if no session to the neighbor was ever used
+	Breaker     BreakerState  // state of the circuit breaker of the neighbor
+	Failures    int           // consecutive failures to reach the neighbor
+}
+
+// Neighbors returns a snapshot of the neighbors of this AS, sorted by interface ID.
+// The address book is copied under its mutex and the sessions are inspected outside of it,
+// so that the snapshot blocks neither the dials nor the resolution of the neighbors.
+func (o *ServiceClientOperator) Neighbors() []NeighborInfo {
+	var sessions []SessionInfo
+	if o.connDialer != nil {
+		sessions = o.connDialer.Sessions()
+	}
+	return o.neighborsWithSessions(sessions)
+}
+
+func (o *ServiceClientOperator) neighborsWithSessions(sessions []SessionInfo) []NeighborInfo {
+	o.neighboringColSvcsMu.Lock()
+	addrs := make(map[uint16]*snet.UDPAddr, len(o.neighboringColSvcs))
+	for id, a := range o.neighboringColSvcs {
+		addrs[id] = a.Copy()
+	}
+	o.neighboringColSvcsMu.Unlock()
+
+	infos := make([]NeighborInfo, 0, len(o.neighboringIAs))
+	for id, ia := range o.neighboringIAs {
+		if id == 0 {
+			continue // ourselves
+		}
+		info := NeighborInfo{
+			InterfaceID: id,
+			IA:          ia,
+			Addr:        addrs[id],
+		}
+		if info.Addr != nil {
+			info.SessionOpen, info.LastUse = sessionStatus(info.Addr, sessions)
+		}
+		info.Breaker, info.Failures = o.breakerFor(id).status()
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].InterfaceID < infos[j].InterfaceID
+	})
+	return infos
+}
+
 func (o *ServiceClientOperator) Initialized() bool {
 	o.neighboringColSvcsMu.Lock()
 	defer o.neighboringColSvcsMu.Unlock()
@@ -130,6 +199,8 @@ func (o *ServiceClientOperator) ColibriClientForIA(ctx context.Context, dst *add
 // ColibriClient finds or creates a ColibriClient that can reach the next neighbor in
 // the path passed as argument. The underneath connection will be COLIBRI or regular SCION,
 // depending on the type of the path passed as argument.
+// If the neighbor failed too many times in a row, it fails fast with ErrBreakerOpen until
+// the cooldown of its circuit breaker has passed.
 func (o *ServiceClientOperator) ColibriClient(
 	ctx context.Context,
 	egressID uint16,
@@ -140,7 +211,36 @@ func (o *ServiceClientOperator) ColibriClient(
 	if err != nil {
 		return nil, err
 	}
-	return o.colibriClient(ctx, rAddr)
+	b := o.breakerFor(egressID)
+	if !b.allow() {
+		return nil, serrors.WithCtx(ErrBreakerOpen, "egress_id", egressID,
+			"neighbor", o.Neighbor(egressID))
+	}
+	conn, err := o.gRPCDialer.Dial(ctx, rAddr)
+	if err != nil {
+		log.Info("error dialing a grpc connection", "addr", rAddr, "err", err)
+		b.record(err)
+		return nil, err
+	}
+	return colpb.NewColibriServiceClient(withPayloadSizes(&breakerConn{
+		ClientConnInterface: conn,
+		breaker:             b,
+	}, o.payloadSizes)), nil
+}
+
+// breakerFor returns the circuit breaker of the neighbor at the egress interface.
+func (o *ServiceClientOperator) breakerFor(egressID uint16) *breaker {
+	o.breakersMu.Lock()
+	defer o.breakersMu.Unlock()
+	if o.breakers == nil {
+		o.breakers = make(map[uint16]*breaker)
+	}
+	b, ok := o.breakers[egressID]
+	if !ok {
+		b = newBreaker(o.breakerThreshold, o.breakerCooldown, o.now)
+		o.breakers[egressID] = b
+	}
+	return b
 }
 
 func (o *ServiceClientOperator) DebugClient(
@@ -175,7 +275,7 @@ func (o *ServiceClientOperator) neighborAddrWithTransport(
 	case transport == nil:
 		log.Info("colibri client operator, first segment reservation setup", "egress", egressID)
 	case transport.Type() == colpath.PathType:
-		if libcol.Tick(transport.InfoField.ExpTick).ToTime().Before(time.Now()) {
+		if libcol.ExpTickToTime(transport.InfoField.ExpTick).Before(time.Now()) {
 			// If the active index we have is expired, don't use it
 			break
 		}
@@ -200,7 +300,7 @@ func (o *ServiceClientOperator) colibriClient(ctx context.Context, rAddr *snet.U
 		log.Info("error dialing a grpc connection", "addr", rAddr, "err", err)
 		return nil, err
 	}
-	return colpb.NewColibriServiceClient(conn), nil
+	return colpb.NewColibriServiceClient(withPayloadSizes(conn, o.payloadSizes)), nil
 }
 
 func (o *ServiceClientOperator) debugClient(ctx context.Context, rAddr *snet.UDPAddr) (
@@ -211,7 +311,7 @@ func (o *ServiceClientOperator) debugClient(ctx context.Context, rAddr *snet.UDP
 		log.Info("error dialing a grpc connection", "addr", rAddr, "err", err)
 		return nil, err
 	}
-	return colpb.NewColibriDebugServiceClient(conn), nil
+	return colpb.NewColibriDebugServiceClient(withPayloadSizes(conn, o.payloadSizes)), nil
 }
 
 func (o *ServiceClientOperator) neighborAddr(egressID uint16) (*snet.UDPAddr, bool) {
@@ -325,6 +425,27 @@ func (o *ServiceClientOperator) periodicDiscoverServices() {
 	}
 }
 
+// sessionStatus returns whether any session to the host of the address is open, and the
+// last time any of them was used. The paths of the sessions are ignored, as the operator
+// dials the same neighbor using different paths, e.g. SCION or COLIBRI.
+func sessionStatus(a *snet.UDPAddr, sessions []SessionInfo) (bool, time.Time) {
+	var open bool
+	var lastUse time.Time
+	for _, s := range sessions {
+		remote, ok := s.Remote.(*snet.UDPAddr)
+		if !ok || remote.Host == nil || a.Host == nil || !remote.IA.Equal(a.IA) ||
+			!remote.Host.IP.Equal(a.Host.IP) || remote.Host.Port != a.Host.Port {
+
+			continue
+		}
+		open = open || s.Open
+		if s.LastUse.After(lastUse) {
+			lastUse = s.LastUse
+		}
+	}
+	return open, lastUse
+}
+
 // neighbors returns the neighboring IAs by egress interface ID.
 func neighbors(topo TopoLoader) map[uint16]addr.IA {
 	neighbors := make(map[uint16]addr.IA)
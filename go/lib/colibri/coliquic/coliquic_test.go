@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -35,14 +36,22 @@ import (
 	"github.com/lucas-clemente/quic-go"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/scionproto/scion/go/co/reservation/test"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/daemon"
+	"github.com/scionproto/scion/go/lib/metrics"
+	slpath "github.com/scionproto/scion/go/lib/slayers/path"
 	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	caddr "github.com/scionproto/scion/go/lib/slayers/path/colibri/addr"
+	"github.com/scionproto/scion/go/lib/slayers/path/empty"
+	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/path"
 	"github.com/scionproto/scion/go/lib/snet/squic"
@@ -285,17 +294,20 @@ func TestColibriGRPC(t *testing.T) {
 		func(ctx context.Context, _ *colpb.SegmentSetupRequest) (
 			*colpb.SegmentSetupResponse, error) {
 
-			p, ok := peer.FromContext(ctx)
+			p, addr, err := PeerPath(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, addr)
+			require.IsType(t, path.Colibri{}, addr.Path)
+			require.IsType(t, &colibri.ColibriPathMinimal{}, p)
+			localIA, ok := LocalIAFromContext(ctx)
 			require.True(t, ok)
-			require.NotNil(t, p)
-			require.IsType(t, &snet.UDPAddr{}, p.Addr)
-			require.IsType(t, path.Colibri{}, p.Addr.(*snet.UDPAddr).Path)
+			require.Equal(t, xtest.MustParseIA("1-ff00:0:111"), localIA)
 			ok, usage, err := UsageFromContext(ctx)
 			require.NoError(t, err)
 			require.True(t, ok)
 			require.Greater(t, usage, uint64(0))
 			return &colpb.SegmentSetupResponse{SuccessFailure: &colpb.SegmentSetupResponse_Token{
-				Token: p.Addr.(*snet.UDPAddr).Path.(path.Colibri).Raw,
+				Token: addr.Path.(path.Colibri).Raw,
 			}}, nil
 		})
 
@@ -307,7 +319,8 @@ func TestColibriGRPC(t *testing.T) {
 		return res, err
 	}
 
-	gRPCServer := NewGrpcServer(grpc.UnaryInterceptor(testInterceptor),
+	gRPCServer := NewGrpcServer(xtest.MustParseIA("1-ff00:0:111"),
+		grpc.UnaryInterceptor(testInterceptor),
 		sgrpc.UnaryServerInterceptor())
 	colpb.RegisterColibriServiceServer(gRPCServer, handler)
 
@@ -345,7 +358,12 @@ func TestColibriGRPC(t *testing.T) {
 	gRPCClient := colpb.NewColibriServiceClient(conn)
 	res, err := gRPCClient.SegmentSetup(ctx, &colpb.SegmentSetupRequest{})
 	require.NoError(t, err)
-	require.Equal(t, clientAddr.(*snet.UDPAddr).Path.(path.Colibri).Raw, res.GetToken())
+	clientPath := clientAddr.(*snet.UDPAddr).Path.(path.Colibri)
+	expectedToken, err := clientPath.ToColibriPath()
+	require.NoError(t, err)
+	token := &colibri.ColibriPath{}
+	require.NoError(t, token.DecodeFromBytes(res.GetToken()))
+	require.True(t, expectedToken.Equal(token))
 	require.True(t, testInterceptorCalled)
 
 	gRPCServer.GracefulStop()
@@ -356,6 +374,494 @@ func TestColibriGRPC(t *testing.T) {
 	}
 }
 
+func TestMaxRequestBytes(t *testing.T) {
+	steps := func(n int) []*colpb.PathStep {
+		steps := make([]*colpb.PathStep, n)
+		for i := range steps {
+			steps[i] = &colpb.PathStep{}
+		}
+		return steps
+	}
+	cases := map[string]struct {
+		req          *colpb.SegmentSetupRequest
+		expectedCode codes.Code
+	}{
+		"small": {
+			req: &colpb.SegmentSetupRequest{
+				Params: &colpb.SegmentSetupRequest_Params{Steps: steps(3)},
+			},
+			expectedCode: codes.OK,
+		},
+		"max_steps": {
+			req: &colpb.SegmentSetupRequest{
+				Params: &colpb.SegmentSetupRequest_Params{Steps: steps(colibri.MaxHopFields)},
+			},
+			expectedCode: codes.OK,
+		},
+		"too_many_steps": {
+			req: &colpb.SegmentSetupRequest{
+				Params: &colpb.SegmentSetupRequest_Params{
+					Steps: steps(colibri.MaxHopFields + 1),
+				},
+			},
+			expectedCode: codes.ResourceExhausted,
+		},
+		"too_many_beads": {
+			req: &colpb.SegmentSetupRequest{
+				Params: &colpb.SegmentSetupRequest_Params{
+					Allocationtrail: func() []*colpb.AllocationBead {
+						beads := make([]*colpb.AllocationBead, colibri.MaxHopFields+1)
+						for i := range beads {
+							beads[i] = &colpb.AllocationBead{}
+						}
+						return beads
+					}(),
+				},
+			},
+			expectedCode: codes.ResourceExhausted,
+		},
+		"too_many_bytes": {
+			req: &colpb.SegmentSetupRequest{
+				Base: &colpb.Request{
+					Authenticators: &colpb.Authenticators{
+						Macs: [][]byte{make([]byte, 8192)},
+					},
+				},
+			},
+			expectedCode: codes.ResourceExhausted,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancelF := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancelF()
+			mctrl := gomock.NewController(t)
+			defer mctrl.Finish()
+
+			handler := mock_col.NewMockColibriServiceServer(mctrl)
+			handler.EXPECT().SegmentSetup(gomock.Any(), gomock.Any()).AnyTimes().
+				Return(&colpb.SegmentSetupResponse{}, nil)
+			server := NewGrpcServer(xtest.MustParseIA("1-ff00:0:111"), WithMaxRequestBytes(4096))
+			colpb.RegisterColibriServiceServer(server, handler)
+			listener := bufconn.Listen(1024 * 1024)
+			go func() { server.Serve(listener) }()
+			defer server.Stop()
+
+			conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(),
+				grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+					return listener.Dial()
+				}))
+			require.NoError(t, err)
+			defer conn.Close()
+			_, err = colpb.NewColibriServiceClient(conn).SegmentSetup(ctx, tc.req)
+			require.Equal(t, tc.expectedCode, status.Code(err), "err: %v", err)
+		})
+	}
+}
+
+func TestPeerPath(t *testing.T) {
+	noPathAddr := mockScionAddress(t, "1-ff00:0:111", "127.0.0.1:12345")
+	noPathAddr.(*snet.UDPAddr).Path = nil
+	cases := map[string]struct {
+		peer         net.Addr // no peer in context if nil
+		expectedType slpath.Type
+		expectNoPath bool
+		expectError  bool
+	}{
+		"colibri": {
+			peer:         mockColibriAddress(t, "1-ff00:0:111", "127.0.0.1:12345"),
+			expectedType: colibri.PathType,
+		},
+		"scion": {
+			peer:         mockScionAddress(t, "1-ff00:0:111", "127.0.0.1:12345"),
+			expectedType: scion.PathType,
+		},
+		"empty_path": {
+			peer: &snet.UDPAddr{
+				IA:   xtest.MustParseIA("1-ff00:0:111"),
+				Host: xtest.MustParseUDPAddr(t, "127.0.0.1:12345"),
+				Path: path.Empty{},
+			},
+			expectedType: empty.PathType,
+		},
+		"raw_empty_path": {
+			peer: &snet.UDPAddr{
+				IA:   xtest.MustParseIA("1-ff00:0:111"),
+				Host: xtest.MustParseUDPAddr(t, "127.0.0.1:12345"),
+				Path: snet.RawPath{PathType: empty.PathType},
+			},
+			expectedType: empty.PathType,
+		},
+		"raw_empty_path_with_bytes": {
+			peer: &snet.UDPAddr{
+				IA:   xtest.MustParseIA("1-ff00:0:111"),
+				Host: xtest.MustParseUDPAddr(t, "127.0.0.1:12345"),
+				Path: snet.RawPath{PathType: empty.PathType, Raw: []byte{0}},
+			},
+			expectError: true,
+		},
+		"no_path": {
+			peer:         noPathAddr,
+			expectNoPath: true,
+		},
+		"not_scion": {
+			peer:        xtest.MustParseUDPAddr(t, "127.0.0.1:12345"),
+			expectError: true,
+		},
+		"no_peer": {
+			expectError: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.peer != nil {
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: tc.peer})
+			}
+			p, addr, err := PeerPath(ctx)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.peer, addr)
+			if tc.expectNoPath {
+				require.Nil(t, p)
+				return
+			}
+			require.NotNil(t, p)
+			require.Equal(t, tc.expectedType, p.Type())
+		})
+	}
+}
+
+// TestGetColibriPathEmpty decodes a SCION packet with an empty path, as received by snet,
+// and checks that no COLIBRI path is found for its source address.
+func TestGetColibriPathEmpty(t *testing.T) {
+	pkt := snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Destination: snet.SCIONAddress{
+				IA:   xtest.MustParseIA("1-ff00:0:111"),
+				Host: addr.HostFromIPStr("127.0.0.2"),
+			},
+			Source: snet.SCIONAddress{
+				IA:   xtest.MustParseIA("1-ff00:0:111"),
+				Host: addr.HostFromIPStr("127.0.0.1"),
+			},
+			Path: path.Empty{},
+			Payload: snet.UDPPayload{
+				SrcPort: 12345,
+				DstPort: 54321,
+				Payload: []byte("hello"),
+			},
+		},
+	}
+	require.NoError(t, pkt.Serialize())
+	received := snet.Packet{Bytes: pkt.Bytes}
+	require.NoError(t, received.Decode())
+	raw, ok := received.Path.(*snet.RawPath)
+	require.True(t, ok)
+	require.Equal(t, empty.PathType, raw.PathType)
+	require.Empty(t, raw.Raw)
+	replyPath, err := snet.DefaultReplyPather{}.ReplyPath(raw)
+	require.NoError(t, err)
+
+	cases := map[string]snet.DataplanePath{
+		"raw":   raw,
+		"reply": replyPath,
+	}
+	for name, dataplanePath := range cases {
+		name, dataplanePath := name, dataplanePath
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			session := &remoteAddrSession{
+				remote: &snet.UDPAddr{
+					IA:   received.Source.IA,
+					Host: xtest.MustParseUDPAddr(t, "127.0.0.1:12345"),
+					Path: dataplanePath,
+				},
+			}
+			colPath, err := GetColibriPath(session)
+			require.NoError(t, err)
+			require.Nil(t, colPath)
+
+			p, _, err := addrPath(session.RemoteAddr())
+			require.NoError(t, err)
+			require.Equal(t, empty.PathType, p.Type())
+			require.Equal(t, 0, p.Len())
+		})
+	}
+}
+
+func TestColibriPathMatches(t *testing.T) {
+	sent := newTestColibriPath()
+	reversed := newTestColibriPath()
+	_, err := reversed.Reverse()
+	require.NoError(t, err)
+	other := newTestColibriPath()
+	other.HopFields[1].EgressId++
+
+	cases := map[string]struct {
+		received *colibri.ColibriPath // nil for a non colibri path
+		expected bool
+	}{
+		"same": {
+			received: newTestColibriPath(),
+			expected: true,
+		},
+		"reversed": {
+			received: reversed,
+			expected: true,
+		},
+		"other": {
+			received: other,
+		},
+		"no_colibri_path": {},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			remote := &snet.UDPAddr{
+				IA:   xtest.MustParseIA("1-ff00:0:111"),
+				Host: xtest.MustParseUDPAddr(t, "127.0.0.1:12345"),
+			}
+			if tc.received != nil {
+				raw := make([]byte, tc.received.Len())
+				require.NoError(t, tc.received.SerializeTo(raw))
+				remote.Path = snet.RawPath{
+					PathType: colibri.PathType,
+					Raw:      raw,
+				}
+			}
+			session := &remoteAddrSession{remote: remote}
+			matches, err := ColibriPathMatches(session, sent)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, matches)
+		})
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	now := time.Unix(1000, 0)
+	addr110 := mockScionAddress(t, "1-ff00:0:110", "127.0.0.1:20001").(*snet.UDPAddr)
+	addr112 := mockScionAddress(t, "1-ff00:0:112", "127.0.0.1:20002").(*snet.UDPAddr)
+	cases := map[string]struct {
+		sessions        []SessionInfo
+		expectedOpen    bool
+		expectedLastUse time.Time
+	}{
+		"no_sessions": {},
+		"other_neighbor": {
+			sessions: []SessionInfo{
+				{Remote: addr112, Open: true, LastUse: now},
+			},
+		},
+		"open": {
+			sessions: []SessionInfo{
+				{Remote: addr110, Open: true, LastUse: now},
+				{Remote: addr112, Open: true, LastUse: now.Add(time.Second)},
+			},
+			expectedOpen:    true,
+			expectedLastUse: now,
+		},
+		"closed": {
+			sessions: []SessionInfo{
+				{Remote: addr110, Open: false, LastUse: now},
+			},
+			expectedLastUse: now,
+		},
+		"other_path": {
+			sessions: []SessionInfo{
+				{Remote: addr110, Open: false, LastUse: now},
+				{Remote: mockColibriAddress(t, "1-ff00:0:110", "127.0.0.1:20001"), Open: true,
+					LastUse: now.Add(time.Second)},
+			},
+			expectedOpen:    true,
+			expectedLastUse: now.Add(time.Second),
+		},
+		"other_port": {
+			sessions: []SessionInfo{
+				{Remote: mockScionAddress(t, "1-ff00:0:110", "127.0.0.1:20003"), Open: true,
+					LastUse: now},
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			o := &ServiceClientOperator{
+				neighboringColSvcs: map[uint16]*snet.UDPAddr{1: addr110},
+				neighboringIAs: map[uint16]addr.IA{
+					0: xtest.MustParseIA("1-ff00:0:111"),
+					1: addr110.IA,
+					2: addr112.IA,
+				},
+			}
+			neighbors := o.neighborsWithSessions(tc.sessions)
+			require.Len(t, neighbors, 2) // not ourselves
+			require.Equal(t, uint16(1), neighbors[0].InterfaceID)
+			require.Equal(t, addr110.IA, neighbors[0].IA)
+			require.Equal(t, addr110.Host.String(), neighbors[0].Addr.Host.String())
+			require.Equal(t, tc.expectedOpen, neighbors[0].SessionOpen)
+			require.Equal(t, tc.expectedLastUse, neighbors[0].LastUse)
+			// not yet resolved
+			require.Equal(t, uint16(2), neighbors[1].InterfaceID)
+			require.Nil(t, neighbors[1].Addr)
+			require.False(t, neighbors[1].SessionOpen)
+			require.True(t, neighbors[1].LastUse.IsZero())
+		})
+	}
+}
+
+func TestColibriClientBreaker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	const threshold = 3
+	const cooldown = time.Minute
+
+	// a gRPC server over an in-memory listener, reached only when the dialer does not fail
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	handler := mock_col.NewMockColibriServiceServer(mctrl)
+	handler.EXPECT().SegmentSetup(gomock.Any(), gomock.Any()).Times(2).Return(
+		&colpb.SegmentSetupResponse{}, nil)
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	colpb.RegisterColibriServiceServer(server, handler)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+	dialer := &fakeDialer{lis: lis, err: status.Error(codes.Unavailable, "neighbor down")}
+
+	addr110 := mockScionAddress(t, "1-ff00:0:110", "127.0.0.1:20001").(*snet.UDPAddr)
+	o := &ServiceClientOperator{
+		gRPCDialer:         dialer,
+		neighboringColSvcs: map[uint16]*snet.UDPAddr{1: addr110},
+		neighboringIAs: map[uint16]addr.IA{
+			0: xtest.MustParseIA("1-ff00:0:111"),
+			1: addr110.IA,
+		},
+		breakerThreshold: threshold,
+		breakerCooldown:  cooldown,
+		now:              func() time.Time { return now },
+	}
+	requireBreaker := func(state BreakerState, failures int) {
+		t.Helper()
+		neighbors := o.neighborsWithSessions(nil)
+		require.Len(t, neighbors, 1)
+		require.Equal(t, state, neighbors[0].Breaker)
+		require.Equal(t, failures, neighbors[0].Failures)
+	}
+
+	// the failures below the threshold keep dialing
+	for i := 0; i < threshold; i++ {
+		requireBreaker(BreakerClosed, i)
+		_, err := o.ColibriClient(ctx, 1, nil)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrBreakerOpen)
+	}
+	require.Equal(t, threshold, dialer.calls)
+	requireBreaker(BreakerOpen, threshold)
+
+	// fast fail during the cooldown, without dialing
+	now = now.Add(cooldown - time.Second)
+	_, err := o.ColibriClient(ctx, 1, nil)
+	require.ErrorIs(t, err, ErrBreakerOpen)
+	require.Equal(t, threshold, dialer.calls)
+
+	// after the cooldown, a single probe is allowed; the neighbor is still down
+	now = now.Add(time.Second)
+	requireBreaker(BreakerHalfOpen, threshold)
+	_, err = o.ColibriClient(ctx, 1, nil)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrBreakerOpen)
+	require.Equal(t, threshold+1, dialer.calls)
+	requireBreaker(BreakerOpen, threshold+1)
+	_, err = o.ColibriClient(ctx, 1, nil)
+	require.ErrorIs(t, err, ErrBreakerOpen)
+
+	// the neighbor recovers: only the probe goes through until it succeeds
+	now = now.Add(cooldown)
+	dialer.err = nil
+	client, err := o.ColibriClient(ctx, 1, nil)
+	require.NoError(t, err)
+	_, err = o.ColibriClient(ctx, 1, nil)
+	require.ErrorIs(t, err, ErrBreakerOpen, "the probe is in flight")
+	_, err = client.SegmentSetup(ctx, &colpb.SegmentSetupRequest{})
+	require.NoError(t, err)
+	requireBreaker(BreakerClosed, 0)
+
+	client, err = o.ColibriClient(ctx, 1, nil)
+	require.NoError(t, err)
+	_, err = client.SegmentSetup(ctx, &colpb.SegmentSetupRequest{})
+	require.NoError(t, err)
+	requireBreaker(BreakerClosed, 0)
+}
+
+func TestConnListenerUnblockAccept(t *testing.T) {
+	cases := map[string]struct {
+		serverAddr  string
+		deadline    time.Duration // zero means no deadline
+		closeAfter  time.Duration // zero means do not close
+		expectedErr error
+	}{
+		"close": {
+			serverAddr:  "127.0.0.1:23212",
+			closeAfter:  100 * time.Millisecond,
+			expectedErr: net.ErrClosed,
+		},
+		"deadline": {
+			serverAddr:  "127.0.0.1:23213",
+			deadline:    100 * time.Millisecond,
+			expectedErr: os.ErrDeadlineExceeded,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			thisNet := newMockNetwork(t)
+			serverAddr := mockScionAddress(t, "1-ff00:0:111", tc.serverAddr)
+			serverTlsConfig := &tls.Config{
+				Certificates: []tls.Certificate{*createTestCertificate(t)},
+				NextProtos:   []string{"coliquictest"},
+			}
+			quicLis, err := quic.Listen(newConnMock(t, serverAddr, thisNet),
+				serverTlsConfig, &quic.Config{})
+			require.NoError(t, err)
+			listener := NewConnListener(quicLis)
+			defer listener.Close()
+			if tc.deadline != 0 {
+				err = listener.SetDeadline(time.Now().Add(tc.deadline))
+				require.NoError(t, err)
+			}
+
+			errs := make(chan error)
+			go func() {
+				_, err := listener.Accept()
+				errs <- err
+			}()
+			if tc.closeAfter != 0 {
+				time.Sleep(tc.closeAfter)
+				require.NoError(t, listener.Close())
+			}
+			select {
+			case err := <-errs:
+				require.ErrorIs(t, err, tc.expectedErr)
+			case <-time.After(time.Second):
+				require.FailNow(t, "accept did not return in time")
+			}
+		})
+	}
+}
+
 type MockTopoLoader struct{}
 
 func (MockTopoLoader) InterfaceIDs() []uint16 {
@@ -566,6 +1072,77 @@ func (c *connMock) SetWriteDeadline(t time.Time) error {
 	return nil
 }
 
+func TestPayloadSizeConn(t *testing.T) {
+	req := &colpb.SegmentSetupRequest{
+		Base: &colpb.Request{
+			Id: &colpb.ReservationID{
+				Asid:   0xff0000000111,
+				Suffix: xtest.MustParseHexString("0123456789abcdef01234567"),
+			},
+			Index:     1,
+			Timestamp: 42,
+		},
+		Params: &colpb.SegmentSetupRequest_Params{
+			ExpirationTime: 300,
+			Rlc:            3,
+			PathType:       1,
+			Minbw:          1,
+			Maxbw:          13,
+			Splitcls:       2,
+		},
+	}
+	res := &colpb.SegmentSetupResponse{
+		Timestamp: 42,
+		SuccessFailure: &colpb.SegmentSetupResponse_Token{
+			Token: xtest.MustParseHexString("0123456789"),
+		},
+	}
+	const method = "/proto.colibri.v1.ColibriService/SegmentSetup"
+
+	cases := map[string]struct {
+		err               error
+		expectedResponses bool
+	}{
+		"success": {
+			expectedResponses: true,
+		},
+		"failure": {
+			err: status.Error(codes.Unavailable, "boom"),
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			sizes := &fakeSizesHistogram{observed: map[string][]float64{}}
+			conn := withPayloadSizes(&fakeClientConn{reply: res, err: tc.err}, sizes)
+			client := colpb.NewColibriServiceClient(conn)
+			_, err := client.SegmentSetup(context.Background(), req)
+			if tc.err != nil {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			expectedReq, err := proto.Marshal(req)
+			require.NoError(t, err)
+			require.Equal(t, []float64{float64(len(expectedReq))},
+				sizes.observed[method+" request"])
+			if tc.expectedResponses {
+				expectedRes, err := proto.Marshal(res)
+				require.NoError(t, err)
+				require.Equal(t, []float64{float64(len(expectedRes))},
+					sizes.observed[method+" response"])
+			} else {
+				require.NotContains(t, sizes.observed, method+" response")
+			}
+		})
+	}
+	// a nil histogram leaves the connection untouched
+	conn := &fakeClientConn{}
+	require.Same(t, conn, withPayloadSizes(conn, nil))
+}
+
 // createTestCertificate is based on https://github.com/lucas-clemente/quic-go/blob/
 // e098ccd2b3bf560d3d8056dccc1a35b229a2a47a/example/echo/echo.go#L92
 func createTestCertificate(t *testing.T) *tls.Certificate {
@@ -584,3 +1161,65 @@ func createTestCertificate(t *testing.T) *tls.Certificate {
 	require.NoError(t, err)
 	return &tlsCert
 }
+
+// remoteAddrSession is a quic.Session that only knows its remote address.
+type remoteAddrSession struct {
+	quic.Session
+	remote net.Addr
+}
+
+func (s *remoteAddrSession) RemoteAddr() net.Addr {
+	return s.remote
+}
+
+// fakeClientConn replies to all unary calls with a copy of reply, or fails with err.
+type fakeClientConn struct {
+	grpc.ClientConnInterface
+	reply proto.Message
+	err   error
+}
+
+func (c *fakeClientConn) Invoke(_ context.Context, _ string, _, reply interface{},
+	_ ...grpc.CallOption) error {
+
+	if c.err != nil {
+		return c.err
+	}
+	proto.Merge(reply.(proto.Message), c.reply)
+	return nil
+}
+
+// fakeDialer fails with err if set, or dials to the in-memory listener otherwise.
+type fakeDialer struct {
+	lis   *bufconn.Listener
+	err   error
+	calls int
+}
+
+func (d *fakeDialer) Dial(ctx context.Context, _ net.Addr) (*grpc.ClientConn, error) {
+	d.calls++
+	if d.err != nil {
+		return nil, d.err
+	}
+	return grpc.DialContext(ctx, "bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return d.lis.Dial()
+		}))
+}
+
+// fakeSizesHistogram keeps the observed values per "method" and "kind" labels.
+type fakeSizesHistogram struct {
+	labels   string
+	observed map[string][]float64
+}
+
+func (h *fakeSizesHistogram) With(labelValues ...string) metrics.Histogram {
+	return &fakeSizesHistogram{
+		labels:   labelValues[1] + " " + labelValues[3],
+		observed: h.observed,
+	}
+}
+
+func (h *fakeSizesHistogram) Observe(value float64) {
+	h.observed[h.labels] = append(h.observed[h.labels], value)
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coliquic
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/scionproto/scion/go/lib/metrics"
+)
+
+// NewPayloadSizesHistogram creates and registers the histogram observing the serialized sizes
+// of the requests and responses of the RPCs issued by a ServiceClientOperator.
+// The "method" label is the full gRPC method name, and the "kind" label is either request
+// or response.
+func NewPayloadSizesHistogram() metrics.Histogram {
+	return metrics.NewPromHistogramFrom(prometheus.HistogramOpts{
+		Name:    "colibri_client_rpc_payload_bytes",
+		Help:    "Serialized sizes of the requests and responses of the COLIBRI RPCs issued.",
+		Buckets: prometheus.ExponentialBuckets(64, 2, 12),
+	}, []string{"method", "kind"})
+}
+
+// payloadSizeConn observes the serialized sizes of the requests and responses of the unary
+// calls made on the connection it wraps. Only the responses of successful calls are observed.
+type payloadSizeConn struct {
+	grpc.ClientConnInterface
+	sizes metrics.Histogram
+}
+
+// withPayloadSizes returns the connection instrumented to observe the payload sizes, or the
+// connection itself if sizes is nil.
+func withPayloadSizes(conn grpc.ClientConnInterface,
+	sizes metrics.Histogram) grpc.ClientConnInterface {
+
+	if sizes == nil {
+		return conn
+	}
+	return &payloadSizeConn{
+		ClientConnInterface: conn,
+		sizes:               sizes,
+	}
+}
+
+func (c *payloadSizeConn) Invoke(ctx context.Context, method string, args, reply interface{},
+	opts ...grpc.CallOption) error {
+
+	c.observe(method, "request", args)
+	err := c.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+	if err == nil {
+		c.observe(method, "response", reply)
+	}
+	return err
+}
+
+func (c *payloadSizeConn) observe(method, kind string, msg interface{}) {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return
+	}
+	metrics.HistogramObserve(metrics.HistogramWith(c.sizes, "method", method, "kind", kind),
+		float64(proto.Size(m)))
+}
@@ -57,8 +57,27 @@ type PersistentQUIC struct {
 	sessionsMu sync.Mutex
 	sessions   map[string]quic.Session // active session per dst address
 	opened     []quic.Session          // all sessions ever opened
+	// usage is kept under its own mutex, so that it can be read while a dial is ongoing.
+	usageMu sync.Mutex
+	usage   map[string]sessionUsage // last use of the active session per dst address
 }
 
+// SessionInfo describes a session to a destination address, as kept by PersistentQUIC.
+type SessionInfo struct {
+	Remote  net.Addr
+	Open    bool      // false if the session was closed, e.g. by the other end
+	LastUse time.Time // the last time a stream was opened on the session
+}
+
+type sessionUsage struct {
+	remote  net.Addr
+	session quic.Session
+	lastUse time.Time
+}
+
+// NewPersistentQUIC returns a PersistentQUIC dialing over pconn. The sessions negotiate the
+// application protocols (ALPN) in tlsConfig.NextProtos, and those that negotiate another one
+// are closed. See WithNextProtos.
 func NewPersistentQUIC(pconn net.PacketConn, tlsConfig *tls.Config,
 	quicConfig *quic.Config) *PersistentQUIC {
 
@@ -69,9 +88,26 @@ func NewPersistentQUIC(pconn net.PacketConn, tlsConfig *tls.Config,
 		sessionsMu: sync.Mutex{},
 		sessions:   make(map[string]quic.Session),
 		opened:     make([]quic.Session, 0),
+		usage:      make(map[string]sessionUsage),
 	}
 }
 
+// Sessions returns the information about the active sessions. It doesn't block while
+// another call to Dial is in progress.
+func (pq *PersistentQUIC) Sessions() []SessionInfo {
+	pq.usageMu.Lock()
+	defer pq.usageMu.Unlock()
+	infos := make([]SessionInfo, 0, len(pq.usage))
+	for _, u := range pq.usage {
+		infos = append(infos, SessionInfo{
+			Remote:  u.remote,
+			Open:    u.session.Context().Err() == nil,
+			LastUse: u.lastUse,
+		})
+	}
+	return infos
+}
+
 // Dial reuses an existing quic session for the path in the destination address, or creates a
 // new one. With the session, it opens a new stream that behaves like a net.Conn.
 func (pq *PersistentQUIC) Dial(ctx context.Context, dst net.Addr) (net.Conn, error) {
@@ -90,6 +126,7 @@ func (pq *PersistentQUIC) Dial(ctx context.Context, dst net.Addr) (net.Conn, err
 		}
 		stream, err := sess.OpenStream()
 		if err == nil {
+			pq.markUsed(repr, dst, sess)
 			return streamAsConn{
 				stream:  stream,
 				session: sess,
@@ -112,6 +149,7 @@ func (pq *PersistentQUIC) Dial(ctx context.Context, dst net.Addr) (net.Conn, err
 			return nil, err
 		}
 		delete(pq.sessions, repr)
+		pq.markGone(repr)
 	}
 	return nil, serrors.New("could not reuse or create a session", "err", sessionError)
 }
@@ -139,12 +177,34 @@ func (pq *PersistentQUIC) obtainSession(ctx context.Context, addr net.Addr, repr
 		if err != nil {
 			return nil, err
 		}
+		if err := rejectUnknownALPN(sess, pq.tlsConfig.NextProtos); err != nil {
+			return nil, err
+		}
 		pq.sessions[repr] = sess
 		pq.opened = append(pq.opened, sess)
 	}
 	return sess, nil
 }
 
+func (pq *PersistentQUIC) markUsed(repr string, dst net.Addr, sess quic.Session) {
+	if udpAddr, ok := dst.(*snet.UDPAddr); ok {
+		dst = udpAddr.Copy()
+	}
+	pq.usageMu.Lock()
+	defer pq.usageMu.Unlock()
+	pq.usage[repr] = sessionUsage{
+		remote:  dst,
+		session: sess,
+		lastUse: time.Now(),
+	}
+}
+
+func (pq *PersistentQUIC) markGone(repr string) {
+	pq.usageMu.Lock()
+	defer pq.usageMu.Unlock()
+	delete(pq.usage, repr)
+}
+
 // streamAsConn is a net.Conn backed by a quic stream.
 type streamAsConn struct {
 	stream  quic.Stream
@@ -176,6 +236,11 @@ func (c streamAsConn) SetWriteDeadline(t time.Time) error {
 	return c.stream.SetWriteDeadline(t)
 }
 
+// ConnectionState returns the state of the QUIC session of the stream.
+func (c streamAsConn) ConnectionState() quic.ConnectionState {
+	return c.session.ConnectionState()
+}
+
 func (c streamAsConn) LocalAddr() net.Addr {
 	return c.session.LocalAddr()
 }
@@ -206,7 +271,7 @@ func addrToString(addr net.Addr) (string, error) {
 // The representation is invariant to e.g. packet size and precision timestamps.
 func snetAddrToString(addr *snet.UDPAddr) (string, error) {
 	switch p := addr.Path.(type) {
-	case path.Empty:
+	case nil, path.Empty:
 		return addrPathToString(addr, empty.PathType, nil), nil
 	case path.SCION:
 		return addrPathToString(addr, scion.PathType, p.Raw), nil
@@ -40,6 +40,9 @@ type Listener struct {
 	acceptErrs  chan error
 }
 
+// NewListener returns a Listener on pconn. The sessions negotiate the application protocols
+// (ALPN) in tlsConfig.NextProtos, and those that negotiate another one are closed.
+// See WithNextProtos.
 func NewListener(pconn net.PacketConn, tlsConfig *tls.Config, quicConfig *quic.Config) *Listener {
 	return &Listener{
 		pconn:      pconn,
@@ -112,6 +115,10 @@ func (l *Listener) acceptNewSessions() {
 			l.acceptErrs <- err
 			return // the error is not recoverable
 		}
+		if err := rejectUnknownALPN(sess, l.tlsConfig.NextProtos); err != nil {
+			log.Debug("rejected new session", "remote", sess.RemoteAddr(), "err", err)
+			continue
+		}
 		go func() {
 			defer log.HandlePanic()
 			l.acceptNewStreams(sess)
@@ -28,6 +28,11 @@ import (
 	"github.com/lucas-clemente/quic-go"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
+
+	"github.com/scionproto/scion/go/lib/slayers/path/empty"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
 )
 
 func TestInvariantColibriRepresentation(t *testing.T) {
@@ -51,6 +56,29 @@ func TestInvariantColibriRepresentation(t *testing.T) {
 	require.Equal(t, repr1, repr2)
 }
 
+// TestEmptyPathRepresentation checks that all the forms of an empty path share the same
+// address representation.
+func TestEmptyPathRepresentation(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:111")
+	host := xtest.MustParseUDPAddr(t, "127.0.0.1:12345")
+	expected, err := addrToString(&snet.UDPAddr{IA: ia, Host: host, Path: path.Empty{}})
+	require.NoError(t, err)
+
+	cases := map[string]snet.DataplanePath{
+		"nil":       nil,
+		"raw_empty": snet.RawPath{PathType: empty.PathType},
+	}
+	for name, p := range cases {
+		name, p := name, p
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			repr, err := addrToString(&snet.UDPAddr{IA: ia, Host: host, Path: p})
+			require.NoError(t, err)
+			require.Equal(t, expected, repr)
+		})
+	}
+}
+
 // TestPersistentClientWithPersistentServer creates a persistent server and a persistent client
 // and uses them at the same time.
 func TestPersistentClientWithPersistentServer(t *testing.T) {
@@ -499,6 +527,12 @@ func TestCloseSession(t *testing.T) {
 	for s := range sessions {
 		require.NoError(t, s.Context().Err())
 	}
+	// only the active session is reported, as open
+	infos := dialer.Sessions()
+	require.Len(t, infos, 1)
+	require.Equal(t, serverAddr.String(), infos[0].Remote.String())
+	require.True(t, infos[0].Open)
+	require.False(t, infos[0].LastUse.IsZero())
 	// all connections are still open
 	for _, c := range conns {
 		cc, ok := c.(contexter)
@@ -508,6 +542,10 @@ func TestCloseSession(t *testing.T) {
 	// closing sessions and streams now
 	err := dialer.Close()
 	require.NoError(t, err)
+	// the session is still reported, now as closed
+	infos = dialer.Sessions()
+	require.Len(t, infos, 1)
+	require.False(t, infos[0].Open)
 	// all sessions should be closed
 	for s := range sessions {
 		require.Error(t, s.Context().Err())
@@ -527,6 +565,76 @@ func TestCloseSession(t *testing.T) {
 	stop <- struct{}{}
 }
 
+// TestMismatchedALPN checks that the session fails at the handshake if the client and the
+// server do not share an application protocol (ALPN), instead of hanging until the deadline.
+func TestMismatchedALPN(t *testing.T) {
+	// quic-go shares the packet connections with the same local address, thus each case uses
+	// its own addresses
+	cases := map[string]struct {
+		port         int
+		serverProtos []string
+		clientProtos []string
+		expectError  bool
+	}{
+		"default": {
+			port: 28001,
+		},
+		"one_shared": {
+			port:         28011,
+			serverProtos: []string{"coliquic2", DefaultALPN},
+			clientProtos: []string{DefaultALPN},
+		},
+		"mismatched": {
+			port:         28021,
+			serverProtos: []string{"coliquic2"},
+			clientProtos: []string{DefaultALPN},
+			expectError:  true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancelF := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancelF()
+			thisNet := newMockNetwork(t)
+			serverAddr := mockScionAddressWithPath(t, "1-ff00:0:110",
+				fmt.Sprintf("127.0.0.1:%d", tc.port), "1-ff00:0:111", 41, 1, "1-ff00:0:110")
+			serverTlsConfig := WithNextProtos(&tls.Config{
+				Certificates: []tls.Certificate{*createTestCertificate(t)},
+			}, tc.serverProtos...)
+			listener := NewListener(newConnMock(t, serverAddr, thisNet), serverTlsConfig, nil)
+			go func() {
+				// the listener only listens once Accept is called
+				if conn, err := listener.Accept(); err == nil {
+					conn.Close()
+				}
+			}()
+
+			clientTlsConfig := WithNextProtos(&tls.Config{
+				InsecureSkipVerify: true,
+			}, tc.clientProtos...)
+			dialer := NewPersistentQUIC(
+				newConnMock(t, mockScionAddress(t, "1-ff00:0:111",
+					fmt.Sprintf("127.0.0.1:%d", tc.port+1)), thisNet),
+				clientTlsConfig, nil)
+			conn, err := dialer.Dial(ctx, serverAddr)
+			// the dial must not have waited for the deadline
+			require.NoError(t, ctx.Err())
+			if tc.expectError {
+				require.Error(t, err)
+				require.Nil(t, conn)
+				require.Empty(t, dialer.Sessions())
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, conn.Close())
+			}
+			require.NoError(t, dialer.Close())
+			require.NoError(t, listener.Close())
+		})
+	}
+}
+
 func waitWithContext(ctx context.Context, wg *sync.WaitGroup) error {
 	done := make(chan struct{})
 	go func() {
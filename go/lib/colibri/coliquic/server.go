@@ -24,8 +24,10 @@ import (
 
 	"github.com/lucas-clemente/quic-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -34,6 +36,7 @@ import (
 	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers/path"
 	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/snet"
 	utilp "github.com/scionproto/scion/go/lib/snet/path"
@@ -42,23 +45,25 @@ import (
 	"github.com/scionproto/scion/go/lib/sock/reliable/reconnect"
 	"github.com/scionproto/scion/go/lib/svc"
 	libgrpc "github.com/scionproto/scion/go/pkg/grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
 )
 
 // GetColibriPath returns the (last) COLIBRI path used with this quic Session, or nil if none.
+// The path is returned as received: if the reservation is traversed in reverse, e.g. for
+// down-path reservations, it has the R flag set and its hop fields reversed with respect to
+// the path sent by the peer. Use ColibriPathMatches to compare it with the sent one.
 func GetColibriPath(session quic.Session) (*colibri.ColibriPath, error) {
 	// TODO(juagargi) currently, the same session can receive packets from multitude of
 	// COLIBRI paths (or non colibri), which should not be allowed. To enforce that the limits
 	// of the reservation are respected, only one colibri path must be allowed thru the
 	// life of the session. For now we assume no malicious parties.
 	var colPath *colibri.ColibriPath
-	netAddr := session.RemoteAddr()
-	addr, _ := netAddr.(*snet.UDPAddr)
-	if addr != nil {
-		cp, err := utilp.SnetToDataplanePath(addr.Path)
+	if _, ok := session.RemoteAddr().(*snet.UDPAddr); ok {
+		cp, _, err := addrPath(session.RemoteAddr())
 		if err != nil {
 			return nil, err
 		}
-		if cp.Type() == colibri.PathType {
+		if cp != nil && cp.Type() == colibri.PathType {
 			if colPath, _ = cp.(*colibri.ColibriPath); colPath == nil {
 				// it's a colibri path, but not colibri.ColibriPath. Reconstruct from binary:
 				buff := make([]byte, cp.Len())
@@ -75,8 +80,76 @@ func GetColibriPath(session quic.Session) (*colibri.ColibriPath, error) {
 	return colPath, nil
 }
 
+// ColibriPathMatches returns true if the COLIBRI path used with this quic Session is the sent
+// one, either as sent or reversed. It returns false if the session uses no COLIBRI path.
+func ColibriPathMatches(session quic.Session, sent *colibri.ColibriPath) (bool, error) {
+	colPath, err := GetColibriPath(session)
+	if err != nil || colPath == nil {
+		return false, err
+	}
+	return colPath.EqualIgnoringDirection(sent), nil
+}
+
+// PeerPath returns the SCION address of the gRPC peer in the context, and the path decoded
+// from it. The path is nil if the address has no path.
+// It returns an error if there is no peer, or its address is not a SCION one.
+func PeerPath(ctx context.Context) (path.Path, *snet.UDPAddr, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p == nil {
+		return nil, nil, serrors.New("could not retrieve peer from context")
+	}
+	return addrPath(p.Addr)
+}
+
+// addrPath returns the SCION address and its decoded path, or an error if the address is not
+// a SCION one.
+func addrPath(a net.Addr) (path.Path, *snet.UDPAddr, error) {
+	udpAddr, ok := a.(*snet.UDPAddr)
+	if !ok || udpAddr == nil {
+		return nil, nil, serrors.New("peer address is not a SCION address", "addr", a,
+			"type", common.TypeOf(a))
+	}
+	if udpAddr.Path == nil {
+		return nil, udpAddr, nil
+	}
+	// a RawPath cannot be set on a SCION header, decode it directly. This also covers
+	// the empty path, which has no raw bytes at all.
+	switch raw := udpAddr.Path.(type) {
+	case snet.RawPath:
+		return decodeRawPath(&raw, udpAddr)
+	case *snet.RawPath:
+		return decodeRawPath(raw, udpAddr)
+	}
+	p, err := utilp.SnetToDataplanePath(udpAddr.Path)
+	if err != nil {
+		return nil, nil, serrors.WrapStr("decoding peer path", err, "addr", udpAddr)
+	}
+	return p, udpAddr, nil
+}
+
+func decodeRawPath(raw *snet.RawPath, udpAddr *snet.UDPAddr) (path.Path, *snet.UDPAddr, error) {
+	p, err := path.NewPath(raw.PathType)
+	if err != nil {
+		return nil, nil, serrors.WrapStr("decoding peer path", err, "addr", udpAddr)
+	}
+	if err := p.DecodeFromBytes(raw.Raw); err != nil {
+		return nil, nil, serrors.WrapStr("decoding peer path", err, "addr", udpAddr)
+	}
+	return p, udpAddr, nil
+}
+
 // NewConnListener adapts a quic.Listener to be a net.Listener.
-func NewConnListener(listener quic.Listener) net.Listener {
+// Closing the returned listener unblocks a pending Accept, which then returns net.ErrClosed.
+// An optional accept deadline can be set with SetDeadline.
+// If protos is not empty, the sessions that negotiated an application protocol (ALPN) other
+// than those are closed, and never returned by Accept.
+func NewConnListener(listener quic.Listener, protos ...string) *squic.ConnListener {
+	if len(protos) > 0 {
+		listener = alpnListener{
+			Listener: listener,
+			protos:   append([]string{}, protos...),
+		}
+	}
 	return squic.NewConnListener(listener)
 }
 
@@ -94,17 +167,20 @@ type ServerStack struct {
 	serverNet        *snet.SCIONNetwork
 }
 
+// NewServerStack creates the sockets and listeners of a COLIBRI service. Its QUIC sessions
+// negotiate the application protocols (ALPN) alpn, in order of preference, or DefaultALPN if
+// none are given.
 func NewServerStack(ctx context.Context, serverAddr *snet.UDPAddr, debugSvcAddr *net.TCPAddr,
-	daemonAddr string) (
+	daemonAddr string, alpn ...string) (
 
 	*ServerStack, error) {
 	s := &ServerStack{}
-	err := s.init(ctx, serverAddr, debugSvcAddr, daemonAddr)
+	err := s.init(ctx, serverAddr, debugSvcAddr, daemonAddr, alpn)
 	return s, err
 }
 
 func (s *ServerStack) init(ctx context.Context, serverAddr *snet.UDPAddr, debugSrvAddr *net.TCPAddr,
-	daemonAddr string) error {
+	daemonAddr string, alpn []string) error {
 
 	var err error
 	if s.clientNet != nil {
@@ -146,6 +222,7 @@ func (s *ServerStack) init(ctx context.Context, serverAddr *snet.UDPAddr, debugS
 	if err != nil {
 		return err
 	}
+	ephemeralTLSConfig = WithNextProtos(ephemeralTLSConfig, alpn...)
 
 	s.Resolver = &svc.Resolver{
 		LocalIA: s.serverAddr.IA,
@@ -233,14 +310,129 @@ func (s *ServerStack) initQUICSockets(daemonAddr string) (
 	return client, server, nil
 }
 
-func NewGrpcServer(opt ...grpc.ServerOption) *grpc.Server {
+// NewGrpcServer returns a gRPC server to be used with colibri. The handlers can obtain the
+// given local IA from their context with LocalIAFromContext.
+// Besides the gRPC server options, WithMaxRequestBytes and WithALPN can be passed.
+func NewGrpcServer(localIA addr.IA, opt ...grpc.ServerOption) *grpc.Server {
 	h := &statsHandler{
 		usage: make(map[string]uint64),
 	}
-	opts := append(opt, grpc.StatsHandler(h))
+	interceptors := []grpc.UnaryServerInterceptor{localIAInterceptor(localIA)}
+	opts := make([]grpc.ServerOption, 0, len(opt)+3)
+	for _, o := range opt {
+		if maxBytes, ok := o.(maxRequestBytesOption); ok {
+			opts = append(opts, grpc.MaxRecvMsgSize(maxBytes.n))
+			interceptors = append(interceptors, requestBoundsInterceptor)
+			continue
+		}
+		if alpn, ok := o.(alpnOption); ok {
+			opts = append(opts, grpc.Creds(newALPNCredentials(alpn.protos)))
+			continue
+		}
+		opts = append(opts, o)
+	}
+	opts = append(opts,
+		grpc.StatsHandler(h),
+		grpc.ChainUnaryInterceptor(interceptors...),
+	)
 	return grpc.NewServer(opts...)
 }
 
+// maxRequestBytesOption is the option returned by WithMaxRequestBytes.
+type maxRequestBytesOption struct {
+	grpc.EmptyServerOption
+	n int
+}
+
+// WithMaxRequestBytes returns an option for NewGrpcServer that limits the size of the received
+// messages to n bytes. Additionally, colibri requests with more steps, allocation beads or
+// authenticators than a colibri path can have hop fields are rejected.
+// Rejected requests fail with codes.ResourceExhausted.
+func WithMaxRequestBytes(n int) grpc.ServerOption {
+	return maxRequestBytesOption{n: n}
+}
+
+// maxE2ESegments is the maximum number of segment reservations stitched by an E2E reservation.
+const maxE2ESegments = 3
+
+// requestBoundsInterceptor rejects the colibri requests that exceed the bounds of a path.
+func requestBoundsInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	if err := checkRequestBounds(req); err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return handler(ctx, req)
+}
+
+// checkRequestBounds returns an error if the request has more elements than allowed.
+func checkRequestBounds(req interface{}) error {
+	type bound struct {
+		name  string
+		count int
+		max   int
+	}
+	var bounds []bound
+	switch req := req.(type) {
+	case *colpb.SegmentSetupRequest:
+		bounds = []bound{
+			{"steps", len(req.GetParams().GetSteps()), colibri.MaxHopFields},
+			{"allocation trail", len(req.GetParams().GetAllocationtrail()), colibri.MaxHopFields},
+			{"authenticators", len(req.GetBase().GetAuthenticators().GetMacs()),
+				colibri.MaxHopFields},
+		}
+	case *colpb.E2ESetupRequest:
+		bounds = []bound{
+			{"steps", len(req.GetParams().GetSteps()), colibri.MaxHopFields},
+			{"steps without shortcuts", len(req.GetParams().GetStepsNoShortcuts()),
+				colibri.MaxHopFields},
+			{"segments", len(req.GetParams().GetSegments()), maxE2ESegments},
+			{"allocation trail", len(req.GetAllocationtrail()), colibri.MaxHopFields},
+			{"authenticators", len(req.GetBase().GetBase().GetAuthenticators().GetMacs()),
+				colibri.MaxHopFields},
+		}
+	case *colpb.SetupReservationRequest:
+		bounds = []bound{
+			{"steps", len(req.GetSteps()), colibri.MaxHopFields},
+			{"steps without shortcuts", len(req.GetStepsNoShortcuts()), colibri.MaxHopFields},
+			{"segments", len(req.GetSegments()), maxE2ESegments},
+			{"authenticators", len(req.GetAuthenticators().GetMacs()), colibri.MaxHopFields},
+		}
+	case *colpb.CleanupReservationRequest:
+		bounds = []bound{
+			{"steps", len(req.GetSteps()), colibri.MaxHopFields},
+			{"authenticators", len(req.GetBase().GetAuthenticators().GetMacs()),
+				colibri.MaxHopFields},
+		}
+	}
+	for _, b := range bounds {
+		if b.count > b.max {
+			return serrors.New("request too large", "field", b.name, "count", b.count,
+				"max", b.max)
+		}
+	}
+	return nil
+}
+
+// LocalIAFromContext returns the local IA the gRPC server was created with, and false if
+// the context was not created by such a server.
+func LocalIAFromContext(ctx context.Context) (addr.IA, bool) {
+	ia, ok := ctx.Value(localIAKey{}).(addr.IA)
+	return ia, ok
+}
+
+// localIAKey is used as key inside context to store the local IA.
+type localIAKey struct{}
+
+// localIAInterceptor injects the local IA in the context of the unary calls.
+func localIAInterceptor(localIA addr.IA) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		return handler(context.WithValue(ctx, localIAKey{}, localIA), req)
+	}
+}
+
 // UsageFromContext returns a bool saying if this peer was using colibri and
 // an approximation of the bandwidth used in that case.
 // TODO(juagargi) maybe use google.golang.org/protobuf/proto Size() instead?
@@ -254,15 +446,15 @@ func UsageFromContext(ctx context.Context) (bool, uint64, error) {
 		return false, 0, serrors.New("could not retrieve handler from context",
 			"raw_handler", ctx.Value(statsHandlerKey{}))
 	}
-	peer, ok := peer.FromContext(ctx)
-	if !ok {
-		return false, 0, serrors.New("could not retrieve peer from context")
+	p, addr, err := PeerPath(ctx)
+	if err != nil {
+		return false, 0, err
 	}
-	if raw := colibriTransportPath(peer.Addr); raw != nil {
+	if raw := serializedColibriPath(p); raw != nil {
 		usage, ok := handler.popUsage(raw)
 		if !ok {
 			return true, 0, serrors.New("could not retrieve this peer from stats handler",
-				"peer", peer.Addr)
+				"peer", addr)
 		}
 		return true, usage, nil
 	}
@@ -286,12 +478,12 @@ func (h *statsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) conte
 
 func (h *statsHandler) HandleRPC(ctx context.Context, st stats.RPCStats) {
 	logger := log.FromCtx(ctx)
-	peer, ok := peer.FromContext((ctx))
-	if !ok || peer.Addr.(*snet.UDPAddr) == nil {
+	p, peerAddr, err := PeerPath(ctx)
+	if err != nil {
 		// not a SCION family address
 		return
 	}
-	peerRaw := colibriTransportPath(peer.Addr)
+	peerRaw := serializedColibriPath(p)
 	prev := ctx.Value(bandwidthKey{})
 	if prev == nil || prev.(*snet.UDPAddr) == nil {
 		return
@@ -299,13 +491,13 @@ func (h *statsHandler) HandleRPC(ctx context.Context, st stats.RPCStats) {
 	prevRaw := colibriTransportPath(prev.(*snet.UDPAddr))
 	if prevRaw == nil || !bytes.Equal(peerRaw, prevRaw) {
 		logger.Info("error value from context not matching stats handler",
-			"ctx_value", prev, "peer_addr", peer.Addr)
+			"ctx_value", prev, "peer_addr", peerAddr)
 		return
 	}
 	handlerCtx := ctx.Value(statsHandlerKey{})
 	if handlerCtx == nil || handlerCtx != h {
 		logger.Info("error handler from context not matching stats handler",
-			"ctx_handler", handlerCtx, "handler", h, "peer", peer.Addr)
+			"ctx_handler", handlerCtx, "handler", h, "peer", peerAddr)
 		return
 	}
 	// estimate the size from the statistics
@@ -329,17 +521,15 @@ func (h *statsHandler) HandleRPC(ctx context.Context, st stats.RPCStats) {
 		return
 	}
 	if size < 0 {
-		logger.Info("error stats size is negative", "size", size, "peer", peer.Addr)
+		logger.Info("error stats size is negative", "size", size, "peer", peerAddr)
 		return
 	}
 	h.addUsage(prevRaw, uint64(size))
 }
 
 func (h *statsHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
-	if info.RemoteAddr.(*snet.UDPAddr) != nil {
-		addr := info.RemoteAddr.(*snet.UDPAddr)
-		raw := colibriTransportPath(addr)
-		if raw != nil {
+	if p, addr, err := addrPath(info.RemoteAddr); err == nil {
+		if raw := serializedColibriPath(p); raw != nil {
 			h.addUsage(raw, 0)
 			// store a pointer to this stats handler in the context so that we can retrieve the
 			// usage from the service calls themselves.
@@ -385,11 +575,16 @@ func (ignoreSCMP) Handle(pkt *snet.Packet) error {
 
 // colibriTransportPath returns nil if a colibri path cannot be extracted from the address.
 func colibriTransportPath(addr net.Addr) []byte {
-	if addr == nil || addr.(*snet.UDPAddr) == nil {
+	p, _, err := addrPath(addr)
+	if err != nil {
 		return nil
 	}
-	p, err := utilp.SnetToDataplanePath(addr.(*snet.UDPAddr).Path)
-	if err != nil || p == nil || p.Type() != colibri.PathType {
+	return serializedColibriPath(p)
+}
+
+// serializedColibriPath returns the raw bytes of the path if it is colibri, or nil otherwise.
+func serializedColibriPath(p path.Path) []byte {
+	if p == nil || p.Type() != colibri.PathType {
 		return nil
 	}
 	buff := make([]byte, p.Len())
@@ -31,7 +31,6 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
-	"github.com/scionproto/scion/go/lib/util"
 )
 
 const (
@@ -40,7 +39,7 @@ const (
 	// clockSkew denotes the maximal clock skew
 	clockSkew = time.Second
 	// TimestampResolution denotes the resolution of the epic timestamp
-	TimestampResolution = 4 * time.Nanosecond
+	TimestampResolution = colibri.TimestampResolution
 	// TickDuration denotes the length of one Colibri tick in seconds
 	TickDuration = reservation.SecsPerTick
 	// ExpirationOffset denotes the offset that is subtracted from the expiration time to
@@ -105,7 +104,7 @@ func ParseColibriTimestampCustom(ts colibri.Timestamp) (tsRel uint32, pktId uint
 // If the current time is not between the expiration time minus 16 seconds and the expiration time,
 // an error is returned.
 func CreateTsRel(expirationTick uint32, now time.Time) (uint32, error) {
-	expiration := util.SecsToTime(TickDuration * expirationTick)
+	expiration := reservation.ExpTickToTime(expirationTick)
 	timestamp := expiration.Add(-ExpirationOffset)
 	if now.After(expiration) {
 		return 0, serrors.New("provided packet expiration time is in the past",
@@ -134,7 +133,7 @@ func VerifyExpirationTick(expirationTick uint32) bool {
 // account.
 func VerifyTimestamp(expirationTick uint32, ts colibri.Timestamp, now time.Time) bool {
 	tsRel, _, _ := ParseColibriTimestamp(ts)
-	expiration := util.SecsToTime(TickDuration * expirationTick)
+	expiration := reservation.ExpTickToTime(expirationTick)
 	diff := time.Duration(tsRel) * TimestampResolution
 	tsSender := expiration.Add(-ExpirationOffset).Add(diff)
 
@@ -179,6 +178,45 @@ func VerifyMAC(privateKey cipher.Block, ts colibri.Timestamp, inf *colibri.InfoF
 	return nil
 }
 
+// HopFieldVerifier checks the MAC of the hop field at position idx in the path. It returns
+// nil if the MAC is correct, and an error otherwise.
+type HopFieldVerifier func(idx int, inf *colibri.InfoField, hf *colibri.HopField) error
+
+// VerifyHopFieldMACs checks the MAC of every hop field in the path using the verifier.
+// It returns the index of the first failing hop field and its error, or -1 and nil if all
+// hop fields are correct.
+func VerifyHopFieldMACs(p *colibri.ColibriPath, verify HopFieldVerifier) (int, error) {
+	if p == nil || p.InfoField == nil {
+		return -1, serrors.New("incomplete colibri path")
+	}
+	for i, hf := range p.HopFields {
+		if err := verify(i, p.InfoField, hf); err != nil {
+			return i, serrors.WrapStr("hop field verification failed", err, "hop", i)
+		}
+	}
+	return -1, nil
+}
+
+// StaticMACVerifier returns a HopFieldVerifier that checks the static MACs of the hop fields
+// using the colibri key of each AS. keys must follow the order of the hop fields.
+func StaticMACVerifier(keys []cipher.Block, srcAS, dstAS addr.AS) HopFieldVerifier {
+	return func(idx int, inf *colibri.InfoField, hf *colibri.HopField) error {
+		if idx >= len(keys) {
+			return serrors.New("no key for hop field", "hop", idx, "keys", len(keys))
+		}
+		var mac [4]byte
+		if err := MACStatic(mac[:], keys[idx], inf, hf, srcAS, dstAS); err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare(mac[:], hf.Mac) != 1 {
+			return serrors.New("colibri mac verification failed",
+				"calculated", hex.EncodeToString(mac[:]),
+				"packet", hex.EncodeToString(hf.Mac))
+		}
+		return nil
+	}
+}
+
 // MACInputStatic prepares the buffer using the passed parameters to be used as input for the
 // static MAC computation.
 // buffer is expected to be at least `LengthInputData` bytes long.
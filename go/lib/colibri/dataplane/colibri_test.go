@@ -15,7 +15,9 @@
 package colibri_test
 
 import (
+	"crypto/cipher"
 	"encoding/binary"
+	"fmt"
 	"math"
 	"net"
 	"testing"
@@ -231,6 +233,52 @@ func TestPacketHVFVerification(t *testing.T) {
 
 }
 
+func TestVerifyHopFieldMACs(t *testing.T) {
+	s := createScionCmnAddrHdr()
+	c := createColibriPath()
+	c.InfoField.C = true
+
+	keys := make([]cipher.Block, len(c.HopFields))
+	for i := range keys {
+		var err error
+		keys[i], err = libcolibri.InitColibriKey([]byte(fmt.Sprintf("a_random_key_%03d", i)))
+		require.NoError(t, err)
+		var mac [4]byte
+		err = libcolibri.MACStatic(mac[:], keys[i], c.InfoField, c.HopFields[i],
+			s.SrcIA.AS(), s.DstIA.AS())
+		require.NoError(t, err)
+		c.HopFields[i].Mac = mac[:]
+	}
+	verifier := libcolibri.StaticMACVerifier(keys, s.SrcIA.AS(), s.DstIA.AS())
+
+	idx, err := libcolibri.VerifyHopFieldMACs(c, verifier)
+	require.NoError(t, err)
+	require.Equal(t, -1, idx)
+
+	// tamper with the third MAC
+	c.HopFields[2].Mac = []byte{0, 0, 0, 0}
+	idx, err = libcolibri.VerifyHopFieldMACs(c, verifier)
+	require.Error(t, err)
+	require.Equal(t, 2, idx)
+
+	// missing keys
+	idx, err = libcolibri.VerifyHopFieldMACs(c,
+		libcolibri.StaticMACVerifier(keys[:1], s.SrcIA.AS(), s.DstIA.AS()))
+	require.Error(t, err)
+	require.Equal(t, 1, idx)
+
+	// custom verifier
+	idx, err = libcolibri.VerifyHopFieldMACs(c,
+		func(i int, _ *colibri.InfoField, _ *colibri.HopField) error {
+			if i == 5 {
+				return fmt.Errorf("bad hop")
+			}
+			return nil
+		})
+	require.Error(t, err)
+	require.Equal(t, 5, idx)
+}
+
 func createScionCmnAddrHdr() *slayers.SCION {
 	spkt := &slayers.SCION{
 		Header: sheader.Header{
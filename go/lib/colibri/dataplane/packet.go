@@ -0,0 +1,83 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri
+
+import (
+	"net"
+
+	"github.com/google/gopacket"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	caddr "github.com/scionproto/scion/go/lib/slayers/path/colibri/addr"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// BuildColibriPacket serializes a complete SCION packet from src to dst, using the colibri path
+// and carrying the payload inside a UDP datagram. The lengths and the UDP checksum are computed.
+// The endpoints of the colibri path are set from src and dst, as the colibri path rewrites the
+// SCION addresses when serialized. The colibri path passed as argument is not modified.
+func BuildColibriPacket(src, dst *snet.UDPAddr, colPath *colibri.ColibriPath,
+	payload []byte) ([]byte, error) {
+
+	if src == nil || src.Host == nil || dst == nil || dst.Host == nil {
+		return nil, serrors.New("source and destination addresses must be set",
+			"src", src, "dst", dst)
+	}
+	if colPath == nil {
+		return nil, serrors.New("no colibri path set")
+	}
+	p := colPath.Clone()
+	p.Src = caddr.NewEndpointWithIP(src.IA, src.Host.IP)
+	p.Dst = caddr.NewEndpointWithIP(dst.IA, dst.Host.IP)
+
+	scionLayer := &slayers.SCION{
+		PathType: colibri.PathType,
+		Path:     p,
+	}
+	scionLayer.Version = 0
+	scionLayer.FlowID = 1
+	scionLayer.NextHdr = common.L4UDP
+	scionLayer.SrcIA = src.IA
+	scionLayer.DstIA = dst.IA
+	if err := scionLayer.SetSrcAddr(&net.IPAddr{IP: src.Host.IP}); err != nil {
+		return nil, serrors.WrapStr("setting source address", err)
+	}
+	if err := scionLayer.SetDstAddr(&net.IPAddr{IP: dst.Host.IP}); err != nil {
+		return nil, serrors.WrapStr("setting destination address", err)
+	}
+
+	udpLayer := &slayers.UDP{
+		SrcPort: uint16(src.Host.Port),
+		DstPort: uint16(dst.Host.Port),
+	}
+	if err := udpLayer.SetNetworkLayerForChecksum(scionLayer); err != nil {
+		return nil, serrors.WrapStr("setting network layer for checksum", err)
+	}
+
+	buffer := gopacket.NewSerializeBuffer()
+	options := gopacket.SerializeOptions{
+		ComputeChecksums: true,
+		FixLengths:       true,
+	}
+	err := gopacket.SerializeLayers(buffer, options, scionLayer, udpLayer,
+		gopacket.Payload(payload))
+	if err != nil {
+		return nil, serrors.WrapStr("serializing colibri packet", err)
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package colibri_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/stretchr/testify/require"
+
+	libcolibri "github.com/scionproto/scion/go/lib/colibri/dataplane"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/slayers"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestBuildColibriPacket(t *testing.T) {
+	src := &snet.UDPAddr{
+		IA:   xtest.MustParseIA("1-ff00:0:111"),
+		Host: &net.UDPAddr{IP: net.ParseIP("10.0.0.1").To4(), Port: 1234},
+	}
+	dst := &snet.UDPAddr{
+		IA:   xtest.MustParseIA("1-ff00:0:112"),
+		Host: &net.UDPAddr{IP: net.ParseIP("10.0.0.2").To4(), Port: 5678},
+	}
+	colPath := createColibriPath()
+	payload := []byte("hello colibri")
+
+	raw, err := libcolibri.BuildColibriPacket(src, dst, colPath, payload)
+	require.NoError(t, err)
+	require.Nil(t, colPath.Src, "the colibri path argument must not be modified")
+
+	var scionLayer slayers.SCION
+	var udpLayer slayers.UDP
+	var payloadLayer gopacket.Payload
+	parser := gopacket.NewDecodingLayerParser(slayers.LayerTypeSCION, &scionLayer, &udpLayer,
+		&payloadLayer)
+	decoded := make([]gopacket.LayerType, 0, 3)
+	err = parser.DecodeLayers(raw, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, []gopacket.LayerType{slayers.LayerTypeSCION, slayers.LayerTypeSCIONUDP,
+		gopacket.LayerTypePayload}, decoded)
+
+	// SCION header
+	require.Equal(t, colibri.PathType, scionLayer.PathType)
+	require.Equal(t, common.L4UDP, scionLayer.NextHdr)
+	require.Equal(t, src.IA, scionLayer.SrcIA)
+	require.Equal(t, dst.IA, scionLayer.DstIA)
+	srcAddr, err := scionLayer.SrcAddr()
+	require.NoError(t, err)
+	require.Equal(t, src.Host.IP, srcAddr.(*net.IPAddr).IP)
+	dstAddr, err := scionLayer.DstAddr()
+	require.NoError(t, err)
+	require.Equal(t, dst.Host.IP, dstAddr.(*net.IPAddr).IP)
+	require.Equal(t, len(payload)+8, int(scionLayer.PayloadLen))
+
+	// colibri path
+	rawPath := make([]byte, scionLayer.Path.Len())
+	err = scionLayer.Path.SerializeTo(rawPath)
+	require.NoError(t, err)
+	decodedPath := &colibri.ColibriPath{}
+	err = decodedPath.DecodeFromBytes(rawPath)
+	require.NoError(t, err)
+	require.Equal(t, colPath.PacketTimestamp, decodedPath.PacketTimestamp)
+	require.Equal(t, colPath.InfoField.ResIdSuffix, decodedPath.InfoField.ResIdSuffix)
+	require.Equal(t, colPath.HopFields, decodedPath.HopFields)
+
+	// UDP
+	require.Equal(t, uint16(src.Host.Port), udpLayer.SrcPort)
+	require.Equal(t, uint16(dst.Host.Port), udpLayer.DstPort)
+	require.Equal(t, len(payload)+8, int(udpLayer.Length))
+	require.NotZero(t, udpLayer.Checksum)
+	require.Equal(t, payload, udpLayer.Payload)
+
+	// payload
+	require.Equal(t, payload, payloadLayer.Payload())
+}
+
+func TestBuildColibriPacketErrors(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA:   xtest.MustParseIA("1-ff00:0:111"),
+		Host: &net.UDPAddr{IP: net.ParseIP("10.0.0.1").To4(), Port: 1234},
+	}
+	cases := map[string]struct {
+		src     *snet.UDPAddr
+		dst     *snet.UDPAddr
+		colPath *colibri.ColibriPath
+	}{
+		"no_src": {
+			dst:     addr,
+			colPath: createColibriPath(),
+		},
+		"no_dst": {
+			src:     addr,
+			colPath: createColibriPath(),
+		},
+		"no_path": {
+			src: addr,
+			dst: addr,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := libcolibri.BuildColibriPacket(tc.src, tc.dst, tc.colPath, []byte{})
+			require.Error(t, err)
+		})
+	}
+}
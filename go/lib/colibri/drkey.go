@@ -247,7 +247,6 @@ func serializeResponse(res *E2EResponse, steps base.PathSteps, timestamp time.Ti
 	allHfs := colibriPath.HopFields
 	payloads := make([][]byte, len(allHfs))
 	for i := range steps {
-		colibriPath.InfoField.HFCount = uint8(len(allHfs) - i)
 		colibriPath.HopFields = allHfs[i:]
 		payloads[i] = make([]byte, 1+4+colibriPath.Len()) // marker + timestamp + path
 		payloads[i][0] = 0                                // success marker
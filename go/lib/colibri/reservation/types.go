@@ -77,7 +77,7 @@ func IDFromRaw(raw []byte) (*ID, error) {
 }
 
 // IDFromString expects a string like "ff00:0:1-01234567" (suffix in hex).
-// The function will not validate the ID to be legal. I.e. the suffix could be any length.
+// The suffix must be that of a segment or an e2e ID, i.e. 4 or 12 bytes long.
 func IDFromString(str string) (*ID, error) {
 	parts := strings.Split(str, "-")
 	if len(parts) != 2 {
@@ -91,12 +91,42 @@ func IDFromString(str string) (*ID, error) {
 	if err != nil {
 		return nil, serrors.WrapStr("bad colibri id", err, "string", str)
 	}
+	if len(suffix) != IDSuffixSegLen && len(suffix) != IDSuffixE2ELen {
+		return nil, serrors.New("bad colibri id, the suffix should be 4 (segment) or "+
+			"12 (e2e) bytes long", "string", str, "suffix_len", len(suffix))
+	}
 	return &ID{
 		ASID:   as,
 		Suffix: suffix,
 	}, nil
 }
 
+// SegmentIDFromString is like IDFromString, but only accepts segment reservation IDs, e.g.
+// "ff00:0:1-01234567".
+func SegmentIDFromString(str string) (*ID, error) {
+	id, err := IDFromString(str)
+	if err != nil {
+		return nil, err
+	}
+	if !id.IsSegmentID() {
+		return nil, serrors.New("not a segment reservation id", "string", str)
+	}
+	return id, nil
+}
+
+// E2EIDFromString is like IDFromString, but only accepts e2e reservation IDs, e.g.
+// "ff00:0:1-0123456789abcdef01234567".
+func E2EIDFromString(str string) (*ID, error) {
+	id, err := IDFromString(str)
+	if err != nil {
+		return nil, err
+	}
+	if !id.IsE2EID() {
+		return nil, serrors.New("not an e2e reservation id", "string", str)
+	}
+	return id, nil
+}
+
 func (id *ID) SetSegmentSuffix(suffix int) {
 	if id.Suffix == nil {
 		id.Suffix = make([]byte, 4)
@@ -196,6 +226,28 @@ func TickFromTime(t time.Time) Tick {
 	return Tick(util.TimeToSecs(t) / SecsPerTick)
 }
 
+// ExpTickToTime returns the time corresponding to the 32 bit expiration tick found in the
+// colibri info field. The computation is done with 64 bits, and thus it never overflows.
+func ExpTickToTime(expTick uint32) time.Time {
+	return time.Unix(int64(expTick)*SecsPerTick, 0)
+}
+
+// TimeToExpTick returns the 32 bit expiration tick for the colibri info field that contains
+// the time t. It returns an error if t is before the Unix epoch or too far in the future
+// to be represented with 32 bits.
+func TimeToExpTick(t time.Time) (uint32, error) {
+	secs := t.Unix()
+	if secs < 0 {
+		return 0, serrors.New("time before the Unix epoch", "time", t)
+	}
+	tick := secs / SecsPerTick
+	if tick > math.MaxUint32 {
+		return 0, serrors.New("time too far in the future for the expiration tick",
+			"time", t, "max", ExpTickToTime(math.MaxUint32))
+	}
+	return uint32(tick), nil
+}
+
 // TicksFromDuration returns duration as ticks.
 func TicksFromDuration(dur time.Duration) Tick {
 	secsDur := (dur + DurationPerTick - 1).Truncate(DurationPerTick)
@@ -204,7 +256,13 @@ func TicksFromDuration(dur time.Duration) Tick {
 }
 
 func (t Tick) ToTime() time.Time {
-	return util.SecsToTime(uint32(t) * SecsPerTick)
+	return ExpTickToTime(t.ToExpTick())
+}
+
+// ToExpTick returns the tick as the 32 bit expiration tick of the colibri info field.
+// Both have the same range, thus the ticks obtained with TimeToExpTick are never truncated.
+func (t Tick) ToExpTick() uint32 {
+	return uint32(t)
 }
 
 func (t Tick) ToDuration() time.Duration {
@@ -258,9 +316,18 @@ func MinBWCls(a, b BWCls) BWCls {
 }
 
 // SplitCls is the traffic split parameter. split = sqrt(2^c). The split divides the bandwidth
-// in control traffic (BW * split) and end to end traffic (BW * (1-s)). 0 <= splitCls <= 256 .
+// in control traffic (BW * split) and end to end traffic (BW * (1-s)). 0 <= splitCls <= 63 .
 type SplitCls uint8
 
+// Validate returns an error if the split class is out of range, i.e. greater than 63.
+// Split classes received from other ASes must be validated, as they are carried in 32 bits.
+func (s SplitCls) Validate() error {
+	if s > 63 {
+		return serrors.New("invalid SplitCls", "split_cls", s)
+	}
+	return nil
+}
+
 func (s SplitCls) SplitForControl() float64 {
 	return math.Sqrt(1. / math.Pow(2., float64(s)))
 }
@@ -455,7 +522,7 @@ func (f *InfoField) Read(b []byte) (int, error) {
 		return 0, serrors.New("buffer too small", "min_size", InfoFieldLen,
 			"current_size", len(b))
 	}
-	binary.BigEndian.PutUint32(b[:4], uint32(f.ExpirationTick))
+	binary.BigEndian.PutUint32(b[:4], f.ExpirationTick.ToExpTick())
 	b[4] = byte(f.BWCls)
 	b[5] = byte(f.RLC)
 	b[6] = byte(f.Idx<<4) | uint8(f.PathType)
@@ -576,6 +643,21 @@ type AllocationBead struct {
 
 type AllocationBeads []AllocationBead
 
+// Bottleneck returns the minimum of all the allocated BW in the AllocationBeads, i.e. the
+// bandwidth class that was admitted end to end.
+func (bs AllocationBeads) Bottleneck() BWCls {
+	if len(bs) == 0 {
+		return 0
+	}
+	var min BWCls = math.MaxUint8
+	for _, b := range bs {
+		if b.AllocBW < min {
+			min = b.AllocBW
+		}
+	}
+	return min
+}
+
 // MinMax returns the minimum of all the max BW in the AllocationBeads.
 func (bs AllocationBeads) MinMax() BWCls {
 	if len(bs) == 0 {
@@ -16,6 +16,7 @@ package reservation
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -66,6 +67,26 @@ func TestIDFromString(t *testing.T) {
 			s:             "ffaa:1:1-0123-0123",
 			expectedError: true,
 		},
+		"short_suffix": {
+			s:             "ffaa:1:1-012345",
+			expectedError: true,
+		},
+		"between_lengths": {
+			s:             "ffaa:1:1-0123456789abcdef",
+			expectedError: true,
+		},
+		"long_suffix": {
+			s:             "ffaa:1:1-0123456789abcdef0123456789",
+			expectedError: true,
+		},
+		"no_suffix": {
+			s:             "ffaa:1:1-",
+			expectedError: true,
+		},
+		"empty": {
+			s:             "",
+			expectedError: true,
+		},
 	}
 	for name, tc := range cases {
 		name, tc := name, tc
@@ -82,6 +103,54 @@ func TestIDFromString(t *testing.T) {
 	}
 }
 
+func TestSegmentAndE2EIDFromString(t *testing.T) {
+	cases := map[string]struct {
+		s           string
+		expectedSeg bool // parsed by SegmentIDFromString
+		expectedE2E bool // parsed by E2EIDFromString
+	}{
+		"segR": {
+			s:           "ffaa:1:1-01234567",
+			expectedSeg: true,
+		},
+		"eeR": {
+			s:           "ffaa:1:1-0123456789abcdef01234567",
+			expectedE2E: true,
+		},
+		"bad_suffix": {
+			s: "ffaa:1:1-0123456789",
+		},
+		"bad_AS": {
+			s: "xxxx:1:1-01234567",
+		},
+		"no_separator": {
+			s: "ffaa:1:101234567",
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			seg, err := SegmentIDFromString(tc.s)
+			if tc.expectedSeg {
+				require.NoError(t, err)
+				require.True(t, seg.IsSegmentID())
+				require.Equal(t, tc.s, seg.String())
+			} else {
+				require.Error(t, err)
+			}
+			e2e, err := E2EIDFromString(tc.s)
+			if tc.expectedE2E {
+				require.NoError(t, err)
+				require.True(t, e2e.IsE2EID())
+				require.Equal(t, tc.s, e2e.String())
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
 func TestIDRead(t *testing.T) {
 	reference := ID{
 		ASID: xtest.MustParseAS("ffaa:0:1101"),
@@ -159,6 +228,93 @@ func TestTickToTime(t *testing.T) {
 	require.Equal(t, time.Unix(4, 0), TickFromTime(time.Unix(4, 0)).ToTime())
 }
 
+func TestExpTickToTime(t *testing.T) {
+	cases := map[string]struct {
+		expTick  uint32
+		expected time.Time
+	}{
+		"zero": {
+			expTick:  0,
+			expected: time.Unix(0, 0),
+		},
+		"one": {
+			expTick:  1,
+			expected: time.Unix(4, 0),
+		},
+		"seconds_overflow_32bits": {
+			expTick:  1893452400,
+			expected: time.Unix(7573809600, 0),
+		},
+		"max": {
+			expTick:  math.MaxUint32,
+			expected: time.Unix(4*math.MaxUint32, 0),
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.expected, ExpTickToTime(tc.expTick))
+			require.Equal(t, tc.expected, Tick(tc.expTick).ToTime())
+			require.Equal(t, tc.expTick, Tick(tc.expTick).ToExpTick())
+		})
+	}
+}
+
+func TestTimeToExpTick(t *testing.T) {
+	cases := map[string]struct {
+		time     time.Time
+		expected uint32
+		expErr   bool
+	}{
+		"zero": {
+			time:     time.Unix(0, 0),
+			expected: 0,
+		},
+		"within_tick": {
+			time:     time.Unix(7, 999999),
+			expected: 1,
+		},
+		"after_2106": {
+			time:     time.Unix(1<<32, 0),
+			expected: 1 << 30,
+		},
+		"test_value": {
+			time:     time.Unix(4*1893452400, 0),
+			expected: 1893452400,
+		},
+		"last_tick": {
+			time:     time.Unix(4*math.MaxUint32+3, 0),
+			expected: math.MaxUint32,
+		},
+		"overflow": {
+			time:   time.Unix(4*(math.MaxUint32+1), 0),
+			expErr: true,
+		},
+		"before_epoch": {
+			time:   time.Unix(-1, 0),
+			expErr: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tick, err := TimeToExpTick(tc.time)
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tick)
+			// round trip
+			tick, err = TimeToExpTick(ExpTickToTime(tick))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tick)
+		})
+	}
+}
+
 func TestTickFromDuration(t *testing.T) {
 	cases := map[time.Duration]Tick{
 		0:                                       0,
@@ -202,6 +358,15 @@ func TestValidateBWCls(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestValidateSplitCls(t *testing.T) {
+	for i := 0; i < 64; i++ {
+		require.NoError(t, SplitCls(i).Validate())
+	}
+	for _, c := range []SplitCls{64, 255} {
+		require.Error(t, c.Validate(), c)
+	}
+}
+
 func TestBWClsToKbps(t *testing.T) {
 	cases := map[BWCls]uint64{
 		0:  0,
@@ -524,6 +689,26 @@ func TestAllocationBeadsMinMax(t *testing.T) {
 	}
 }
 
+func TestAllocationBeadsBottleneck(t *testing.T) {
+	cases := []struct {
+		Trail AllocationBeads
+		Min   BWCls
+	}{
+		{newAllocationBeads(), 0},
+		{newAllocationBeads(1, 2), 1},
+		{newAllocationBeads(5, 7, 3, 7), 3},
+		{newAllocationBeads(255, 255, 4, 3), 4},
+	}
+	for i, c := range cases {
+		name := fmt.Sprintf("iteration %d", i)
+		t.Run(name, func(t *testing.T) {
+			c := c
+			t.Parallel()
+			require.Equal(t, c.Min, c.Trail.Bottleneck())
+		})
+	}
+}
+
 func TestValidateToken(t *testing.T) {
 	tok := newToken(t)
 	err := tok.Validate()
@@ -15,7 +15,13 @@
 package colibri
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
@@ -31,6 +37,14 @@ type ColibriPathFacade interface {
 	GetCurrentHopField() *HopField
 }
 
+// MaxHopFields is the maximum number of hop fields in a COLIBRI path. It matches the limit of
+// the regular SCION path (scion.MaxHops); longer paths are rejected instead of being encoded.
+const MaxHopFields = 64
+
+// TimestampResolution is the resolution of the relative time (TsRel) encoded in the first four
+// bytes of the packet timestamp.
+const TimestampResolution = 4 * time.Nanosecond
+
 type Timestamp [8]byte
 
 type ColibriPath struct {
@@ -63,6 +77,59 @@ func (c *ColibriPath) String() string {
 		c.Src, c.Dst, ID, inf.Ver, inf.HFCount, inf.CurrHF, inf.S, inf.C, inf.R)
 }
 
+// AnonymizedString returns a description of the path that is safe to log: that of String,
+// followed by the interfaces of the hop fields, but never their MACs. If hashIfaces is set,
+// the interface IDs are also replaced by AnonymizedIfID.
+func (c *ColibriPath) AnonymizedString(hashIfaces bool) string {
+	if c == nil || c.InfoField == nil {
+		return "(nil)"
+	}
+	hops := make([]string, len(c.HopFields))
+	for i, hf := range c.HopFields {
+		if hashIfaces {
+			hops[i] = AnonymizedIfID(hf.IngressId) + ">" + AnonymizedIfID(hf.EgressId)
+		} else {
+			hops[i] = fmt.Sprintf("%d>%d", hf.IngressId, hf.EgressId)
+		}
+	}
+	return fmt.Sprintf("%s [%s] (MACs redacted)", c.String(), strings.Join(hops, " "))
+}
+
+// anonymizationSalt makes the anonymized interface IDs different in every process, so that
+// they cannot be reversed by hashing all the possible IDs.
+var anonymizationSalt = func() []byte {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		panic(err)
+	}
+	return salt
+}()
+
+// AnonymizedIfID returns a short hash of the interface ID, to log it without revealing it.
+// The same ID always yields the same hash within the same process.
+func AnonymizedIfID(id uint16) string {
+	h := sha256.New()
+	h.Write(anonymizationSalt)
+	_ = binary.Write(h, binary.BigEndian, id)
+	return "#" + hex.EncodeToString(h.Sum(nil)[:3])
+}
+
+// SetPacketTimestamp encodes now in the relative time (TsRel) of the packet timestamp.
+// As the routers expect it when checking the freshness of the packet, the time is relative to
+// the expiration time of the info field minus the duration of an E2E reservation, in units of
+// TimestampResolution. A time outside of that window is clamped to it, and the packet will not
+// be considered fresh. The packet ID in the last four bytes of the timestamp is left untouched.
+func (c *ColibriPath) SetPacketTimestamp(now time.Time) {
+	expiration := reservation.ExpTickToTime(c.InfoField.ExpTick)
+	diff := now.Sub(expiration.Add(-reservation.E2ERsvDuration))
+	if diff < 0 {
+		diff = 0
+	} else if diff > reservation.E2ERsvDuration {
+		diff = reservation.E2ERsvDuration
+	}
+	binary.BigEndian.PutUint32(c.PacketTimestamp[:4], uint32(diff/TimestampResolution))
+}
+
 func (c *ColibriPath) GetInfoField() *InfoField {
 	return c.InfoField
 }
@@ -71,6 +138,8 @@ func (c *ColibriPath) GetCurrentHopField() *HopField {
 	return c.HopFields[c.InfoField.CurrHF]
 }
 
+// SerializeTo writes the path to b, which must be at least Len bytes long. The path is
+// normalized first, thus HFCount always matches the serialized hop fields. See Normalize.
 func (c *ColibriPath) SerializeTo(b []byte) error {
 	if c == nil {
 		return serrors.New("colibri path must not be nil")
@@ -78,6 +147,11 @@ func (c *ColibriPath) SerializeTo(b []byte) error {
 	if c.InfoField == nil {
 		return serrors.New("the info field must not be nil")
 	}
+	if len(c.HopFields) > MaxHopFields {
+		return serrors.New("too many hop fields in colibri path", "hop_fields", len(c.HopFields),
+			"max", MaxHopFields)
+	}
+	c.Normalize()
 	if len(b) < c.Len() {
 		return serrors.New("buffer for ColibriPath too short", "is:", len(b),
 			"needs:", c.Len())
@@ -150,6 +224,10 @@ func (c *ColibriPath) DecodeFromBytes(b []byte) error {
 		return err
 	}
 	nrHopFields := int(c.InfoField.HFCount)
+	if nrHopFields > MaxHopFields {
+		return serrors.New("too many hop fields in colibri path", "HFCount", nrHopFields,
+			"max", MaxHopFields)
+	}
 	if 8+LenInfoField+(nrHopFields*LenHopField) > len(b) {
 		return serrors.New("raw colibri path is smaller than what is " +
 			"indicated by HFCount in the info field")
@@ -166,7 +244,31 @@ func (c *ColibriPath) DecodeFromBytes(b []byte) error {
 	return nil
 }
 
+// ErrTruncated indicates that the buffer holding a colibri path is shorter than the path,
+// according to the HFCount in its info field.
+var ErrTruncated = serrors.New("truncated colibri path")
+
+// checkTruncated returns an error wrapping ErrTruncated if b is shorter than the timestamp,
+// the info field and the HFCount hop fields of the colibri path it contains.
+func checkTruncated(b []byte) error {
+	if len(b) < 8+LenInfoField {
+		return serrors.WrapStr("buffer too short for colibri path", ErrTruncated,
+			"is", len(b), "needs", 8+LenInfoField)
+	}
+	hfCount := int(b[8+3])
+	if needs := 8 + LenInfoField + hfCount*LenHopField; len(b) < needs {
+		return serrors.WrapStr("buffer shorter than indicated by HFCount", ErrTruncated,
+			"HFCount", hfCount, "is", len(b), "needs", needs)
+	}
+	return nil
+}
+
+// BuildFromHeader decodes the path from b, which must contain the whole path, and takes the
+// source and destination endpoints from the SCION header.
 func (cp *ColibriPath) BuildFromHeader(b []byte, sc *scion.Header) error {
+	if err := checkTruncated(b); err != nil {
+		return err
+	}
 	cp.Src = caddr.NewEndpointWithRaw(sc.SrcIA, sc.RawSrcAddr, sc.SrcAddrType, sc.SrcAddrLen)
 	cp.Dst = caddr.NewEndpointWithRaw(sc.DstIA, sc.RawDstAddr, sc.DstAddrType, sc.DstAddrLen)
 	return cp.DecodeFromBytes(b)
@@ -218,7 +320,59 @@ func (c *ColibriPath) Type() path.Type {
 	return PathType
 }
 
+// Normalize updates the info field after hop fields were added to or removed from the path:
+// HFCount is set to the number of hop fields, and CurrHF, if beyond them, to the last one.
+// Paths without info field or with more than MaxHopFields hop fields are left untouched.
+func (c *ColibriPath) Normalize() {
+	if c == nil || c.InfoField == nil || len(c.HopFields) > MaxHopFields {
+		return
+	}
+	c.InfoField.HFCount = uint8(len(c.HopFields))
+	if c.InfoField.CurrHF >= c.InfoField.HFCount {
+		c.InfoField.CurrHF = 0
+		if c.InfoField.HFCount > 0 {
+			c.InfoField.CurrHF = c.InfoField.HFCount - 1
+		}
+	}
+}
+
+// Validate checks that the path is well formed: the info field is present, HFCount matches the
+// number of hop fields, which lies between 2 and MaxHopFields, and CurrHF points to one of them.
+func (c *ColibriPath) Validate() error {
+	if c == nil {
+		return serrors.New("colibri path must not be nil")
+	}
+	if c.InfoField == nil {
+		return serrors.New("the info field must not be nil")
+	}
+	if int(c.InfoField.HFCount) != len(c.HopFields) {
+		return serrors.New("HFCount does not match the number of hop fields",
+			"HFCount", c.InfoField.HFCount, "hop_fields", len(c.HopFields))
+	}
+	if len(c.HopFields) < 2 {
+		return serrors.New("a colibri path must have at least two hop fields",
+			"hop_fields", len(c.HopFields))
+	}
+	if len(c.HopFields) > MaxHopFields {
+		return serrors.New("too many hop fields in colibri path", "hop_fields", len(c.HopFields),
+			"max", MaxHopFields)
+	}
+	if c.InfoField.CurrHF >= c.InfoField.HFCount {
+		return serrors.New("CurrHF >= HFCount", "CurrHF", c.InfoField.CurrHF,
+			"HFCount", c.InfoField.HFCount)
+	}
+	for i, hf := range c.HopFields {
+		if hf == nil {
+			return serrors.New("hop field must not be nil", "index", i)
+		}
+	}
+	return nil
+}
+
 func (c *ColibriPath) ToMinimal() (*ColibriPathMinimal, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
 	min := &ColibriPathMinimal{
 		PacketTimestamp: c.PacketTimestamp,
 		InfoField:       c.InfoField.Clone(),
@@ -231,6 +385,49 @@ func (c *ColibriPath) ToMinimal() (*ColibriPathMinimal, error) {
 	return min, err
 }
 
+// Equal compares the timestamp, info field and hop fields of both paths. Two paths are equal
+// if they would be serialized to the same bytes; the Src and Dst endpoints are not compared.
+func (c *ColibriPath) Equal(other *ColibriPath) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	if c.PacketTimestamp != other.PacketTimestamp ||
+		!c.InfoField.Equal(other.InfoField) ||
+		len(c.HopFields) != len(other.HopFields) {
+
+		return false
+	}
+	for i, hf := range c.HopFields {
+		if !hf.Equal(other.HopFields[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualIgnoringDirection returns true if both paths are equal, or if one is the reverse of the
+// other, as e.g. the path of a down-path reservation received with the R flag set.
+// The current hop field is not compared, as it depends on where along the path, and in which
+// direction, the path was observed.
+func (c *ColibriPath) EqualIgnoringDirection(other *ColibriPath) bool {
+	if c == nil || other == nil || c.InfoField == nil || other.InfoField == nil {
+		return c.Equal(other)
+	}
+	o := other.Clone()
+	o.InfoField.CurrHF = c.InfoField.CurrHF
+	if c.Equal(o) {
+		return true
+	}
+	if o.Validate() != nil {
+		return false
+	}
+	if _, err := o.Reverse(); err != nil {
+		return false
+	}
+	o.InfoField.CurrHF = c.InfoField.CurrHF
+	return c.Equal(o)
+}
+
 func (c *ColibriPath) Clone() *ColibriPath {
 	p := &ColibriPath{
 		PacketTimestamp: c.PacketTimestamp,
@@ -15,6 +15,7 @@
 package colibri
 
 import (
+	"encoding/binary"
 	"fmt"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -79,6 +80,19 @@ func (c *ColibriPathMinimal) String() string {
 		c.Src, c.Dst, ID, inf.Ver, inf.HFCount, inf.CurrHF, inf.S, inf.C, inf.R)
 }
 
+// AnonymizedString returns a description of the path that is safe to log, as described in
+// ColibriPath.AnonymizedString.
+func (c *ColibriPathMinimal) AnonymizedString(hashIfaces bool) string {
+	if c == nil || c.InfoField == nil {
+		return "(nil)"
+	}
+	p, err := c.ToColibriPath()
+	if err != nil {
+		return fmt.Sprintf("%s (invalid: %s)", c.String(), err)
+	}
+	return p.AnonymizedString(hashIfaces)
+}
+
 func (c *ColibriPathMinimal) GetInfoField() *InfoField {
 	return c.InfoField
 }
@@ -105,6 +119,10 @@ func (c *ColibriPathMinimal) DecodeFromBytes(b []byte) error {
 	}
 	nrHopFields := int(c.InfoField.HFCount)
 	currHF := int(c.InfoField.CurrHF)
+	if nrHopFields > MaxHopFields {
+		return serrors.New("too many hop fields in colibri path", "HFCount", nrHopFields,
+			"max", MaxHopFields)
+	}
 	if 8+LenInfoField+(nrHopFields*LenHopField) > len(b) {
 		return serrors.New("raw colibri path is smaller than what is " +
 			"indicated by HFCount in the info field")
@@ -124,7 +142,79 @@ func (c *ColibriPathMinimal) DecodeFromBytes(b []byte) error {
 	return nil
 }
 
+// DecodeRawFromBytes validates the COLIBRI path in b and sets it as the Raw buffer, without
+// decoding the timestamp, the info field nor the current hop field. Nothing is allocated, and
+// the fields are read from the Raw buffer with the Raw* accessors. This is meant for the
+// forwarding hot path; use DecodeFromBytes to decode the path for the control plane.
+func (c *ColibriPathMinimal) DecodeRawFromBytes(b []byte) error {
+	if c == nil {
+		return serrors.New("colibri path must not be nil")
+	}
+	if len(b) < LenMinColibri {
+		return serrors.New("raw colibri path too short", "is:", len(b),
+			"needs:", LenMinColibri)
+	}
+	nrHopFields := int(b[8+3])
+	currHF := int(b[8+2])
+	if nrHopFields > MaxHopFields {
+		return serrors.New("too many hop fields in colibri path", "HFCount", nrHopFields,
+			"max", MaxHopFields)
+	}
+	length := 8 + LenInfoField + nrHopFields*LenHopField
+	if length > len(b) {
+		return serrors.New("raw colibri path is smaller than what is " +
+			"indicated by HFCount in the info field")
+	}
+	if currHF >= nrHopFields {
+		return serrors.New("colibri currHF >= nrHopFields", "currHF", currHF,
+			"nrHopFields", nrHopFields)
+	}
+	c.Raw = b[:length]
+	return nil
+}
+
+// RawHFCount returns the number of hop fields of the path, read from the Raw buffer.
+func (c *ColibriPathMinimal) RawHFCount() int {
+	return int(c.Raw[8+3])
+}
+
+// RawCurrHF returns the index of the current hop field, read from the Raw buffer.
+func (c *ColibriPathMinimal) RawCurrHF() int {
+	return int(c.Raw[8+2])
+}
+
+// RawIngress returns the ingress interface of the hop field i, read from the Raw buffer.
+// It panics if i is not less than RawHFCount.
+func (c *ColibriPathMinimal) RawIngress(i int) uint16 {
+	return binary.BigEndian.Uint16(c.rawHopField(i)[:2])
+}
+
+// RawEgress returns the egress interface of the hop field i, read from the Raw buffer.
+// It panics if i is not less than RawHFCount.
+func (c *ColibriPathMinimal) RawEgress(i int) uint16 {
+	return binary.BigEndian.Uint16(c.rawHopField(i)[2:4])
+}
+
+// RawMac returns the MAC of the hop field i. The returned slice points into the Raw buffer
+// and must not be modified. It panics if i is not less than RawHFCount.
+func (c *ColibriPathMinimal) RawMac(i int) []byte {
+	return c.rawHopField(i)[4:LenHopField]
+}
+
+func (c *ColibriPathMinimal) rawHopField(i int) []byte {
+	if i < 0 || i >= c.RawHFCount() {
+		panic(fmt.Sprintf("colibri hop field %d out of range, HFCount %d", i, c.RawHFCount()))
+	}
+	start := 8 + LenInfoField + i*LenHopField
+	return c.Raw[start : start+LenHopField]
+}
+
+// BuildFromHeader decodes the path from b, which must contain the whole path, and takes the
+// source and destination endpoints from the SCION header.
 func (c *ColibriPathMinimal) BuildFromHeader(b []byte, sc *scion.Header) error {
+	if err := checkTruncated(b); err != nil {
+		return err
+	}
 	c.Src = caddr.NewEndpointWithRaw(sc.SrcIA, sc.RawSrcAddr, sc.SrcAddrType, sc.SrcAddrLen)
 	c.Dst = caddr.NewEndpointWithRaw(sc.DstIA, sc.RawDstAddr, sc.DstAddrType, sc.DstAddrLen)
 	err := c.DecodeFromBytes(b)
@@ -150,6 +240,10 @@ func (c *ColibriPathMinimal) SerializeToInternal() error {
 	if c.InfoField.HFCount < 2 {
 		return serrors.New("a colibri path must have at least two hop fields")
 	}
+	if int(c.InfoField.HFCount) > MaxHopFields {
+		return serrors.New("too many hop fields in colibri path", "HFCount",
+			c.InfoField.HFCount, "max", MaxHopFields)
+	}
 	if len(c.Raw) < c.Len() {
 		return serrors.New("internal Raw buffer for ColibriPath too short", "is:", len(c.Raw),
 			"needs:", c.Len())
@@ -175,6 +269,21 @@ func (c *ColibriPathMinimal) SerializeTo(b []byte) error {
 	return nil
 }
 
+// Bytes returns the serialized path with the current values of its timestamp and info field.
+// Unlike SerializeTo, the Raw buffer of the path is not modified.
+func (c *ColibriPathMinimal) Bytes() ([]byte, error) {
+	if c == nil {
+		return nil, serrors.New("colibri path must not be nil")
+	}
+	cp := *c
+	cp.Raw = append([]byte{}, c.Raw...)
+	buff := make([]byte, cp.Len())
+	if err := cp.SerializeTo(buff); err != nil {
+		return nil, err
+	}
+	return buff, nil
+}
+
 func (c *ColibriPathMinimal) SyncWithScionHeader(scion *scion.Header) error {
 
 	// log.Debug("deleteme before colibri path sync", "path", c.String())
@@ -288,7 +397,9 @@ func (c *ColibriPathMinimal) Type() path.Type {
 }
 
 // UpdateCurrHF increases the CurrHF index.
-// The CurrHopField reference is not updated and will still point to the old hop field.
+// If the Raw buffer contains the hop fields, the CurrHopField is decoded from it, so that it
+// corresponds to the new CurrHF. The CurrHopField is overwritten in place, without allocating.
+// The Raw buffer itself is updated only when serializing.
 func (c *ColibriPathMinimal) UpdateCurrHF() error {
 	if c == nil {
 		return serrors.New("colibri path must not be nil")
@@ -301,7 +412,14 @@ func (c *ColibriPathMinimal) UpdateCurrHF() error {
 		return serrors.New("colibri path already at end")
 	}
 	c.InfoField.CurrHF = c.InfoField.CurrHF + 1
-	return nil
+	start := 8 + LenInfoField + int(c.InfoField.CurrHF)*LenHopField
+	if len(c.Raw) < start+LenHopField {
+		return nil
+	}
+	if c.CurrHopField == nil {
+		c.CurrHopField = &HopField{}
+	}
+	return c.CurrHopField.DecodeFromBytes(c.Raw[start : start+LenHopField])
 }
 
 // IsLastHop returns whether the currHF index denotes the last hop.
@@ -309,7 +427,25 @@ func (c *ColibriPathMinimal) IsLastHop() bool {
 	return c.InfoField.CurrHF == c.InfoField.HFCount-1
 }
 
-// ToColibriPath converts ColibriPathMinimal to a ColibriPath.
+// ToFull reconstructs the full COLIBRI path, including all its hop fields, from the minimal
+// one. It is the inverse of ColibriPath.ToMinimal: converting the result back to its minimal
+// form yields the same bytes. Unlike ToColibriPath, the minimal path is not modified.
+func (c *ColibriPathMinimal) ToFull() (*ColibriPath, error) {
+	raw, err := c.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	p := &ColibriPath{}
+	if err := p.DecodeFromBytes(raw); err != nil {
+		return nil, err
+	}
+	p.Src = c.Src
+	p.Dst = c.Dst
+	return p, nil
+}
+
+// ToColibriPath converts ColibriPathMinimal to a ColibriPath. The current values of the
+// timestamp and info field are first serialized to the Raw buffer.
 func (c *ColibriPathMinimal) ToColibriPath() (*ColibriPath, error) {
 	if c == nil {
 		return nil, serrors.New("colibri path must not be nil")
@@ -329,13 +465,24 @@ func (c *ColibriPathMinimal) ToColibriPath() (*ColibriPath, error) {
 	return colibriPath, nil
 }
 
+// Clone returns a deep copy of the path. The fields not set in c, e.g. the endpoints of a
+// path decoded from a packet, are not set in the copy either.
 func (c *ColibriPathMinimal) Clone() *ColibriPathMinimal {
-	return &ColibriPathMinimal{
+	p := &ColibriPathMinimal{
 		PacketTimestamp: c.PacketTimestamp,
 		Raw:             append([]byte{}, c.Raw...),
-		InfoField:       c.InfoField.Clone(),
-		CurrHopField:    c.CurrHopField.Clone(),
-		Src:             c.Src.Clone(),
-		Dst:             c.Dst.Clone(),
 	}
+	if c.InfoField != nil {
+		p.InfoField = c.InfoField.Clone()
+	}
+	if c.CurrHopField != nil {
+		p.CurrHopField = c.CurrHopField.Clone()
+	}
+	if c.Src != nil {
+		p.Src = c.Src.Clone()
+	}
+	if c.Dst != nil {
+		p.Dst = c.Dst.Clone()
+	}
+	return p
 }
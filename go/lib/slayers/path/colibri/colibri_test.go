@@ -15,14 +15,24 @@
 package colibri_test
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/scionproto/scion/go/lib/addr"
+	libcolibri "github.com/scionproto/scion/go/lib/colibri/dataplane"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	caddr "github.com/scionproto/scion/go/lib/slayers/path/colibri/addr"
+	"github.com/scionproto/scion/go/lib/slayers/scion"
 )
 
 func TestColibriSerializeDecode(t *testing.T) {
@@ -51,6 +61,101 @@ func TestColibriSerializeDecode(t *testing.T) {
 	}
 }
 
+func TestColibriLen(t *testing.T) {
+	const lenPadding = 16
+	for _, hfCount := range []int{1, 3, 7} {
+		p := newColibriPath()
+		p.HopFields = make([]*colibri.HopField, hfCount)
+		for i := range p.HopFields {
+			p.HopFields[i] = &colibri.HopField{
+				IngressId: uint16(2 * i),
+				EgressId:  uint16(2*i + 1),
+				Mac:       []byte{1, 2, 3, 4},
+			}
+		}
+		p.InfoField.HFCount = uint8(hfCount)
+		p.InfoField.CurrHF = 0
+
+		// the serialization must write exactly p.Len() bytes
+		buff := bytes.Repeat([]byte{0xff}, p.Len()+lenPadding)
+		require.NoError(t, p.SerializeTo(buff), "hop fields: %d", hfCount)
+		require.Equal(t, bytes.Repeat([]byte{0xff}, lenPadding), buff[p.Len():],
+			"hop fields: %d", hfCount)
+		require.Error(t, p.SerializeTo(buff[:p.Len()-1]), "hop fields: %d", hfCount)
+		require.Equal(t, 8+colibri.LenInfoField+hfCount*colibri.LenHopField, p.Len(),
+			"hop fields: %d", hfCount)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]struct {
+		mutate         func(p *colibri.ColibriPath)
+		expectedCount  uint8
+		expectedCurrHF uint8
+	}{
+		"unchanged": {
+			mutate:         func(p *colibri.ColibriPath) {},
+			expectedCount:  5,
+			expectedCurrHF: 1,
+		},
+		"added_hop": {
+			mutate: func(p *colibri.ColibriPath) {
+				p.HopFields = append(p.HopFields, &colibri.HopField{Mac: make([]byte, 4)})
+			},
+			expectedCount:  6,
+			expectedCurrHF: 1,
+		},
+		"removed_hops": {
+			mutate: func(p *colibri.ColibriPath) {
+				p.HopFields = p.HopFields[:3]
+			},
+			expectedCount:  3,
+			expectedCurrHF: 1,
+		},
+		"removed_current_hop": {
+			mutate: func(p *colibri.ColibriPath) {
+				p.InfoField.CurrHF = 4
+				p.HopFields = p.HopFields[:2]
+			},
+			expectedCount:  2,
+			expectedCurrHF: 1,
+		},
+		"removed_all_hops": {
+			mutate: func(p *colibri.ColibriPath) {
+				p.HopFields = nil
+			},
+			expectedCount:  0,
+			expectedCurrHF: 0,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := newColibriPath()
+			tc.mutate(p)
+			p.Normalize()
+			require.Equal(t, tc.expectedCount, p.InfoField.HFCount)
+			require.Equal(t, tc.expectedCurrHF, p.InfoField.CurrHF)
+			if len(p.HopFields) < 2 {
+				return // not a valid path, it cannot be decoded
+			}
+			require.NoError(t, p.Validate())
+
+			// serializing also normalizes the path, thus it can be decoded again
+			p = newColibriPath()
+			tc.mutate(p)
+			buff := make([]byte, p.Len())
+			require.NoError(t, p.SerializeTo(buff))
+			decoded := &colibri.ColibriPath{}
+			require.NoError(t, decoded.DecodeFromBytes(buff))
+			require.Equal(t, tc.expectedCount, decoded.InfoField.HFCount)
+			require.Equal(t, tc.expectedCurrHF, decoded.InfoField.CurrHF)
+			require.Len(t, decoded.HopFields, len(p.HopFields))
+		})
+	}
+}
+
 func TestColibriReverse(t *testing.T) {
 	colPath := newColibriPath()
 	// use the colPath colibri path but chop it to hfCount hop fields:
@@ -111,6 +216,633 @@ func TestSerializeToBytes(t *testing.T) {
 	require.Equal(t, min, got)
 }
 
+func TestMinimalBytes(t *testing.T) {
+	min, err := newColibriPath().ToMinimal()
+	require.NoError(t, err)
+	raw := append([]byte{}, min.Raw...)
+
+	buff, err := min.Bytes()
+	require.NoError(t, err)
+	require.Len(t, buff, min.Len())
+	require.Equal(t, raw, buff)
+
+	// modify the path in place, as SyncWithScionHeader does
+	min.InfoField.OrigPayLen = 42
+	buff, err = min.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, raw, min.Raw, "Bytes must not modify the Raw buffer")
+	got := &colibri.ColibriPathMinimal{}
+	require.NoError(t, got.DecodeFromBytes(buff))
+	require.Equal(t, min.InfoField, got.InfoField)
+	require.Equal(t, min.CurrHopField, got.CurrHopField)
+
+	// incomplete path
+	_, err = (&colibri.ColibriPathMinimal{}).Bytes()
+	require.Error(t, err)
+}
+
+func TestMinimalForwardingStep(t *testing.T) {
+	full := newColibriPath()
+	full.InfoField.CurrHF = 0
+	srcIA, err := addr.ParseIA("1-ff00:0:1")
+	require.NoError(t, err)
+	dstIA, err := addr.ParseIA("1-ff00:0:2")
+	require.NoError(t, err)
+	full.Src = caddr.NewEndpointWithIP(srcIA, net.ParseIP("10.0.0.1"))
+	full.Dst = caddr.NewEndpointWithIP(dstIA, net.ParseIP("10.0.0.2"))
+	raw := make([]byte, full.Len())
+	require.NoError(t, full.SerializeTo(raw))
+
+	min := &colibri.ColibriPathMinimal{}
+	require.NoError(t, min.DecodeFromBytes(raw))
+	min.Src, min.Dst = full.Src, full.Dst
+	for curr := 1; curr < len(full.HopFields); curr++ {
+		// forwarding step
+		require.NoError(t, min.UpdateCurrHF())
+		require.Equal(t, full.HopFields[curr], min.GetCurrentHopField())
+		require.NoError(t, min.SyncWithScionHeader(&scion.Header{PayloadLen: 100}))
+		buff := make([]byte, min.Len())
+		require.NoError(t, min.SerializeTo(buff))
+
+		// the wire bytes reflect the advanced CurrHF, and nothing else but the info field
+		got := &colibri.ColibriPath{}
+		require.NoError(t, got.DecodeFromBytes(buff))
+		require.Equal(t, uint8(curr), got.InfoField.CurrHF)
+		require.Equal(t, full.InfoField.HFCount, got.InfoField.HFCount)
+		require.Len(t, got.HopFields, int(got.InfoField.HFCount))
+		require.Equal(t, full.HopFields, got.HopFields)
+		require.Equal(t, uint16(100), got.InfoField.OrigPayLen)
+
+		gotMin := &colibri.ColibriPathMinimal{}
+		require.NoError(t, gotMin.DecodeFromBytes(buff))
+		require.Equal(t, min.InfoField, gotMin.InfoField)
+		require.Equal(t, full.HopFields[curr], gotMin.CurrHopField)
+	}
+	// already at the last hop
+	require.Error(t, min.UpdateCurrHF())
+	require.Equal(t, full.InfoField.HFCount-1, min.InfoField.CurrHF)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		min.InfoField.CurrHF = 0
+		_ = min.UpdateCurrHF()
+	})
+	require.Zero(t, allocs)
+	require.Equal(t, full.HopFields[1], min.GetCurrentHopField())
+}
+
+func TestMinimalRawAccessors(t *testing.T) {
+	full := newColibriPath()
+	raw := make([]byte, full.Len())
+	require.NoError(t, full.SerializeTo(raw))
+	decoded := &colibri.ColibriPath{}
+	require.NoError(t, decoded.DecodeFromBytes(raw))
+	decodedMin := &colibri.ColibriPathMinimal{}
+	require.NoError(t, decodedMin.DecodeFromBytes(raw))
+
+	min := &colibri.ColibriPathMinimal{}
+	require.NoError(t, min.DecodeRawFromBytes(raw))
+	require.Equal(t, decodedMin.Raw, min.Raw)
+	require.Equal(t, int(decoded.InfoField.HFCount), min.RawHFCount())
+	require.Equal(t, int(decoded.InfoField.CurrHF), min.RawCurrHF())
+	for i, hf := range decoded.HopFields {
+		require.Equal(t, hf.IngressId, min.RawIngress(i), "hop field %d", i)
+		require.Equal(t, hf.EgressId, min.RawEgress(i), "hop field %d", i)
+		require.Equal(t, hf.Mac, min.RawMac(i), "hop field %d", i)
+	}
+	curr := min.RawCurrHF()
+	require.Equal(t, decodedMin.CurrHopField, &colibri.HopField{
+		IngressId: min.RawIngress(curr),
+		EgressId:  min.RawEgress(curr),
+		Mac:       min.RawMac(curr),
+	})
+	require.Panics(t, func() { min.RawIngress(min.RawHFCount()) })
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = min.DecodeRawFromBytes(raw)
+		_ = min.RawIngress(min.RawCurrHF())
+		_ = min.RawEgress(min.RawCurrHF())
+		_ = min.RawMac(min.RawCurrHF())
+	})
+	require.Zero(t, allocs)
+
+	// the same validation as DecodeFromBytes
+	require.Error(t, min.DecodeRawFromBytes(raw[:colibri.LenMinColibri-1]))
+	require.Error(t, min.DecodeRawFromBytes(raw[:len(raw)-1]))
+	invalid := append([]byte{}, raw...)
+	invalid[8+2] = invalid[8+3] // CurrHF == HFCount
+	require.Error(t, min.DecodeRawFromBytes(invalid))
+	require.Error(t, (&colibri.ColibriPathMinimal{}).DecodeFromBytes(invalid))
+}
+
+func BenchmarkMinimalDecodeFromBytes(b *testing.B) {
+	raw := make([]byte, newColibriPath().Len())
+	require.NoError(b, newColibriPath().SerializeTo(raw))
+	min := &colibri.ColibriPathMinimal{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = min.DecodeFromBytes(raw)
+		_ = min.CurrHopField.IngressId
+		_ = min.CurrHopField.EgressId
+		_ = min.CurrHopField.Mac
+	}
+}
+
+func BenchmarkMinimalUpdateCurrHF(b *testing.B) {
+	raw := make([]byte, newColibriPath().Len())
+	require.NoError(b, newColibriPath().SerializeTo(raw))
+	min := &colibri.ColibriPathMinimal{}
+	require.NoError(b, min.DecodeFromBytes(raw))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		min.InfoField.CurrHF = 0
+		_ = min.UpdateCurrHF()
+	}
+}
+
+func BenchmarkMinimalDecodeRawFromBytes(b *testing.B) {
+	raw := make([]byte, newColibriPath().Len())
+	require.NoError(b, newColibriPath().SerializeTo(raw))
+	min := &colibri.ColibriPathMinimal{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = min.DecodeRawFromBytes(raw)
+		curr := min.RawCurrHF()
+		_ = min.RawIngress(curr)
+		_ = min.RawEgress(curr)
+		_ = min.RawMac(curr)
+	}
+}
+
+func TestMinimalToFull(t *testing.T) {
+	cases := map[string]struct {
+		hfCount int
+		currHF  uint8
+	}{
+		"two_hops_first":  {hfCount: 2, currHF: 0},
+		"two_hops_last":   {hfCount: 2, currHF: 1},
+		"five_hops_mid":   {hfCount: 5, currHF: 2},
+		"max_hops_last":   {hfCount: colibri.MaxHopFields, currHF: colibri.MaxHopFields - 1},
+		"max_hops_middle": {hfCount: colibri.MaxHopFields, currHF: 17},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := newColibriPathWithHopFields(tc.hfCount)
+			p.InfoField.CurrHF = tc.currHF
+			srcIA, err := addr.ParseIA("1-ff00:0:111")
+			require.NoError(t, err)
+			dstIA, err := addr.ParseIA("1-ff00:0:112")
+			require.NoError(t, err)
+			p.Src = caddr.NewEndpointWithIP(srcIA, net.ParseIP("10.1.1.1"))
+			p.Dst = caddr.NewEndpointWithIP(dstIA, net.ParseIP("10.2.2.2"))
+			min, err := p.ToMinimal()
+			require.NoError(t, err)
+			raw := append([]byte{}, min.Raw...)
+
+			full, err := min.ToFull()
+			require.NoError(t, err)
+			require.True(t, p.Equal(full))
+			require.Equal(t, p.Src, full.Src)
+			require.Equal(t, p.Dst, full.Dst)
+			require.Equal(t, raw, min.Raw, "ToFull must not modify the minimal path")
+
+			// round trip back to the minimal form, byte identical
+			min2, err := full.ToMinimal()
+			require.NoError(t, err)
+			require.Equal(t, raw, min2.Raw)
+			require.Equal(t, min, min2)
+		})
+	}
+
+	_, err := (*colibri.ColibriPathMinimal)(nil).ToFull()
+	require.Error(t, err)
+}
+
+func TestMinimalClone(t *testing.T) {
+	p := newColibriPathWithHopFields(3)
+	srcIA, err := addr.ParseIA("1-ff00:0:111")
+	require.NoError(t, err)
+	dstIA, err := addr.ParseIA("1-ff00:0:112")
+	require.NoError(t, err)
+	p.Src = caddr.NewEndpointWithIP(srcIA, net.ParseIP("10.1.1.1"))
+	p.Dst = caddr.NewEndpointWithIP(dstIA, net.ParseIP("10.2.2.2"))
+	min, err := p.ToMinimal()
+	require.NoError(t, err)
+
+	c := min.Clone()
+	require.Equal(t, min, c)
+	require.NotSame(t, min.InfoField, c.InfoField)
+	require.NotSame(t, min.CurrHopField, c.CurrHopField)
+	require.NotSame(t, min.Src, c.Src)
+	require.NotSame(t, min.Dst, c.Dst)
+
+	// without endpoints, e.g. as decoded from a packet
+	min.Src, min.Dst = nil, nil
+	c = min.Clone()
+	require.Equal(t, min, c)
+	require.Nil(t, c.Src)
+	require.Nil(t, c.Dst)
+	_, err = c.ReverseAsColibri()
+	require.NoError(t, err)
+
+	// nothing set
+	c = (&colibri.ColibriPathMinimal{}).Clone()
+	require.Nil(t, c.InfoField)
+	require.Nil(t, c.CurrHopField)
+}
+
+func TestColibriEqual(t *testing.T) {
+	cases := map[string]struct {
+		modify   func(p *colibri.ColibriPath)
+		expected bool
+	}{
+		"same": {
+			modify:   func(p *colibri.ColibriPath) {},
+			expected: true,
+		},
+		"short_suffix": {
+			modify: func(p *colibri.ColibriPath) {
+				// serialized the same as the 12 zero bytes of the original suffix
+				p.InfoField.ResIdSuffix = []byte{0, 0, 0}
+			},
+			expected: true,
+		},
+		"different_mac": {
+			modify: func(p *colibri.ColibriPath) {
+				p.HopFields[2].Mac = []byte{0xff, 0xff, 0xff, 0xff}
+			},
+		},
+		"different_ingress": {
+			modify: func(p *colibri.ColibriPath) {
+				p.HopFields[0].IngressId++
+			},
+		},
+		"different_timestamp": {
+			modify: func(p *colibri.ColibriPath) {
+				p.PacketTimestamp[0] = 1
+			},
+		},
+		"different_info_field": {
+			modify: func(p *colibri.ColibriPath) {
+				p.InfoField.BwCls++
+			},
+		},
+		"fewer_hop_fields": {
+			modify: func(p *colibri.ColibriPath) {
+				p.HopFields = p.HopFields[:len(p.HopFields)-1]
+			},
+		},
+		"nil_info_field": {
+			modify: func(p *colibri.ColibriPath) {
+				p.InfoField = nil
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := newColibriPath()
+			other := newColibriPath()
+			tc.modify(other)
+			require.Equal(t, tc.expected, p.Equal(other))
+			require.Equal(t, tc.expected, other.Equal(p))
+		})
+	}
+
+	// a decoded path equals the original one, regardless of the buffers
+	p := newColibriPath()
+	buff := make([]byte, p.Len())
+	require.NoError(t, p.SerializeTo(buff))
+	decoded := &colibri.ColibriPath{}
+	require.NoError(t, decoded.DecodeFromBytes(buff))
+	require.True(t, p.Equal(decoded))
+	require.True(t, (*colibri.ColibriPath)(nil).Equal(nil))
+	require.False(t, p.Equal(nil))
+}
+
+func TestColibriEqualIgnoringDirection(t *testing.T) {
+	reverse := func(p *colibri.ColibriPath) {
+		_, err := p.Reverse()
+		require.NoError(t, err)
+	}
+	cases := map[string]struct {
+		modify   func(p *colibri.ColibriPath)
+		expected bool
+	}{
+		"same": {
+			modify:   func(p *colibri.ColibriPath) {},
+			expected: true,
+		},
+		"reversed": {
+			modify:   reverse,
+			expected: true,
+		},
+		"reversed_twice": {
+			modify: func(p *colibri.ColibriPath) {
+				reverse(p)
+				reverse(p)
+			},
+			expected: true,
+		},
+		"other_curr_hf": {
+			modify: func(p *colibri.ColibriPath) {
+				p.InfoField.CurrHF = p.InfoField.HFCount - 1
+			},
+			expected: true,
+		},
+		"reversed_other_curr_hf": {
+			modify: func(p *colibri.ColibriPath) {
+				reverse(p)
+				p.InfoField.CurrHF = 0
+			},
+			expected: true,
+		},
+		"only_r_flag": {
+			modify: func(p *colibri.ColibriPath) {
+				p.InfoField.R = !p.InfoField.R
+			},
+		},
+		"only_hop_fields_reversed": {
+			modify: func(p *colibri.ColibriPath) {
+				reverse(p)
+				p.InfoField.R = !p.InfoField.R
+			},
+		},
+		"reversed_different_mac": {
+			modify: func(p *colibri.ColibriPath) {
+				reverse(p)
+				p.HopFields[2].Mac = []byte{0xff, 0xff, 0xff, 0xff}
+			},
+		},
+		"reversed_different_info_field": {
+			modify: func(p *colibri.ColibriPath) {
+				reverse(p)
+				p.InfoField.BwCls++
+			},
+		},
+		"nil_info_field": {
+			modify: func(p *colibri.ColibriPath) {
+				p.InfoField = nil
+			},
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := newColibriPath()
+			other := newColibriPath()
+			tc.modify(other)
+			require.Equal(t, tc.expected, p.EqualIgnoringDirection(other))
+			require.Equal(t, tc.expected, other.EqualIgnoringDirection(p))
+		})
+	}
+	require.True(t, (*colibri.ColibriPath)(nil).EqualIgnoringDirection(nil))
+	require.False(t, newColibriPath().EqualIgnoringDirection(nil))
+}
+
+func TestBuildFromHeaderTruncated(t *testing.T) {
+	p := newColibriPath()
+	raw := make([]byte, p.Len())
+	require.NoError(t, p.SerializeTo(raw))
+	inflated := append([]byte{}, raw...)
+	inflated[8+3] = colibri.MaxHopFields // HFCount
+
+	cases := map[string]struct {
+		raw         []byte
+		expectedErr error // nil if no error is expected
+	}{
+		"complete": {
+			raw: raw,
+		},
+		"empty": {
+			raw:         []byte{},
+			expectedErr: colibri.ErrTruncated,
+		},
+		"only_timestamp": {
+			raw:         raw[:8],
+			expectedErr: colibri.ErrTruncated,
+		},
+		"no_hop_fields": {
+			raw:         raw[:8+colibri.LenInfoField],
+			expectedErr: colibri.ErrTruncated,
+		},
+		"missing_last_hop_field": {
+			raw:         raw[:len(raw)-colibri.LenHopField],
+			expectedErr: colibri.ErrTruncated,
+		},
+		"missing_last_byte": {
+			raw:         raw[:len(raw)-1],
+			expectedErr: colibri.ErrTruncated,
+		},
+		"inflated_hf_count": {
+			raw:         inflated,
+			expectedErr: colibri.ErrTruncated,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			paths := map[string]interface {
+				BuildFromHeader([]byte, *scion.Header) error
+			}{
+				"full":    &colibri.ColibriPath{},
+				"minimal": &colibri.ColibriPathMinimal{},
+			}
+			for kind, p := range paths {
+				err := p.BuildFromHeader(tc.raw, &scion.Header{})
+				if tc.expectedErr == nil {
+					require.NoError(t, err, kind)
+				} else {
+					require.ErrorIs(t, err, tc.expectedErr, kind)
+				}
+			}
+		})
+	}
+
+	// no truncation of the path panics, whatever its HFCount, and those shorter than
+	// indicated by HFCount are reported as truncated
+	for n := 0; n < len(raw); n++ {
+		for hfCount := 0; hfCount <= 0xff; hfCount++ {
+			b := append([]byte{}, raw[:n]...)
+			if n > 8+3 {
+				b[8+3] = byte(hfCount)
+			}
+			errFull := (&colibri.ColibriPath{}).BuildFromHeader(b, &scion.Header{})
+			errMin := (&colibri.ColibriPathMinimal{}).BuildFromHeader(b, &scion.Header{})
+			if n < 8+colibri.LenInfoField+hfCount*colibri.LenHopField {
+				require.ErrorIs(t, errFull, colibri.ErrTruncated, "n: %d, HFCount: %d",
+					n, hfCount)
+				require.ErrorIs(t, errMin, colibri.ErrTruncated, "n: %d, HFCount: %d",
+					n, hfCount)
+			}
+		}
+	}
+}
+
+func TestColibriMaxHopFields(t *testing.T) {
+	cases := map[string]struct {
+		hfCount int
+		valid   bool
+	}{
+		"min":     {hfCount: 2, valid: true},
+		"max":     {hfCount: colibri.MaxHopFields, valid: true},
+		"max_+_1": {hfCount: colibri.MaxHopFields + 1, valid: false},
+		"uint8":   {hfCount: 255, valid: false},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := newColibriPathWithHopFields(tc.hfCount)
+			buff := make([]byte, p.Len())
+			min, err := p.ToMinimal()
+			if !tc.valid {
+				require.Error(t, p.Validate())
+				require.Error(t, p.SerializeTo(buff))
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, p.Validate())
+			require.NoError(t, p.SerializeTo(buff))
+			require.NoError(t, err)
+			require.Equal(t, tc.hfCount, int(min.InfoField.HFCount))
+
+			decoded := &colibri.ColibriPath{}
+			require.NoError(t, decoded.DecodeFromBytes(buff))
+			require.True(t, p.Equal(decoded))
+			decodedMin := &colibri.ColibriPathMinimal{}
+			require.NoError(t, decodedMin.DecodeFromBytes(buff))
+			require.Equal(t, min.Raw, decodedMin.Raw)
+		})
+	}
+
+	// a raw path announcing more than MaxHopFields hop fields must not be decoded
+	p := newColibriPathWithHopFields(colibri.MaxHopFields)
+	buff := make([]byte, p.Len()+colibri.LenHopField)
+	require.NoError(t, p.SerializeTo(buff))
+	buff[8+3] = colibri.MaxHopFields + 1 // HFCount in the info field
+	require.Error(t, (&colibri.ColibriPath{}).DecodeFromBytes(buff))
+	require.Error(t, (&colibri.ColibriPathMinimal{}).DecodeFromBytes(buff))
+
+	// the remaining checks of Validate
+	p = newColibriPath()
+	p.InfoField.HFCount++
+	require.Error(t, p.Validate())
+	p = newColibriPath()
+	p.InfoField.CurrHF = p.InfoField.HFCount
+	require.Error(t, p.Validate())
+	_, err := p.ToMinimal()
+	require.Error(t, err)
+}
+
+func TestSetPacketTimestamp(t *testing.T) {
+	const expTick = 1000
+	expiration := reservation.ExpTickToTime(expTick)
+	cases := map[string]struct {
+		now         time.Time
+		expected    time.Time // decoded time
+		expectStale bool      // not fresh at now for the routers
+	}{
+		"start_of_window": {
+			now:      expiration.Add(-reservation.E2ERsvDuration),
+			expected: expiration.Add(-reservation.E2ERsvDuration),
+		},
+		"within_window": {
+			now:      expiration.Add(-10*time.Second + 123456789*time.Nanosecond),
+			expected: expiration.Add(-10*time.Second + 123456789*time.Nanosecond),
+		},
+		"end_of_window": {
+			now:      expiration,
+			expected: expiration,
+		},
+		"before_window": {
+			now:         expiration.Add(-time.Minute),
+			expected:    expiration.Add(-reservation.E2ERsvDuration),
+			expectStale: true,
+		},
+		"after_window": {
+			now:         expiration.Add(time.Minute),
+			expected:    expiration,
+			expectStale: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := newColibriPath()
+			p.InfoField.ExpTick = expTick
+			binary.BigEndian.PutUint32(p.PacketTimestamp[4:], 0xdeadbeef)
+
+			p.SetPacketTimestamp(tc.now)
+			tsRel, pktID := libcolibri.ParseColibriTimestampCustom(p.PacketTimestamp)
+			require.Equal(t, uint32(0xdeadbeef), pktID, "packet ID must be untouched")
+			decoded := expiration.Add(-reservation.E2ERsvDuration).
+				Add(time.Duration(tsRel) * colibri.TimestampResolution)
+			require.WithinDuration(t, tc.expected, decoded, colibri.TimestampResolution)
+			// the routers check the freshness with the same encoding
+			require.Equal(t, !tc.expectStale,
+				libcolibri.VerifyTimestamp(expTick, p.PacketTimestamp, tc.now))
+		})
+	}
+}
+
+func TestAnonymizedString(t *testing.T) {
+	cases := map[string]struct {
+		hashIfaces bool
+		minimal    bool
+	}{
+		"full":           {},
+		"full_hashed":    {hashIfaces: true},
+		"minimal":        {minimal: true},
+		"minimal_hashed": {minimal: true, hashIfaces: true},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			p := newColibriPath()
+			for i, hf := range p.HopFields {
+				binary.BigEndian.PutUint32(hf.Mac, 0xdeadbe00+uint32(i))
+			}
+			var str string
+			if tc.minimal {
+				min, err := p.ToMinimal()
+				require.NoError(t, err)
+				str = min.AnonymizedString(tc.hashIfaces)
+			} else {
+				str = p.AnonymizedString(tc.hashIfaces)
+			}
+			// no MAC bytes, in any of the usual representations
+			for _, hf := range p.HopFields {
+				require.NotContains(t, str, hex.EncodeToString(hf.Mac))
+				require.NotContains(t, str, fmt.Sprint(hf.Mac))
+			}
+			require.NotContains(t, str, "deadbe")
+			// the structure is kept
+			require.Contains(t, str, "#HFs:5,CurrHF:1")
+			hops := str[strings.LastIndex(str, "[")+1 : strings.LastIndex(str, "]")]
+			require.Len(t, strings.Fields(hops), len(p.HopFields))
+			first := fmt.Sprintf("%d>%d", p.HopFields[0].IngressId, p.HopFields[0].EgressId)
+			hashed := colibri.AnonymizedIfID(p.HopFields[0].IngressId) + ">" +
+				colibri.AnonymizedIfID(p.HopFields[0].EgressId)
+			if tc.hashIfaces {
+				require.Contains(t, str, hashed)
+				require.NotContains(t, hops, first)
+			} else {
+				require.Contains(t, hops, first)
+			}
+		})
+	}
+}
+
 func newColibriPath() *colibri.ColibriPath {
 	p := &colibri.ColibriPath{
 		PacketTimestamp: [8]byte{},
@@ -134,3 +866,17 @@ func newColibriPath() *colibri.ColibriPath {
 	}
 	return p
 }
+
+func newColibriPathWithHopFields(hfCount int) *colibri.ColibriPath {
+	p := newColibriPath()
+	p.InfoField.HFCount = uint8(hfCount)
+	p.HopFields = make([]*colibri.HopField, hfCount)
+	for i := range p.HopFields {
+		p.HopFields[i] = &colibri.HopField{
+			IngressId: uint16(2 * i),
+			EgressId:  uint16(2*i + 1),
+			Mac:       make([]byte, 4),
+		}
+	}
+	return p
+}
@@ -15,6 +15,7 @@
 package colibri
 
 import (
+	"bytes"
 	"encoding/binary"
 
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -31,6 +32,7 @@ type HopField struct {
 	Mac []byte // TODO(juagargi) this ought to be [4]byte instead, remove Clone() method
 }
 
+// DecodeFromBytes decodes the hop field from b. A 4 byte Mac already present in hf is reused.
 func (hf *HopField) DecodeFromBytes(b []byte) error {
 	if hf == nil {
 		return serrors.New("colibri hop field must not be nil")
@@ -40,7 +42,9 @@ func (hf *HopField) DecodeFromBytes(b []byte) error {
 	}
 	hf.IngressId = binary.BigEndian.Uint16(b[:2])
 	hf.EgressId = binary.BigEndian.Uint16(b[2:4])
-	hf.Mac = make([]byte, 4)
+	if len(hf.Mac) != 4 {
+		hf.Mac = make([]byte, 4)
+	}
 	copy(hf.Mac, b[4:8])
 	return nil
 }
@@ -71,6 +75,19 @@ func (hf *HopField) Clone() *HopField {
 	return c
 }
 
+// Equal returns true if both hop fields would be serialized to the same bytes.
+func (hf *HopField) Equal(other *HopField) bool {
+	if hf == nil || other == nil {
+		return hf == other
+	}
+	var mac, otherMac [4]byte
+	copy(mac[:], hf.Mac)
+	copy(otherMac[:], other.Mac)
+	return hf.IngressId == other.IngressId &&
+		hf.EgressId == other.EgressId &&
+		bytes.Equal(mac[:], otherMac[:])
+}
+
 func (hf *HopField) SwapInEg() {
 	hf.IngressId, hf.EgressId = hf.EgressId, hf.IngressId
 }
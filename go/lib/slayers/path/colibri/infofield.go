@@ -15,6 +15,7 @@
 package colibri
 
 import (
+	"bytes"
 	"encoding/binary"
 
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -111,3 +112,24 @@ func (inf *InfoField) Clone() *InfoField {
 	copy(c.ResIdSuffix, inf.ResIdSuffix)
 	return &c
 }
+
+// Equal returns true if both info fields would be serialized to the same bytes.
+func (inf *InfoField) Equal(other *InfoField) bool {
+	if inf == nil || other == nil {
+		return inf == other
+	}
+	var suffix, otherSuffix [LenSuffix]byte
+	copy(suffix[:], inf.ResIdSuffix)
+	copy(otherSuffix[:], other.ResIdSuffix)
+	return inf.C == other.C &&
+		inf.R == other.R &&
+		inf.S == other.S &&
+		inf.Ver == other.Ver &&
+		inf.CurrHF == other.CurrHF &&
+		inf.HFCount == other.HFCount &&
+		bytes.Equal(suffix[:], otherSuffix[:]) &&
+		inf.ExpTick == other.ExpTick &&
+		inf.BwCls == other.BwCls &&
+		inf.Rlc == other.Rlc &&
+		inf.OrigPayLen == other.OrigPayLen
+}
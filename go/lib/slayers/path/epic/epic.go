@@ -78,8 +78,13 @@ func (p *Path) SerializeTo(b []byte) error {
 	return p.ScionPath.SerializeTo(b[MetadataLen:])
 }
 
+// SyncWithScionHeader forwards the call to the SCION path type subheader. The packet ID and the
+// hop validation fields do not depend on the SCION header, and are serialized as set.
 func (p *Path) SyncWithScionHeader(scion *sheader.Header) error {
-	return nil
+	if p.ScionPath == nil {
+		return serrors.New("SCION path is nil")
+	}
+	return p.ScionPath.SyncWithScionHeader(scion)
 }
 
 // DecodeFromBytes deserializes the buffer b into the Path. On failure, an error is returned,
@@ -21,6 +21,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/slayers/path/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
+	sheader "github.com/scionproto/scion/go/lib/slayers/scion"
 )
 
 var (
@@ -186,6 +187,22 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestSyncWithScionHeader(t *testing.T) {
+	p := epic.Path{}
+	assert.NoError(t, p.DecodeFromBytes(rawEpicPath))
+	hdr := &sheader.Header{PayloadLen: 100}
+	assert.NoError(t, p.SyncWithScionHeader(hdr))
+
+	// the EPIC fields are serialized unmodified
+	b := make([]byte, p.Len())
+	assert.NoError(t, p.SerializeTo(b))
+	assert.Equal(t, rawEpicPath, b)
+	assert.Equal(t, epic.PktID{Timestamp: 1, Counter: 0x02000003}, p.PktID)
+
+	p.ScionPath = nil
+	assert.Error(t, p.SyncWithScionHeader(hdr))
+}
+
 func TestReverse(t *testing.T) {
 	testCases := map[string]struct {
 		Path         *epic.Path
@@ -16,6 +16,7 @@ package slayers
 
 import (
 	"encoding/binary"
+	"errors"
 
 	"github.com/google/gopacket"
 
@@ -100,7 +101,7 @@ func (s *SCION) NetworkFlow() gopacket.Flow {
 }
 
 func (s *SCION) SerializeTo(b gopacket.SerializeBuffer, opts gopacket.SerializeOptions) error {
-	scnLen := CmnHdrLen + s.AddrHdrLen() + s.Path.Len()
+	scnLen := s.HeaderLen()
 	buf, err := b.PrependBytes(scnLen)
 	if err != nil {
 		return err
@@ -190,6 +191,9 @@ func (s *SCION) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
 
 	err = s.Path.BuildFromHeader(data[offset:offset+pathLen], &s.Header)
 	if err != nil {
+		if errors.Is(err, colibri.ErrTruncated) {
+			df.SetTruncated()
+		}
 		return err
 	}
 	s.Contents = data[:hdrBytes]
@@ -198,6 +202,31 @@ func (s *SCION) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
 	return nil
 }
 
+// DecodePackets decodes a buffer containing back-to-back SCION packets, e.g. from a packet
+// capture. Each packet is delimited by its header length and payload length: its Payload
+// contains only its own L4 payload, and the next packet starts right after it.
+// It returns an error if any of the packets cannot be decoded or is truncated.
+func DecodePackets(data []byte) ([]*SCION, error) {
+	var pkts []*SCION
+	for offset := 0; offset < len(data); {
+		s := &SCION{}
+		if err := s.DecodeFromBytes(data[offset:], gopacket.NilDecodeFeedback); err != nil {
+			return nil, serrors.WrapStr("decoding packet", err, "packet", len(pkts),
+				"offset", offset)
+		}
+		hdrBytes := int(s.HdrLen) * LineLen
+		pktLen := hdrBytes + int(s.PayloadLen)
+		if len(data)-offset < pktLen {
+			return nil, serrors.New("packet payload is truncated", "packet", len(pkts),
+				"offset", offset, "expected", pktLen, "actual", len(data)-offset)
+		}
+		s.Payload = s.Payload[:s.PayloadLen]
+		pkts = append(pkts, s)
+		offset += pktLen
+	}
+	return pkts, nil
+}
+
 func decodeSCION(data []byte, pb gopacket.PacketBuilder) error {
 	scn := &SCION{}
 	err := scn.DecodeFromBytes(data, pb)
@@ -271,6 +300,22 @@ func (s *SCION) AddrHdrLen() int {
 	return 2*addr.IABytes + addrBytes(s.DstAddrLen) + addrBytes(s.SrcAddrLen)
 }
 
+// HeaderLen returns the length of the SCION header in bytes, i.e., the common header, the address
+// header and the path. The path length is taken from the actual path, as it depends on the number
+// of hop fields for e.g. SCION and colibri paths.
+func (s *SCION) HeaderLen() int {
+	return CmnHdrLen + s.AddrHdrLen() + s.Path.Len()
+}
+
+// EffectiveMTU returns the number of payload bytes that fit in a packet on a link with the given
+// MTU, once the SCION header is accounted for. If the header does not fit, zero is returned.
+func (s *SCION) EffectiveMTU(linkMTU int) int {
+	if mtu := linkMTU - s.HeaderLen(); mtu > 0 {
+		return mtu
+	}
+	return 0
+}
+
 // SerializeAddrHdr serializes destination and source ISD-AS-Host address triples into the provided
 // buffer. The caller must ensure that the correct address types and lengths are set in the SCION
 // layer, otherwise the results of this method are undefined.
@@ -27,7 +27,9 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/slayers/path/empty"
+	"github.com/scionproto/scion/go/lib/slayers/path/epic"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	sheader "github.com/scionproto/scion/go/lib/slayers/scion"
@@ -223,6 +225,234 @@ func TestSCIONSerializeDecode(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestDecodePackets(t *testing.T) {
+	// serializePkt returns a serialized packet with the given L4 payload.
+	serializePkt := func(t *testing.T, flowID uint32, payload []byte) []byte {
+		t.Helper()
+		spkt := prepPacket(t, common.L4UDP)
+		spkt.FlowID = flowID
+		buffer := gopacket.NewSerializeBuffer()
+		require.NoError(t, gopacket.SerializeLayers(buffer,
+			gopacket.SerializeOptions{FixLengths: true}, spkt, gopacket.Payload(payload)))
+		return append([]byte{}, buffer.Bytes()...)
+	}
+	payloads := [][]byte{
+		[]byte("first payload"),
+		{},
+		[]byte("the third payload"),
+	}
+	var pkts [][]byte
+	for i, payload := range payloads {
+		pkts = append(pkts, serializePkt(t, uint32(i+1), payload))
+	}
+	concat := func(pkts ...[]byte) []byte {
+		var data []byte
+		for _, pkt := range pkts {
+			data = append(data, pkt...)
+		}
+		return data
+	}
+
+	cases := map[string]struct {
+		data        []byte
+		expected    [][]byte // the expected payloads
+		expectError bool
+	}{
+		"empty": {
+			data:     nil,
+			expected: nil,
+		},
+		"one": {
+			data:     pkts[0],
+			expected: payloads[:1],
+		},
+		"two": {
+			data:     concat(pkts[0], pkts[1]),
+			expected: payloads[:2],
+		},
+		"three": {
+			data:     concat(pkts...),
+			expected: payloads,
+		},
+		"truncated_payload": {
+			data:        concat(pkts[0], pkts[2][:len(pkts[2])-1]),
+			expectError: true,
+		},
+		"truncated_header": {
+			data:        concat(pkts[0], pkts[1][:slayers.CmnHdrLen-1]),
+			expectError: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			got, err := slayers.DecodePackets(tc.data)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tc.expected))
+			for i, payload := range tc.expected {
+				require.Equal(t, uint32(i+1), got[i].FlowID)
+				require.Equal(t, payload, got[i].Payload)
+				require.Equal(t, xtest.MustParseIA("1-ff00:0:111"), got[i].DstIA)
+			}
+		})
+	}
+}
+
+func TestSCIONSerializeDecodeEPIC(t *testing.T) {
+	want := prepPacket(t, common.L4UDP)
+	scionPath := &scion.Raw{}
+	require.NoError(t, scionPath.DecodeFromBytes(rawPath))
+	want.PathType = epic.PathType
+	want.Path = &epic.Path{
+		PktID: epic.PktID{
+			Timestamp: 0x01020304,
+			Counter:   0x05060708,
+		},
+		PHVF:      []byte{1, 2, 3, 4},
+		LHVF:      []byte{5, 6, 7, 8},
+		ScionPath: scionPath,
+	}
+	buffer := gopacket.NewSerializeBuffer()
+	require.NoError(t, want.SerializeTo(buffer, gopacket.SerializeOptions{FixLengths: true}))
+
+	// the packet ID follows the common and address headers
+	raw := buffer.Bytes()
+	offset := slayers.CmnHdrLen + want.AddrHdrLen()
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, raw[offset:offset+epic.PktIDLen])
+	assert.Equal(t, rawPath, raw[offset+epic.MetadataLen:])
+
+	got := &slayers.SCION{}
+	require.NoError(t, got.DecodeFromBytes(raw, gopacket.NilDecodeFeedback))
+	want.BaseLayer = got.BaseLayer
+	assert.Equal(t, want, got)
+
+	// serializing the decoded packet again yields the same bytes
+	again := gopacket.NewSerializeBuffer()
+	require.NoError(t, got.SerializeTo(again, gopacket.SerializeOptions{FixLengths: true}))
+	assert.Equal(t, raw, again.Bytes())
+}
+
+func TestSCIONDecodeTruncatedColibri(t *testing.T) {
+	colPath := &colibri.ColibriPath{
+		InfoField: &colibri.InfoField{
+			HFCount:     2,
+			ResIdSuffix: make([]byte, colibri.LenSuffix),
+		},
+		HopFields: []*colibri.HopField{
+			{EgressId: 1, Mac: make([]byte, 4)},
+			{IngressId: 2, Mac: make([]byte, 4)},
+		},
+	}
+	rawColPath := make([]byte, colPath.Len())
+	require.NoError(t, colPath.SerializeTo(rawColPath))
+	// replace the SCION path of a packet with the colibri one
+	spkt := prepPacket(t, common.L4UDP)
+	offset := slayers.CmnHdrLen + spkt.AddrHdrLen()
+	raw := append(prepRawPacket(t)[:offset:offset], rawColPath...)
+	raw[5] = uint8(len(raw) / slayers.LineLen) // HdrLen
+	raw[8] = uint8(colibri.PathType)
+
+	cases := map[string]struct {
+		hfCount           uint8
+		expectedTruncated bool
+	}{
+		"complete": {
+			hfCount: 2,
+		},
+		"inflated_hf_count": {
+			hfCount:           3,
+			expectedTruncated: true,
+		},
+		"max_hf_count": {
+			hfCount:           colibri.MaxHopFields,
+			expectedTruncated: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			b := append([]byte{}, raw...)
+			b[offset+8+3] = tc.hfCount
+			df := &truncatedFeedback{}
+			err := (&slayers.SCION{}).DecodeFromBytes(b, df)
+			if tc.expectedTruncated {
+				require.ErrorIs(t, err, colibri.ErrTruncated)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedTruncated, df.truncated)
+		})
+	}
+}
+
+// truncatedFeedback is a gopacket.DecodeFeedback that records whether the packet was truncated.
+type truncatedFeedback struct {
+	truncated bool
+}
+
+func (f *truncatedFeedback) SetTruncated() {
+	f.truncated = true
+}
+
+func TestSCIONHeaderLen(t *testing.T) {
+	// common header (12) + address header with an IPv6 and an IPv4 host (36)
+	const baseLen = 48
+	colibriPath := func(hops int) path.Path {
+		p := &colibri.ColibriPath{
+			InfoField: &colibri.InfoField{HFCount: uint8(hops)},
+			HopFields: make([]*colibri.HopField, hops),
+		}
+		for i := range p.HopFields {
+			p.HopFields[i] = &colibri.HopField{}
+		}
+		return p
+	}
+	cases := map[string]struct {
+		path        path.Path
+		expectedLen int
+	}{
+		"empty": {
+			path:        empty.Path{},
+			expectedLen: baseLen,
+		},
+		"scion": {
+			path:        prepPacket(t, common.L4UDP).Path,
+			expectedLen: baseLen + len(rawPath),
+		},
+		"colibri 2 hops": {
+			path:        colibriPath(2),
+			expectedLen: baseLen + 8 + colibri.LenInfoField + 2*colibri.LenHopField,
+		},
+		"colibri 5 hops": {
+			path:        colibriPath(5),
+			expectedLen: baseLen + 8 + colibri.LenInfoField + 5*colibri.LenHopField,
+		},
+		"colibri max hops": {
+			path: colibriPath(colibri.MaxHopFields),
+			expectedLen: baseLen + 8 + colibri.LenInfoField +
+				colibri.MaxHopFields*colibri.LenHopField,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			s := prepPacket(t, common.L4UDP)
+			s.Path = tc.path
+			require.Equal(t, tc.expectedLen, s.HeaderLen())
+			require.Equal(t, 1500-tc.expectedLen, s.EffectiveMTU(1500))
+			require.Equal(t, 0, s.EffectiveMTU(tc.expectedLen))
+			require.Equal(t, 0, s.EffectiveMTU(tc.expectedLen-1))
+		})
+	}
+}
+
 func TestSetAndGetAddr(t *testing.T) {
 	testCases := map[string]struct {
 		srcAddr net.Addr
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/slayers"
 	"github.com/scionproto/scion/go/lib/slayers/path"
+	"github.com/scionproto/scion/go/lib/slayers/path/empty"
 	"github.com/scionproto/scion/go/lib/slayers/path/onehop"
 	"github.com/scionproto/scion/go/lib/slayers/path/scion"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -86,6 +87,24 @@ func TestPacketSerializeDecodeLoop(t *testing.T) {
 				},
 			},
 		},
+		"UDP empty path packet": {
+			PacketInfo: snet.PacketInfo{
+				Destination: snet.SCIONAddress{
+					IA:   xtest.MustParseIA("1-ff00:0:110"),
+					Host: addr.HostIPv4(net.ParseIP("127.0.0.2").To4()),
+				},
+				Source: snet.SCIONAddress{
+					IA:   xtest.MustParseIA("1-ff00:0:110"),
+					Host: addr.HostIPv4(net.ParseIP("127.0.0.1").To4()),
+				},
+				Path: snetpath.Empty{},
+				Payload: snet.UDPPayload{
+					SrcPort: 25,
+					DstPort: 1925,
+					Payload: []byte("hello packet"),
+				},
+			},
+		},
 		"SCMP EchoRequest": {
 			PacketInfo: snet.PacketInfo{
 				Destination: snet.SCIONAddress{
@@ -234,6 +253,11 @@ func TestPacketSerializeDecodeLoop(t *testing.T) {
 
 func convertRawPath(r *snet.RawPath) (snet.DataplanePath, error) {
 	switch r.PathType {
+	case empty.PathType:
+		if len(r.Raw) != 0 {
+			return nil, serrors.New("non empty raw empty path", "len", len(r.Raw))
+		}
+		return snetpath.Empty{}, nil
 	case scion.PathType:
 		return snetpath.SCION{Raw: r.Raw}, nil
 	case onehop.PathType:
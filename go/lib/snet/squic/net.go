@@ -17,9 +17,11 @@ package squic
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	mrand "math/rand"
 	"net"
+	"os"
 	"sync"
 	"time"
 
@@ -51,6 +53,11 @@ type ConnListener struct {
 
 	ctx    context.Context
 	cancel func()
+
+	// deadlineMtx protects the accept deadline.
+	deadlineMtx sync.Mutex
+	// deadline is the time after which a pending Accept fails. Zero means no deadline.
+	deadline time.Time
 }
 
 // NewConnListener constructs a new listener with the appropriate buffers set.
@@ -65,14 +72,44 @@ func NewConnListener(l quic.Listener) *ConnListener {
 }
 
 // Accept accepts the first stream on a session and wraps it as a net.Conn.
+// If the listener is closed while Accept is blocked, net.ErrClosed is returned, which
+// net.Listener users (e.g. the gRPC server) recognize as the end of serving.
+// If the accept deadline is reached, os.ErrDeadlineExceeded is returned.
 func (l *ConnListener) Accept() (net.Conn, error) {
-	session, err := l.Listener.Accept(l.ctx)
+	ctx := l.ctx
+	if deadline := l.getDeadline(); !deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(l.ctx, deadline)
+		defer cancel()
+	}
+	session, err := l.Listener.Accept(ctx)
 	if err != nil {
+		if l.ctx.Err() != nil {
+			return nil, net.ErrClosed
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, os.ErrDeadlineExceeded
+		}
 		return nil, err
 	}
 	return newAcceptingConn(l.ctx, session), nil
 }
 
+// SetDeadline sets the deadline for pending and future Accept calls.
+// A zero value for t means Accept will not time out.
+func (l *ConnListener) SetDeadline(t time.Time) error {
+	l.deadlineMtx.Lock()
+	defer l.deadlineMtx.Unlock()
+	l.deadline = t
+	return nil
+}
+
+func (l *ConnListener) getDeadline() time.Time {
+	l.deadlineMtx.Lock()
+	defer l.deadlineMtx.Unlock()
+	return l.deadline
+}
+
 // AcceptCtx accepts the first stream on a session and wraps it as a net.Conn. Accepts a context in
 // case the caller doesn't want this to block indefinitely.
 func (l *ConnListener) AcceptCtx(ctx context.Context) (net.Conn, error) {
@@ -83,7 +120,7 @@ func (l *ConnListener) AcceptCtx(ctx context.Context) (net.Conn, error) {
 	return newAcceptingConn(ctx, session), nil
 }
 
-// Close closes the listener.
+// Close closes the listener. Any blocked Accept call is unblocked and returns net.ErrClosed.
 func (l *ConnListener) Close() error {
 	l.cancel()
 	return l.Listener.Close()
@@ -16,7 +16,6 @@ package grpc
 
 import (
 	"context"
-	"fmt"
 	"net"
 	"strconv"
 	"time"
@@ -29,13 +28,12 @@ import (
 	"github.com/scionproto/scion/go/co/reservationstorage"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri"
+	"github.com/scionproto/scion/go/lib/colibri/coliquic"
 	"github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
-	"github.com/scionproto/scion/go/lib/snet"
-	utilp "github.com/scionproto/scion/go/lib/snet/path"
 	"github.com/scionproto/scion/go/lib/util"
 	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
 )
@@ -252,6 +250,47 @@ func (s *ColibriService) ListStitchables(ctx context.Context, msg *colpb.ListSti
 	return translate.PBufStitchableResponse(stitchables), nil
 }
 
+// ListTransportPaths serves the intra AS services, listing the COLIBRI paths of the active
+// segment reservations from this AS to the destination.
+func (s *ColibriService) ListTransportPaths(ctx context.Context,
+	msg *colpb.ListTransportPathsRequest) (*colpb.ListTransportPathsResponse, error) {
+
+	if _, err := checkLocalCaller(ctx); err != nil {
+		return nil, err
+	}
+
+	dstIA := addr.IA(msg.DstIa)
+	rsvs, err := s.Store.GetReservationsAtSource(ctx)
+	if err != nil {
+		log.Info("error colibri store while listing transport paths", "err", err)
+		return &colpb.ListTransportPathsResponse{
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+	now := time.Now()
+	res := &colpb.ListTransportPathsResponse{}
+	for _, r := range rsvs {
+		if len(r.Steps) == 0 || !r.Steps.DstIA().Equal(dstIA) {
+			continue
+		}
+		p, err := r.DeriveColibriPathAtSource()
+		if err != nil {
+			log.Info("error deriving colibri path", "id", r.ID.String(), "err", err)
+			continue
+		}
+		if p == nil || reservation.ExpTickToTime(p.InfoField.ExpTick).Before(now) {
+			continue // no usable index
+		}
+		raw, err := base.ColPathToRaw(p)
+		if err != nil {
+			log.Info("error serializing colibri path", "id", r.ID.String(), "err", err)
+			continue
+		}
+		res.TransportPaths = append(res.TransportPaths, raw)
+	}
+	return res, nil
+}
+
 // SetupReservation serves the intra AS clients, setting up or renewing an E2E reservation.
 func (s *ColibriService) SetupReservation(ctx context.Context, msg *colpb.SetupReservationRequest) (
 	*colpb.SetupReservationResponse, error) {
@@ -446,28 +485,9 @@ func checkLocalCaller(ctx context.Context) (*net.TCPAddr, error) {
 // Beware that the destination field of the colibri address is empty, due to the technical
 // limitation of not being able to recover all fields from the scion address layer.
 func colAddrFromCtx(ctx context.Context) (*colpath.ColibriPathMinimal, error) {
-	logger := log.FromCtx(ctx)
-	gPeer, ok := peer.FromContext(ctx)
-	if !ok {
-		return nil, serrors.New("peer must exist")
-	}
-
-	peer, ok := gPeer.Addr.(*snet.UDPAddr)
-	if !ok {
-		logger.Debug("peer must be *snet.UDPAddr", "actual", fmt.Sprintf("%T", gPeer.Addr))
-		return nil, serrors.New(
-			"peer must be *snet.UDPAddr",
-			"actual",
-			fmt.Sprintf("%T", gPeer),
-		)
-	}
-
-	logger.Debug("deleteme :::::: is this the source of the message???",
-		"ia", peer.IA, "host", peer.Host)
-
-	path, err := utilp.SnetToDataplanePath(peer.Path)
-	if err != nil || path == nil {
-		return nil, serrors.WrapStr("decoding path information", err)
+	path, _, err := coliquic.PeerPath(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	colPath, ok := path.(*colpath.ColibriPathMinimal)
@@ -17,45 +17,73 @@ package grpc
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	base "github.com/scionproto/scion/go/co/reservation"
+	"github.com/scionproto/scion/go/co/reservation/e2e"
 	"github.com/scionproto/scion/go/co/reservation/segment"
 	"github.com/scionproto/scion/go/co/reservation/translate"
 	"github.com/scionproto/scion/go/co/reservationstorage"
 	"github.com/scionproto/scion/go/co/reservationstorage/backend"
+	"github.com/scionproto/scion/go/co/reservationstore"
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/colibri/coliquic"
 	libcol "github.com/scionproto/scion/go/lib/colibri/reservation"
 	"github.com/scionproto/scion/go/lib/log"
 	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/util"
 	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
 )
 
 const newIndexMinDuration = 20 * time.Minute
 
+// Keeper is the set of operations on the kept segment reservations that the debug service
+// can forward to the reservation keeper.
+type Keeper interface {
+	Downgrade(ctx context.Context, id libcol.ID, bw libcol.BWCls) (libcol.IndexNumber, error)
+	Forget(id libcol.ID)
+	Plan() ([]reservationstore.PlanEntry, time.Time)
+	Unmatched(ctx context.Context) []reservationstore.UnmatchedEntry
+}
+
 type debugService struct {
 	now      func() time.Time
 	DB       backend.DB
 	Operator *coliquic.ServiceClientOperator
 	Topo     *topology.Loader
 	Store    reservationstorage.Store
+	Keeper   Keeper
+	// DebugCommands enables the commands that alter the reservations only for testing,
+	// e.g. CmdIndexExpire.
+	DebugCommands bool
+	// ReadOnly makes the commands that modify the reservations fail, e.g. CmdIndexNew.
+	ReadOnly bool
 }
 
+// errReadOnly is returned by the commands that modify the reservations in a read-only instance.
+var errReadOnly = status.Error(codes.FailedPrecondition,
+	"read-only instance, reservations cannot be modified")
+
+// errNoKeeper is returned by the commands that need the reservation keeper, if there is none.
+var errNoKeeper = status.Error(codes.Unavailable, "the reservation keeper is not available")
+
 var _ colpb.ColibriDebugCommandsServiceServer = (*debugService)(nil)
 var _ colpb.ColibriDebugServiceServer = (*debugService)(nil)
 
 func NewDebugService(db backend.DB, operator *coliquic.ServiceClientOperator,
-	topo *topology.Loader, store reservationstorage.Store) *debugService {
+	topo *topology.Loader, store reservationstorage.Store, keeper Keeper) *debugService {
 	return &debugService{
 		now:      time.Now,
 		DB:       db,
 		Operator: operator,
 		Topo:     topo,
 		Store:    store,
+		Keeper:   keeper,
 	}
 }
 
@@ -65,10 +93,7 @@ func (s *debugService) CmdTraceroute(ctx context.Context, req *colpb.CmdTracerou
 	localIA := s.Topo.IA()
 	errF := func(err error) (*colpb.CmdTracerouteResponse, error) {
 		return &colpb.CmdTracerouteResponse{
-			ErrorFound: &colpb.ErrorInIA{
-				Ia:      uint64(localIA),
-				Message: err.Error(),
-			},
+			ErrorFound: errorInIA(localIA, err),
 		}, nil
 	}
 	rsv, err := s.getSegR(ctx, req.Id)
@@ -110,13 +135,14 @@ func (s *debugService) CmdIndexNew(ctx context.Context, req *colpb.CmdIndexNewRe
 	localIA := s.Topo.IA()
 	errF := func(err error) (*colpb.CmdIndexNewResponse, error) {
 		return &colpb.CmdIndexNewResponse{
-			ErrorFound: &colpb.ErrorInIA{
-				Ia:      uint64(localIA),
-				Message: err.Error(),
-			},
+			ErrorFound: errorInIA(localIA, err),
 		}, nil
 	}
 
+	if s.ReadOnly && !req.DryRun {
+		return errF(errReadOnly)
+	}
+
 	rsv, err := s.getSegR(ctx, req.Id)
 	if err != nil {
 		return errF(err)
@@ -153,16 +179,33 @@ func (s *debugService) CmdIndexNew(ctx context.Context, req *colpb.CmdIndexNewRe
 		TransportPath:  rsv.TransportPath,
 		Reservation:    rsv,
 	}
+	if req.DryRun {
+		// only compute the admission here, neither persisting nor contacting other ASes
+		bw, err := s.Store.DryRunSegmentReservation(ctx, renewReq)
+		if errors.Is(err, reservationstore.ErrAdmissionDenied) {
+			return errF(status.Errorf(codes.PermissionDenied, "new index (dry run): %v", err))
+		}
+		if err != nil {
+			return errF(status.Errorf(codes.Internal, "new index (dry run): %v", err))
+		}
+		return &colpb.CmdIndexNewResponse{
+			Index:   uint32(renewReq.Index),
+			AllocBw: uint32(bw),
+		}, nil
+	}
 	err = s.Store.InitSegmentReservation(ctx, renewReq)
+	if errors.Is(err, reservationstore.ErrAdmissionDenied) {
+		return errF(status.Errorf(codes.PermissionDenied, "new index: %v", err))
+	}
 	if err != nil {
-		return errF(err)
+		return errF(status.Errorf(codes.Internal, "new index: %v", err))
 	}
 	// reload the reservation
 	rsv = renewReq.Reservation
 
 	// confirm index
 	if err := rsv.SetIndexConfirmed(renewReq.Index); err != nil {
-		return errF(err)
+		return errF(status.Errorf(codes.Internal, "confirming index locally: %v", err))
 	}
 	confirmReq := base.NewRequest(s.now(), &renewReq.ID, renewReq.Index, len(renewReq.Steps))
 	steps := renewReq.Steps
@@ -191,33 +234,90 @@ func (s *debugService) CmdIndexActivate(ctx context.Context, req *colpb.CmdIndex
 	localIA := s.Topo.IA()
 	errF := func(err error) (*colpb.CmdIndexActivateResponse, error) {
 		return &colpb.CmdIndexActivateResponse{
-			ErrorFound: &colpb.ErrorInIA{
-				Ia:      uint64(localIA),
-				Message: err.Error(),
-			},
+			ErrorFound: errorInIA(localIA, err),
 		}, nil
 	}
 
+	if s.ReadOnly {
+		return errF(errReadOnly)
+	}
+
 	rsv, err := s.getSegR(ctx, req.Id)
 	if err != nil {
 		return errF(err)
 	}
 	if req.Index > 15 {
-		return errF(status.Errorf(codes.Internal,
+		return errF(status.Errorf(codes.InvalidArgument,
 			"bad index number %d, not between 0 and 15", req.Index))
 	}
 
-	activateReq := base.NewRequest(s.now(), &rsv.ID, libcol.IndexNumber(req.Index), len(rsv.Steps))
+	if err := s.activateIndex(ctx, rsv, libcol.IndexNumber(req.Index)); err != nil {
+		return errF(err)
+	}
+	return &colpb.CmdIndexActivateResponse{}, nil
+}
+
+// CmdIndexActivateAll activates the next index of each segment reservation initiated by this
+// AS, or only of those ending at the requested IA. The reservations without an index to
+// activate are skipped. A failed activation does not stop the others, and is reported in the
+// result of its reservation.
+func (s *debugService) CmdIndexActivateAll(ctx context.Context,
+	req *colpb.CmdIndexActivateAllRequest) (*colpb.CmdIndexActivateAllResponse, error) {
+
+	localIA := s.Topo.IA()
+	errF := func(err error) (*colpb.CmdIndexActivateAllResponse, error) {
+		return &colpb.CmdIndexActivateAllResponse{
+			ErrorFound: errorInIA(localIA, err),
+		}, nil
+	}
+
+	if s.ReadOnly {
+		return errF(errReadOnly)
+	}
+
+	rsvs, err := s.DB.GetAllSegmentRsvs(ctx)
+	if err != nil {
+		return errF(status.Errorf(codes.Internal, "retrieving segment reservations: %v", err))
+	}
+	dstIA := addr.IA(req.DstIa)
+	res := &colpb.CmdIndexActivateAllResponse{}
+	for _, rsv := range rsvs {
+		if rsv.ID.ASID != localIA.AS() || len(rsv.Steps) == 0 ||
+			(dstIA != 0 && rsv.Steps.DstIA() != dstIA) {
+
+			continue
+		}
+		idx := rsv.NextIndexToActivate()
+		if idx == nil {
+			continue
+		}
+		result := &colpb.IndexActivateResult{
+			Id:    translate.PBufID(&rsv.ID),
+			Index: uint32(idx.Idx),
+		}
+		if err := s.activateIndex(ctx, rsv, idx.Idx); err != nil {
+			result.ErrorFound = errorInIA(localIA, err)
+		}
+		res.Results = append(res.Results, result)
+	}
+	return res, nil
+}
+
+// activateIndex initiates the activation of the index of the segment reservation along
+// its path.
+func (s *debugService) activateIndex(ctx context.Context, rsv *segment.Reservation,
+	idx libcol.IndexNumber) error {
+
+	activateReq := base.NewRequest(s.now(), &rsv.ID, idx, len(rsv.Steps))
 	res, err := s.Store.InitActivateSegmentReservation(ctx, activateReq, rsv.Steps, rsv.Transport())
 	if err != nil {
-		return errF(status.Errorf(codes.Internal,
-			"activating index: %v", err))
+		return status.Errorf(codes.Internal, "activating index: %v", err)
 	}
 	if !res.Success() {
-		return errF(status.Errorf(codes.Internal,
-			"failed response: %s", res.(*base.ResponseFailure).Message))
+		return status.Errorf(codes.Internal,
+			"failed response: %s", res.(*base.ResponseFailure).Message)
 	}
-	return &colpb.CmdIndexActivateResponse{}, nil
+	return nil
 }
 
 func (s *debugService) CmdIndexCleanup(ctx context.Context, req *colpb.CmdIndexCleanupRequest,
@@ -226,13 +326,14 @@ func (s *debugService) CmdIndexCleanup(ctx context.Context, req *colpb.CmdIndexC
 	localIA := s.Topo.IA()
 	errF := func(err error) (*colpb.CmdIndexCleanupResponse, error) {
 		return &colpb.CmdIndexCleanupResponse{
-			ErrorFound: &colpb.ErrorInIA{
-				Ia:      uint64(localIA),
-				Message: err.Error(),
-			},
+			ErrorFound: errorInIA(localIA, err),
 		}, nil
 	}
 
+	if s.ReadOnly {
+		return errF(errReadOnly)
+	}
+
 	rsv, err := s.getSegR(ctx, req.Id)
 	if err != nil {
 		return errF(err)
@@ -255,6 +356,441 @@ func (s *debugService) CmdIndexCleanup(ctx context.Context, req *colpb.CmdIndexC
 	return &colpb.CmdIndexCleanupResponse{}, nil
 }
 
+// CmdIndexExpire sets the expiration of the index to the past and runs the expirer, which
+// removes it from the store. It is only available if the debug commands are enabled.
+func (s *debugService) CmdIndexExpire(ctx context.Context, req *colpb.CmdIndexExpireRequest,
+) (*colpb.CmdIndexExpireResponse, error) {
+
+	localIA := s.Topo.IA()
+	errF := func(err error) (*colpb.CmdIndexExpireResponse, error) {
+		return &colpb.CmdIndexExpireResponse{
+			ErrorFound: errorInIA(localIA, err),
+		}, nil
+	}
+
+	if s.ReadOnly {
+		return errF(errReadOnly)
+	}
+
+	if !s.DebugCommands {
+		return errF(status.Errorf(codes.Unimplemented,
+			"expiring indices requires the debug commands to be enabled"))
+	}
+	if req.Index > 15 {
+		return errF(status.Errorf(codes.InvalidArgument,
+			"bad index number %d, not between 0 and 15", req.Index))
+	}
+	rsv, err := s.getSegR(ctx, req.Id)
+	if err != nil {
+		return errF(err)
+	}
+	index := rsv.Index(libcol.IndexNumber(req.Index))
+	if index == nil {
+		return errF(status.Errorf(codes.NotFound, "index %d not found in %s",
+			req.Index, rsv.ID))
+	}
+	now := s.now()
+	expiration := now.Add(-time.Second)
+	index.Expiration = expiration
+	index.Token.ExpirationTick = libcol.TickFromTime(expiration)
+	if err := s.DB.PersistSegmentRsv(ctx, rsv); err != nil {
+		return errF(status.Errorf(codes.Internal, "storing the expired index: %v", err))
+	}
+	removed, _, err := s.Store.DeleteExpiredIndices(ctx, now)
+	if err != nil {
+		return errF(status.Errorf(codes.Internal, "removing the expired indices: %v", err))
+	}
+	// confirm the index is gone
+	rsv, err = s.DB.GetSegmentRsvFromID(ctx, &rsv.ID)
+	if err != nil {
+		return errF(status.Errorf(codes.Internal, "error retrieving segment: %s", err))
+	}
+	if rsv != nil && rsv.Index(libcol.IndexNumber(req.Index)) != nil {
+		return errF(status.Errorf(codes.Internal, "index %d still present after expiring it",
+			req.Index))
+	}
+	log.Info("index expired from the debug service", "id", translate.ID(req.Id).String(),
+		"idx", req.Index, "removed", removed)
+	return &colpb.CmdIndexExpireResponse{
+		Expiration: util.TimeToSecs(expiration),
+		Removed:    uint32(removed),
+	}, nil
+}
+
+func (s *debugService) CmdReservationResize(ctx context.Context,
+	req *colpb.CmdReservationResizeRequest) (*colpb.CmdReservationResizeResponse, error) {
+
+	localIA := s.Topo.IA()
+	errF := func(err error) (*colpb.CmdReservationResizeResponse, error) {
+		return &colpb.CmdReservationResizeResponse{
+			ErrorFound: errorInIA(localIA, err),
+		}, nil
+	}
+
+	if s.ReadOnly {
+		return errF(errReadOnly)
+	}
+	if s.Keeper == nil {
+		return errF(errNoKeeper)
+	}
+
+	if req.Bw > 63 {
+		return errF(status.Errorf(codes.InvalidArgument,
+			"bad bandwidth class %d, not between 0 and 63", req.Bw))
+	}
+	idx, err := s.Keeper.Downgrade(ctx, *translate.ID(req.Id), libcol.BWCls(req.Bw))
+	if err != nil {
+		return errF(status.Errorf(codes.Internal, "downgrading reservation: %v", err))
+	}
+	return &colpb.CmdReservationResizeResponse{
+		Index: uint32(idx),
+	}, nil
+}
+
+// CmdReservationDelete tears down the segment reservation along its whole path and removes
+// it from the local DB. If the teardown fails, the response points to the AS where it failed.
+// With force, the reservation is removed from the local DB even if the teardown failed.
+func (s *debugService) CmdReservationDelete(ctx context.Context,
+	req *colpb.CmdReservationDeleteRequest) (*colpb.CmdReservationDeleteResponse, error) {
+
+	localIA := s.Topo.IA()
+	errF := func(err error) (*colpb.CmdReservationDeleteResponse, error) {
+		return &colpb.CmdReservationDeleteResponse{
+			ErrorFound: errorInIA(localIA, err),
+		}, nil
+	}
+
+	if s.ReadOnly {
+		return errF(errReadOnly)
+	}
+	if s.Keeper == nil {
+		return errF(errNoKeeper)
+	}
+
+	rsv, err := s.getSegR(ctx, req.Id)
+	if err != nil {
+		return errF(err)
+	}
+
+	// the teardown travels from the initiator, thus in reverse for down-path SegRs; the failed
+	// step of the response refers to these same steps
+	steps := rsv.Steps
+	if rsv.PathType == libcol.DownPath {
+		steps = steps.Reverse()
+	}
+	var errFound *colpb.ErrorInIA
+	teardownReq := base.NewRequest(s.now(), &rsv.ID, 0, len(steps))
+	res, err := s.Store.InitTearDownSegmentReservation(ctx, teardownReq, steps,
+		rsv.Transport())
+	switch {
+	case err != nil:
+		errFound = errorInIA(localIA, status.Errorf(codes.Internal, "tearing down: %v", err))
+	case !res.Success():
+		failure := res.(*base.ResponseFailure)
+		failedIA := localIA
+		if int(failure.FailedStep) < len(steps) {
+			failedIA = steps[failure.FailedStep].IA
+		}
+		errFound = errorInIA(failedIA, status.Errorf(codes.Internal,
+			"failed response: %s", failure.Message))
+	}
+	if errFound != nil {
+		log.Info("error tearing down segment reservation", "id", rsv.ID.String(),
+			"ia", addr.IA(errFound.Ia), "err", errFound.Message, "force", req.Force)
+	}
+
+	// the teardown removes the local state before forwarding the request, thus check
+	// whether the reservation is still present
+	stillPresent, err := s.DB.GetSegmentRsvFromID(ctx, &rsv.ID)
+	if err != nil {
+		return errF(status.Errorf(codes.Internal, "checking local DB: %v", err))
+	}
+	if stillPresent != nil && (errFound == nil || req.Force) {
+		if err := s.DB.DeleteSegmentRsv(ctx, &rsv.ID); err != nil {
+			return errF(status.Errorf(codes.Internal, "deleting locally: %v", err))
+		}
+		stillPresent = nil
+	}
+	deleted := stillPresent == nil
+	if deleted {
+		s.Keeper.Forget(rsv.ID)
+	}
+	return &colpb.CmdReservationDeleteResponse{
+		ErrorFound:     errFound,
+		DeletedLocally: deleted,
+	}, nil
+}
+
+// CmdReservationShow returns the details of the segment reservation, including its
+// serialized transport path.
+func (s *debugService) CmdReservationShow(ctx context.Context,
+	req *colpb.CmdReservationShowRequest) (*colpb.CmdReservationShowResponse, error) {
+
+	localIA := s.Topo.IA()
+	errF := func(err error) (*colpb.CmdReservationShowResponse, error) {
+		return &colpb.CmdReservationShowResponse{
+			ErrorFound: errorInIA(localIA, err),
+		}, nil
+	}
+
+	rsv, err := s.getSegR(ctx, req.Id)
+	if err != nil {
+		return errF(err)
+	}
+	var transport []byte
+	if rsv.TransportPath != nil {
+		if transport, err = rsv.TransportPath.Bytes(); err != nil {
+			return errF(status.Errorf(codes.Internal, "serializing transport path: %v", err))
+		}
+	}
+	snapshot := rsv.IndicesSnapshot()
+	indices := make([]uint32, len(snapshot))
+	details := make([]*colpb.IndexSummary, len(snapshot))
+	for i, index := range snapshot {
+		indices[i] = uint32(index.Idx)
+		details[i] = &colpb.IndexSummary{
+			Index:      uint32(index.Idx),
+			Expiration: util.TimeToSecs(index.Expiration),
+			State:      uint32(index.State),
+			AllocBw:    uint32(index.AllocBW),
+		}
+	}
+	var validUntil uint32
+	if t := rsv.ValidUntil(); !t.IsZero() {
+		validUntil = util.TimeToSecs(t)
+	}
+	activeIndex := int32(-1)
+	if idx, ok := rsv.ActiveIndexNumber(); ok {
+		activeIndex = int32(idx)
+	}
+	removed := rsv.History().Entries()
+	history := make([]*colpb.IndexHistoryEntry, len(removed))
+	for i, e := range removed {
+		history[i] = &colpb.IndexHistoryEntry{
+			Index:      uint32(e.Idx),
+			AllocBw:    uint32(e.AllocBW),
+			Expiration: util.TimeToSecs(e.Expiration),
+			Removed:    util.TimeToSecs(e.Removed),
+		}
+	}
+	return &colpb.CmdReservationShowResponse{
+		PathType:      uint32(rsv.PathType),
+		Steps:         translate.PBufSteps(rsv.Steps),
+		CurrentStep:   uint32(rsv.CurrentStep),
+		Indices:       indices,
+		ActiveIndex:   activeIndex,
+		TransportPath: transport,
+		History:       history,
+		IndexDetails:  details,
+		ValidUntil:    validUntil,
+	}, nil
+}
+
+// CmdReservationDBDump returns the fields of the segment reservation and its indices as stored
+// in the DB, to compare them with the reservation in memory. It is only available if the debug
+// commands are enabled.
+func (s *debugService) CmdReservationDBDump(ctx context.Context,
+	req *colpb.CmdReservationDBDumpRequest) (*colpb.CmdReservationDBDumpResponse, error) {
+
+	localIA := s.Topo.IA()
+	errF := func(err error) (*colpb.CmdReservationDBDumpResponse, error) {
+		return &colpb.CmdReservationDBDumpResponse{
+			ErrorFound: errorInIA(localIA, err),
+		}, nil
+	}
+
+	if !s.DebugCommands {
+		return errF(status.Errorf(codes.Unimplemented,
+			"dumping the DB requires the debug commands to be enabled"))
+	}
+	dumper, ok := s.DB.(backend.RawDumper)
+	if !ok {
+		return errF(status.Errorf(codes.Unimplemented, "the DB cannot dump its rows"))
+	}
+	row, err := dumper.DebugSegmentRsvRow(ctx, translate.ID(req.Id))
+	if err != nil {
+		return errF(status.Errorf(codes.Internal, "error retrieving segment: %s", err))
+	}
+	if row == nil {
+		return errF(status.Errorf(codes.NotFound, "segment not found: %s", req.Id))
+	}
+	indices := make([]*colpb.IndexDBRow, len(row.Indices))
+	for i, index := range row.Indices {
+		indices[i] = &colpb.IndexDBRow{
+			Index:      index.IndexNumber,
+			Expiration: index.Expiration,
+			State:      index.State,
+			MinBw:      index.MinBW,
+			MaxBw:      index.MaxBW,
+			AllocBw:    index.AllocBW,
+			Token:      index.Token,
+		}
+	}
+	return &colpb.CmdReservationDBDumpResponse{
+		RowId:         row.RowID,
+		Ingress:       uint32(row.Ingress),
+		Egress:        uint32(row.Egress),
+		PathType:      uint32(row.PathType),
+		Steps:         row.Steps,
+		CurrentStep:   uint32(row.CurrentStep),
+		TransportPath: row.TransportPath,
+		EndProps:      uint32(row.EndProps),
+		TrafficSplit:  uint32(row.TrafficSplit),
+		SrcIa:         row.SrcIA,
+		DstIa:         row.DstIA,
+		ActiveIndex:   int32(row.ActiveIndex),
+		IndexHistory:  row.IndexHistory,
+		Indices:       indices,
+	}, nil
+}
+
+// CmdKeeperPlan returns what the keeper would do next for each configured segment reservation,
+// optionally only for those with the requested labels.
+// The plan is computed at request time, and nothing is modified.
+func (s *debugService) CmdKeeperPlan(ctx context.Context, req *colpb.CmdKeeperPlanRequest) (
+	*colpb.CmdKeeperPlanResponse, error) {
+
+	if s.Keeper == nil {
+		return &colpb.CmdKeeperPlanResponse{
+			ErrorFound: errorInIA(s.Topo.IA(), errNoKeeper),
+		}, nil
+	}
+	plan, wakeup := s.Keeper.Plan()
+	entries := make([]*colpb.KeeperPlanEntry, 0, len(plan))
+	for _, e := range plan {
+		if !e.HasLabels(req.Labels) {
+			continue
+		}
+		entry := &colpb.KeeperPlanEntry{
+			DstIa:     uint64(e.Dst),
+			PathType:  uint32(e.PathType),
+			Predicate: e.Predicate,
+			MinBw:     uint32(e.MinBW),
+			MaxBw:     uint32(e.MaxBW),
+			Action:    e.Action.String(),
+			Labels:    e.Labels,
+		}
+		if e.ID != nil {
+			entry.Id = translate.PBufID(e.ID)
+			entry.Compliance = e.Compliance.String()
+		}
+		entries = append(entries, entry)
+	}
+	return &colpb.CmdKeeperPlanResponse{
+		Entries: entries,
+		Wakeup:  util.TimeToSecs(wakeup),
+	}, nil
+}
+
+// CmdKeeperUnmatched returns the configured segment reservations for which no path to their
+// destination satisfies their predicate, optionally only those with the requested labels.
+// The paths are looked up at request time, and nothing is modified.
+func (s *debugService) CmdKeeperUnmatched(ctx context.Context,
+	req *colpb.CmdKeeperUnmatchedRequest) (*colpb.CmdKeeperUnmatchedResponse, error) {
+
+	if s.Keeper == nil {
+		return &colpb.CmdKeeperUnmatchedResponse{
+			ErrorFound: errorInIA(s.Topo.IA(), errNoKeeper),
+		}, nil
+	}
+	unmatched := s.Keeper.Unmatched(ctx)
+	entries := make([]*colpb.KeeperUnmatchedEntry, 0, len(unmatched))
+	for _, e := range unmatched {
+		if !e.HasLabels(req.Labels) {
+			continue
+		}
+		entry := &colpb.KeeperUnmatchedEntry{
+			DstIa:     uint64(e.Dst),
+			PathType:  uint32(e.PathType),
+			Predicate: e.Predicate,
+			Paths:     uint32(e.Paths),
+			Labels:    e.Labels,
+		}
+		if e.Err != nil {
+			entry.PathsError = e.Err.Error()
+		}
+		entries = append(entries, entry)
+	}
+	return &colpb.CmdKeeperUnmatchedResponse{
+		Entries: entries,
+	}, nil
+}
+
+// CmdE2EList returns the e2e reservations stored in this AS. If a parent segment reservation
+// is specified, only the e2e reservations on top of it are returned.
+func (s *debugService) CmdE2EList(ctx context.Context, req *colpb.CmdE2EListRequest) (
+	*colpb.CmdE2EListResponse, error) {
+
+	localIA := s.Topo.IA()
+	errF := func(err error) (*colpb.CmdE2EListResponse, error) {
+		return &colpb.CmdE2EListResponse{
+			ErrorFound: errorInIA(localIA, err),
+		}, nil
+	}
+
+	var rsvs []*e2e.Reservation
+	var err error
+	if req.Parent != nil {
+		rsvs, err = s.DB.GetE2ERsvsOnSegRsv(ctx, translate.ID(req.Parent))
+	} else {
+		rsvs, err = s.DB.GetAllE2ERsvs(ctx)
+	}
+	if err != nil {
+		return errF(status.Errorf(codes.Internal, "error retrieving e2e reservations: %s", err))
+	}
+	entries := make([]*colpb.E2EListEntry, len(rsvs))
+	for i, r := range rsvs {
+		entries[i] = &colpb.E2EListEntry{
+			Id:    translate.PBufID(&r.ID),
+			Alloc: r.AllocResv(),
+		}
+		if len(r.Indices) > 0 {
+			index := r.Indices[len(r.Indices)-1]
+			entries[i].HasIndex = true
+			entries[i].Idx = uint32(index.Idx)
+			entries[i].Bw = uint32(index.AllocBW)
+			entries[i].Exptime = uint64(index.Expiration.UnixMilli())
+		}
+	}
+	return &colpb.CmdE2EListResponse{
+		Reservations: entries,
+	}, nil
+}
+
+// CmdNeighbors returns the neighbors known by the client operator, with the address of their
+// colibri service, the status of the QUIC sessions to them, and their circuit breakers.
+func (s *debugService) CmdNeighbors(ctx context.Context, req *colpb.CmdNeighborsRequest) (
+	*colpb.CmdNeighborsResponse, error) {
+
+	localIA := s.Topo.IA()
+	if s.Operator == nil {
+		return &colpb.CmdNeighborsResponse{
+			ErrorFound: errorInIA(localIA, status.Error(codes.Internal,
+				"no colibri client operator")),
+		}, nil
+	}
+	neighbors := s.Operator.Neighbors()
+	entries := make([]*colpb.NeighborEntry, len(neighbors))
+	for i, n := range neighbors {
+		entries[i] = &colpb.NeighborEntry{
+			InterfaceId:         uint32(n.InterfaceID),
+			Ia:                  uint64(n.IA),
+			SessionOpen:         n.SessionOpen,
+			Breaker:             n.Breaker.String(),
+			ConsecutiveFailures: uint32(n.Failures),
+		}
+		if n.Addr != nil {
+			entries[i].Address = n.Addr.Host.String()
+		}
+		if !n.LastUse.IsZero() {
+			entries[i].LastUse = uint64(n.LastUse.UnixMilli())
+		}
+	}
+	return &colpb.CmdNeighborsResponse{
+		Neighbors: entries,
+	}, nil
+}
+
 func (s *debugService) Traceroute(ctx context.Context, req *colpb.TracerouteRequest,
 ) (*colpb.TracerouteResponse, error) {
 
@@ -262,10 +798,7 @@ func (s *debugService) Traceroute(ctx context.Context, req *colpb.TracerouteRequ
 	reqTimeStamp := uint64(time.Now().UnixMicro())
 	errF := func(err error) (*colpb.TracerouteResponse, error) {
 		return &colpb.TracerouteResponse{
-			ErrorFound: &colpb.ErrorInIA{
-				Ia:      uint64(localIA),
-				Message: err.Error(),
-			},
+			ErrorFound: errorInIA(localIA, err),
 		}, nil
 	}
 	rsv, err := s.getSegR(ctx, req.Id)
@@ -345,10 +878,36 @@ func (s *debugService) getSegR(ctx context.Context, id *colpb.ReservationID,
 	ID := translate.ID(id)
 	segR, err := s.DB.GetSegmentRsvFromID(ctx, ID)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "error retrieving segment: %s", err)
+		return nil, status.Errorf(codes.Internal, "error retrieving segment: %s", err)
 	}
 	if segR == nil {
 		return nil, status.Errorf(codes.NotFound, "segment not found: %s", id)
 	}
 	return segR, nil
 }
+
+// errorInIA describes err as originated at ia. The code of the error is derived from the gRPC
+// status code of err, if any.
+func errorInIA(ia addr.IA, err error) *colpb.ErrorInIA {
+	return &colpb.ErrorInIA{
+		Ia:      uint64(ia),
+		Message: err.Error(),
+		Code:    errorCode(err),
+	}
+}
+
+// errorCode classifies err according to its gRPC status code.
+func errorCode(err error) colpb.ErrorCode {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return colpb.ErrorCode_ERROR_CODE_NOT_FOUND
+	case codes.InvalidArgument, codes.OutOfRange:
+		return colpb.ErrorCode_ERROR_CODE_INVALID_ARGUMENT
+	case codes.PermissionDenied:
+		return colpb.ErrorCode_ERROR_CODE_ADMISSION_DENIED
+	case codes.Internal:
+		return colpb.ErrorCode_ERROR_CODE_INTERNAL
+	default:
+		return colpb.ErrorCode_ERROR_CODE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	base "github.com/scionproto/scion/go/co/reservation"
+	"github.com/scionproto/scion/go/co/reservation/segment"
+	"github.com/scionproto/scion/go/co/reservation/segmenttest"
+	"github.com/scionproto/scion/go/co/reservation/test"
+	"github.com/scionproto/scion/go/co/reservation/translate"
+	"github.com/scionproto/scion/go/co/reservationstorage/backend/mock_backend"
+	"github.com/scionproto/scion/go/co/reservationstorage/mock_reservationstorage"
+	"github.com/scionproto/scion/go/co/reservationstore"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	colgrpc "github.com/scionproto/scion/go/pkg/co/colibri/grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+func TestDebugServiceWithoutKeeper(t *testing.T) {
+	id := translate.PBufID(&segmenttest.NewRsv(segmenttest.WithID("ff00:0:111", "01234567")).ID)
+	cases := map[string]func(context.Context, colpb.ColibriDebugCommandsServiceServer) (
+		*colpb.ErrorInIA, error){
+
+		"resize": func(ctx context.Context, s colpb.ColibriDebugCommandsServiceServer) (
+			*colpb.ErrorInIA, error) {
+
+			res, err := s.CmdReservationResize(ctx,
+				&colpb.CmdReservationResizeRequest{Id: id, Bw: 5})
+			return res.GetErrorFound(), err
+		},
+		"delete": func(ctx context.Context, s colpb.ColibriDebugCommandsServiceServer) (
+			*colpb.ErrorInIA, error) {
+
+			res, err := s.CmdReservationDelete(ctx, &colpb.CmdReservationDeleteRequest{Id: id})
+			return res.GetErrorFound(), err
+		},
+		"keeper_plan": func(ctx context.Context, s colpb.ColibriDebugCommandsServiceServer) (
+			*colpb.ErrorInIA, error) {
+
+			res, err := s.CmdKeeperPlan(ctx, &colpb.CmdKeeperPlanRequest{})
+			return res.GetErrorFound(), err
+		},
+		"keeper_unmatched": func(ctx context.Context, s colpb.ColibriDebugCommandsServiceServer) (
+			*colpb.ErrorInIA, error) {
+
+			res, err := s.CmdKeeperUnmatched(ctx, &colpb.CmdKeeperUnmatchedRequest{})
+			return res.GetErrorFound(), err
+		},
+	}
+	for name, cmd := range cases {
+		name, cmd := name, cmd
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// neither the DB nor the store are used
+			db := mock_backend.NewMockDB(ctrl)
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			s := colgrpc.NewDebugService(db, nil, loadTopo(t), store, nil)
+
+			errFound, err := cmd(context.Background(), s)
+			require.NoError(t, err)
+			require.NotNil(t, errFound)
+			require.Equal(t, uint64(xtest.MustParseIA("1-ff00:0:111")), errFound.Ia)
+			require.Contains(t, errFound.Message, codes.Unavailable.String())
+		})
+	}
+}
+
+func TestCmdReservationDelete(t *testing.T) {
+	cases := map[string]struct {
+		pathType      reservation.PathType
+		path          []interface{}
+		force         bool
+		res           base.Response
+		stillPresent  bool
+		expectedSteps base.PathSteps // as passed to the store
+		expectedIA    string         // where the teardown failed, empty if it did not
+		deleted       bool
+	}{
+		"up_path": {
+			pathType:     reservation.UpPath,
+			path:         []interface{}{"1-ff00:0:111", 1, 1, "1-ff00:0:112", 2, 1, "1-ff00:0:113"},
+			res:          &base.ResponseSuccess{},
+			stillPresent: false,
+			expectedSteps: test.NewSteps(
+				"1-ff00:0:111", 1, 1, "1-ff00:0:112", 2, 1, "1-ff00:0:113"),
+			deleted: true,
+		},
+		"up_path_failed": {
+			pathType:     reservation.UpPath,
+			path:         []interface{}{"1-ff00:0:111", 1, 1, "1-ff00:0:112", 2, 1, "1-ff00:0:113"},
+			res:          &base.ResponseFailure{FailedStep: 2, Message: "test"},
+			stillPresent: true,
+			expectedSteps: test.NewSteps(
+				"1-ff00:0:111", 1, 1, "1-ff00:0:112", 2, 1, "1-ff00:0:113"),
+			expectedIA: "1-ff00:0:113",
+		},
+		"down_path_failed": {
+			pathType:     reservation.DownPath,
+			path:         []interface{}{"1-ff00:0:113", 1, 2, "1-ff00:0:112", 1, 1, "1-ff00:0:111"},
+			res:          &base.ResponseFailure{FailedStep: 2, Message: "test"},
+			stillPresent: true,
+			// the teardown starts at this AS, the last step of the down-path SegR
+			expectedSteps: test.NewSteps(
+				"1-ff00:0:113", 1, 2, "1-ff00:0:112", 1, 1, "1-ff00:0:111").Reverse(),
+			expectedIA: "1-ff00:0:113",
+		},
+		"down_path_failed_forced": {
+			pathType:     reservation.DownPath,
+			path:         []interface{}{"1-ff00:0:113", 1, 2, "1-ff00:0:112", 1, 1, "1-ff00:0:111"},
+			force:        true,
+			res:          &base.ResponseFailure{FailedStep: 1, Message: "test"},
+			stillPresent: true,
+			expectedSteps: test.NewSteps(
+				"1-ff00:0:113", 1, 2, "1-ff00:0:112", 1, 1, "1-ff00:0:111").Reverse(),
+			expectedIA: "1-ff00:0:112",
+			deleted:    true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			rsv := segmenttest.NewRsv(segmenttest.WithID("ff00:0:111", "01234567"),
+				segmenttest.WithPath(tc.path...),
+				segmenttest.WithPathType(tc.pathType))
+			db := mock_backend.NewMockDB(ctrl)
+			store := mock_reservationstorage.NewMockStore(ctrl)
+			// the first lookup finds the reservation, the second one checks whether the
+			// teardown removed it
+			gomock.InOrder(
+				db.EXPECT().GetSegmentRsvFromID(gomock.Any(), gomock.Any()).Return(rsv, nil),
+				db.EXPECT().GetSegmentRsvFromID(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(context.Context, *reservation.ID) (*segment.Reservation, error) {
+						if tc.stillPresent {
+							return rsv, nil
+						}
+						return nil, nil
+					}),
+			)
+			if tc.stillPresent && tc.deleted {
+				db.EXPECT().DeleteSegmentRsv(gomock.Any(), &rsv.ID).Return(nil)
+			}
+			store.EXPECT().InitTearDownSegmentReservation(gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *base.Request, steps base.PathSteps,
+					_ *colpath.ColibriPathMinimal) (base.Response, error) {
+
+					require.Equal(t, tc.expectedSteps, steps)
+					require.Len(t, req.Authenticators, len(steps)-1)
+					return tc.res, nil
+				})
+			keeper := &fakeKeeper{}
+			s := colgrpc.NewDebugService(db, nil, loadTopo(t), store, keeper)
+
+			res, err := s.CmdReservationDelete(context.Background(),
+				&colpb.CmdReservationDeleteRequest{
+					Id:    translate.PBufID(&rsv.ID),
+					Force: tc.force,
+				})
+			require.NoError(t, err)
+			if tc.expectedIA == "" {
+				require.Nil(t, res.ErrorFound)
+			} else {
+				require.NotNil(t, res.ErrorFound)
+				require.Equal(t, uint64(xtest.MustParseIA(tc.expectedIA)), res.ErrorFound.Ia)
+			}
+			require.Equal(t, tc.deleted, res.DeletedLocally)
+			if tc.deleted {
+				require.Equal(t, []reservation.ID{rsv.ID}, keeper.forgotten)
+			} else {
+				require.Empty(t, keeper.forgotten)
+			}
+		})
+	}
+}
+
+// fakeKeeper records the reservations it is asked to forget.
+type fakeKeeper struct {
+	forgotten []reservation.ID
+}
+
+func (k *fakeKeeper) Downgrade(context.Context, reservation.ID, reservation.BWCls) (
+	reservation.IndexNumber, error) {
+
+	return 0, nil
+}
+
+func (k *fakeKeeper) Forget(id reservation.ID) {
+	k.forgotten = append(k.forgotten, id)
+}
+
+func (k *fakeKeeper) Plan() ([]reservationstore.PlanEntry, time.Time) {
+	return nil, time.Time{}
+}
+
+func (k *fakeKeeper) Unmatched(context.Context) []reservationstore.UnmatchedEntry {
+	return nil
+}
+
+// loadTopo returns the topology of 1-ff00:0:111, without any interfaces.
+func loadTopo(t *testing.T) *topology.Loader {
+	topo, err := topology.NewLoader(topology.LoaderCfg{File: "testdata/topology.json"})
+	require.NoError(t, err)
+	return topo
+}
@@ -17,10 +17,12 @@ package config
 import (
 	"io"
 	"net"
+	"time"
 
 	colconf "github.com/scionproto/scion/go/co/reservation/conf"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/pkg/storage"
 )
 
@@ -33,6 +35,30 @@ type ColibriConfig struct {
 	Capacities       *colconf.Capacities   `toml:"omitempty"`
 	Reservations     *colconf.Reservations `toml:"omitempty"`
 	DebugServerAddr  string                `toml:"debug_server_addr,omitempty"`
+	// SegmentsReportInterval is the interval between reports of the segment reservations
+	// in the DB. Defaults to 10m.
+	SegmentsReportInterval util.DurWrap `toml:"segments_report_interval,omitempty"`
+	// DisableSegmentsReport disables the periodic report of the segment reservations.
+	DisableSegmentsReport bool `toml:"disable_segments_report,omitempty"`
+	// E2EsReportInterval is the interval between reports of the e2e reservations in the DB.
+	// Defaults to 5m.
+	E2EsReportInterval util.DurWrap `toml:"e2es_report_interval,omitempty"`
+	// DisableE2EsReport disables the periodic report of the e2e reservations.
+	DisableE2EsReport bool `toml:"disable_e2es_report,omitempty"`
+	// DebugCommands enables the debug commands that alter the reservations for testing,
+	// such as expiring an index. Never enable it in production.
+	DebugCommands bool `toml:"debug_commands,omitempty"`
+	// ReadOnly makes this instance only serve the status of the reservations in the DB:
+	// the reservations are neither kept nor expired, and the debug commands that would
+	// modify them fail.
+	ReadOnly bool `toml:"read_only,omitempty"`
+	// LogFullPaths logs the paths of the reservations with all their details, instead of
+	// anonymizing them. Only meant for debugging.
+	LogFullPaths bool `toml:"log_full_paths,omitempty"`
+	// ALPN are the application protocols negotiated by the QUIC sessions with other COLIBRI
+	// services, in order of preference. The sessions that negotiate another one are rejected.
+	// If empty, coliquic.DefaultALPN is used.
+	ALPN []string `toml:"alpn,omitempty"`
 }
 
 func (cfg *ColibriConfig) Validate() error {
@@ -61,6 +87,12 @@ func (cfg *ColibriConfig) InitDefaults() {
 		cfg.DB.MaxOpenConns = 100
 	}
 	cfg.Delta = 0.8
+	if cfg.SegmentsReportInterval.Duration == 0 {
+		cfg.SegmentsReportInterval.Duration = 10 * time.Minute
+	}
+	if cfg.E2EsReportInterval.Duration == 0 {
+		cfg.E2EsReportInterval.Duration = 5 * time.Minute
+	}
 	cfg.Capacities = &colconf.Capacities{}
 	cfg.Reservations = &colconf.Reservations{}
 }
@@ -79,4 +111,20 @@ delta = 0.8
 capacities = "capacities.json"
 reservations = "reservations.json"
 debug_server_addr = "127.0.0.1:44001"
+# interval between reports of the reservations in the DB (default 10m and 5m)
+segments_report_interval = "10m"
+e2es_report_interval = "5m"
+# disable the reports of the reservations in the DB (default false)
+disable_segments_report = false
+disable_e2es_report = false
+# enable the debug commands that alter the reservations for testing, such as expiring an
+# index. Never enable them in production (default false)
+debug_commands = false
+# only report the reservations in the DB, never keep nor modify them (default false)
+read_only = false
+# log the paths of the reservations without anonymizing them, for debugging (default false)
+log_full_paths = false
+# application protocols (ALPN) negotiated with other COLIBRI services over QUIC, in order of
+# preference. Several ones allow running different protocol versions (default ["SCION"])
+alpn = ["SCION"]
 `
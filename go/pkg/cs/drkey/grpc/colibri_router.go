@@ -0,0 +1,122 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/resolver"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/reservation"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	sc_grpc "github.com/scionproto/scion/go/pkg/grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+)
+
+// ColibriRouter returns the COLIBRI paths of the segment reservations from the local AS, as
+// listed by the local COLIBRI service. It is the Router of a Fetcher with RequireColibri.
+type ColibriRouter struct {
+	// Dialer dials the local COLIBRI service, addressed as addr.SvcCOL.
+	Dialer sc_grpc.Dialer
+	// NextHop returns the underlay address of the border router of a local interface, or nil
+	// if the interface is unknown.
+	NextHop func(ifID uint16) *net.UDPAddr
+}
+
+var _ snet.Router = (*ColibriRouter)(nil)
+
+// ColibriTopology is the part of the topology used by a ColibriRouter.
+type ColibriTopology interface {
+	ColibriServiceAddresses() []*net.UDPAddr
+	UnderlayNextHop(ifID uint16) *net.UDPAddr
+}
+
+// NewColibriRouter returns a ColibriRouter that reaches the COLIBRI service of the topology over
+// TCP, as the other intra AS clients of the service do.
+func NewColibriRouter(topo ColibriTopology) *ColibriRouter {
+	return &ColibriRouter{
+		Dialer: &sc_grpc.TCPDialer{
+			SvcResolver: func(dst addr.HostSVC) []resolver.Address {
+				if dst.Base() != addr.SvcCOL {
+					return nil
+				}
+				var targets []resolver.Address
+				for _, entry := range topo.ColibriServiceAddresses() {
+					targets = append(targets, resolver.Address{Addr: entry.String()})
+				}
+				return targets
+			},
+		},
+		NextHop: topo.UnderlayNextHop,
+	}
+}
+
+// Route returns a COLIBRI path to dst, or nil if there is none.
+func (r *ColibriRouter) Route(ctx context.Context, dst addr.IA) (snet.Path, error) {
+	paths, err := r.AllRoutes(ctx, dst)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+	return paths[0], nil
+}
+
+// AllRoutes returns the COLIBRI paths to dst. The paths that cannot be used from this AS are
+// skipped.
+func (r *ColibriRouter) AllRoutes(ctx context.Context, dst addr.IA) ([]snet.Path, error) {
+	conn, err := r.Dialer.Dial(ctx, addr.SvcCOL)
+	if err != nil {
+		return nil, serrors.WrapStr("dialing the colibri service", err)
+	}
+	defer conn.Close()
+	client := colpb.NewColibriServiceClient(conn)
+	res, err := client.ListTransportPaths(ctx, &colpb.ListTransportPathsRequest{
+		DstIa: uint64(dst),
+	})
+	if err != nil {
+		return nil, serrors.WrapStr("listing colibri paths", err, "dst", dst)
+	}
+	if res.ErrorMessage != "" {
+		return nil, serrors.New("listing colibri paths", "dst", dst, "err", res.ErrorMessage)
+	}
+	paths := make([]snet.Path, 0, len(res.TransportPaths))
+	for _, raw := range res.TransportPaths {
+		p := &colpath.ColibriPathMinimal{}
+		if err := p.FromBytes(raw); err != nil {
+			log.Debug("ignoring undecodable colibri path", "dst", dst, "err", err)
+			continue
+		}
+		nextHop := r.NextHop(p.CurrHopField.EgressId)
+		if nextHop == nil {
+			log.Debug("ignoring colibri path with unknown egress", "dst", dst,
+				"egress", p.CurrHopField.EgressId)
+			continue
+		}
+		paths = append(paths, snetpath.Path{
+			Dst:           dst,
+			DataplanePath: snetpath.Colibri{ColibriPathMinimal: *p},
+			NextHop:       nextHop,
+			Meta: snet.PathMetadata{
+				Expiry: reservation.ExpTickToTime(p.InfoField.ExpTick),
+			},
+		})
+	}
+	return paths, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
+	"github.com/scionproto/scion/go/lib/xtest"
+	dk_grpc "github.com/scionproto/scion/go/pkg/cs/drkey/grpc"
+	"github.com/scionproto/scion/go/pkg/grpc/mock_grpc"
+	colpb "github.com/scionproto/scion/go/pkg/proto/colibri"
+	"github.com/scionproto/scion/go/pkg/proto/colibri/mock_colibri"
+)
+
+// colibriTopology has a COLIBRI service and a single interface.
+type colibriTopology struct {
+	colibriService *net.UDPAddr
+	ifID           uint16
+	nextHop        *net.UDPAddr
+}
+
+func (t colibriTopology) ColibriServiceAddresses() []*net.UDPAddr {
+	return []*net.UDPAddr{t.colibriService}
+}
+
+func (t colibriTopology) UnderlayNextHop(ifID uint16) *net.UDPAddr {
+	if ifID != t.ifID {
+		return nil
+	}
+	return t.nextHop
+}
+
+func TestColibriRouter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srcIA := xtest.MustParseIA("1-ff00:0:111")
+	colibriPath := newColibriDataplanePath(t).(snetpath.Colibri)
+	raw, err := colibriPath.ToBytes()
+	require.NoError(t, err)
+	otherEgress := newColibriDataplanePathWithEgress(t, 42).(snetpath.Colibri)
+	rawOtherEgress, err := otherEgress.ToBytes()
+	require.NoError(t, err)
+
+	// the COLIBRI service of the local AS lists the paths of its segment reservations
+	service := mock_colibri.NewMockColibriServiceServer(ctrl)
+	service.EXPECT().ListTransportPaths(gomock.Any(), gomock.Any()).MinTimes(1).DoAndReturn(
+		func(_ context.Context, req *colpb.ListTransportPathsRequest) (
+			*colpb.ListTransportPathsResponse, error) {
+
+			require.Equal(t, uint64(srcIA), req.DstIa)
+			return &colpb.ListTransportPathsResponse{
+				TransportPaths: [][]byte{raw, rawOtherEgress},
+			}, nil
+		})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	colpb.RegisterColibriServiceServer(server, service)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	nextHop := &net.UDPAddr{IP: net.ParseIP("127.0.0.2"), Port: 30041}
+	router := dk_grpc.NewColibriRouter(colibriTopology{
+		colibriService: &net.UDPAddr{
+			IP:   lis.Addr().(*net.TCPAddr).IP,
+			Port: lis.Addr().(*net.TCPAddr).Port,
+		},
+		ifID:    colibriPath.CurrHopField.EgressId,
+		nextHop: nextHop,
+	})
+	ctx, cancelF := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelF()
+	paths, err := router.AllRoutes(ctx, srcIA)
+	require.NoError(t, err)
+	// only the path leaving through a known interface is returned
+	require.Len(t, paths, 1)
+	require.Equal(t, srcIA, paths[0].Destination())
+	require.Equal(t, nextHop, paths[0].UnderlayNextHop())
+	dataplane, ok := paths[0].Dataplane().(snetpath.Colibri)
+	require.True(t, ok)
+	require.Equal(t, colibriPath.Raw, dataplane.Raw)
+
+	// a fetcher requiring COLIBRI dials the remote CS over these paths
+	dialed := make(chan snet.DataplanePath, 1)
+	grpcDialer := mock_grpc.NewMockDialer(ctrl)
+	grpcDialer.EXPECT().Dial(gomock.Any(), gomock.Any()).MinTimes(1).DoAndReturn(
+		func(_ context.Context, remote net.Addr) (*grpc.ClientConn, error) {
+			select {
+			case dialed <- remote.(*snet.SVCAddr).Path:
+			default:
+			}
+			return nil, serrors.New("test done")
+		})
+	fetcher := dk_grpc.Fetcher{
+		Dialer:         grpcDialer,
+		Router:         router,
+		MaxRetries:     1,
+		RequireColibri: true,
+	}
+	_, err = fetcher.Lvl1(ctx, drkey.Lvl1Meta{
+		ProtoId:  drkey.Generic,
+		Validity: time.Now(),
+		SrcIA:    srcIA,
+		DstIA:    xtest.MustParseIA("1-ff00:0:112"),
+	})
+	require.Error(t, err)
+	dialedPath := <-dialed
+	require.IsType(t, snetpath.Colibri{}, dialedPath)
+	require.Equal(t, colibriPath.Raw, dialedPath.(snetpath.Colibri).Raw)
+}
@@ -25,11 +25,13 @@ import (
 
 	"github.com/scionproto/scion/go/cs/config"
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/colibri/coliquic"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
+	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	cs_drkey "github.com/scionproto/scion/go/pkg/cs/drkey"
 	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
 	dkpb "github.com/scionproto/scion/go/pkg/proto/drkey"
@@ -42,6 +44,9 @@ type Server struct {
 	// AllowedSVHostProto is a set of Host,Protocol pairs that represents the allowed
 	// protocols hosts can obtain secrets values for.
 	AllowedSVHostProto map[config.HostProto]struct{}
+	// RequireColibri, if set, rejects the level 1 requests that did not arrive over a
+	// COLIBRI path.
+	RequireColibri bool
 }
 
 var _ cppb.DRKeyInterServiceServer = &Server{}
@@ -59,6 +64,11 @@ func (d *Server) Lvl1(ctx context.Context,
 	if err != nil {
 		return nil, serrors.WrapStr("retrieving info from certficate", err)
 	}
+	if d.RequireColibri {
+		if err := checkColibriPeer(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	lvl1Meta, err := getMeta(req.ProtocolId, req.ValTime, d.LocalIA, dstIA)
 	if err != nil {
@@ -99,6 +109,18 @@ func getMeta(protoId dkpb.Protocol, ts *timestamppb.Timestamp, srcIA,
 	}, nil
 }
 
+// checkColibriPeer returns an error if the gRPC peer in the context is not using a COLIBRI path.
+func checkColibriPeer(ctx context.Context) error {
+	p, _, err := coliquic.PeerPath(ctx)
+	if err != nil {
+		return serrors.WrapStr("level 1 requests must arrive over a COLIBRI path", err)
+	}
+	if p == nil || p.Type() != colpath.PathType {
+		return serrors.New("level 1 requests must arrive over a COLIBRI path")
+	}
+	return nil
+}
+
 func extractIAFromPeer(peer *peer.Peer) (addr.IA, error) {
 	if peer.AuthInfo == nil {
 		return 0, serrors.New("no auth info", "peer", peer)
@@ -16,15 +16,24 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"math/rand"
+	"strings"
+	"sync"
 	"time"
 
+	"google.golang.org/grpc"
+
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/drkey"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
+	"github.com/scionproto/scion/go/lib/scrypto/cppki"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
 	csdrkey "github.com/scionproto/scion/go/pkg/cs/drkey"
 	sc_grpc "github.com/scionproto/scion/go/pkg/grpc"
 	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
@@ -41,15 +50,39 @@ const (
 	// This timeout needs to be long enough to allow for service address
 	// resolution and the QUIC handshake to complete (two roundtrips).
 	defaultRPCDialTimeout time.Duration = 2 * time.Second
+	// maxConcurrentRemotes is the maximum number of remote CSes that Lvl1Batch
+	// fetches keys from at the same time.
+	maxConcurrentRemotes = 8
 )
 
-var errNotReachable = serrors.New("remote not reachable")
+var (
+	errNotReachable = serrors.New("remote not reachable")
+	errWrongPeer    = serrors.New("peer certificate does not belong to the source IA")
+)
+
+// Labels used for the metrics of the Fetcher, those labels should be used for initialization.
+var (
+	Lvl1LatencyLabels = []string{prom.LabelSrc, prom.LabelResult}
+	Lvl1RetriesLabels = []string{prom.LabelSrc}
+)
 
 // Fetcher obtains Lvl1 DRKey from a remote CS.
 type Fetcher struct {
 	Dialer     sc_grpc.Dialer
 	Router     snet.Router
 	MaxRetries int
+	// DialTimeout bounds each attempt to dial the remote, so that a stuck dial does not consume
+	// the deadline of the whole fetch. If zero, defaultRPCDialTimeout is used.
+	DialTimeout time.Duration
+	// Latency, if set, observes the duration of each Lvl1 fetch including all its attempts,
+	// labeled with Lvl1LatencyLabels.
+	Latency metrics.Histogram
+	// Retries, if set, counts the attempts after the first one, labeled with Lvl1RetriesLabels.
+	Retries metrics.Counter
+	// RequireColibri, if set, restricts the paths to the remote CS to the COLIBRI paths returned
+	// by the Router. The Dialer must then be able to dial over COLIBRI, e.g. with a
+	// coliquic.PersistentQUIC as its connection dialer.
+	RequireColibri bool
 }
 
 var _ csdrkey.Fetcher = (*Fetcher)(nil)
@@ -57,6 +90,117 @@ var _ csdrkey.Fetcher = (*Fetcher)(nil)
 // Lvl1 queries a CS for a level 1 key.
 func (f Fetcher) Lvl1(ctx context.Context,
 	meta drkey.Lvl1Meta) (drkey.Lvl1Key, error) {
+
+	start := time.Now()
+	key, err := f.lvl1(ctx, meta)
+	f.observeLatency(meta.SrcIA, start, err)
+	return key, err
+}
+
+// Lvl1Batch queries the CSes of the source IAs for the level 1 keys described by metas.
+// The keys with the same source IA are fetched over a single connection, and several
+// remotes are queried concurrently. The i-th key and error correspond to the i-th meta.
+func (f Fetcher) Lvl1Batch(ctx context.Context,
+	metas []drkey.Lvl1Meta) ([]drkey.Lvl1Key, []error) {
+
+	keys := make([]drkey.Lvl1Key, len(metas))
+	errs := make([]error, len(metas))
+	groups := make(map[addr.IA][]int)
+	var order []addr.IA
+	for i, meta := range metas {
+		if _, ok := groups[meta.SrcIA]; !ok {
+			order = append(order, meta.SrcIA)
+		}
+		groups[meta.SrcIA] = append(groups[meta.SrcIA], i)
+	}
+
+	start := time.Now()
+	sem := make(chan struct{}, maxConcurrentRemotes)
+	wg := sync.WaitGroup{}
+	wg.Add(len(order))
+	for _, srcIA := range order {
+		srcIA := srcIA
+		sem <- struct{}{}
+		go func() {
+			defer log.HandlePanic()
+			defer wg.Done()
+			defer func() { <-sem }()
+			// each goroutine writes only the positions of its own group
+			f.lvl1FromRemote(ctx, srcIA, metas, groups[srcIA], keys, errs)
+			for _, i := range groups[srcIA] {
+				f.observeLatency(srcIA, start, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return keys, errs
+}
+
+// lvl1FromRemote fetches the keys for the metas at the positions indices, all of them
+// with srcIA as source, reusing the connection to the remote for all of them.
+func (f Fetcher) lvl1FromRemote(ctx context.Context, srcIA addr.IA, metas []drkey.Lvl1Meta,
+	indices []int, keys []drkey.Lvl1Key, errs []error) {
+
+	logger := log.FromCtx(ctx)
+	reqs := make(map[int]*dkpb.Lvl1Request, len(indices))
+	for _, i := range indices {
+		req, err := drkey.Lvl1MetaToProtoRequest(metas[i])
+		if err != nil {
+			errs[i] = serrors.WrapStr("parsing lvl1 request to protobuf", err)
+			continue
+		}
+		reqs[i] = req
+	}
+	for try := 0; try < f.MaxRetries && len(reqs) > 0; try++ {
+		if try > 0 {
+			metrics.CounterInc(metrics.CounterWith(f.Retries, prom.LabelSrc, srcIA.String()))
+		}
+		conn, err := f.dial(ctx, srcIA)
+		if errors.Is(err, errNotReachable) {
+			logger.Debug("Lvl1 fetch failed", "try", try+1, "peer", srcIA, "err", err)
+			for i := range reqs {
+				errs[i] = err
+			}
+			return
+		}
+		if err != nil {
+			logger.Debug("Lvl1 fetch failed", "try", try+1, "peer", srcIA, "err", err)
+			continue
+		}
+		client := cppb.NewDRKeyInterServiceClient(conn)
+		for i, req := range reqs {
+			rep, err := client.Lvl1(ctx, req)
+			if err != nil {
+				logger.Debug("Lvl1 fetch failed", "try", try+1, "peer", srcIA, "err", err)
+				continue
+			}
+			delete(reqs, i)
+			if keys[i], err = drkey.GetLvl1KeyFromReply(metas[i], rep); err != nil {
+				errs[i] = serrors.WrapStr("obtaining level 1 key from reply", err)
+			}
+		}
+		conn.Close()
+	}
+	for i := range reqs {
+		errs[i] = serrors.New("Reached max retry attempts on fetching lvl1 key")
+	}
+}
+
+func (f Fetcher) observeLatency(srcIA addr.IA, start time.Time, err error) {
+	result := prom.Success
+	switch {
+	case serrors.IsTimeout(err):
+		result = prom.ErrTimeout
+	case err != nil:
+		result = prom.ErrNotClassified
+	}
+	metrics.HistogramObserve(metrics.HistogramWith(f.Latency,
+		prom.LabelSrc, srcIA.String(), prom.LabelResult, result),
+		time.Since(start).Seconds())
+}
+
+func (f Fetcher) lvl1(ctx context.Context,
+	meta drkey.Lvl1Meta) (drkey.Lvl1Key, error) {
 	logger := log.FromCtx(ctx)
 
 	req, err := drkey.Lvl1MetaToProtoRequest(meta)
@@ -67,6 +211,10 @@ func (f Fetcher) Lvl1(ctx context.Context,
 
 	var rep *dkpb.Lvl1Response
 	for i := 0; i < f.MaxRetries; i++ {
+		if i > 0 {
+			metrics.CounterInc(metrics.CounterWith(f.Retries,
+				prom.LabelSrc, meta.SrcIA.String()))
+		}
 		rep, err = f.getLvl1Key(ctx, meta.SrcIA, req)
 		if errors.Is(err, errNotReachable) {
 			logger.Debug("Lvl1 fetch failed", "try", i+1, "peer", meta.SrcIA, "err", err)
@@ -86,6 +234,59 @@ func (f Fetcher) Lvl1(ctx context.Context,
 
 func (f Fetcher) getLvl1Key(ctx context.Context, srcIA addr.IA,
 	req *dkpb.Lvl1Request) (*dkpb.Lvl1Response, error) {
+	conn, err := f.dial(ctx, srcIA)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := cppb.NewDRKeyInterServiceClient(conn)
+	rep, err := client.Lvl1(ctx, req)
+	if err != nil {
+		return nil, serrors.WrapStr("requesting level 1 key", err)
+	}
+	return rep, nil
+}
+
+// ClientTLSConfig returns a copy of conf for the TLS credentials of the Dialer of a Fetcher.
+// In addition to the verification configured in conf, the handshake fails unless the
+// certificate presented by the remote CS belongs to the IA in the server name, i.e. the
+// source IA of the requested keys. Otherwise a misrouted connection could obtain the key
+// from another AS.
+func ClientTLSConfig(conf *tls.Config) *tls.Config {
+	c := conf.Clone()
+	verify := conf.VerifyConnection
+	c.VerifyConnection = func(cs tls.ConnectionState) error {
+		if verify != nil {
+			if err := verify(cs); err != nil {
+				return err
+			}
+		}
+		return verifyPeerIA(cs)
+	}
+	return c
+}
+
+// verifyPeerIA checks that the certificate of the peer belongs to the IA in the server name.
+func verifyPeerIA(cs tls.ConnectionState) error {
+	serverIA, err := addr.ParseIA(strings.Split(cs.ServerName, ",")[0])
+	if err != nil {
+		return serrors.Wrap(errWrongPeer, err, "server_name", cs.ServerName)
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return serrors.WithCtx(errWrongPeer, "expected", serverIA)
+	}
+	certIA, err := cppki.ExtractIA(cs.PeerCertificates[0].Subject)
+	if err != nil {
+		return serrors.Wrap(errWrongPeer, err, "expected", serverIA)
+	}
+	if !certIA.Equal(serverIA) {
+		return serrors.WithCtx(errWrongPeer, "expected", serverIA, "actual", certIA)
+	}
+	return nil
+}
+
+// dial connects to the CS of srcIA over one of the paths to it.
+func (f Fetcher) dial(ctx context.Context, srcIA addr.IA) (*grpc.ClientConn, error) {
 	logger := log.FromCtx(ctx)
 
 	logger.Info("Resolving server", "srcIA", srcIA.String())
@@ -99,19 +300,17 @@ func (f Fetcher) getLvl1Key(ctx context.Context, srcIA addr.IA,
 		NextHop: path.UnderlayNextHop(),
 		SVC:     addr.SvcCS,
 	}
-	dialCtx, cancelF := context.WithTimeout(ctx, defaultRPCDialTimeout)
+	timeout := f.DialTimeout
+	if timeout == 0 {
+		timeout = defaultRPCDialTimeout
+	}
+	dialCtx, cancelF := context.WithTimeout(ctx, timeout)
 	defer cancelF()
 	conn, err := f.Dialer.Dial(dialCtx, remote)
 	if err != nil {
 		return nil, serrors.WrapStr("dialing", err)
 	}
-	defer conn.Close()
-	client := cppb.NewDRKeyInterServiceClient(conn)
-	rep, err := client.Lvl1(ctx, req)
-	if err != nil {
-		return nil, serrors.WrapStr("requesting level 1 key", err)
-	}
-	return rep, nil
+	return conn, nil
 }
 
 func (f Fetcher) pathToDst(ctx context.Context, dst addr.IA) (snet.Path, error) {
@@ -119,9 +318,24 @@ func (f Fetcher) pathToDst(ctx context.Context, dst addr.IA) (snet.Path, error)
 	if err != nil {
 		return nil, serrors.Wrap(errNotReachable, err)
 	}
+	if f.RequireColibri {
+		paths = colibriPaths(paths)
+	}
 	if len(paths) == 0 {
 		return nil, errNotReachable
 	}
 	path := paths[rand.Intn(len(paths))]
 	return path, nil
 }
+
+// colibriPaths returns the paths that use COLIBRI in the data plane.
+func colibriPaths(paths []snet.Path) []snet.Path {
+	var colPaths []snet.Path
+	for _, p := range paths {
+		switch p.Dataplane().(type) {
+		case snetpath.Colibri, *snetpath.Colibri:
+			colPaths = append(colPaths, p)
+		}
+	}
+	return colPaths
+}
@@ -17,34 +17,60 @@ package grpc_test
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"log"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/drkey"
+	"github.com/scionproto/scion/go/lib/metrics"
+	"github.com/scionproto/scion/go/lib/prom"
 	"github.com/scionproto/scion/go/lib/scrypto/cppki"
+	"github.com/scionproto/scion/go/lib/serrors"
+	colpath "github.com/scionproto/scion/go/lib/slayers/path/colibri"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/mock_snet"
+	snetpath "github.com/scionproto/scion/go/lib/snet/path"
 	"github.com/scionproto/scion/go/lib/xtest"
 	dk_grpc "github.com/scionproto/scion/go/pkg/cs/drkey/grpc"
 	"github.com/scionproto/scion/go/pkg/cs/drkey/mock_drkey"
 	"github.com/scionproto/scion/go/pkg/grpc/mock_grpc"
 	cppb "github.com/scionproto/scion/go/pkg/proto/control_plane"
+	dkpb "github.com/scionproto/scion/go/pkg/proto/drkey"
 	"github.com/scionproto/scion/go/pkg/trust"
 	"github.com/scionproto/scion/go/pkg/trust/mock_trust"
 )
 
 func dialer(creds credentials.TransportCredentials,
 	drkeyServer cppb.DRKeyInterServiceServer) func(context.Context, string) (net.Conn, error) {
+
+	return dialerWithPeerAddr(creds, drkeyServer, nil)
+}
+
+// dialerWithPeerAddr is like dialer, but if peerAddr is not nil, the server sees it as the
+// address of the client.
+func dialerWithPeerAddr(creds credentials.TransportCredentials,
+	drkeyServer cppb.DRKeyInterServiceServer,
+	peerAddr net.Addr) func(context.Context, string) (net.Conn, error) {
+
 	bufsize := 1024 * 1024
-	listener := bufconn.Listen(bufsize)
+	bufListener := bufconn.Listen(bufsize)
+	var listener net.Listener = bufListener
+	if peerAddr != nil {
+		listener = peerAddrListener{Listener: bufListener, peerAddr: peerAddr}
+	}
 
 	server := grpc.NewServer(grpc.Creds(creds))
 
@@ -57,7 +83,7 @@ func dialer(creds credentials.TransportCredentials,
 	}()
 
 	return func(context.Context, string) (net.Conn, error) {
-		return listener.Dial()
+		return bufListener.Dial()
 	}
 }
 
@@ -138,3 +164,453 @@ func TestLvl1KeyFetching(t *testing.T) {
 	_, err = fetcher.Lvl1(context.Background(), meta)
 	require.NoError(t, err)
 }
+
+func TestLvl1FetchingMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srcIA := xtest.MustParseIA("1-ff00:0:111")
+	grpcDialer := mock_grpc.NewMockDialer(ctrl)
+	grpcDialer.EXPECT().Dial(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		dialRemotes(map[addr.IA]serverDialer{
+			srcIA: dialer(serverCreds(t, "ff00_0_111"), &flakyServer{failures: 1}),
+		}))
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Dataplane().AnyTimes().Return(nil)
+	path.EXPECT().UnderlayNextHop().AnyTimes().Return(&net.UDPAddr{})
+	router := mock_snet.NewMockRouter(ctrl)
+	router.EXPECT().AllRoutes(gomock.Any(), gomock.Any()).AnyTimes().
+		Return([]snet.Path{path}, nil)
+
+	retries := metrics.NewTestCounter()
+	latency := &testHistogram{observations: map[string][]float64{}}
+	fetcher := dk_grpc.Fetcher{
+		Dialer:     grpcDialer,
+		Router:     router,
+		MaxRetries: 3,
+		Latency:    latency,
+		Retries:    retries,
+	}
+
+	meta := drkey.Lvl1Meta{
+		ProtoId:  drkey.Generic,
+		Validity: time.Now(),
+		SrcIA:    srcIA,
+	}
+	_, err := fetcher.Lvl1(context.Background(), meta)
+	require.NoError(t, err)
+
+	require.Equal(t, float64(1),
+		metrics.CounterValue(retries.With(prom.LabelSrc, srcIA.String())))
+	labels := strings.Join([]string{prom.LabelSrc, srcIA.String(),
+		prom.LabelResult, prom.Success}, ",")
+	require.Len(t, latency.observations, 1)
+	require.Len(t, latency.observations[labels], 1)
+	require.Greater(t, latency.observations[labels][0], float64(0))
+}
+
+func TestLvl1BatchFetching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	grpcDialer := mock_grpc.NewMockDialer(ctrl)
+	// one connection per remote, although there are two keys from the same remote
+	grpcDialer.EXPECT().Dial(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		dialRemotes(map[addr.IA]serverDialer{
+			xtest.MustParseIA("1-ff00:0:111"): dialer(serverCreds(t, "ff00_0_111"),
+				&flakyServer{}),
+			xtest.MustParseIA("1-ff00:0:112"): dialer(serverCreds(t, "ff00_0_112"),
+				&flakyServer{}),
+		}))
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Dataplane().AnyTimes().Return(nil)
+	path.EXPECT().UnderlayNextHop().AnyTimes().Return(&net.UDPAddr{})
+	router := mock_snet.NewMockRouter(ctrl)
+	router.EXPECT().AllRoutes(gomock.Any(), gomock.Any()).AnyTimes().
+		Return([]snet.Path{path}, nil)
+
+	fetcher := dk_grpc.Fetcher{
+		Dialer:     grpcDialer,
+		Router:     router,
+		MaxRetries: 3,
+	}
+
+	now := time.Now()
+	metas := []drkey.Lvl1Meta{
+		{
+			ProtoId:  drkey.Generic,
+			Validity: now,
+			SrcIA:    xtest.MustParseIA("1-ff00:0:111"),
+		},
+		{
+			ProtoId:  drkey.Generic,
+			Validity: now,
+			SrcIA:    xtest.MustParseIA("1-ff00:0:112"),
+		},
+		{
+			ProtoId:  drkey.Generic,
+			Validity: now.Add(time.Minute),
+			SrcIA:    xtest.MustParseIA("1-ff00:0:111"),
+		},
+	}
+	keys, errs := fetcher.Lvl1Batch(context.Background(), metas)
+	require.Len(t, keys, len(metas))
+	require.Len(t, errs, len(metas))
+	for i, meta := range metas {
+		require.NoError(t, errs[i])
+		require.Equal(t, meta.SrcIA, keys[i].SrcIA)
+		require.Equal(t, meta.ProtoId, keys[i].ProtoId)
+	}
+}
+
+func TestLvl1FetchingWrongPeer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srcIA := xtest.MustParseIA("1-ff00:0:111")
+	grpcDialer := mock_grpc.NewMockDialer(ctrl)
+	// the remote presents the certificate of another IA: the handshake fails on each try
+	grpcDialer.EXPECT().Dial(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		dialRemotes(map[addr.IA]serverDialer{
+			srcIA: dialer(serverCreds(t, "ff00_0_112"), &flakyServer{}),
+		}))
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Dataplane().AnyTimes().Return(nil)
+	path.EXPECT().UnderlayNextHop().AnyTimes().Return(&net.UDPAddr{})
+	router := mock_snet.NewMockRouter(ctrl)
+	router.EXPECT().AllRoutes(gomock.Any(), gomock.Any()).AnyTimes().
+		Return([]snet.Path{path}, nil)
+
+	fetcher := dk_grpc.Fetcher{
+		Dialer:     grpcDialer,
+		Router:     router,
+		MaxRetries: 3,
+	}
+	meta := drkey.Lvl1Meta{
+		ProtoId:  drkey.Generic,
+		Validity: time.Now(),
+		SrcIA:    srcIA,
+	}
+	_, err := fetcher.Lvl1(context.Background(), meta)
+	require.Error(t, err)
+}
+
+func TestClientTLSConfig(t *testing.T) {
+	cert111, err := x509.ParseCertificate(loadCert(t, "ff00_0_111").Certificate[0])
+	require.NoError(t, err)
+	cases := map[string]struct {
+		serverName string
+		verify     func(tls.ConnectionState) error
+		assertErr  assert.ErrorAssertionFunc
+	}{
+		"matching IA": {
+			serverName: "1-ff00:0:111,127.0.0.1",
+			assertErr:  assert.NoError,
+		},
+		"other IA": {
+			serverName: "1-ff00:0:112,127.0.0.1",
+			assertErr:  assert.Error,
+		},
+		"no IA": {
+			serverName: "127.0.0.1",
+			assertErr:  assert.Error,
+		},
+		"configured verification fails": {
+			serverName: "1-ff00:0:111,127.0.0.1",
+			verify: func(tls.ConnectionState) error {
+				return serrors.New("test")
+			},
+			assertErr: assert.Error,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			conf := &tls.Config{
+				InsecureSkipVerify: true,
+				VerifyConnection:   tc.verify,
+			}
+			c := dk_grpc.ClientTLSConfig(conf)
+			require.Equal(t, conf.InsecureSkipVerify, c.InsecureSkipVerify)
+			err := c.VerifyConnection(tls.ConnectionState{
+				ServerName:       tc.serverName,
+				PeerCertificates: []*x509.Certificate{cert111},
+			})
+			tc.assertErr(t, err)
+		})
+	}
+}
+
+func TestLvl1FetchingDialTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const dialTimeout = 50 * time.Millisecond
+	var remaining []time.Duration
+	grpcDialer := mock_grpc.NewMockDialer(ctrl)
+	// the dial hangs until the context of the attempt is done
+	grpcDialer.EXPECT().Dial(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(ctx context.Context, _ net.Addr) (*grpc.ClientConn, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			remaining = append(remaining, time.Until(deadline))
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+	path := mock_snet.NewMockPath(ctrl)
+	path.EXPECT().Dataplane().AnyTimes().Return(nil)
+	path.EXPECT().UnderlayNextHop().AnyTimes().Return(&net.UDPAddr{})
+	router := mock_snet.NewMockRouter(ctrl)
+	router.EXPECT().AllRoutes(gomock.Any(), gomock.Any()).AnyTimes().
+		Return([]snet.Path{path}, nil)
+
+	fetcher := dk_grpc.Fetcher{
+		Dialer:      grpcDialer,
+		Router:      router,
+		MaxRetries:  2,
+		DialTimeout: dialTimeout,
+	}
+	meta := drkey.Lvl1Meta{
+		ProtoId:  drkey.Generic,
+		Validity: time.Now(),
+		SrcIA:    xtest.MustParseIA("1-ff00:0:111"),
+	}
+	// the overall deadline allows for many attempts
+	ctx, cancelF := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelF()
+	start := time.Now()
+	_, err := fetcher.Lvl1(ctx, meta)
+	require.Error(t, err)
+	require.Less(t, time.Since(start), time.Second)
+	require.Len(t, remaining, 2)
+	for _, d := range remaining {
+		require.LessOrEqual(t, d, dialTimeout)
+	}
+	require.NoError(t, ctx.Err())
+}
+
+func TestLvl1FetchingOverColibri(t *testing.T) {
+	srcIA := xtest.MustParseIA("1-ff00:0:111")
+	dstIA := xtest.MustParseIA("1-ff00:0:112")
+	colibriPath := newColibriDataplanePath(t)
+	cases := map[string]struct {
+		fetcherRequires bool
+		serverRequires  bool
+		routerPath      snet.DataplanePath // path to the remote, returned by the router
+		peerPath        snet.DataplanePath // path of the client, as seen by the server
+		expectDial      bool
+		expectErr       bool
+	}{
+		"colibri": {
+			fetcherRequires: true,
+			serverRequires:  true,
+			routerPath:      colibriPath,
+			peerPath:        colibriPath,
+			expectDial:      true,
+		},
+		"no_colibri_path_to_remote": {
+			fetcherRequires: true,
+			serverRequires:  true,
+			expectErr:       true,
+		},
+		"server_rejects_non_colibri_peer": {
+			serverRequires: true,
+			expectDial:     true,
+			expectErr:      true,
+		},
+		"colibri_not_required": {
+			expectDial: true,
+		},
+	}
+	for name, tc := range cases {
+		name, tc := name, tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			engine := mock_drkey.NewMockServiceEngine(ctrl)
+			engine.EXPECT().DeriveLvl1(gomock.Any()).AnyTimes().
+				Return(drkey.Lvl1Key{Epoch: drkey.NewEpoch(0, 100)}, nil)
+			drkeyServ := &dk_grpc.Server{
+				LocalIA:        srcIA,
+				Engine:         engine,
+				RequireColibri: tc.serverRequires,
+			}
+			serverCert := loadCert(t, "ff00_0_111")
+			srvCreds := credentials.NewTLS(&tls.Config{
+				Certificates: []tls.Certificate{serverCert},
+				ClientAuth:   tls.RequireAnyClientCert,
+			})
+			// the client reaches the server over a (mocked) SCION connection with peerPath
+			peerAddr := &snet.UDPAddr{
+				IA:   dstIA,
+				Host: &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 10000},
+				Path: tc.peerPath,
+			}
+			srvDialer := dialerWithPeerAddr(srvCreds, drkeyServ, peerAddr)
+
+			clientCert := loadCert(t, "ff00_0_112")
+			grpcDialer := mock_grpc.NewMockDialer(ctrl)
+			dial := grpcDialer.EXPECT().Dial(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, remote net.Addr) (*grpc.ClientConn, error) {
+					require.Equal(t, tc.routerPath, remote.(*snet.SVCAddr).Path)
+					creds := credentials.NewTLS(&tls.Config{
+						InsecureSkipVerify: true,
+						Certificates:       []tls.Certificate{clientCert},
+					})
+					return grpc.DialContext(ctx, srcIA.String()+",127.0.0.1:10000",
+						grpc.WithTransportCredentials(creds),
+						grpc.WithContextDialer(srvDialer),
+					)
+				})
+			if tc.expectDial {
+				dial.MinTimes(1)
+			} else {
+				dial.Times(0)
+			}
+
+			path := mock_snet.NewMockPath(ctrl)
+			path.EXPECT().Dataplane().AnyTimes().Return(tc.routerPath)
+			path.EXPECT().UnderlayNextHop().AnyTimes().Return(&net.UDPAddr{})
+			router := mock_snet.NewMockRouter(ctrl)
+			router.EXPECT().AllRoutes(gomock.Any(), gomock.Any()).AnyTimes().
+				Return([]snet.Path{path}, nil)
+
+			fetcher := dk_grpc.Fetcher{
+				Dialer:         grpcDialer,
+				Router:         router,
+				MaxRetries:     2,
+				RequireColibri: tc.fetcherRequires,
+			}
+			meta := drkey.Lvl1Meta{
+				ProtoId:  drkey.Generic,
+				Validity: time.Now(),
+				SrcIA:    srcIA,
+				DstIA:    dstIA,
+			}
+			_, err := fetcher.Lvl1(context.Background(), meta)
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+type serverDialer = func(context.Context, string) (net.Conn, error)
+
+// peerAddrListener accepts connections that report peerAddr as their remote address.
+type peerAddrListener struct {
+	net.Listener
+	peerAddr net.Addr
+}
+
+func (l peerAddrListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return peerAddrConn{Conn: conn, peerAddr: l.peerAddr}, nil
+}
+
+type peerAddrConn struct {
+	net.Conn
+	peerAddr net.Addr
+}
+
+func (c peerAddrConn) RemoteAddr() net.Addr {
+	return c.peerAddr
+}
+
+// newColibriDataplanePath returns a COLIBRI path with two hop fields.
+func newColibriDataplanePath(t *testing.T) snet.DataplanePath {
+	return newColibriDataplanePathWithEgress(t, 1)
+}
+
+// newColibriDataplanePathWithEgress returns a COLIBRI path with two hop fields, leaving the
+// first AS through egress.
+func newColibriDataplanePathWithEgress(t *testing.T, egress uint16) snet.DataplanePath {
+	p := &colpath.ColibriPath{
+		InfoField: &colpath.InfoField{
+			ResIdSuffix: make([]byte, 12),
+			HFCount:     2,
+		},
+		HopFields: []*colpath.HopField{
+			{EgressId: egress, Mac: make([]byte, 4)},
+			{IngressId: 2, Mac: make([]byte, 4)},
+		},
+	}
+	min, err := p.ToMinimal()
+	require.NoError(t, err)
+	return snetpath.Colibri{ColibriPathMinimal: *min}
+}
+
+// serverCreds returns the credentials of a server presenting the AS certificate of as,
+// e.g. "ff00_0_111".
+func serverCreds(t *testing.T, as string) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{loadCert(t, as)}})
+}
+
+// loadCert returns the AS certificate of as, e.g. "ff00_0_111", with its key.
+func loadCert(t *testing.T, as string) tls.Certificate {
+	dir := "testdata/common/ISD1/AS" + as + "/crypto/as/"
+	cert, err := tls.LoadX509KeyPair(dir+"ISD1-AS"+as+".pem", dir+"cp-as.key")
+	require.NoError(t, err)
+	return cert
+}
+
+// dialRemotes returns a Dial function connecting to the server of the remote IA.
+// The certificate presented by the server is only checked to belong to the remote IA.
+func dialRemotes(servers map[addr.IA]serverDialer) func(context.Context, net.Addr) (
+	*grpc.ClientConn, error) {
+
+	return func(ctx context.Context, remote net.Addr) (*grpc.ClientConn, error) {
+		ia := remote.(*snet.SVCAddr).IA
+		creds := credentials.NewTLS(dk_grpc.ClientTLSConfig(&tls.Config{
+			InsecureSkipVerify: true,
+		}))
+		return grpc.DialContext(ctx, ia.String()+",127.0.0.1:10000",
+			grpc.WithTransportCredentials(creds),
+			grpc.WithContextDialer(servers[ia]),
+		)
+	}
+}
+
+// flakyServer fails the first level 1 requests, and then answers with an empty key.
+type flakyServer struct {
+	cppb.UnimplementedDRKeyInterServiceServer
+	failures int
+}
+
+func (s *flakyServer) Lvl1(context.Context, *dkpb.Lvl1Request) (*dkpb.Lvl1Response, error) {
+	if s.failures > 0 {
+		s.failures--
+		return nil, status.Error(codes.Unavailable, "flaky server")
+	}
+	return drkey.KeyToLvl1Resp(drkey.Lvl1Key{Epoch: drkey.NewEpoch(0, 100)})
+}
+
+// testHistogram records the observations per set of labels.
+type testHistogram struct {
+	labels       []string
+	observations map[string][]float64
+}
+
+func (h *testHistogram) With(labelValues ...string) metrics.Histogram {
+	return &testHistogram{
+		labels:       append(append([]string{}, h.labels...), labelValues...),
+		observations: h.observations,
+	}
+}
+
+func (h *testHistogram) Observe(value float64) {
+	key := strings.Join(h.labels, ",")
+	h.observations[key] = append(h.observations[key], value)
+}
@@ -93,7 +93,8 @@ func (SimpleDialer) Dial(ctx context.Context, address net.Addr) (*grpc.ClientCon
 // for AS internal communication, and is capable of resolving svc addresses.
 type TCPDialer struct {
 	SvcResolver func(addr.HostSVC) []resolver.Address
-	LocalAddr   *net.TCPAddr
+	// LocalAddr is the optional local address the connections are dialed from.
+	LocalAddr *net.TCPAddr
 }
 
 // Dial dials a gRPC connection over TCP. It resolves svc addresses.
@@ -122,11 +123,19 @@ func (t *TCPDialer) Dial(ctx context.Context, dst net.Addr) (*grpc.ClientConn, e
 		)
 	}
 
-	return grpc.DialContext(ctx, dst.String(),
+	opts := []grpc.DialOption{
 		grpc.WithInsecure(),
 		UnaryClientInterceptor(),
 		StreamClientInterceptor(),
-	)
+	}
+	if t.LocalAddr != nil {
+		opts = append(opts, grpc.WithContextDialer(
+			func(ctx context.Context, addr string) (net.Conn, error) {
+				d := net.Dialer{LocalAddr: t.LocalAddr}
+				return d.DialContext(ctx, "tcp", addr)
+			}))
+	}
+	return grpc.DialContext(ctx, dst.String(), opts...)
 }
 
 // AddressRewriter redirects to QUIC endpoints.
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	helloworldpb "google.golang.org/grpc/examples/helloworld/helloworld"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/resolver"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -140,6 +141,34 @@ func TestTCPDial(t *testing.T) {
 
 }
 
+func TestTCPDialLocalAddr(t *testing.T) {
+	lis, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	peers := make(chan net.Addr, 1)
+	s := grpc.NewServer()
+	helloworldpb.RegisterGreeterServer(s, &peerServer{peers: peers})
+	go func() { s.Serve(lis) }()
+	defer s.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	local := &net.TCPAddr{IP: net.ParseIP("127.0.0.2")}
+	dialer := libgrpc.TCPDialer{LocalAddr: local}
+	conn, err := dialer.Dial(ctx, lis.Addr())
+	require.NoError(t, err)
+	defer conn.Close()
+	c := helloworldpb.NewGreeterClient(conn)
+	_, err = c.SayHello(ctx, &helloworldpb.HelloRequest{Name: "dummy"})
+	require.NoError(t, err)
+
+	p := <-peers
+	require.IsType(t, &net.TCPAddr{}, p)
+	require.True(t, local.IP.Equal(p.(*net.TCPAddr).IP), "peer %s", p)
+}
+
 // server is used to implement helloworld.GreeterServer.
 type server struct {
 	helloworldpb.UnimplementedGreeterServer
@@ -152,3 +181,18 @@ func (s *server) SayHello(ctx context.Context,
 	log.Printf("Received: %v", in.GetName())
 	return &helloworldpb.HelloReply{Message: "Hello " + in.GetName()}, nil
 }
+
+// peerServer implements helloworld.GreeterServer, reporting the address of each client.
+type peerServer struct {
+	helloworldpb.UnimplementedGreeterServer
+	peers chan<- net.Addr
+}
+
+func (s *peerServer) SayHello(ctx context.Context,
+	in *helloworldpb.HelloRequest) (*helloworldpb.HelloReply, error) {
+
+	if p, ok := peer.FromContext(ctx); ok {
+		s.peers <- p.Addr
+	}
+	return &helloworldpb.HelloReply{Message: "Hello " + in.GetName()}, nil
+}
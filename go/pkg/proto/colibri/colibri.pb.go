@@ -1552,6 +1552,108 @@ func (x *ListStitchablesResponse) GetDown() []*ListReservationsResponse_Reservat
 	return nil
 }
 
+type ListTransportPathsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DstIa uint64 `protobuf:"varint,1,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
+}
+
+func (x *ListTransportPathsRequest) Reset() {
+	*x = ListTransportPathsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTransportPathsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransportPathsRequest) ProtoMessage() {}
+
+func (x *ListTransportPathsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransportPathsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransportPathsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTransportPathsRequest) GetDstIa() uint64 {
+	if x != nil {
+		return x.DstIa
+	}
+	return 0
+}
+
+type ListTransportPathsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorMessage   string   `protobuf:"bytes,1,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	TransportPaths [][]byte `protobuf:"bytes,2,rep,name=transport_paths,json=transportPaths,proto3" json:"transport_paths,omitempty"`
+}
+
+func (x *ListTransportPathsResponse) Reset() {
+	*x = ListTransportPathsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTransportPathsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransportPathsResponse) ProtoMessage() {}
+
+func (x *ListTransportPathsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransportPathsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransportPathsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListTransportPathsResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ListTransportPathsResponse) GetTransportPaths() [][]byte {
+	if x != nil {
+		return x.TransportPaths
+	}
+	return nil
+}
+
 type SetupReservationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1572,7 +1674,7 @@ type SetupReservationRequest struct {
 func (x *SetupReservationRequest) Reset() {
 	*x = SetupReservationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[26]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1585,7 +1687,7 @@ func (x *SetupReservationRequest) String() string {
 func (*SetupReservationRequest) ProtoMessage() {}
 
 func (x *SetupReservationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[26]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1598,7 +1700,7 @@ func (x *SetupReservationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetupReservationRequest.ProtoReflect.Descriptor instead.
 func (*SetupReservationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{26}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *SetupReservationRequest) GetId() *ReservationID {
@@ -1685,7 +1787,7 @@ type SetupReservationResponse struct {
 func (x *SetupReservationResponse) Reset() {
 	*x = SetupReservationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[27]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1698,7 +1800,7 @@ func (x *SetupReservationResponse) String() string {
 func (*SetupReservationResponse) ProtoMessage() {}
 
 func (x *SetupReservationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[27]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1711,7 +1813,7 @@ func (x *SetupReservationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetupReservationResponse.ProtoReflect.Descriptor instead.
 func (*SetupReservationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{27}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *SetupReservationResponse) GetFailure() *SetupReservationResponse_Failure {
@@ -1756,7 +1858,7 @@ type CleanupReservationRequest struct {
 func (x *CleanupReservationRequest) Reset() {
 	*x = CleanupReservationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[28]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1769,7 +1871,7 @@ func (x *CleanupReservationRequest) String() string {
 func (*CleanupReservationRequest) ProtoMessage() {}
 
 func (x *CleanupReservationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[28]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1782,7 +1884,7 @@ func (x *CleanupReservationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupReservationRequest.ProtoReflect.Descriptor instead.
 func (*CleanupReservationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{28}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *CleanupReservationRequest) GetBase() *Request {
@@ -1826,7 +1928,7 @@ type CleanupReservationResponse struct {
 func (x *CleanupReservationResponse) Reset() {
 	*x = CleanupReservationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[29]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1839,7 +1941,7 @@ func (x *CleanupReservationResponse) String() string {
 func (*CleanupReservationResponse) ProtoMessage() {}
 
 func (x *CleanupReservationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[29]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1852,7 +1954,7 @@ func (x *CleanupReservationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CleanupReservationResponse.ProtoReflect.Descriptor instead.
 func (*CleanupReservationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{29}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *CleanupReservationResponse) GetFailure() *CleanupReservationResponse_Failure {
@@ -1891,7 +1993,7 @@ type AddAdmissionEntryRequest struct {
 func (x *AddAdmissionEntryRequest) Reset() {
 	*x = AddAdmissionEntryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[30]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1904,7 +2006,7 @@ func (x *AddAdmissionEntryRequest) String() string {
 func (*AddAdmissionEntryRequest) ProtoMessage() {}
 
 func (x *AddAdmissionEntryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[30]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1917,7 +2019,7 @@ func (x *AddAdmissionEntryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddAdmissionEntryRequest.ProtoReflect.Descriptor instead.
 func (*AddAdmissionEntryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{30}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *AddAdmissionEntryRequest) GetDstHost() []byte {
@@ -1966,7 +2068,7 @@ type AddAdmissionEntryResponse struct {
 func (x *AddAdmissionEntryResponse) Reset() {
 	*x = AddAdmissionEntryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[31]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1979,7 +2081,7 @@ func (x *AddAdmissionEntryResponse) String() string {
 func (*AddAdmissionEntryResponse) ProtoMessage() {}
 
 func (x *AddAdmissionEntryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[31]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1992,7 +2094,7 @@ func (x *AddAdmissionEntryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddAdmissionEntryResponse.ProtoReflect.Descriptor instead.
 func (*AddAdmissionEntryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{31}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *AddAdmissionEntryResponse) GetValidUntil() uint32 {
@@ -2011,7 +2113,7 @@ type Response_Success struct {
 func (x *Response_Success) Reset() {
 	*x = Response_Success{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[32]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2024,7 +2126,7 @@ func (x *Response_Success) String() string {
 func (*Response_Success) ProtoMessage() {}
 
 func (x *Response_Success) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[32]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2053,7 +2155,7 @@ type Response_Failure struct {
 func (x *Response_Failure) Reset() {
 	*x = Response_Failure{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[33]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2066,7 +2168,7 @@ func (x *Response_Failure) String() string {
 func (*Response_Failure) ProtoMessage() {}
 
 func (x *Response_Failure) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[33]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2125,7 +2227,7 @@ type SegmentSetupRequest_Params struct {
 func (x *SegmentSetupRequest_Params) Reset() {
 	*x = SegmentSetupRequest_Params{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[34]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2138,7 +2240,7 @@ func (x *SegmentSetupRequest_Params) String() string {
 func (*SegmentSetupRequest_Params) ProtoMessage() {}
 
 func (x *SegmentSetupRequest_Params) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[34]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2250,7 +2352,7 @@ type SegmentSetupResponse_Failure struct {
 func (x *SegmentSetupResponse_Failure) Reset() {
 	*x = SegmentSetupResponse_Failure{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[35]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2263,7 +2365,7 @@ func (x *SegmentSetupResponse_Failure) String() string {
 func (*SegmentSetupResponse_Failure) ProtoMessage() {}
 
 func (x *SegmentSetupResponse_Failure) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[35]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2312,7 +2414,7 @@ type ListReservationsResponse_ReservationLooks struct {
 func (x *ListReservationsResponse_ReservationLooks) Reset() {
 	*x = ListReservationsResponse_ReservationLooks{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[36]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2325,7 +2427,7 @@ func (x *ListReservationsResponse_ReservationLooks) String() string {
 func (*ListReservationsResponse_ReservationLooks) ProtoMessage() {}
 
 func (x *ListReservationsResponse_ReservationLooks) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[36]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2419,7 +2521,7 @@ type E2ESetupRequest_PathParams struct {
 func (x *E2ESetupRequest_PathParams) Reset() {
 	*x = E2ESetupRequest_PathParams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[37]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2432,7 +2534,7 @@ func (x *E2ESetupRequest_PathParams) String() string {
 func (*E2ESetupRequest_PathParams) ProtoMessage() {}
 
 func (x *E2ESetupRequest_PathParams) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[37]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2494,7 +2596,7 @@ type E2ESetupRequest_E2ESetupBead struct {
 func (x *E2ESetupRequest_E2ESetupBead) Reset() {
 	*x = E2ESetupRequest_E2ESetupBead{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[38]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2507,7 +2609,7 @@ func (x *E2ESetupRequest_E2ESetupBead) String() string {
 func (*E2ESetupRequest_E2ESetupBead) ProtoMessage() {}
 
 func (x *E2ESetupRequest_E2ESetupBead) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[38]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2543,7 +2645,7 @@ type E2ESetupResponse_Failure struct {
 func (x *E2ESetupResponse_Failure) Reset() {
 	*x = E2ESetupResponse_Failure{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[39]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2556,7 +2658,7 @@ func (x *E2ESetupResponse_Failure) String() string {
 func (*E2ESetupResponse_Failure) ProtoMessage() {}
 
 func (x *E2ESetupResponse_Failure) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[39]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2606,7 +2708,7 @@ type SetupReservationResponse_Failure struct {
 func (x *SetupReservationResponse_Failure) Reset() {
 	*x = SetupReservationResponse_Failure{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[40]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2619,7 +2721,7 @@ func (x *SetupReservationResponse_Failure) String() string {
 func (*SetupReservationResponse_Failure) ProtoMessage() {}
 
 func (x *SetupReservationResponse_Failure) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[40]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2632,7 +2734,7 @@ func (x *SetupReservationResponse_Failure) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetupReservationResponse_Failure.ProtoReflect.Descriptor instead.
 func (*SetupReservationResponse_Failure) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{27, 0}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{29, 0}
 }
 
 func (x *SetupReservationResponse_Failure) GetErrorMessage() string {
@@ -2668,7 +2770,7 @@ type SetupReservationResponse_Success struct {
 func (x *SetupReservationResponse_Success) Reset() {
 	*x = SetupReservationResponse_Success{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[41]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2681,7 +2783,7 @@ func (x *SetupReservationResponse_Success) String() string {
 func (*SetupReservationResponse_Success) ProtoMessage() {}
 
 func (x *SetupReservationResponse_Success) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[41]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2694,7 +2796,7 @@ func (x *SetupReservationResponse_Success) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetupReservationResponse_Success.ProtoReflect.Descriptor instead.
 func (*SetupReservationResponse_Success) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{27, 1}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{29, 1}
 }
 
 func (x *SetupReservationResponse_Success) GetTransportPath() []byte {
@@ -2723,7 +2825,7 @@ type CleanupReservationResponse_Failure struct {
 func (x *CleanupReservationResponse_Failure) Reset() {
 	*x = CleanupReservationResponse_Failure{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[42]
+		mi := &file_proto_colibri_v1_colibri_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2736,7 +2838,7 @@ func (x *CleanupReservationResponse_Failure) String() string {
 func (*CleanupReservationResponse_Failure) ProtoMessage() {}
 
 func (x *CleanupReservationResponse_Failure) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[42]
+	mi := &file_proto_colibri_v1_colibri_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2749,7 +2851,7 @@ func (x *CleanupReservationResponse_Failure) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use CleanupReservationResponse_Failure.ProtoReflect.Descriptor instead.
 func (*CleanupReservationResponse_Failure) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{29, 0}
+	return file_proto_colibri_v1_colibri_proto_rawDescGZIP(), []int{31, 0}
 }
 
 func (x *CleanupReservationResponse_Failure) GetErrorMessage() string {
@@ -3076,7 +3178,17 @@ var file_proto_colibri_v1_colibri_proto_rawDesc = []byte{
 	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
 	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x65,
 	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x04, 0x64, 0x6f,
-	0x77, 0x6e, 0x22, 0xda, 0x03, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x75, 0x70, 0x52, 0x65, 0x73, 0x65,
+	0x77, 0x6e, 0x22, 0x32, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x15, 0x0a, 0x06, 0x64, 0x73, 0x74, 0x5f, 0x69, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x64, 0x73, 0x74, 0x49, 0x61, 0x22, 0x6a, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74,
+	0x68, 0x73, 0x22, 0xda, 0x03, 0x0a, 0x17, 0x53, 0x65, 0x74, 0x75, 0x70, 0x52, 0x65, 0x73, 0x65,
 	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f,
 	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
@@ -3180,7 +3292,7 @@ var file_proto_colibri_v1_colibri_proto_rawDesc = []byte{
 	0x64, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x61, 0x6c, 0x69,
 	0x64, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x76,
-	0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x32, 0xa6, 0x0a, 0x0a, 0x0e, 0x43, 0x6f,
+	0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x32, 0x99, 0x0b, 0x0a, 0x0e, 0x43, 0x6f,
 	0x6c, 0x69, 0x62, 0x72, 0x69, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5f, 0x0a, 0x0c,
 	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x75, 0x70, 0x12, 0x25, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e,
@@ -3263,10 +3375,18 @@ var file_proto_colibri_v1_colibri_proto_rawDesc = []byte{
 	0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
 	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x41, 0x64, 0x6d, 0x69, 0x73, 0x73,
 	0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f,
-	0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
-	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x22, 0x00, 0x12, 0x71, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
+	0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -3281,7 +3401,7 @@ func file_proto_colibri_v1_colibri_proto_rawDescGZIP() []byte {
 	return file_proto_colibri_v1_colibri_proto_rawDescData
 }
 
-var file_proto_colibri_v1_colibri_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_proto_colibri_v1_colibri_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_proto_colibri_v1_colibri_proto_goTypes = []interface{}{
 	(*ReservationID)(nil),                             // 0: proto.colibri.v1.ReservationID
 	(*PathEndProps)(nil),                              // 1: proto.colibri.v1.PathEndProps
@@ -3309,33 +3429,35 @@ var file_proto_colibri_v1_colibri_proto_goTypes = []interface{}{
 	(*CleanupE2EIndexResponse)(nil),                   // 23: proto.colibri.v1.CleanupE2EIndexResponse
 	(*ListStitchablesRequest)(nil),                    // 24: proto.colibri.v1.ListStitchablesRequest
 	(*ListStitchablesResponse)(nil),                   // 25: proto.colibri.v1.ListStitchablesResponse
-	(*SetupReservationRequest)(nil),                   // 26: proto.colibri.v1.SetupReservationRequest
-	(*SetupReservationResponse)(nil),                  // 27: proto.colibri.v1.SetupReservationResponse
-	(*CleanupReservationRequest)(nil),                 // 28: proto.colibri.v1.CleanupReservationRequest
-	(*CleanupReservationResponse)(nil),                // 29: proto.colibri.v1.CleanupReservationResponse
-	(*AddAdmissionEntryRequest)(nil),                  // 30: proto.colibri.v1.AddAdmissionEntryRequest
-	(*AddAdmissionEntryResponse)(nil),                 // 31: proto.colibri.v1.AddAdmissionEntryResponse
-	(*Response_Success)(nil),                          // 32: proto.colibri.v1.Response.Success
-	(*Response_Failure)(nil),                          // 33: proto.colibri.v1.Response.Failure
-	(*SegmentSetupRequest_Params)(nil),                // 34: proto.colibri.v1.SegmentSetupRequest.Params
-	(*SegmentSetupResponse_Failure)(nil),              // 35: proto.colibri.v1.SegmentSetupResponse.Failure
-	(*ListReservationsResponse_ReservationLooks)(nil), // 36: proto.colibri.v1.ListReservationsResponse.ReservationLooks
-	(*E2ESetupRequest_PathParams)(nil),                // 37: proto.colibri.v1.E2ESetupRequest.PathParams
-	(*E2ESetupRequest_E2ESetupBead)(nil),              // 38: proto.colibri.v1.E2ESetupRequest.E2ESetupBead
-	(*E2ESetupResponse_Failure)(nil),                  // 39: proto.colibri.v1.E2ESetupResponse.Failure
-	(*SetupReservationResponse_Failure)(nil),          // 40: proto.colibri.v1.SetupReservationResponse.Failure
-	(*SetupReservationResponse_Success)(nil),          // 41: proto.colibri.v1.SetupReservationResponse.Success
-	(*CleanupReservationResponse_Failure)(nil),        // 42: proto.colibri.v1.CleanupReservationResponse.Failure
+	(*ListTransportPathsRequest)(nil),                 // 26: proto.colibri.v1.ListTransportPathsRequest
+	(*ListTransportPathsResponse)(nil),                // 27: proto.colibri.v1.ListTransportPathsResponse
+	(*SetupReservationRequest)(nil),                   // 28: proto.colibri.v1.SetupReservationRequest
+	(*SetupReservationResponse)(nil),                  // 29: proto.colibri.v1.SetupReservationResponse
+	(*CleanupReservationRequest)(nil),                 // 30: proto.colibri.v1.CleanupReservationRequest
+	(*CleanupReservationResponse)(nil),                // 31: proto.colibri.v1.CleanupReservationResponse
+	(*AddAdmissionEntryRequest)(nil),                  // 32: proto.colibri.v1.AddAdmissionEntryRequest
+	(*AddAdmissionEntryResponse)(nil),                 // 33: proto.colibri.v1.AddAdmissionEntryResponse
+	(*Response_Success)(nil),                          // 34: proto.colibri.v1.Response.Success
+	(*Response_Failure)(nil),                          // 35: proto.colibri.v1.Response.Failure
+	(*SegmentSetupRequest_Params)(nil),                // 36: proto.colibri.v1.SegmentSetupRequest.Params
+	(*SegmentSetupResponse_Failure)(nil),              // 37: proto.colibri.v1.SegmentSetupResponse.Failure
+	(*ListReservationsResponse_ReservationLooks)(nil), // 38: proto.colibri.v1.ListReservationsResponse.ReservationLooks
+	(*E2ESetupRequest_PathParams)(nil),                // 39: proto.colibri.v1.E2ESetupRequest.PathParams
+	(*E2ESetupRequest_E2ESetupBead)(nil),              // 40: proto.colibri.v1.E2ESetupRequest.E2ESetupBead
+	(*E2ESetupResponse_Failure)(nil),                  // 41: proto.colibri.v1.E2ESetupResponse.Failure
+	(*SetupReservationResponse_Failure)(nil),          // 42: proto.colibri.v1.SetupReservationResponse.Failure
+	(*SetupReservationResponse_Success)(nil),          // 43: proto.colibri.v1.SetupReservationResponse.Success
+	(*CleanupReservationResponse_Failure)(nil),        // 44: proto.colibri.v1.CleanupReservationResponse.Failure
 }
 var file_proto_colibri_v1_colibri_proto_depIdxs = []int32{
 	0,  // 0: proto.colibri.v1.Request.id:type_name -> proto.colibri.v1.ReservationID
 	4,  // 1: proto.colibri.v1.Request.authenticators:type_name -> proto.colibri.v1.Authenticators
-	32, // 2: proto.colibri.v1.Response.success:type_name -> proto.colibri.v1.Response.Success
-	33, // 3: proto.colibri.v1.Response.failure:type_name -> proto.colibri.v1.Response.Failure
+	34, // 2: proto.colibri.v1.Response.success:type_name -> proto.colibri.v1.Response.Success
+	35, // 3: proto.colibri.v1.Response.failure:type_name -> proto.colibri.v1.Response.Failure
 	4,  // 4: proto.colibri.v1.Response.authenticators:type_name -> proto.colibri.v1.Authenticators
 	5,  // 5: proto.colibri.v1.SegmentSetupRequest.base:type_name -> proto.colibri.v1.Request
-	34, // 6: proto.colibri.v1.SegmentSetupRequest.params:type_name -> proto.colibri.v1.SegmentSetupRequest.Params
-	35, // 7: proto.colibri.v1.SegmentSetupResponse.failure:type_name -> proto.colibri.v1.SegmentSetupResponse.Failure
+	36, // 6: proto.colibri.v1.SegmentSetupRequest.params:type_name -> proto.colibri.v1.SegmentSetupRequest.Params
+	37, // 7: proto.colibri.v1.SegmentSetupResponse.failure:type_name -> proto.colibri.v1.SegmentSetupResponse.Failure
 	4,  // 8: proto.colibri.v1.SegmentSetupResponse.authenticators:type_name -> proto.colibri.v1.Authenticators
 	5,  // 9: proto.colibri.v1.ConfirmSegmentIndexRequest.base:type_name -> proto.colibri.v1.Request
 	6,  // 10: proto.colibri.v1.ConfirmSegmentIndexResponse.base:type_name -> proto.colibri.v1.Response
@@ -3346,43 +3468,43 @@ var file_proto_colibri_v1_colibri_proto_depIdxs = []int32{
 	5,  // 15: proto.colibri.v1.CleanupSegmentIndexRequest.base:type_name -> proto.colibri.v1.Request
 	6,  // 16: proto.colibri.v1.CleanupSegmentIndexResponse.base:type_name -> proto.colibri.v1.Response
 	4,  // 17: proto.colibri.v1.ListReservationsRequest.authenticators:type_name -> proto.colibri.v1.Authenticators
-	36, // 18: proto.colibri.v1.ListReservationsResponse.reservations:type_name -> proto.colibri.v1.ListReservationsResponse.ReservationLooks
+	38, // 18: proto.colibri.v1.ListReservationsResponse.reservations:type_name -> proto.colibri.v1.ListReservationsResponse.ReservationLooks
 	4,  // 19: proto.colibri.v1.ListReservationsResponse.authenticators:type_name -> proto.colibri.v1.Authenticators
 	5,  // 20: proto.colibri.v1.E2ERequest.base:type_name -> proto.colibri.v1.Request
 	19, // 21: proto.colibri.v1.E2ESetupRequest.base:type_name -> proto.colibri.v1.E2ERequest
-	37, // 22: proto.colibri.v1.E2ESetupRequest.params:type_name -> proto.colibri.v1.E2ESetupRequest.PathParams
-	38, // 23: proto.colibri.v1.E2ESetupRequest.allocationtrail:type_name -> proto.colibri.v1.E2ESetupRequest.E2ESetupBead
-	39, // 24: proto.colibri.v1.E2ESetupResponse.failure:type_name -> proto.colibri.v1.E2ESetupResponse.Failure
+	39, // 22: proto.colibri.v1.E2ESetupRequest.params:type_name -> proto.colibri.v1.E2ESetupRequest.PathParams
+	40, // 23: proto.colibri.v1.E2ESetupRequest.allocationtrail:type_name -> proto.colibri.v1.E2ESetupRequest.E2ESetupBead
+	41, // 24: proto.colibri.v1.E2ESetupResponse.failure:type_name -> proto.colibri.v1.E2ESetupResponse.Failure
 	4,  // 25: proto.colibri.v1.E2ESetupResponse.authenticators:type_name -> proto.colibri.v1.Authenticators
 	19, // 26: proto.colibri.v1.CleanupE2EIndexRequest.base:type_name -> proto.colibri.v1.E2ERequest
 	6,  // 27: proto.colibri.v1.CleanupE2EIndexResponse.base:type_name -> proto.colibri.v1.Response
-	36, // 28: proto.colibri.v1.ListStitchablesResponse.up:type_name -> proto.colibri.v1.ListReservationsResponse.ReservationLooks
-	36, // 29: proto.colibri.v1.ListStitchablesResponse.core:type_name -> proto.colibri.v1.ListReservationsResponse.ReservationLooks
-	36, // 30: proto.colibri.v1.ListStitchablesResponse.down:type_name -> proto.colibri.v1.ListReservationsResponse.ReservationLooks
+	38, // 28: proto.colibri.v1.ListStitchablesResponse.up:type_name -> proto.colibri.v1.ListReservationsResponse.ReservationLooks
+	38, // 29: proto.colibri.v1.ListStitchablesResponse.core:type_name -> proto.colibri.v1.ListReservationsResponse.ReservationLooks
+	38, // 30: proto.colibri.v1.ListStitchablesResponse.down:type_name -> proto.colibri.v1.ListReservationsResponse.ReservationLooks
 	0,  // 31: proto.colibri.v1.SetupReservationRequest.id:type_name -> proto.colibri.v1.ReservationID
 	0,  // 32: proto.colibri.v1.SetupReservationRequest.segments:type_name -> proto.colibri.v1.ReservationID
 	3,  // 33: proto.colibri.v1.SetupReservationRequest.steps:type_name -> proto.colibri.v1.PathStep
 	3,  // 34: proto.colibri.v1.SetupReservationRequest.steps_no_shortcuts:type_name -> proto.colibri.v1.PathStep
 	4,  // 35: proto.colibri.v1.SetupReservationRequest.authenticators:type_name -> proto.colibri.v1.Authenticators
-	40, // 36: proto.colibri.v1.SetupReservationResponse.failure:type_name -> proto.colibri.v1.SetupReservationResponse.Failure
-	41, // 37: proto.colibri.v1.SetupReservationResponse.success:type_name -> proto.colibri.v1.SetupReservationResponse.Success
+	42, // 36: proto.colibri.v1.SetupReservationResponse.failure:type_name -> proto.colibri.v1.SetupReservationResponse.Failure
+	43, // 37: proto.colibri.v1.SetupReservationResponse.success:type_name -> proto.colibri.v1.SetupReservationResponse.Success
 	4,  // 38: proto.colibri.v1.SetupReservationResponse.authenticators:type_name -> proto.colibri.v1.Authenticators
 	5,  // 39: proto.colibri.v1.CleanupReservationRequest.base:type_name -> proto.colibri.v1.Request
 	3,  // 40: proto.colibri.v1.CleanupReservationRequest.steps:type_name -> proto.colibri.v1.PathStep
-	42, // 41: proto.colibri.v1.CleanupReservationResponse.failure:type_name -> proto.colibri.v1.CleanupReservationResponse.Failure
+	44, // 41: proto.colibri.v1.CleanupReservationResponse.failure:type_name -> proto.colibri.v1.CleanupReservationResponse.Failure
 	4,  // 42: proto.colibri.v1.CleanupReservationResponse.authenticators:type_name -> proto.colibri.v1.Authenticators
 	1,  // 43: proto.colibri.v1.SegmentSetupRequest.Params.props_at_start:type_name -> proto.colibri.v1.PathEndProps
 	1,  // 44: proto.colibri.v1.SegmentSetupRequest.Params.props_at_end:type_name -> proto.colibri.v1.PathEndProps
 	2,  // 45: proto.colibri.v1.SegmentSetupRequest.Params.allocationtrail:type_name -> proto.colibri.v1.AllocationBead
 	3,  // 46: proto.colibri.v1.SegmentSetupRequest.Params.steps:type_name -> proto.colibri.v1.PathStep
-	33, // 47: proto.colibri.v1.SegmentSetupResponse.Failure.failure:type_name -> proto.colibri.v1.Response.Failure
-	34, // 48: proto.colibri.v1.SegmentSetupResponse.Failure.request:type_name -> proto.colibri.v1.SegmentSetupRequest.Params
+	35, // 47: proto.colibri.v1.SegmentSetupResponse.Failure.failure:type_name -> proto.colibri.v1.Response.Failure
+	36, // 48: proto.colibri.v1.SegmentSetupResponse.Failure.request:type_name -> proto.colibri.v1.SegmentSetupRequest.Params
 	0,  // 49: proto.colibri.v1.ListReservationsResponse.ReservationLooks.id:type_name -> proto.colibri.v1.ReservationID
 	3,  // 50: proto.colibri.v1.ListReservationsResponse.ReservationLooks.path_steps:type_name -> proto.colibri.v1.PathStep
 	0,  // 51: proto.colibri.v1.E2ESetupRequest.PathParams.segments:type_name -> proto.colibri.v1.ReservationID
 	3,  // 52: proto.colibri.v1.E2ESetupRequest.PathParams.steps:type_name -> proto.colibri.v1.PathStep
 	3,  // 53: proto.colibri.v1.E2ESetupRequest.PathParams.steps_no_shortcuts:type_name -> proto.colibri.v1.PathStep
-	38, // 54: proto.colibri.v1.E2ESetupResponse.Failure.allocationtrail:type_name -> proto.colibri.v1.E2ESetupRequest.E2ESetupBead
+	40, // 54: proto.colibri.v1.E2ESetupResponse.Failure.allocationtrail:type_name -> proto.colibri.v1.E2ESetupRequest.E2ESetupBead
 	7,  // 55: proto.colibri.v1.ColibriService.SegmentSetup:input_type -> proto.colibri.v1.SegmentSetupRequest
 	9,  // 56: proto.colibri.v1.ColibriService.ConfirmSegmentIndex:input_type -> proto.colibri.v1.ConfirmSegmentIndexRequest
 	11, // 57: proto.colibri.v1.ColibriService.ActivateSegmentIndex:input_type -> proto.colibri.v1.ActivateSegmentIndexRequest
@@ -3392,23 +3514,25 @@ var file_proto_colibri_v1_colibri_proto_depIdxs = []int32{
 	20, // 61: proto.colibri.v1.ColibriService.E2ESetup:input_type -> proto.colibri.v1.E2ESetupRequest
 	22, // 62: proto.colibri.v1.ColibriService.CleanupE2EIndex:input_type -> proto.colibri.v1.CleanupE2EIndexRequest
 	24, // 63: proto.colibri.v1.ColibriService.ListStitchables:input_type -> proto.colibri.v1.ListStitchablesRequest
-	26, // 64: proto.colibri.v1.ColibriService.SetupReservation:input_type -> proto.colibri.v1.SetupReservationRequest
-	28, // 65: proto.colibri.v1.ColibriService.CleanupReservation:input_type -> proto.colibri.v1.CleanupReservationRequest
-	30, // 66: proto.colibri.v1.ColibriService.AddAdmissionEntry:input_type -> proto.colibri.v1.AddAdmissionEntryRequest
-	8,  // 67: proto.colibri.v1.ColibriService.SegmentSetup:output_type -> proto.colibri.v1.SegmentSetupResponse
-	10, // 68: proto.colibri.v1.ColibriService.ConfirmSegmentIndex:output_type -> proto.colibri.v1.ConfirmSegmentIndexResponse
-	12, // 69: proto.colibri.v1.ColibriService.ActivateSegmentIndex:output_type -> proto.colibri.v1.ActivateSegmentIndexResponse
-	14, // 70: proto.colibri.v1.ColibriService.TeardownSegment:output_type -> proto.colibri.v1.TeardownSegmentResponse
-	16, // 71: proto.colibri.v1.ColibriService.CleanupSegmentIndex:output_type -> proto.colibri.v1.CleanupSegmentIndexResponse
-	18, // 72: proto.colibri.v1.ColibriService.ListReservations:output_type -> proto.colibri.v1.ListReservationsResponse
-	21, // 73: proto.colibri.v1.ColibriService.E2ESetup:output_type -> proto.colibri.v1.E2ESetupResponse
-	23, // 74: proto.colibri.v1.ColibriService.CleanupE2EIndex:output_type -> proto.colibri.v1.CleanupE2EIndexResponse
-	25, // 75: proto.colibri.v1.ColibriService.ListStitchables:output_type -> proto.colibri.v1.ListStitchablesResponse
-	27, // 76: proto.colibri.v1.ColibriService.SetupReservation:output_type -> proto.colibri.v1.SetupReservationResponse
-	29, // 77: proto.colibri.v1.ColibriService.CleanupReservation:output_type -> proto.colibri.v1.CleanupReservationResponse
-	31, // 78: proto.colibri.v1.ColibriService.AddAdmissionEntry:output_type -> proto.colibri.v1.AddAdmissionEntryResponse
-	67, // [67:79] is the sub-list for method output_type
-	55, // [55:67] is the sub-list for method input_type
+	28, // 64: proto.colibri.v1.ColibriService.SetupReservation:input_type -> proto.colibri.v1.SetupReservationRequest
+	30, // 65: proto.colibri.v1.ColibriService.CleanupReservation:input_type -> proto.colibri.v1.CleanupReservationRequest
+	32, // 66: proto.colibri.v1.ColibriService.AddAdmissionEntry:input_type -> proto.colibri.v1.AddAdmissionEntryRequest
+	26, // 67: proto.colibri.v1.ColibriService.ListTransportPaths:input_type -> proto.colibri.v1.ListTransportPathsRequest
+	8,  // 68: proto.colibri.v1.ColibriService.SegmentSetup:output_type -> proto.colibri.v1.SegmentSetupResponse
+	10, // 69: proto.colibri.v1.ColibriService.ConfirmSegmentIndex:output_type -> proto.colibri.v1.ConfirmSegmentIndexResponse
+	12, // 70: proto.colibri.v1.ColibriService.ActivateSegmentIndex:output_type -> proto.colibri.v1.ActivateSegmentIndexResponse
+	14, // 71: proto.colibri.v1.ColibriService.TeardownSegment:output_type -> proto.colibri.v1.TeardownSegmentResponse
+	16, // 72: proto.colibri.v1.ColibriService.CleanupSegmentIndex:output_type -> proto.colibri.v1.CleanupSegmentIndexResponse
+	18, // 73: proto.colibri.v1.ColibriService.ListReservations:output_type -> proto.colibri.v1.ListReservationsResponse
+	21, // 74: proto.colibri.v1.ColibriService.E2ESetup:output_type -> proto.colibri.v1.E2ESetupResponse
+	23, // 75: proto.colibri.v1.ColibriService.CleanupE2EIndex:output_type -> proto.colibri.v1.CleanupE2EIndexResponse
+	25, // 76: proto.colibri.v1.ColibriService.ListStitchables:output_type -> proto.colibri.v1.ListStitchablesResponse
+	29, // 77: proto.colibri.v1.ColibriService.SetupReservation:output_type -> proto.colibri.v1.SetupReservationResponse
+	31, // 78: proto.colibri.v1.ColibriService.CleanupReservation:output_type -> proto.colibri.v1.CleanupReservationResponse
+	33, // 79: proto.colibri.v1.ColibriService.AddAdmissionEntry:output_type -> proto.colibri.v1.AddAdmissionEntryResponse
+	27, // 80: proto.colibri.v1.ColibriService.ListTransportPaths:output_type -> proto.colibri.v1.ListTransportPathsResponse
+	68, // [68:81] is the sub-list for method output_type
+	55, // [55:68] is the sub-list for method input_type
 	55, // [55:55] is the sub-list for extension type_name
 	55, // [55:55] is the sub-list for extension extendee
 	0,  // [0:55] is the sub-list for field type_name
@@ -3733,7 +3857,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetupReservationRequest); i {
+			switch v := v.(*ListTransportPathsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3745,7 +3869,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetupReservationResponse); i {
+			switch v := v.(*ListTransportPathsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3757,7 +3881,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CleanupReservationRequest); i {
+			switch v := v.(*SetupReservationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3769,7 +3893,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CleanupReservationResponse); i {
+			switch v := v.(*SetupReservationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3781,7 +3905,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddAdmissionEntryRequest); i {
+			switch v := v.(*CleanupReservationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3793,7 +3917,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddAdmissionEntryResponse); i {
+			switch v := v.(*CleanupReservationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3805,7 +3929,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Response_Success); i {
+			switch v := v.(*AddAdmissionEntryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3817,7 +3941,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Response_Failure); i {
+			switch v := v.(*AddAdmissionEntryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3829,7 +3953,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SegmentSetupRequest_Params); i {
+			switch v := v.(*Response_Success); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3841,7 +3965,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SegmentSetupResponse_Failure); i {
+			switch v := v.(*Response_Failure); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3853,7 +3977,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListReservationsResponse_ReservationLooks); i {
+			switch v := v.(*SegmentSetupRequest_Params); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3865,7 +3989,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*E2ESetupRequest_PathParams); i {
+			switch v := v.(*SegmentSetupResponse_Failure); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3877,7 +4001,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*E2ESetupRequest_E2ESetupBead); i {
+			switch v := v.(*ListReservationsResponse_ReservationLooks); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3889,7 +4013,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*E2ESetupResponse_Failure); i {
+			switch v := v.(*E2ESetupRequest_PathParams); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3901,7 +4025,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetupReservationResponse_Failure); i {
+			switch v := v.(*E2ESetupRequest_E2ESetupBead); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3913,7 +4037,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetupReservationResponse_Success); i {
+			switch v := v.(*E2ESetupResponse_Failure); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3925,6 +4049,30 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			}
 		}
 		file_proto_colibri_v1_colibri_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetupReservationResponse_Failure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetupReservationResponse_Success); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_colibri_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CleanupReservationResponse_Failure); i {
 			case 0:
 				return &v.state
@@ -3951,7 +4099,7 @@ func file_proto_colibri_v1_colibri_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_colibri_v1_colibri_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   43,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -3989,6 +4137,7 @@ type ColibriServiceClient interface {
 	SetupReservation(ctx context.Context, in *SetupReservationRequest, opts ...grpc.CallOption) (*SetupReservationResponse, error)
 	CleanupReservation(ctx context.Context, in *CleanupReservationRequest, opts ...grpc.CallOption) (*CleanupReservationResponse, error)
 	AddAdmissionEntry(ctx context.Context, in *AddAdmissionEntryRequest, opts ...grpc.CallOption) (*AddAdmissionEntryResponse, error)
+	ListTransportPaths(ctx context.Context, in *ListTransportPathsRequest, opts ...grpc.CallOption) (*ListTransportPathsResponse, error)
 }
 
 type colibriServiceClient struct {
@@ -4107,6 +4256,15 @@ func (c *colibriServiceClient) AddAdmissionEntry(ctx context.Context, in *AddAdm
 	return out, nil
 }
 
+func (c *colibriServiceClient) ListTransportPaths(ctx context.Context, in *ListTransportPathsRequest, opts ...grpc.CallOption) (*ListTransportPathsResponse, error) {
+	out := new(ListTransportPathsResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriService/ListTransportPaths", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ColibriServiceServer is the server API for ColibriService service.
 type ColibriServiceServer interface {
 	SegmentSetup(context.Context, *SegmentSetupRequest) (*SegmentSetupResponse, error)
@@ -4121,6 +4279,7 @@ type ColibriServiceServer interface {
 	SetupReservation(context.Context, *SetupReservationRequest) (*SetupReservationResponse, error)
 	CleanupReservation(context.Context, *CleanupReservationRequest) (*CleanupReservationResponse, error)
 	AddAdmissionEntry(context.Context, *AddAdmissionEntryRequest) (*AddAdmissionEntryResponse, error)
+	ListTransportPaths(context.Context, *ListTransportPathsRequest) (*ListTransportPathsResponse, error)
 }
 
 // UnimplementedColibriServiceServer can be embedded to have forward compatible implementations.
@@ -4163,6 +4322,9 @@ func (*UnimplementedColibriServiceServer) CleanupReservation(context.Context, *C
 func (*UnimplementedColibriServiceServer) AddAdmissionEntry(context.Context, *AddAdmissionEntryRequest) (*AddAdmissionEntryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddAdmissionEntry not implemented")
 }
+func (*UnimplementedColibriServiceServer) ListTransportPaths(context.Context, *ListTransportPathsRequest) (*ListTransportPathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransportPaths not implemented")
+}
 
 func RegisterColibriServiceServer(s *grpc.Server, srv ColibriServiceServer) {
 	s.RegisterService(&_ColibriService_serviceDesc, srv)
@@ -4384,6 +4546,24 @@ func _ColibriService_AddAdmissionEntry_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ColibriService_ListTransportPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransportPathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriServiceServer).ListTransportPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriService/ListTransportPaths",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriServiceServer).ListTransportPaths(ctx, req.(*ListTransportPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ColibriService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.colibri.v1.ColibriService",
 	HandlerType: (*ColibriServiceServer)(nil),
@@ -4436,6 +4616,10 @@ var _ColibriService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AddAdmissionEntry",
 			Handler:    _ColibriService_AddAdmissionEntry_Handler,
 		},
+		{
+			MethodName: "ListTransportPaths",
+			Handler:    _ColibriService_ListTransportPaths_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/colibri/v1/colibri.proto",
@@ -24,6 +24,61 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED      ErrorCode = 0
+	ErrorCode_ERROR_CODE_NOT_FOUND        ErrorCode = 1
+	ErrorCode_ERROR_CODE_INVALID_ARGUMENT ErrorCode = 2
+	ErrorCode_ERROR_CODE_ADMISSION_DENIED ErrorCode = 3
+	ErrorCode_ERROR_CODE_INTERNAL         ErrorCode = 4
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_CODE_UNSPECIFIED",
+		1: "ERROR_CODE_NOT_FOUND",
+		2: "ERROR_CODE_INVALID_ARGUMENT",
+		3: "ERROR_CODE_ADMISSION_DENIED",
+		4: "ERROR_CODE_INTERNAL",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":      0,
+		"ERROR_CODE_NOT_FOUND":        1,
+		"ERROR_CODE_INVALID_ARGUMENT": 2,
+		"ERROR_CODE_ADMISSION_DENIED": 3,
+		"ERROR_CODE_INTERNAL":         4,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_colibri_v1_debug_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_proto_colibri_v1_debug_proto_enumTypes[0]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{0}
+}
+
 type CmdTracerouteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -163,7 +218,8 @@ type CmdIndexNewRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id     *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DryRun bool           `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (x *CmdIndexNewRequest) Reset() {
@@ -205,6 +261,13 @@ func (x *CmdIndexNewRequest) GetId() *ReservationID {
 	return nil
 }
 
+func (x *CmdIndexNewRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
 type CmdIndexNewResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -212,6 +275,7 @@ type CmdIndexNewResponse struct {
 
 	ErrorFound *ErrorInIA `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
 	Index      uint32     `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	AllocBw    uint32     `protobuf:"varint,3,opt,name=alloc_bw,json=allocBw,proto3" json:"alloc_bw,omitempty"`
 }
 
 func (x *CmdIndexNewResponse) Reset() {
@@ -260,6 +324,13 @@ func (x *CmdIndexNewResponse) GetIndex() uint32 {
 	return 0
 }
 
+func (x *CmdIndexNewResponse) GetAllocBw() uint32 {
+	if x != nil {
+		return x.AllocBw
+	}
+	return 0
+}
+
 type CmdIndexActivateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -362,17 +433,16 @@ func (x *CmdIndexActivateResponse) GetErrorFound() *ErrorInIA {
 	return nil
 }
 
-type CmdIndexCleanupRequest struct {
+type CmdIndexActivateAllRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id    *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Index uint32         `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	DstIa uint64 `protobuf:"varint,1,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
 }
 
-func (x *CmdIndexCleanupRequest) Reset() {
-	*x = CmdIndexCleanupRequest{}
+func (x *CmdIndexActivateAllRequest) Reset() {
+	*x = CmdIndexActivateAllRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_colibri_v1_debug_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -380,13 +450,13 @@ func (x *CmdIndexCleanupRequest) Reset() {
 	}
 }
 
-func (x *CmdIndexCleanupRequest) String() string {
+func (x *CmdIndexActivateAllRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CmdIndexCleanupRequest) ProtoMessage() {}
+func (*CmdIndexActivateAllRequest) ProtoMessage() {}
 
-func (x *CmdIndexCleanupRequest) ProtoReflect() protoreflect.Message {
+func (x *CmdIndexActivateAllRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_colibri_v1_debug_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -398,35 +468,29 @@ func (x *CmdIndexCleanupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CmdIndexCleanupRequest.ProtoReflect.Descriptor instead.
-func (*CmdIndexCleanupRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CmdIndexActivateAllRequest.ProtoReflect.Descriptor instead.
+func (*CmdIndexActivateAllRequest) Descriptor() ([]byte, []int) {
 	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CmdIndexCleanupRequest) GetId() *ReservationID {
-	if x != nil {
-		return x.Id
-	}
-	return nil
-}
-
-func (x *CmdIndexCleanupRequest) GetIndex() uint32 {
+func (x *CmdIndexActivateAllRequest) GetDstIa() uint64 {
 	if x != nil {
-		return x.Index
+		return x.DstIa
 	}
 	return 0
 }
 
-type CmdIndexCleanupResponse struct {
+type CmdIndexActivateAllResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ErrorFound *ErrorInIA `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	ErrorFound *ErrorInIA             `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	Results    []*IndexActivateResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *CmdIndexCleanupResponse) Reset() {
-	*x = CmdIndexCleanupResponse{}
+func (x *CmdIndexActivateAllResponse) Reset() {
+	*x = CmdIndexActivateAllResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_colibri_v1_debug_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -434,13 +498,13 @@ func (x *CmdIndexCleanupResponse) Reset() {
 	}
 }
 
-func (x *CmdIndexCleanupResponse) String() string {
+func (x *CmdIndexActivateAllResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CmdIndexCleanupResponse) ProtoMessage() {}
+func (*CmdIndexActivateAllResponse) ProtoMessage() {}
 
-func (x *CmdIndexCleanupResponse) ProtoReflect() protoreflect.Message {
+func (x *CmdIndexActivateAllResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_colibri_v1_debug_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -452,29 +516,37 @@ func (x *CmdIndexCleanupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CmdIndexCleanupResponse.ProtoReflect.Descriptor instead.
-func (*CmdIndexCleanupResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CmdIndexActivateAllResponse.ProtoReflect.Descriptor instead.
+func (*CmdIndexActivateAllResponse) Descriptor() ([]byte, []int) {
 	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CmdIndexCleanupResponse) GetErrorFound() *ErrorInIA {
+func (x *CmdIndexActivateAllResponse) GetErrorFound() *ErrorInIA {
 	if x != nil {
 		return x.ErrorFound
 	}
 	return nil
 }
 
-type TracerouteRequest struct {
+func (x *CmdIndexActivateAllResponse) GetResults() []*IndexActivateResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type IndexActivateResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Id         *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UseColibri bool           `protobuf:"varint,2,opt,name=use_colibri,json=useColibri,proto3" json:"use_colibri,omitempty"`
+	Index      uint32         `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	ErrorFound *ErrorInIA     `protobuf:"bytes,3,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
 }
 
-func (x *TracerouteRequest) Reset() {
-	*x = TracerouteRequest{}
+func (x *IndexActivateResult) Reset() {
+	*x = IndexActivateResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_colibri_v1_debug_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -482,13 +554,13 @@ func (x *TracerouteRequest) Reset() {
 	}
 }
 
-func (x *TracerouteRequest) String() string {
+func (x *IndexActivateResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TracerouteRequest) ProtoMessage() {}
+func (*IndexActivateResult) ProtoMessage() {}
 
-func (x *TracerouteRequest) ProtoReflect() protoreflect.Message {
+func (x *IndexActivateResult) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_colibri_v1_debug_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -500,39 +572,43 @@ func (x *TracerouteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TracerouteRequest.ProtoReflect.Descriptor instead.
-func (*TracerouteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use IndexActivateResult.ProtoReflect.Descriptor instead.
+func (*IndexActivateResult) Descriptor() ([]byte, []int) {
 	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *TracerouteRequest) GetId() *ReservationID {
+func (x *IndexActivateResult) GetId() *ReservationID {
 	if x != nil {
 		return x.Id
 	}
 	return nil
 }
 
-func (x *TracerouteRequest) GetUseColibri() bool {
+func (x *IndexActivateResult) GetIndex() uint32 {
 	if x != nil {
-		return x.UseColibri
+		return x.Index
 	}
-	return false
+	return 0
 }
 
-type TracerouteResponse struct {
+func (x *IndexActivateResult) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+type CmdIndexExpireRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                   *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	IaStamp              []uint64       `protobuf:"varint,2,rep,packed,name=ia_stamp,json=iaStamp,proto3" json:"ia_stamp,omitempty"`
-	TimeStampFromRequest []uint64       `protobuf:"varint,3,rep,packed,name=time_stamp_from_request,json=timeStampFromRequest,proto3" json:"time_stamp_from_request,omitempty"`
-	TimeStampAtResponse  []uint64       `protobuf:"varint,4,rep,packed,name=time_stamp_at_response,json=timeStampAtResponse,proto3" json:"time_stamp_at_response,omitempty"`
-	ErrorFound           *ErrorInIA     `protobuf:"bytes,10,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	Id    *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Index uint32         `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
 }
 
-func (x *TracerouteResponse) Reset() {
-	*x = TracerouteResponse{}
+func (x *CmdIndexExpireRequest) Reset() {
+	*x = CmdIndexExpireRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_colibri_v1_debug_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -540,13 +616,13 @@ func (x *TracerouteResponse) Reset() {
 	}
 }
 
-func (x *TracerouteResponse) String() string {
+func (x *CmdIndexExpireRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TracerouteResponse) ProtoMessage() {}
+func (*CmdIndexExpireRequest) ProtoMessage() {}
 
-func (x *TracerouteResponse) ProtoReflect() protoreflect.Message {
+func (x *CmdIndexExpireRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_colibri_v1_debug_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -558,72 +634,114 @@ func (x *TracerouteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TracerouteResponse.ProtoReflect.Descriptor instead.
-func (*TracerouteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CmdIndexExpireRequest.ProtoReflect.Descriptor instead.
+func (*CmdIndexExpireRequest) Descriptor() ([]byte, []int) {
 	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *TracerouteResponse) GetId() *ReservationID {
+func (x *CmdIndexExpireRequest) GetId() *ReservationID {
 	if x != nil {
 		return x.Id
 	}
 	return nil
 }
 
-func (x *TracerouteResponse) GetIaStamp() []uint64 {
+func (x *CmdIndexExpireRequest) GetIndex() uint32 {
 	if x != nil {
-		return x.IaStamp
+		return x.Index
 	}
-	return nil
+	return 0
 }
 
-func (x *TracerouteResponse) GetTimeStampFromRequest() []uint64 {
+type CmdIndexExpireResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound *ErrorInIA `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	Expiration uint32     `protobuf:"varint,2,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Removed    uint32     `protobuf:"varint,3,opt,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *CmdIndexExpireResponse) Reset() {
+	*x = CmdIndexExpireResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdIndexExpireResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdIndexExpireResponse) ProtoMessage() {}
+
+func (x *CmdIndexExpireResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdIndexExpireResponse.ProtoReflect.Descriptor instead.
+func (*CmdIndexExpireResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CmdIndexExpireResponse) GetErrorFound() *ErrorInIA {
 	if x != nil {
-		return x.TimeStampFromRequest
+		return x.ErrorFound
 	}
 	return nil
 }
 
-func (x *TracerouteResponse) GetTimeStampAtResponse() []uint64 {
+func (x *CmdIndexExpireResponse) GetExpiration() uint32 {
 	if x != nil {
-		return x.TimeStampAtResponse
+		return x.Expiration
 	}
-	return nil
+	return 0
 }
 
-func (x *TracerouteResponse) GetErrorFound() *ErrorInIA {
+func (x *CmdIndexExpireResponse) GetRemoved() uint32 {
 	if x != nil {
-		return x.ErrorFound
+		return x.Removed
 	}
-	return nil
+	return 0
 }
 
-type ErrorInIA struct {
+type CmdIndexCleanupRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ia      uint64 `protobuf:"varint,1,opt,name=ia,proto3" json:"ia,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Id    *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Index uint32         `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
 }
 
-func (x *ErrorInIA) Reset() {
-	*x = ErrorInIA{}
+func (x *CmdIndexCleanupRequest) Reset() {
+	*x = CmdIndexCleanupRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_colibri_v1_debug_proto_msgTypes[10]
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ErrorInIA) String() string {
+func (x *CmdIndexCleanupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ErrorInIA) ProtoMessage() {}
+func (*CmdIndexCleanupRequest) ProtoMessage() {}
 
-func (x *ErrorInIA) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_colibri_v1_debug_proto_msgTypes[10]
+func (x *CmdIndexCleanupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -634,225 +752,2884 @@ func (x *ErrorInIA) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ErrorInIA.ProtoReflect.Descriptor instead.
-func (*ErrorInIA) Descriptor() ([]byte, []int) {
-	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CmdIndexCleanupRequest.ProtoReflect.Descriptor instead.
+func (*CmdIndexCleanupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ErrorInIA) GetIa() uint64 {
+func (x *CmdIndexCleanupRequest) GetId() *ReservationID {
 	if x != nil {
-		return x.Ia
+		return x.Id
 	}
-	return 0
+	return nil
 }
 
-func (x *ErrorInIA) GetMessage() string {
+func (x *CmdIndexCleanupRequest) GetIndex() uint32 {
 	if x != nil {
-		return x.Message
+		return x.Index
 	}
-	return ""
+	return 0
 }
 
-var File_proto_colibri_v1_debug_proto protoreflect.FileDescriptor
+type CmdIndexCleanupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_proto_colibri_v1_debug_proto_rawDesc = []byte{
-	0x0a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2f,
-	0x76, 0x31, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31,
-	0x1a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2f,
-	0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0x68, 0x0a, 0x14, 0x43, 0x6d, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
-	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x65,
-	0x5f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
-	0x75, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x22, 0x8d, 0x02, 0x0a, 0x15, 0x43,
-	0x6d, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
-	0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x69, 0x61, 0x53, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x35, 0x0a, 0x17, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x66,
-	0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x04, 0x52, 0x14, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x46, 0x72, 0x6f, 0x6d,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x74, 0x69, 0x6d, 0x65, 0x5f,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x61, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x13, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61,
-	0x6d, 0x70, 0x41, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
-	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x45, 0x0a, 0x12, 0x43, 0x6d,
-	0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69,
-	0x64, 0x22, 0x69, 0x0a, 0x13, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x65, 0x77,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x60, 0x0a, 0x17,
-	0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
-	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x58,
-	0x0a, 0x18, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x5f, 0x0a, 0x16, 0x43, 0x6d, 0x64, 0x49,
-	0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x57, 0x0a, 0x17, 0x43, 0x6d, 0x64,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f,
-	0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72,
-	0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75,
-	0x6e, 0x64, 0x22, 0x65, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
-	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x65, 0x5f,
-	0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x75,
-	0x73, 0x65, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x22, 0x8a, 0x02, 0x0a, 0x12, 0x54, 0x72,
-	0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x04, 0x52, 0x07, 0x69, 0x61, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x35, 0x0a, 0x17,
-	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x14, 0x74,
-	0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x46, 0x72, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x5f, 0x61, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x04, 0x52, 0x13, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x41, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x35, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49,
-	0x6e, 0x49, 0x41, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x02, 0x69, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x32, 0xb6, 0x03,
-	0x0a, 0x1b, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x44, 0x65, 0x62, 0x75, 0x67, 0x43, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x62, 0x0a,
-	0x0d, 0x43, 0x6d, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x26,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
-	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x72, 0x61,
-	0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x5c, 0x0a, 0x0b, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x65, 0x77,
-	0x12, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x65, 0x77, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
-	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x6b, 0x0a, 0x10, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76,
-	0x61, 0x74, 0x65, 0x12, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
-	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41,
-	0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x68, 0x0a, 0x0f,
-	0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x12,
-	0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61, 0x6e,
-	0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x70, 0x0a, 0x13, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72,
-	0x69, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x59, 0x0a,
-	0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54,
-	0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	ErrorFound *ErrorInIA `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
 }
 
-var (
-	file_proto_colibri_v1_debug_proto_rawDescOnce sync.Once
-	file_proto_colibri_v1_debug_proto_rawDescData = file_proto_colibri_v1_debug_proto_rawDesc
-)
+func (x *CmdIndexCleanupResponse) Reset() {
+	*x = CmdIndexCleanupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_proto_colibri_v1_debug_proto_rawDescGZIP() []byte {
-	file_proto_colibri_v1_debug_proto_rawDescOnce.Do(func() {
-		file_proto_colibri_v1_debug_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_colibri_v1_debug_proto_rawDescData)
-	})
-	return file_proto_colibri_v1_debug_proto_rawDescData
+func (x *CmdIndexCleanupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_proto_colibri_v1_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
-var file_proto_colibri_v1_debug_proto_goTypes = []interface{}{
-	(*CmdTracerouteRequest)(nil),     // 0: proto.colibri.v1.CmdTracerouteRequest
-	(*CmdTracerouteResponse)(nil),    // 1: proto.colibri.v1.CmdTracerouteResponse
-	(*CmdIndexNewRequest)(nil),       // 2: proto.colibri.v1.CmdIndexNewRequest
-	(*CmdIndexNewResponse)(nil),      // 3: proto.colibri.v1.CmdIndexNewResponse
-	(*CmdIndexActivateRequest)(nil),  // 4: proto.colibri.v1.CmdIndexActivateRequest
-	(*CmdIndexActivateResponse)(nil), // 5: proto.colibri.v1.CmdIndexActivateResponse
-	(*CmdIndexCleanupRequest)(nil),   // 6: proto.colibri.v1.CmdIndexCleanupRequest
-	(*CmdIndexCleanupResponse)(nil),  // 7: proto.colibri.v1.CmdIndexCleanupResponse
-	(*TracerouteRequest)(nil),        // 8: proto.colibri.v1.TracerouteRequest
-	(*TracerouteResponse)(nil),       // 9: proto.colibri.v1.TracerouteResponse
-	(*ErrorInIA)(nil),                // 10: proto.colibri.v1.ErrorInIA
-	(*ReservationID)(nil),            // 11: proto.colibri.v1.ReservationID
+func (*CmdIndexCleanupResponse) ProtoMessage() {}
+
+func (x *CmdIndexCleanupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_proto_colibri_v1_debug_proto_depIdxs = []int32{
-	11, // 0: proto.colibri.v1.CmdTracerouteRequest.id:type_name -> proto.colibri.v1.ReservationID
-	11, // 1: proto.colibri.v1.CmdTracerouteResponse.id:type_name -> proto.colibri.v1.ReservationID
-	10, // 2: proto.colibri.v1.CmdTracerouteResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
-	11, // 3: proto.colibri.v1.CmdIndexNewRequest.id:type_name -> proto.colibri.v1.ReservationID
-	10, // 4: proto.colibri.v1.CmdIndexNewResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
-	11, // 5: proto.colibri.v1.CmdIndexActivateRequest.id:type_name -> proto.colibri.v1.ReservationID
-	10, // 6: proto.colibri.v1.CmdIndexActivateResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
-	11, // 7: proto.colibri.v1.CmdIndexCleanupRequest.id:type_name -> proto.colibri.v1.ReservationID
-	10, // 8: proto.colibri.v1.CmdIndexCleanupResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
-	11, // 9: proto.colibri.v1.TracerouteRequest.id:type_name -> proto.colibri.v1.ReservationID
-	11, // 10: proto.colibri.v1.TracerouteResponse.id:type_name -> proto.colibri.v1.ReservationID
-	10, // 11: proto.colibri.v1.TracerouteResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
-	0,  // 12: proto.colibri.v1.ColibriDebugCommandsService.CmdTraceroute:input_type -> proto.colibri.v1.CmdTracerouteRequest
-	2,  // 13: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexNew:input_type -> proto.colibri.v1.CmdIndexNewRequest
-	4,  // 14: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexActivate:input_type -> proto.colibri.v1.CmdIndexActivateRequest
-	6,  // 15: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexCleanup:input_type -> proto.colibri.v1.CmdIndexCleanupRequest
-	8,  // 16: proto.colibri.v1.ColibriDebugService.Traceroute:input_type -> proto.colibri.v1.TracerouteRequest
-	1,  // 17: proto.colibri.v1.ColibriDebugCommandsService.CmdTraceroute:output_type -> proto.colibri.v1.CmdTracerouteResponse
-	3,  // 18: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexNew:output_type -> proto.colibri.v1.CmdIndexNewResponse
-	5,  // 19: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexActivate:output_type -> proto.colibri.v1.CmdIndexActivateResponse
-	7,  // 20: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexCleanup:output_type -> proto.colibri.v1.CmdIndexCleanupResponse
-	9,  // 21: proto.colibri.v1.ColibriDebugService.Traceroute:output_type -> proto.colibri.v1.TracerouteResponse
-	17, // [17:22] is the sub-list for method output_type
-	12, // [12:17] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+
+// Deprecated: Use CmdIndexCleanupResponse.ProtoReflect.Descriptor instead.
+func (*CmdIndexCleanupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{12}
 }
 
-func init() { file_proto_colibri_v1_debug_proto_init() }
-func file_proto_colibri_v1_debug_proto_init() {
-	if File_proto_colibri_v1_debug_proto != nil {
-		return
+func (x *CmdIndexCleanupResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
 	}
-	file_proto_colibri_v1_colibri_proto_init()
-	if !protoimpl.UnsafeEnabled {
-		file_proto_colibri_v1_debug_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CmdTracerouteRequest); i {
+	return nil
+}
+
+type CmdReservationResizeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Bw uint32         `protobuf:"varint,2,opt,name=bw,proto3" json:"bw,omitempty"`
+}
+
+func (x *CmdReservationResizeRequest) Reset() {
+	*x = CmdReservationResizeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdReservationResizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdReservationResizeRequest) ProtoMessage() {}
+
+func (x *CmdReservationResizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdReservationResizeRequest.ProtoReflect.Descriptor instead.
+func (*CmdReservationResizeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CmdReservationResizeRequest) GetId() *ReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *CmdReservationResizeRequest) GetBw() uint32 {
+	if x != nil {
+		return x.Bw
+	}
+	return 0
+}
+
+type CmdReservationResizeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound *ErrorInIA `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	Index      uint32     `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *CmdReservationResizeResponse) Reset() {
+	*x = CmdReservationResizeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdReservationResizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdReservationResizeResponse) ProtoMessage() {}
+
+func (x *CmdReservationResizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdReservationResizeResponse.ProtoReflect.Descriptor instead.
+func (*CmdReservationResizeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CmdReservationResizeResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+func (x *CmdReservationResizeResponse) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type CmdReservationDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Force bool           `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *CmdReservationDeleteRequest) Reset() {
+	*x = CmdReservationDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdReservationDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdReservationDeleteRequest) ProtoMessage() {}
+
+func (x *CmdReservationDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdReservationDeleteRequest.ProtoReflect.Descriptor instead.
+func (*CmdReservationDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CmdReservationDeleteRequest) GetId() *ReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *CmdReservationDeleteRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type CmdReservationDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound     *ErrorInIA `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	DeletedLocally bool       `protobuf:"varint,2,opt,name=deleted_locally,json=deletedLocally,proto3" json:"deleted_locally,omitempty"`
+}
+
+func (x *CmdReservationDeleteResponse) Reset() {
+	*x = CmdReservationDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdReservationDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdReservationDeleteResponse) ProtoMessage() {}
+
+func (x *CmdReservationDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdReservationDeleteResponse.ProtoReflect.Descriptor instead.
+func (*CmdReservationDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CmdReservationDeleteResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+func (x *CmdReservationDeleteResponse) GetDeletedLocally() bool {
+	if x != nil {
+		return x.DeletedLocally
+	}
+	return false
+}
+
+type CmdReservationShowRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CmdReservationShowRequest) Reset() {
+	*x = CmdReservationShowRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdReservationShowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdReservationShowRequest) ProtoMessage() {}
+
+func (x *CmdReservationShowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdReservationShowRequest.ProtoReflect.Descriptor instead.
+func (*CmdReservationShowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CmdReservationShowRequest) GetId() *ReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type CmdReservationShowResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound    *ErrorInIA           `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	PathType      uint32               `protobuf:"varint,2,opt,name=path_type,json=pathType,proto3" json:"path_type,omitempty"`
+	Steps         []*PathStep          `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	CurrentStep   uint32               `protobuf:"varint,4,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	Indices       []uint32             `protobuf:"varint,5,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+	ActiveIndex   int32                `protobuf:"varint,6,opt,name=active_index,json=activeIndex,proto3" json:"active_index,omitempty"`
+	TransportPath []byte               `protobuf:"bytes,7,opt,name=transport_path,json=transportPath,proto3" json:"transport_path,omitempty"`
+	History       []*IndexHistoryEntry `protobuf:"bytes,8,rep,name=history,proto3" json:"history,omitempty"`
+	IndexDetails  []*IndexSummary      `protobuf:"bytes,9,rep,name=index_details,json=indexDetails,proto3" json:"index_details,omitempty"`
+	ValidUntil    uint32               `protobuf:"varint,10,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
+}
+
+func (x *CmdReservationShowResponse) Reset() {
+	*x = CmdReservationShowResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdReservationShowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdReservationShowResponse) ProtoMessage() {}
+
+func (x *CmdReservationShowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdReservationShowResponse.ProtoReflect.Descriptor instead.
+func (*CmdReservationShowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CmdReservationShowResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+func (x *CmdReservationShowResponse) GetPathType() uint32 {
+	if x != nil {
+		return x.PathType
+	}
+	return 0
+}
+
+func (x *CmdReservationShowResponse) GetSteps() []*PathStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *CmdReservationShowResponse) GetCurrentStep() uint32 {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return 0
+}
+
+func (x *CmdReservationShowResponse) GetIndices() []uint32 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+func (x *CmdReservationShowResponse) GetActiveIndex() int32 {
+	if x != nil {
+		return x.ActiveIndex
+	}
+	return 0
+}
+
+func (x *CmdReservationShowResponse) GetTransportPath() []byte {
+	if x != nil {
+		return x.TransportPath
+	}
+	return nil
+}
+
+func (x *CmdReservationShowResponse) GetHistory() []*IndexHistoryEntry {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *CmdReservationShowResponse) GetIndexDetails() []*IndexSummary {
+	if x != nil {
+		return x.IndexDetails
+	}
+	return nil
+}
+
+func (x *CmdReservationShowResponse) GetValidUntil() uint32 {
+	if x != nil {
+		return x.ValidUntil
+	}
+	return 0
+}
+
+type IndexSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index      uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Expiration uint32 `protobuf:"varint,2,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	State      uint32 `protobuf:"varint,3,opt,name=state,proto3" json:"state,omitempty"`
+	AllocBw    uint32 `protobuf:"varint,4,opt,name=alloc_bw,json=allocBw,proto3" json:"alloc_bw,omitempty"`
+}
+
+func (x *IndexSummary) Reset() {
+	*x = IndexSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexSummary) ProtoMessage() {}
+
+func (x *IndexSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexSummary.ProtoReflect.Descriptor instead.
+func (*IndexSummary) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *IndexSummary) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IndexSummary) GetExpiration() uint32 {
+	if x != nil {
+		return x.Expiration
+	}
+	return 0
+}
+
+func (x *IndexSummary) GetState() uint32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *IndexSummary) GetAllocBw() uint32 {
+	if x != nil {
+		return x.AllocBw
+	}
+	return 0
+}
+
+type IndexHistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index      uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	AllocBw    uint32 `protobuf:"varint,2,opt,name=alloc_bw,json=allocBw,proto3" json:"alloc_bw,omitempty"`
+	Expiration uint32 `protobuf:"varint,3,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Removed    uint32 `protobuf:"varint,4,opt,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *IndexHistoryEntry) Reset() {
+	*x = IndexHistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexHistoryEntry) ProtoMessage() {}
+
+func (x *IndexHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexHistoryEntry.ProtoReflect.Descriptor instead.
+func (*IndexHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IndexHistoryEntry) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IndexHistoryEntry) GetAllocBw() uint32 {
+	if x != nil {
+		return x.AllocBw
+	}
+	return 0
+}
+
+func (x *IndexHistoryEntry) GetExpiration() uint32 {
+	if x != nil {
+		return x.Expiration
+	}
+	return 0
+}
+
+func (x *IndexHistoryEntry) GetRemoved() uint32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+type CmdReservationDBDumpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CmdReservationDBDumpRequest) Reset() {
+	*x = CmdReservationDBDumpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdReservationDBDumpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdReservationDBDumpRequest) ProtoMessage() {}
+
+func (x *CmdReservationDBDumpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdReservationDBDumpRequest.ProtoReflect.Descriptor instead.
+func (*CmdReservationDBDumpRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CmdReservationDBDumpRequest) GetId() *ReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type CmdReservationDBDumpResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound    *ErrorInIA    `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	RowId         int64         `protobuf:"varint,2,opt,name=row_id,json=rowId,proto3" json:"row_id,omitempty"`
+	Ingress       uint32        `protobuf:"varint,3,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	Egress        uint32        `protobuf:"varint,4,opt,name=egress,proto3" json:"egress,omitempty"`
+	PathType      uint32        `protobuf:"varint,5,opt,name=path_type,json=pathType,proto3" json:"path_type,omitempty"`
+	Steps         []byte        `protobuf:"bytes,6,opt,name=steps,proto3" json:"steps,omitempty"`
+	CurrentStep   uint32        `protobuf:"varint,7,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	TransportPath []byte        `protobuf:"bytes,8,opt,name=transport_path,json=transportPath,proto3" json:"transport_path,omitempty"`
+	EndProps      uint32        `protobuf:"varint,9,opt,name=end_props,json=endProps,proto3" json:"end_props,omitempty"`
+	TrafficSplit  uint32        `protobuf:"varint,10,opt,name=traffic_split,json=trafficSplit,proto3" json:"traffic_split,omitempty"`
+	SrcIa         uint64        `protobuf:"varint,11,opt,name=src_ia,json=srcIa,proto3" json:"src_ia,omitempty"`
+	DstIa         uint64        `protobuf:"varint,12,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
+	ActiveIndex   int32         `protobuf:"varint,13,opt,name=active_index,json=activeIndex,proto3" json:"active_index,omitempty"`
+	IndexHistory  []byte        `protobuf:"bytes,14,opt,name=index_history,json=indexHistory,proto3" json:"index_history,omitempty"`
+	Indices       []*IndexDBRow `protobuf:"bytes,15,rep,name=indices,proto3" json:"indices,omitempty"`
+}
+
+func (x *CmdReservationDBDumpResponse) Reset() {
+	*x = CmdReservationDBDumpResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdReservationDBDumpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdReservationDBDumpResponse) ProtoMessage() {}
+
+func (x *CmdReservationDBDumpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdReservationDBDumpResponse.ProtoReflect.Descriptor instead.
+func (*CmdReservationDBDumpResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CmdReservationDBDumpResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+func (x *CmdReservationDBDumpResponse) GetRowId() int64 {
+	if x != nil {
+		return x.RowId
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetIngress() uint32 {
+	if x != nil {
+		return x.Ingress
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetEgress() uint32 {
+	if x != nil {
+		return x.Egress
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetPathType() uint32 {
+	if x != nil {
+		return x.PathType
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetSteps() []byte {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *CmdReservationDBDumpResponse) GetCurrentStep() uint32 {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetTransportPath() []byte {
+	if x != nil {
+		return x.TransportPath
+	}
+	return nil
+}
+
+func (x *CmdReservationDBDumpResponse) GetEndProps() uint32 {
+	if x != nil {
+		return x.EndProps
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetTrafficSplit() uint32 {
+	if x != nil {
+		return x.TrafficSplit
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetSrcIa() uint64 {
+	if x != nil {
+		return x.SrcIa
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetDstIa() uint64 {
+	if x != nil {
+		return x.DstIa
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetActiveIndex() int32 {
+	if x != nil {
+		return x.ActiveIndex
+	}
+	return 0
+}
+
+func (x *CmdReservationDBDumpResponse) GetIndexHistory() []byte {
+	if x != nil {
+		return x.IndexHistory
+	}
+	return nil
+}
+
+func (x *CmdReservationDBDumpResponse) GetIndices() []*IndexDBRow {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+type IndexDBRow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index      uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Expiration uint32 `protobuf:"varint,2,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	State      uint32 `protobuf:"varint,3,opt,name=state,proto3" json:"state,omitempty"`
+	MinBw      uint32 `protobuf:"varint,4,opt,name=min_bw,json=minBw,proto3" json:"min_bw,omitempty"`
+	MaxBw      uint32 `protobuf:"varint,5,opt,name=max_bw,json=maxBw,proto3" json:"max_bw,omitempty"`
+	AllocBw    uint32 `protobuf:"varint,6,opt,name=alloc_bw,json=allocBw,proto3" json:"alloc_bw,omitempty"`
+	Token      []byte `protobuf:"bytes,7,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *IndexDBRow) Reset() {
+	*x = IndexDBRow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexDBRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexDBRow) ProtoMessage() {}
+
+func (x *IndexDBRow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexDBRow.ProtoReflect.Descriptor instead.
+func (*IndexDBRow) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IndexDBRow) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IndexDBRow) GetExpiration() uint32 {
+	if x != nil {
+		return x.Expiration
+	}
+	return 0
+}
+
+func (x *IndexDBRow) GetState() uint32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *IndexDBRow) GetMinBw() uint32 {
+	if x != nil {
+		return x.MinBw
+	}
+	return 0
+}
+
+func (x *IndexDBRow) GetMaxBw() uint32 {
+	if x != nil {
+		return x.MaxBw
+	}
+	return 0
+}
+
+func (x *IndexDBRow) GetAllocBw() uint32 {
+	if x != nil {
+		return x.AllocBw
+	}
+	return 0
+}
+
+func (x *IndexDBRow) GetToken() []byte {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+type CmdKeeperPlanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CmdKeeperPlanRequest) Reset() {
+	*x = CmdKeeperPlanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdKeeperPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdKeeperPlanRequest) ProtoMessage() {}
+
+func (x *CmdKeeperPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdKeeperPlanRequest.ProtoReflect.Descriptor instead.
+func (*CmdKeeperPlanRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CmdKeeperPlanRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type CmdKeeperPlanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound *ErrorInIA         `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	Entries    []*KeeperPlanEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Wakeup     uint32             `protobuf:"varint,3,opt,name=wakeup,proto3" json:"wakeup,omitempty"`
+}
+
+func (x *CmdKeeperPlanResponse) Reset() {
+	*x = CmdKeeperPlanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdKeeperPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdKeeperPlanResponse) ProtoMessage() {}
+
+func (x *CmdKeeperPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdKeeperPlanResponse.ProtoReflect.Descriptor instead.
+func (*CmdKeeperPlanResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CmdKeeperPlanResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+func (x *CmdKeeperPlanResponse) GetEntries() []*KeeperPlanEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *CmdKeeperPlanResponse) GetWakeup() uint32 {
+	if x != nil {
+		return x.Wakeup
+	}
+	return 0
+}
+
+type KeeperPlanEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DstIa      uint64            `protobuf:"varint,1,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
+	PathType   uint32            `protobuf:"varint,2,opt,name=path_type,json=pathType,proto3" json:"path_type,omitempty"`
+	MinBw      uint32            `protobuf:"varint,3,opt,name=min_bw,json=minBw,proto3" json:"min_bw,omitempty"`
+	MaxBw      uint32            `protobuf:"varint,4,opt,name=max_bw,json=maxBw,proto3" json:"max_bw,omitempty"`
+	Id         *ReservationID    `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	Compliance string            `protobuf:"bytes,6,opt,name=compliance,proto3" json:"compliance,omitempty"`
+	Action     string            `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	Labels     map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Predicate  string            `protobuf:"bytes,9,opt,name=predicate,proto3" json:"predicate,omitempty"`
+}
+
+func (x *KeeperPlanEntry) Reset() {
+	*x = KeeperPlanEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeeperPlanEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeeperPlanEntry) ProtoMessage() {}
+
+func (x *KeeperPlanEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeeperPlanEntry.ProtoReflect.Descriptor instead.
+func (*KeeperPlanEntry) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *KeeperPlanEntry) GetDstIa() uint64 {
+	if x != nil {
+		return x.DstIa
+	}
+	return 0
+}
+
+func (x *KeeperPlanEntry) GetPathType() uint32 {
+	if x != nil {
+		return x.PathType
+	}
+	return 0
+}
+
+func (x *KeeperPlanEntry) GetMinBw() uint32 {
+	if x != nil {
+		return x.MinBw
+	}
+	return 0
+}
+
+func (x *KeeperPlanEntry) GetMaxBw() uint32 {
+	if x != nil {
+		return x.MaxBw
+	}
+	return 0
+}
+
+func (x *KeeperPlanEntry) GetId() *ReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *KeeperPlanEntry) GetCompliance() string {
+	if x != nil {
+		return x.Compliance
+	}
+	return ""
+}
+
+func (x *KeeperPlanEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *KeeperPlanEntry) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *KeeperPlanEntry) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+type CmdKeeperUnmatchedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CmdKeeperUnmatchedRequest) Reset() {
+	*x = CmdKeeperUnmatchedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdKeeperUnmatchedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdKeeperUnmatchedRequest) ProtoMessage() {}
+
+func (x *CmdKeeperUnmatchedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdKeeperUnmatchedRequest.ProtoReflect.Descriptor instead.
+func (*CmdKeeperUnmatchedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CmdKeeperUnmatchedRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type CmdKeeperUnmatchedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound *ErrorInIA              `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	Entries    []*KeeperUnmatchedEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *CmdKeeperUnmatchedResponse) Reset() {
+	*x = CmdKeeperUnmatchedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdKeeperUnmatchedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdKeeperUnmatchedResponse) ProtoMessage() {}
+
+func (x *CmdKeeperUnmatchedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdKeeperUnmatchedResponse.ProtoReflect.Descriptor instead.
+func (*CmdKeeperUnmatchedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CmdKeeperUnmatchedResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+func (x *CmdKeeperUnmatchedResponse) GetEntries() []*KeeperUnmatchedEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type KeeperUnmatchedEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DstIa      uint64            `protobuf:"varint,1,opt,name=dst_ia,json=dstIa,proto3" json:"dst_ia,omitempty"`
+	PathType   uint32            `protobuf:"varint,2,opt,name=path_type,json=pathType,proto3" json:"path_type,omitempty"`
+	Predicate  string            `protobuf:"bytes,3,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	Paths      uint32            `protobuf:"varint,4,opt,name=paths,proto3" json:"paths,omitempty"`
+	PathsError string            `protobuf:"bytes,5,opt,name=paths_error,json=pathsError,proto3" json:"paths_error,omitempty"`
+	Labels     map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *KeeperUnmatchedEntry) Reset() {
+	*x = KeeperUnmatchedEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeeperUnmatchedEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeeperUnmatchedEntry) ProtoMessage() {}
+
+func (x *KeeperUnmatchedEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeeperUnmatchedEntry.ProtoReflect.Descriptor instead.
+func (*KeeperUnmatchedEntry) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *KeeperUnmatchedEntry) GetDstIa() uint64 {
+	if x != nil {
+		return x.DstIa
+	}
+	return 0
+}
+
+func (x *KeeperUnmatchedEntry) GetPathType() uint32 {
+	if x != nil {
+		return x.PathType
+	}
+	return 0
+}
+
+func (x *KeeperUnmatchedEntry) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *KeeperUnmatchedEntry) GetPaths() uint32 {
+	if x != nil {
+		return x.Paths
+	}
+	return 0
+}
+
+func (x *KeeperUnmatchedEntry) GetPathsError() string {
+	if x != nil {
+		return x.PathsError
+	}
+	return ""
+}
+
+func (x *KeeperUnmatchedEntry) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type CmdE2EListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parent *ReservationID `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+}
+
+func (x *CmdE2EListRequest) Reset() {
+	*x = CmdE2EListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdE2EListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdE2EListRequest) ProtoMessage() {}
+
+func (x *CmdE2EListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdE2EListRequest.ProtoReflect.Descriptor instead.
+func (*CmdE2EListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CmdE2EListRequest) GetParent() *ReservationID {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+type CmdE2EListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound   *ErrorInIA      `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	Reservations []*E2EListEntry `protobuf:"bytes,2,rep,name=reservations,proto3" json:"reservations,omitempty"`
+}
+
+func (x *CmdE2EListResponse) Reset() {
+	*x = CmdE2EListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdE2EListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdE2EListResponse) ProtoMessage() {}
+
+func (x *CmdE2EListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdE2EListResponse.ProtoReflect.Descriptor instead.
+func (*CmdE2EListResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CmdE2EListResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+func (x *CmdE2EListResponse) GetReservations() []*E2EListEntry {
+	if x != nil {
+		return x.Reservations
+	}
+	return nil
+}
+
+type E2EListEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Alloc    uint64         `protobuf:"varint,2,opt,name=alloc,proto3" json:"alloc,omitempty"`
+	HasIndex bool           `protobuf:"varint,3,opt,name=has_index,json=hasIndex,proto3" json:"has_index,omitempty"`
+	Idx      uint32         `protobuf:"varint,4,opt,name=idx,proto3" json:"idx,omitempty"`
+	Bw       uint32         `protobuf:"varint,5,opt,name=bw,proto3" json:"bw,omitempty"`
+	Exptime  uint64         `protobuf:"varint,6,opt,name=exptime,proto3" json:"exptime,omitempty"`
+}
+
+func (x *E2EListEntry) Reset() {
+	*x = E2EListEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *E2EListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*E2EListEntry) ProtoMessage() {}
+
+func (x *E2EListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use E2EListEntry.ProtoReflect.Descriptor instead.
+func (*E2EListEntry) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *E2EListEntry) GetId() *ReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *E2EListEntry) GetAlloc() uint64 {
+	if x != nil {
+		return x.Alloc
+	}
+	return 0
+}
+
+func (x *E2EListEntry) GetHasIndex() bool {
+	if x != nil {
+		return x.HasIndex
+	}
+	return false
+}
+
+func (x *E2EListEntry) GetIdx() uint32 {
+	if x != nil {
+		return x.Idx
+	}
+	return 0
+}
+
+func (x *E2EListEntry) GetBw() uint32 {
+	if x != nil {
+		return x.Bw
+	}
+	return 0
+}
+
+func (x *E2EListEntry) GetExptime() uint64 {
+	if x != nil {
+		return x.Exptime
+	}
+	return 0
+}
+
+type CmdNeighborsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CmdNeighborsRequest) Reset() {
+	*x = CmdNeighborsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdNeighborsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdNeighborsRequest) ProtoMessage() {}
+
+func (x *CmdNeighborsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdNeighborsRequest.ProtoReflect.Descriptor instead.
+func (*CmdNeighborsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{33}
+}
+
+type CmdNeighborsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErrorFound *ErrorInIA       `protobuf:"bytes,1,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+	Neighbors  []*NeighborEntry `protobuf:"bytes,2,rep,name=neighbors,proto3" json:"neighbors,omitempty"`
+}
+
+func (x *CmdNeighborsResponse) Reset() {
+	*x = CmdNeighborsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CmdNeighborsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CmdNeighborsResponse) ProtoMessage() {}
+
+func (x *CmdNeighborsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CmdNeighborsResponse.ProtoReflect.Descriptor instead.
+func (*CmdNeighborsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CmdNeighborsResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+func (x *CmdNeighborsResponse) GetNeighbors() []*NeighborEntry {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+type NeighborEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InterfaceId         uint32 `protobuf:"varint,1,opt,name=interface_id,json=interfaceId,proto3" json:"interface_id,omitempty"`
+	Ia                  uint64 `protobuf:"varint,2,opt,name=ia,proto3" json:"ia,omitempty"`
+	Address             string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	SessionOpen         bool   `protobuf:"varint,4,opt,name=session_open,json=sessionOpen,proto3" json:"session_open,omitempty"`
+	LastUse             uint64 `protobuf:"varint,5,opt,name=last_use,json=lastUse,proto3" json:"last_use,omitempty"`
+	Breaker             string `protobuf:"bytes,6,opt,name=breaker,proto3" json:"breaker,omitempty"`
+	ConsecutiveFailures uint32 `protobuf:"varint,7,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+}
+
+func (x *NeighborEntry) Reset() {
+	*x = NeighborEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NeighborEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NeighborEntry) ProtoMessage() {}
+
+func (x *NeighborEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NeighborEntry.ProtoReflect.Descriptor instead.
+func (*NeighborEntry) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *NeighborEntry) GetInterfaceId() uint32 {
+	if x != nil {
+		return x.InterfaceId
+	}
+	return 0
+}
+
+func (x *NeighborEntry) GetIa() uint64 {
+	if x != nil {
+		return x.Ia
+	}
+	return 0
+}
+
+func (x *NeighborEntry) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *NeighborEntry) GetSessionOpen() bool {
+	if x != nil {
+		return x.SessionOpen
+	}
+	return false
+}
+
+func (x *NeighborEntry) GetLastUse() uint64 {
+	if x != nil {
+		return x.LastUse
+	}
+	return 0
+}
+
+func (x *NeighborEntry) GetBreaker() string {
+	if x != nil {
+		return x.Breaker
+	}
+	return ""
+}
+
+func (x *NeighborEntry) GetConsecutiveFailures() uint32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+type TracerouteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UseColibri bool           `protobuf:"varint,2,opt,name=use_colibri,json=useColibri,proto3" json:"use_colibri,omitempty"`
+}
+
+func (x *TracerouteRequest) Reset() {
+	*x = TracerouteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracerouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracerouteRequest) ProtoMessage() {}
+
+func (x *TracerouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracerouteRequest.ProtoReflect.Descriptor instead.
+func (*TracerouteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TracerouteRequest) GetId() *ReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *TracerouteRequest) GetUseColibri() bool {
+	if x != nil {
+		return x.UseColibri
+	}
+	return false
+}
+
+type TracerouteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                   *ReservationID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IaStamp              []uint64       `protobuf:"varint,2,rep,packed,name=ia_stamp,json=iaStamp,proto3" json:"ia_stamp,omitempty"`
+	TimeStampFromRequest []uint64       `protobuf:"varint,3,rep,packed,name=time_stamp_from_request,json=timeStampFromRequest,proto3" json:"time_stamp_from_request,omitempty"`
+	TimeStampAtResponse  []uint64       `protobuf:"varint,4,rep,packed,name=time_stamp_at_response,json=timeStampAtResponse,proto3" json:"time_stamp_at_response,omitempty"`
+	ErrorFound           *ErrorInIA     `protobuf:"bytes,10,opt,name=error_found,json=errorFound,proto3" json:"error_found,omitempty"`
+}
+
+func (x *TracerouteResponse) Reset() {
+	*x = TracerouteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracerouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracerouteResponse) ProtoMessage() {}
+
+func (x *TracerouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracerouteResponse.ProtoReflect.Descriptor instead.
+func (*TracerouteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *TracerouteResponse) GetId() *ReservationID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *TracerouteResponse) GetIaStamp() []uint64 {
+	if x != nil {
+		return x.IaStamp
+	}
+	return nil
+}
+
+func (x *TracerouteResponse) GetTimeStampFromRequest() []uint64 {
+	if x != nil {
+		return x.TimeStampFromRequest
+	}
+	return nil
+}
+
+func (x *TracerouteResponse) GetTimeStampAtResponse() []uint64 {
+	if x != nil {
+		return x.TimeStampAtResponse
+	}
+	return nil
+}
+
+func (x *TracerouteResponse) GetErrorFound() *ErrorInIA {
+	if x != nil {
+		return x.ErrorFound
+	}
+	return nil
+}
+
+type ErrorInIA struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ia      uint64    `protobuf:"varint,1,opt,name=ia,proto3" json:"ia,omitempty"`
+	Message string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Code    ErrorCode `protobuf:"varint,3,opt,name=code,proto3,enum=proto.colibri.v1.ErrorCode" json:"code,omitempty"`
+}
+
+func (x *ErrorInIA) Reset() {
+	*x = ErrorInIA{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_colibri_v1_debug_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorInIA) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorInIA) ProtoMessage() {}
+
+func (x *ErrorInIA) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_colibri_v1_debug_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorInIA.ProtoReflect.Descriptor instead.
+func (*ErrorInIA) Descriptor() ([]byte, []int) {
+	return file_proto_colibri_v1_debug_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ErrorInIA) GetIa() uint64 {
+	if x != nil {
+		return x.Ia
+	}
+	return 0
+}
+
+func (x *ErrorInIA) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorInIA) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+var File_proto_colibri_v1_debug_proto protoreflect.FileDescriptor
+
+var file_proto_colibri_v1_debug_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31,
+	0x1a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2f,
+	0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x68, 0x0a, 0x14, 0x43, 0x6d, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x73, 0x65,
+	0x5f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x75, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x22, 0x8d, 0x02, 0x0a, 0x15, 0x43,
+	0x6d, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x69, 0x61, 0x53, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x35, 0x0a, 0x17, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x14, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x46, 0x72, 0x6f, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x61, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x13, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x6d, 0x70, 0x41, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x5e, 0x0a, 0x12, 0x43, 0x6d,
+	0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x13, 0x43,
+	0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x5f,
+	0x62, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x42,
+	0x77, 0x22, 0x60, 0x0a, 0x17, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0x58, 0x0a, 0x18, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49,
+	0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x33, 0x0a,
+	0x1a, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x64,
+	0x73, 0x74, 0x5f, 0x69, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74,
+	0x49, 0x61, 0x22, 0x9c, 0x01, 0x0a, 0x1b, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x3f, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0x9a, 0x01, 0x0a, 0x13, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e,
+	0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x5e,
+	0x0a, 0x15, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x90,
+	0x01, 0x0a, 0x16, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x64, 0x22, 0x5f, 0x0a, 0x16, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65,
+	0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x22, 0x57, 0x0a, 0x17, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c,
+	0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a,
+	0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52,
+	0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x5e, 0x0a, 0x1b, 0x43,
+	0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x62,
+	0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x62, 0x77, 0x22, 0x72, 0x0a, 0x1c, 0x43,
+	0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0x64, 0x0a, 0x1b, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x85, 0x01, 0x0a, 0x1c, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46,
+	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x6c, 0x79, 0x22, 0x4c, 0x0a,
+	0x19, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x68, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x22, 0xd5, 0x03, 0x0a, 0x1a,
+	0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x68,
+	0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x74, 0x68,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x74,
+	0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x53, 0x74, 0x65, 0x70,
+	0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x65, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e,
+	0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x64,
+	0x69, 0x63, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x3d,
+	0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x43, 0x0a,
+	0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x52, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x75, 0x6e, 0x74, 0x69,
+	0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e,
+	0x74, 0x69, 0x6c, 0x22, 0x75, 0x0a, 0x0c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x5f, 0x62, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x42, 0x77, 0x22, 0x7e, 0x0a, 0x11, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x5f, 0x62,
+	0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x42, 0x77,
+	0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0x4e, 0x0a, 0x1b, 0x43, 0x6d,
+	0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x42, 0x44, 0x75,
+	0x6d, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x22, 0x92, 0x04, 0x0a, 0x1c, 0x43,
+	0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x42, 0x44,
+	0x75, 0x6d, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x6f, 0x77,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x72, 0x6f, 0x77, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x65, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x74, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x5f, 0x73, 0x74, 0x65, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x65, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x70, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x5f, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x53, 0x70, 0x6c, 0x69,
+	0x74, 0x12, 0x15, 0x0a, 0x06, 0x73, 0x72, 0x63, 0x5f, 0x69, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x73, 0x72, 0x63, 0x49, 0x61, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73, 0x74, 0x5f,
+	0x69, 0x61, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49, 0x61, 0x12,
+	0x21, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x68, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x36, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63,
+	0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x44, 0x42, 0x52, 0x6f, 0x77, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22,
+	0xb7, 0x01, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x44, 0x42, 0x52, 0x6f, 0x77, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x69,
+	0x6e, 0x5f, 0x62, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x42,
+	0x77, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x6d, 0x61, 0x78, 0x42, 0x77, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6c, 0x6c, 0x6f,
+	0x63, 0x5f, 0x62, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f,
+	0x63, 0x42, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x9d, 0x01, 0x0a, 0x14, 0x43, 0x6d,
+	0x64, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x4a, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x50,
+	0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39,
+	0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xaa, 0x01, 0x0a, 0x15, 0x43, 0x6d,
+	0x64, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e,
+	0x64, 0x12, 0x3b, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x77, 0x61, 0x6b, 0x65, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x77, 0x61, 0x6b, 0x65, 0x75, 0x70, 0x22, 0xfc, 0x02, 0x0a, 0x0f, 0x4b, 0x65, 0x65, 0x70, 0x65,
+	0x72, 0x50, 0x6c, 0x61, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73,
+	0x74, 0x5f, 0x69, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49,
+	0x61, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x74, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x15,
+	0x0a, 0x06, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x6d, 0x69, 0x6e, 0x42, 0x77, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x77, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x61, 0x78, 0x42, 0x77, 0x12, 0x2f, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x50,
+	0x6c, 0x61, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa7, 0x01, 0x0a, 0x19, 0x43, 0x6d, 0x64, 0x4b, 0x65, 0x65,
+	0x70, 0x65, 0x72, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x4f, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72,
+	0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x9c, 0x01, 0x0a, 0x1a, 0x43, 0x6d, 0x64, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x55, 0x6e, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c,
+	0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41,
+	0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x40, 0x0a, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xa6,
+	0x02, 0x0a, 0x14, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x73, 0x74, 0x5f, 0x69,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x73, 0x74, 0x49, 0x61, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x70, 0x61, 0x74, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74,
+	0x68, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x73, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x73, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x4a, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x39, 0x0a, 0x0b,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4c, 0x0a, 0x11, 0x43, 0x6d, 0x64, 0x45, 0x32,
+	0x45, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x06, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x12, 0x43, 0x6d, 0x64, 0x45, 0x32, 0x45,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x42, 0x0a, 0x0c, 0x72, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x32, 0x45, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0c, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xae,
+	0x01, 0x0a, 0x0c, 0x45, 0x32, 0x45, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x68, 0x61, 0x73, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x69, 0x64, 0x78, 0x12, 0x0e, 0x0a, 0x02, 0x62, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x62, 0x77, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x65, 0x78, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x22,
+	0x15, 0x0a, 0x13, 0x43, 0x6d, 0x64, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x93, 0x01, 0x0a, 0x14, 0x43, 0x6d, 0x64, 0x4e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49,
+	0x41, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x3d, 0x0a,
+	0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x22, 0xe7, 0x01, 0x0a,
+	0x0d, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x21,
+	0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
+	0x61, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x19,
+	0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x72, 0x65,
+	0x61, 0x6b, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x72, 0x65, 0x61,
+	0x6b, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x14, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x13, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x65, 0x0a, 0x11, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x75, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x22, 0x8a, 0x02,
+	0x0a, 0x12, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x69, 0x61, 0x53, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x35, 0x0a, 0x17, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x14, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x46, 0x72, 0x6f, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x16, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x61, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x04, 0x52, 0x13, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x6d, 0x70, 0x41, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0b,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x52, 0x0a,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x66, 0x0a, 0x09, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x49, 0x6e, 0x49, 0x41, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x2f, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x2a, 0x9c, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x1a, 0x0a, 0x16, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46,
+	0x4f, 0x55, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f,
+	0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x41, 0x52, 0x47,
+	0x55, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x41, 0x44, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f,
+	0x44, 0x45, 0x4e, 0x49, 0x45, 0x44, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x10,
+	0x04, 0x32, 0x84, 0x0c, 0x0a, 0x1b, 0x43, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x44, 0x65, 0x62,
+	0x75, 0x67, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x62, 0x0a, 0x0d, 0x43, 0x6d, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x12, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d,
+	0x64, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x0b, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x4e, 0x65, 0x77, 0x12, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x4e, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d,
+	0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x6b, 0x0a, 0x10, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x68, 0x0a, 0x0f, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61,
+	0x6e, 0x75, 0x70, 0x12, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43,
+	0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x77, 0x0a, 0x14, 0x43, 0x6d,
+	0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x77, 0x0a, 0x14, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x2d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d,
+	0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x71, 0x0a, 0x12,
+	0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x68,
+	0x6f, 0x77, 0x12, 0x2b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x62, 0x0a, 0x0d, 0x43, 0x6d, 0x64, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e,
+	0x12, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x50, 0x6c, 0x61,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x4b,
+	0x65, 0x65, 0x70, 0x65, 0x72, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0a, 0x43, 0x6d, 0x64, 0x45, 0x32, 0x45, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x45, 0x32, 0x45, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x45, 0x32, 0x45,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x74,
+	0x0a, 0x13, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x41, 0x6c, 0x6c, 0x12, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f,
+	0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x0e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x12, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63,
+	0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x0c, 0x43,
+	0x6d, 0x64, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x12, 0x25, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6d, 0x64, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x77, 0x0a, 0x14,
+	0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x42,
+	0x44, 0x75, 0x6d, 0x70, 0x12, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c,
+	0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x42, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x42, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x71, 0x0a, 0x12, 0x43, 0x6d, 0x64, 0x4b, 0x65, 0x65, 0x70,
+	0x65, 0x72, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12, 0x2b, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6d, 0x64, 0x4b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6d, 0x64, 0x4b,
+	0x65, 0x65, 0x70, 0x65, 0x72, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x70, 0x0a, 0x13, 0x43, 0x6f, 0x6c, 0x69,
+	0x62, 0x72, 0x69, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x59, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x23, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x63, 0x6f, 0x6c, 0x69, 0x62,
+	0x72, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x63, 0x69, 0x6f, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6c, 0x69, 0x62, 0x72, 0x69, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_colibri_v1_debug_proto_rawDescOnce sync.Once
+	file_proto_colibri_v1_debug_proto_rawDescData = file_proto_colibri_v1_debug_proto_rawDesc
+)
+
+func file_proto_colibri_v1_debug_proto_rawDescGZIP() []byte {
+	file_proto_colibri_v1_debug_proto_rawDescOnce.Do(func() {
+		file_proto_colibri_v1_debug_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_colibri_v1_debug_proto_rawDescData)
+	})
+	return file_proto_colibri_v1_debug_proto_rawDescData
+}
+
+var file_proto_colibri_v1_debug_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_colibri_v1_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_proto_colibri_v1_debug_proto_goTypes = []interface{}{
+	(ErrorCode)(0),                       // 0: proto.colibri.v1.ErrorCode
+	(*CmdTracerouteRequest)(nil),         // 1: proto.colibri.v1.CmdTracerouteRequest
+	(*CmdTracerouteResponse)(nil),        // 2: proto.colibri.v1.CmdTracerouteResponse
+	(*CmdIndexNewRequest)(nil),           // 3: proto.colibri.v1.CmdIndexNewRequest
+	(*CmdIndexNewResponse)(nil),          // 4: proto.colibri.v1.CmdIndexNewResponse
+	(*CmdIndexActivateRequest)(nil),      // 5: proto.colibri.v1.CmdIndexActivateRequest
+	(*CmdIndexActivateResponse)(nil),     // 6: proto.colibri.v1.CmdIndexActivateResponse
+	(*CmdIndexActivateAllRequest)(nil),   // 7: proto.colibri.v1.CmdIndexActivateAllRequest
+	(*CmdIndexActivateAllResponse)(nil),  // 8: proto.colibri.v1.CmdIndexActivateAllResponse
+	(*IndexActivateResult)(nil),          // 9: proto.colibri.v1.IndexActivateResult
+	(*CmdIndexExpireRequest)(nil),        // 10: proto.colibri.v1.CmdIndexExpireRequest
+	(*CmdIndexExpireResponse)(nil),       // 11: proto.colibri.v1.CmdIndexExpireResponse
+	(*CmdIndexCleanupRequest)(nil),       // 12: proto.colibri.v1.CmdIndexCleanupRequest
+	(*CmdIndexCleanupResponse)(nil),      // 13: proto.colibri.v1.CmdIndexCleanupResponse
+	(*CmdReservationResizeRequest)(nil),  // 14: proto.colibri.v1.CmdReservationResizeRequest
+	(*CmdReservationResizeResponse)(nil), // 15: proto.colibri.v1.CmdReservationResizeResponse
+	(*CmdReservationDeleteRequest)(nil),  // 16: proto.colibri.v1.CmdReservationDeleteRequest
+	(*CmdReservationDeleteResponse)(nil), // 17: proto.colibri.v1.CmdReservationDeleteResponse
+	(*CmdReservationShowRequest)(nil),    // 18: proto.colibri.v1.CmdReservationShowRequest
+	(*CmdReservationShowResponse)(nil),   // 19: proto.colibri.v1.CmdReservationShowResponse
+	(*IndexSummary)(nil),                 // 20: proto.colibri.v1.IndexSummary
+	(*IndexHistoryEntry)(nil),            // 21: proto.colibri.v1.IndexHistoryEntry
+	(*CmdReservationDBDumpRequest)(nil),  // 22: proto.colibri.v1.CmdReservationDBDumpRequest
+	(*CmdReservationDBDumpResponse)(nil), // 23: proto.colibri.v1.CmdReservationDBDumpResponse
+	(*IndexDBRow)(nil),                   // 24: proto.colibri.v1.IndexDBRow
+	(*CmdKeeperPlanRequest)(nil),         // 25: proto.colibri.v1.CmdKeeperPlanRequest
+	(*CmdKeeperPlanResponse)(nil),        // 26: proto.colibri.v1.CmdKeeperPlanResponse
+	(*KeeperPlanEntry)(nil),              // 27: proto.colibri.v1.KeeperPlanEntry
+	(*CmdKeeperUnmatchedRequest)(nil),    // 28: proto.colibri.v1.CmdKeeperUnmatchedRequest
+	(*CmdKeeperUnmatchedResponse)(nil),   // 29: proto.colibri.v1.CmdKeeperUnmatchedResponse
+	(*KeeperUnmatchedEntry)(nil),         // 30: proto.colibri.v1.KeeperUnmatchedEntry
+	(*CmdE2EListRequest)(nil),            // 31: proto.colibri.v1.CmdE2EListRequest
+	(*CmdE2EListResponse)(nil),           // 32: proto.colibri.v1.CmdE2EListResponse
+	(*E2EListEntry)(nil),                 // 33: proto.colibri.v1.E2EListEntry
+	(*CmdNeighborsRequest)(nil),          // 34: proto.colibri.v1.CmdNeighborsRequest
+	(*CmdNeighborsResponse)(nil),         // 35: proto.colibri.v1.CmdNeighborsResponse
+	(*NeighborEntry)(nil),                // 36: proto.colibri.v1.NeighborEntry
+	(*TracerouteRequest)(nil),            // 37: proto.colibri.v1.TracerouteRequest
+	(*TracerouteResponse)(nil),           // 38: proto.colibri.v1.TracerouteResponse
+	(*ErrorInIA)(nil),                    // 39: proto.colibri.v1.ErrorInIA
+	nil,                                  // 40: proto.colibri.v1.CmdKeeperPlanRequest.LabelsEntry
+	nil,                                  // 41: proto.colibri.v1.KeeperPlanEntry.LabelsEntry
+	nil,                                  // 42: proto.colibri.v1.CmdKeeperUnmatchedRequest.LabelsEntry
+	nil,                                  // 43: proto.colibri.v1.KeeperUnmatchedEntry.LabelsEntry
+	(*ReservationID)(nil),                // 44: proto.colibri.v1.ReservationID
+	(*PathStep)(nil),                     // 45: proto.colibri.v1.PathStep
+}
+var file_proto_colibri_v1_debug_proto_depIdxs = []int32{
+	44, // 0: proto.colibri.v1.CmdTracerouteRequest.id:type_name -> proto.colibri.v1.ReservationID
+	44, // 1: proto.colibri.v1.CmdTracerouteResponse.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 2: proto.colibri.v1.CmdTracerouteResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	44, // 3: proto.colibri.v1.CmdIndexNewRequest.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 4: proto.colibri.v1.CmdIndexNewResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	44, // 5: proto.colibri.v1.CmdIndexActivateRequest.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 6: proto.colibri.v1.CmdIndexActivateResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	39, // 7: proto.colibri.v1.CmdIndexActivateAllResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	9,  // 8: proto.colibri.v1.CmdIndexActivateAllResponse.results:type_name -> proto.colibri.v1.IndexActivateResult
+	44, // 9: proto.colibri.v1.IndexActivateResult.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 10: proto.colibri.v1.IndexActivateResult.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	44, // 11: proto.colibri.v1.CmdIndexExpireRequest.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 12: proto.colibri.v1.CmdIndexExpireResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	44, // 13: proto.colibri.v1.CmdIndexCleanupRequest.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 14: proto.colibri.v1.CmdIndexCleanupResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	44, // 15: proto.colibri.v1.CmdReservationResizeRequest.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 16: proto.colibri.v1.CmdReservationResizeResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	44, // 17: proto.colibri.v1.CmdReservationDeleteRequest.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 18: proto.colibri.v1.CmdReservationDeleteResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	44, // 19: proto.colibri.v1.CmdReservationShowRequest.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 20: proto.colibri.v1.CmdReservationShowResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	45, // 21: proto.colibri.v1.CmdReservationShowResponse.steps:type_name -> proto.colibri.v1.PathStep
+	21, // 22: proto.colibri.v1.CmdReservationShowResponse.history:type_name -> proto.colibri.v1.IndexHistoryEntry
+	20, // 23: proto.colibri.v1.CmdReservationShowResponse.index_details:type_name -> proto.colibri.v1.IndexSummary
+	44, // 24: proto.colibri.v1.CmdReservationDBDumpRequest.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 25: proto.colibri.v1.CmdReservationDBDumpResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	24, // 26: proto.colibri.v1.CmdReservationDBDumpResponse.indices:type_name -> proto.colibri.v1.IndexDBRow
+	40, // 27: proto.colibri.v1.CmdKeeperPlanRequest.labels:type_name -> proto.colibri.v1.CmdKeeperPlanRequest.LabelsEntry
+	39, // 28: proto.colibri.v1.CmdKeeperPlanResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	27, // 29: proto.colibri.v1.CmdKeeperPlanResponse.entries:type_name -> proto.colibri.v1.KeeperPlanEntry
+	44, // 30: proto.colibri.v1.KeeperPlanEntry.id:type_name -> proto.colibri.v1.ReservationID
+	41, // 31: proto.colibri.v1.KeeperPlanEntry.labels:type_name -> proto.colibri.v1.KeeperPlanEntry.LabelsEntry
+	42, // 32: proto.colibri.v1.CmdKeeperUnmatchedRequest.labels:type_name -> proto.colibri.v1.CmdKeeperUnmatchedRequest.LabelsEntry
+	39, // 33: proto.colibri.v1.CmdKeeperUnmatchedResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	30, // 34: proto.colibri.v1.CmdKeeperUnmatchedResponse.entries:type_name -> proto.colibri.v1.KeeperUnmatchedEntry
+	43, // 35: proto.colibri.v1.KeeperUnmatchedEntry.labels:type_name -> proto.colibri.v1.KeeperUnmatchedEntry.LabelsEntry
+	44, // 36: proto.colibri.v1.CmdE2EListRequest.parent:type_name -> proto.colibri.v1.ReservationID
+	39, // 37: proto.colibri.v1.CmdE2EListResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	33, // 38: proto.colibri.v1.CmdE2EListResponse.reservations:type_name -> proto.colibri.v1.E2EListEntry
+	44, // 39: proto.colibri.v1.E2EListEntry.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 40: proto.colibri.v1.CmdNeighborsResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	36, // 41: proto.colibri.v1.CmdNeighborsResponse.neighbors:type_name -> proto.colibri.v1.NeighborEntry
+	44, // 42: proto.colibri.v1.TracerouteRequest.id:type_name -> proto.colibri.v1.ReservationID
+	44, // 43: proto.colibri.v1.TracerouteResponse.id:type_name -> proto.colibri.v1.ReservationID
+	39, // 44: proto.colibri.v1.TracerouteResponse.error_found:type_name -> proto.colibri.v1.ErrorInIA
+	0,  // 45: proto.colibri.v1.ErrorInIA.code:type_name -> proto.colibri.v1.ErrorCode
+	1,  // 46: proto.colibri.v1.ColibriDebugCommandsService.CmdTraceroute:input_type -> proto.colibri.v1.CmdTracerouteRequest
+	3,  // 47: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexNew:input_type -> proto.colibri.v1.CmdIndexNewRequest
+	5,  // 48: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexActivate:input_type -> proto.colibri.v1.CmdIndexActivateRequest
+	12, // 49: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexCleanup:input_type -> proto.colibri.v1.CmdIndexCleanupRequest
+	14, // 50: proto.colibri.v1.ColibriDebugCommandsService.CmdReservationResize:input_type -> proto.colibri.v1.CmdReservationResizeRequest
+	16, // 51: proto.colibri.v1.ColibriDebugCommandsService.CmdReservationDelete:input_type -> proto.colibri.v1.CmdReservationDeleteRequest
+	18, // 52: proto.colibri.v1.ColibriDebugCommandsService.CmdReservationShow:input_type -> proto.colibri.v1.CmdReservationShowRequest
+	25, // 53: proto.colibri.v1.ColibriDebugCommandsService.CmdKeeperPlan:input_type -> proto.colibri.v1.CmdKeeperPlanRequest
+	31, // 54: proto.colibri.v1.ColibriDebugCommandsService.CmdE2EList:input_type -> proto.colibri.v1.CmdE2EListRequest
+	7,  // 55: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexActivateAll:input_type -> proto.colibri.v1.CmdIndexActivateAllRequest
+	10, // 56: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexExpire:input_type -> proto.colibri.v1.CmdIndexExpireRequest
+	34, // 57: proto.colibri.v1.ColibriDebugCommandsService.CmdNeighbors:input_type -> proto.colibri.v1.CmdNeighborsRequest
+	22, // 58: proto.colibri.v1.ColibriDebugCommandsService.CmdReservationDBDump:input_type -> proto.colibri.v1.CmdReservationDBDumpRequest
+	28, // 59: proto.colibri.v1.ColibriDebugCommandsService.CmdKeeperUnmatched:input_type -> proto.colibri.v1.CmdKeeperUnmatchedRequest
+	37, // 60: proto.colibri.v1.ColibriDebugService.Traceroute:input_type -> proto.colibri.v1.TracerouteRequest
+	2,  // 61: proto.colibri.v1.ColibriDebugCommandsService.CmdTraceroute:output_type -> proto.colibri.v1.CmdTracerouteResponse
+	4,  // 62: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexNew:output_type -> proto.colibri.v1.CmdIndexNewResponse
+	6,  // 63: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexActivate:output_type -> proto.colibri.v1.CmdIndexActivateResponse
+	13, // 64: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexCleanup:output_type -> proto.colibri.v1.CmdIndexCleanupResponse
+	15, // 65: proto.colibri.v1.ColibriDebugCommandsService.CmdReservationResize:output_type -> proto.colibri.v1.CmdReservationResizeResponse
+	17, // 66: proto.colibri.v1.ColibriDebugCommandsService.CmdReservationDelete:output_type -> proto.colibri.v1.CmdReservationDeleteResponse
+	19, // 67: proto.colibri.v1.ColibriDebugCommandsService.CmdReservationShow:output_type -> proto.colibri.v1.CmdReservationShowResponse
+	26, // 68: proto.colibri.v1.ColibriDebugCommandsService.CmdKeeperPlan:output_type -> proto.colibri.v1.CmdKeeperPlanResponse
+	32, // 69: proto.colibri.v1.ColibriDebugCommandsService.CmdE2EList:output_type -> proto.colibri.v1.CmdE2EListResponse
+	8,  // 70: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexActivateAll:output_type -> proto.colibri.v1.CmdIndexActivateAllResponse
+	11, // 71: proto.colibri.v1.ColibriDebugCommandsService.CmdIndexExpire:output_type -> proto.colibri.v1.CmdIndexExpireResponse
+	35, // 72: proto.colibri.v1.ColibriDebugCommandsService.CmdNeighbors:output_type -> proto.colibri.v1.CmdNeighborsResponse
+	23, // 73: proto.colibri.v1.ColibriDebugCommandsService.CmdReservationDBDump:output_type -> proto.colibri.v1.CmdReservationDBDumpResponse
+	29, // 74: proto.colibri.v1.ColibriDebugCommandsService.CmdKeeperUnmatched:output_type -> proto.colibri.v1.CmdKeeperUnmatchedResponse
+	38, // 75: proto.colibri.v1.ColibriDebugService.Traceroute:output_type -> proto.colibri.v1.TracerouteResponse
+	61, // [61:76] is the sub-list for method output_type
+	46, // [46:61] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
+}
+
+func init() { file_proto_colibri_v1_debug_proto_init() }
+func file_proto_colibri_v1_debug_proto_init() {
+	if File_proto_colibri_v1_debug_proto != nil {
+		return
+	}
+	file_proto_colibri_v1_colibri_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_proto_colibri_v1_debug_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdTracerouteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdTracerouteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexNewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexNewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexActivateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexActivateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexActivateAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexActivateAllResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexActivateResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexExpireRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexExpireResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexCleanupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdIndexCleanupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdReservationResizeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdReservationResizeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdReservationDeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdReservationDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdReservationShowRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdReservationShowResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexHistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdReservationDBDumpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdReservationDBDumpResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexDBRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdKeeperPlanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdKeeperPlanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeeperPlanEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdKeeperUnmatchedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -863,8 +3640,8 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CmdTracerouteResponse); i {
+		file_proto_colibri_v1_debug_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdKeeperUnmatchedResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -875,8 +3652,8 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CmdIndexNewRequest); i {
+		file_proto_colibri_v1_debug_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeeperUnmatchedEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -887,8 +3664,8 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CmdIndexNewResponse); i {
+		file_proto_colibri_v1_debug_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdE2EListRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -899,8 +3676,8 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CmdIndexActivateRequest); i {
+		file_proto_colibri_v1_debug_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdE2EListResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -911,8 +3688,8 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CmdIndexActivateResponse); i {
+		file_proto_colibri_v1_debug_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*E2EListEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -923,8 +3700,8 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CmdIndexCleanupRequest); i {
+		file_proto_colibri_v1_debug_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdNeighborsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -935,8 +3712,8 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CmdIndexCleanupResponse); i {
+		file_proto_colibri_v1_debug_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CmdNeighborsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -947,7 +3724,19 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_colibri_v1_debug_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NeighborEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_colibri_v1_debug_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TracerouteRequest); i {
 			case 0:
 				return &v.state
@@ -959,7 +3748,7 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_colibri_v1_debug_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TracerouteResponse); i {
 			case 0:
 				return &v.state
@@ -971,7 +3760,7 @@ func file_proto_colibri_v1_debug_proto_init() {
 				return nil
 			}
 		}
-		file_proto_colibri_v1_debug_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_colibri_v1_debug_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ErrorInIA); i {
 			case 0:
 				return &v.state
@@ -989,13 +3778,14 @@ func file_proto_colibri_v1_debug_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_colibri_v1_debug_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
 		GoTypes:           file_proto_colibri_v1_debug_proto_goTypes,
 		DependencyIndexes: file_proto_colibri_v1_debug_proto_depIdxs,
+		EnumInfos:         file_proto_colibri_v1_debug_proto_enumTypes,
 		MessageInfos:      file_proto_colibri_v1_debug_proto_msgTypes,
 	}.Build()
 	File_proto_colibri_v1_debug_proto = out.File
@@ -1020,6 +3810,16 @@ type ColibriDebugCommandsServiceClient interface {
 	CmdIndexNew(ctx context.Context, in *CmdIndexNewRequest, opts ...grpc.CallOption) (*CmdIndexNewResponse, error)
 	CmdIndexActivate(ctx context.Context, in *CmdIndexActivateRequest, opts ...grpc.CallOption) (*CmdIndexActivateResponse, error)
 	CmdIndexCleanup(ctx context.Context, in *CmdIndexCleanupRequest, opts ...grpc.CallOption) (*CmdIndexCleanupResponse, error)
+	CmdReservationResize(ctx context.Context, in *CmdReservationResizeRequest, opts ...grpc.CallOption) (*CmdReservationResizeResponse, error)
+	CmdReservationDelete(ctx context.Context, in *CmdReservationDeleteRequest, opts ...grpc.CallOption) (*CmdReservationDeleteResponse, error)
+	CmdReservationShow(ctx context.Context, in *CmdReservationShowRequest, opts ...grpc.CallOption) (*CmdReservationShowResponse, error)
+	CmdKeeperPlan(ctx context.Context, in *CmdKeeperPlanRequest, opts ...grpc.CallOption) (*CmdKeeperPlanResponse, error)
+	CmdE2EList(ctx context.Context, in *CmdE2EListRequest, opts ...grpc.CallOption) (*CmdE2EListResponse, error)
+	CmdIndexActivateAll(ctx context.Context, in *CmdIndexActivateAllRequest, opts ...grpc.CallOption) (*CmdIndexActivateAllResponse, error)
+	CmdIndexExpire(ctx context.Context, in *CmdIndexExpireRequest, opts ...grpc.CallOption) (*CmdIndexExpireResponse, error)
+	CmdNeighbors(ctx context.Context, in *CmdNeighborsRequest, opts ...grpc.CallOption) (*CmdNeighborsResponse, error)
+	CmdReservationDBDump(ctx context.Context, in *CmdReservationDBDumpRequest, opts ...grpc.CallOption) (*CmdReservationDBDumpResponse, error)
+	CmdKeeperUnmatched(ctx context.Context, in *CmdKeeperUnmatchedRequest, opts ...grpc.CallOption) (*CmdKeeperUnmatchedResponse, error)
 }
 
 type colibriDebugCommandsServiceClient struct {
@@ -1066,12 +3866,112 @@ func (c *colibriDebugCommandsServiceClient) CmdIndexCleanup(ctx context.Context,
 	return out, nil
 }
 
+func (c *colibriDebugCommandsServiceClient) CmdReservationResize(ctx context.Context, in *CmdReservationResizeRequest, opts ...grpc.CallOption) (*CmdReservationResizeResponse, error) {
+	out := new(CmdReservationResizeResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdReservationResize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdReservationDelete(ctx context.Context, in *CmdReservationDeleteRequest, opts ...grpc.CallOption) (*CmdReservationDeleteResponse, error) {
+	out := new(CmdReservationDeleteResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdReservationDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdReservationShow(ctx context.Context, in *CmdReservationShowRequest, opts ...grpc.CallOption) (*CmdReservationShowResponse, error) {
+	out := new(CmdReservationShowResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdReservationShow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdKeeperPlan(ctx context.Context, in *CmdKeeperPlanRequest, opts ...grpc.CallOption) (*CmdKeeperPlanResponse, error) {
+	out := new(CmdKeeperPlanResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdKeeperPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdE2EList(ctx context.Context, in *CmdE2EListRequest, opts ...grpc.CallOption) (*CmdE2EListResponse, error) {
+	out := new(CmdE2EListResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdE2EList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdIndexActivateAll(ctx context.Context, in *CmdIndexActivateAllRequest, opts ...grpc.CallOption) (*CmdIndexActivateAllResponse, error) {
+	out := new(CmdIndexActivateAllResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdIndexActivateAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdIndexExpire(ctx context.Context, in *CmdIndexExpireRequest, opts ...grpc.CallOption) (*CmdIndexExpireResponse, error) {
+	out := new(CmdIndexExpireResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdIndexExpire", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdNeighbors(ctx context.Context, in *CmdNeighborsRequest, opts ...grpc.CallOption) (*CmdNeighborsResponse, error) {
+	out := new(CmdNeighborsResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdNeighbors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdReservationDBDump(ctx context.Context, in *CmdReservationDBDumpRequest, opts ...grpc.CallOption) (*CmdReservationDBDumpResponse, error) {
+	out := new(CmdReservationDBDumpResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdReservationDBDump", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *colibriDebugCommandsServiceClient) CmdKeeperUnmatched(ctx context.Context, in *CmdKeeperUnmatchedRequest, opts ...grpc.CallOption) (*CmdKeeperUnmatchedResponse, error) {
+	out := new(CmdKeeperUnmatchedResponse)
+	err := c.cc.Invoke(ctx, "/proto.colibri.v1.ColibriDebugCommandsService/CmdKeeperUnmatched", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ColibriDebugCommandsServiceServer is the server API for ColibriDebugCommandsService service.
 type ColibriDebugCommandsServiceServer interface {
 	CmdTraceroute(context.Context, *CmdTracerouteRequest) (*CmdTracerouteResponse, error)
 	CmdIndexNew(context.Context, *CmdIndexNewRequest) (*CmdIndexNewResponse, error)
 	CmdIndexActivate(context.Context, *CmdIndexActivateRequest) (*CmdIndexActivateResponse, error)
 	CmdIndexCleanup(context.Context, *CmdIndexCleanupRequest) (*CmdIndexCleanupResponse, error)
+	CmdReservationResize(context.Context, *CmdReservationResizeRequest) (*CmdReservationResizeResponse, error)
+	CmdReservationDelete(context.Context, *CmdReservationDeleteRequest) (*CmdReservationDeleteResponse, error)
+	CmdReservationShow(context.Context, *CmdReservationShowRequest) (*CmdReservationShowResponse, error)
+	CmdKeeperPlan(context.Context, *CmdKeeperPlanRequest) (*CmdKeeperPlanResponse, error)
+	CmdE2EList(context.Context, *CmdE2EListRequest) (*CmdE2EListResponse, error)
+	CmdIndexActivateAll(context.Context, *CmdIndexActivateAllRequest) (*CmdIndexActivateAllResponse, error)
+	CmdIndexExpire(context.Context, *CmdIndexExpireRequest) (*CmdIndexExpireResponse, error)
+	CmdNeighbors(context.Context, *CmdNeighborsRequest) (*CmdNeighborsResponse, error)
+	CmdReservationDBDump(context.Context, *CmdReservationDBDumpRequest) (*CmdReservationDBDumpResponse, error)
+	CmdKeeperUnmatched(context.Context, *CmdKeeperUnmatchedRequest) (*CmdKeeperUnmatchedResponse, error)
 }
 
 // UnimplementedColibriDebugCommandsServiceServer can be embedded to have forward compatible implementations.
@@ -1090,6 +3990,36 @@ func (*UnimplementedColibriDebugCommandsServiceServer) CmdIndexActivate(context.
 func (*UnimplementedColibriDebugCommandsServiceServer) CmdIndexCleanup(context.Context, *CmdIndexCleanupRequest) (*CmdIndexCleanupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CmdIndexCleanup not implemented")
 }
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdReservationResize(context.Context, *CmdReservationResizeRequest) (*CmdReservationResizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdReservationResize not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdReservationDelete(context.Context, *CmdReservationDeleteRequest) (*CmdReservationDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdReservationDelete not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdReservationShow(context.Context, *CmdReservationShowRequest) (*CmdReservationShowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdReservationShow not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdKeeperPlan(context.Context, *CmdKeeperPlanRequest) (*CmdKeeperPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdKeeperPlan not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdE2EList(context.Context, *CmdE2EListRequest) (*CmdE2EListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdE2EList not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdIndexActivateAll(context.Context, *CmdIndexActivateAllRequest) (*CmdIndexActivateAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdIndexActivateAll not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdIndexExpire(context.Context, *CmdIndexExpireRequest) (*CmdIndexExpireResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdIndexExpire not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdNeighbors(context.Context, *CmdNeighborsRequest) (*CmdNeighborsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdNeighbors not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdReservationDBDump(context.Context, *CmdReservationDBDumpRequest) (*CmdReservationDBDumpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdReservationDBDump not implemented")
+}
+func (*UnimplementedColibriDebugCommandsServiceServer) CmdKeeperUnmatched(context.Context, *CmdKeeperUnmatchedRequest) (*CmdKeeperUnmatchedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CmdKeeperUnmatched not implemented")
+}
 
 func RegisterColibriDebugCommandsServiceServer(s *grpc.Server, srv ColibriDebugCommandsServiceServer) {
 	s.RegisterService(&_ColibriDebugCommandsService_serviceDesc, srv)
@@ -1167,6 +4097,186 @@ func _ColibriDebugCommandsService_CmdIndexCleanup_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ColibriDebugCommandsService_CmdReservationResize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdReservationResizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdReservationResize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdReservationResize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdReservationResize(ctx, req.(*CmdReservationResizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdReservationDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdReservationDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdReservationDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdReservationDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdReservationDelete(ctx, req.(*CmdReservationDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdReservationShow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdReservationShowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdReservationShow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdReservationShow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdReservationShow(ctx, req.(*CmdReservationShowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdKeeperPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdKeeperPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdKeeperPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdKeeperPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdKeeperPlan(ctx, req.(*CmdKeeperPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdE2EList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdE2EListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdE2EList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdE2EList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdE2EList(ctx, req.(*CmdE2EListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdIndexActivateAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdIndexActivateAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdIndexActivateAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdIndexActivateAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdIndexActivateAll(ctx, req.(*CmdIndexActivateAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdIndexExpire_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdIndexExpireRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdIndexExpire(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdIndexExpire",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdIndexExpire(ctx, req.(*CmdIndexExpireRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdNeighbors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdNeighborsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdNeighbors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdNeighbors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdNeighbors(ctx, req.(*CmdNeighborsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdReservationDBDump_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdReservationDBDumpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdReservationDBDump(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdReservationDBDump",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdReservationDBDump(ctx, req.(*CmdReservationDBDumpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ColibriDebugCommandsService_CmdKeeperUnmatched_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CmdKeeperUnmatchedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ColibriDebugCommandsServiceServer).CmdKeeperUnmatched(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.colibri.v1.ColibriDebugCommandsService/CmdKeeperUnmatched",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ColibriDebugCommandsServiceServer).CmdKeeperUnmatched(ctx, req.(*CmdKeeperUnmatchedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ColibriDebugCommandsService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.colibri.v1.ColibriDebugCommandsService",
 	HandlerType: (*ColibriDebugCommandsServiceServer)(nil),
@@ -1187,6 +4297,46 @@ var _ColibriDebugCommandsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CmdIndexCleanup",
 			Handler:    _ColibriDebugCommandsService_CmdIndexCleanup_Handler,
 		},
+		{
+			MethodName: "CmdReservationResize",
+			Handler:    _ColibriDebugCommandsService_CmdReservationResize_Handler,
+		},
+		{
+			MethodName: "CmdReservationDelete",
+			Handler:    _ColibriDebugCommandsService_CmdReservationDelete_Handler,
+		},
+		{
+			MethodName: "CmdReservationShow",
+			Handler:    _ColibriDebugCommandsService_CmdReservationShow_Handler,
+		},
+		{
+			MethodName: "CmdKeeperPlan",
+			Handler:    _ColibriDebugCommandsService_CmdKeeperPlan_Handler,
+		},
+		{
+			MethodName: "CmdE2EList",
+			Handler:    _ColibriDebugCommandsService_CmdE2EList_Handler,
+		},
+		{
+			MethodName: "CmdIndexActivateAll",
+			Handler:    _ColibriDebugCommandsService_CmdIndexActivateAll_Handler,
+		},
+		{
+			MethodName: "CmdIndexExpire",
+			Handler:    _ColibriDebugCommandsService_CmdIndexExpire_Handler,
+		},
+		{
+			MethodName: "CmdNeighbors",
+			Handler:    _ColibriDebugCommandsService_CmdNeighbors_Handler,
+		},
+		{
+			MethodName: "CmdReservationDBDump",
+			Handler:    _ColibriDebugCommandsService_CmdReservationDBDump_Handler,
+		},
+		{
+			MethodName: "CmdKeeperUnmatched",
+			Handler:    _ColibriDebugCommandsService_CmdKeeperUnmatched_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/colibri/v1/debug.proto",
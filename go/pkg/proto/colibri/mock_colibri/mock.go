@@ -216,6 +216,26 @@ func (mr *MockColibriServiceClientMockRecorder) ListStitchables(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStitchables", reflect.TypeOf((*MockColibriServiceClient)(nil).ListStitchables), varargs...)
 }
 
+// ListTransportPaths mocks base method.
+func (m *MockColibriServiceClient) ListTransportPaths(arg0 context.Context, arg1 *colibri.ListTransportPathsRequest, arg2 ...grpc.CallOption) (*colibri.ListTransportPathsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTransportPaths", varargs...)
+	ret0, _ := ret[0].(*colibri.ListTransportPathsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransportPaths indicates an expected call of ListTransportPaths.
+func (mr *MockColibriServiceClientMockRecorder) ListTransportPaths(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransportPaths", reflect.TypeOf((*MockColibriServiceClient)(nil).ListTransportPaths), varargs...)
+}
+
 // SegmentSetup mocks base method.
 func (m *MockColibriServiceClient) SegmentSetup(arg0 context.Context, arg1 *colibri.SegmentSetupRequest, arg2 ...grpc.CallOption) (*colibri.SegmentSetupResponse, error) {
 	m.ctrl.T.Helper()
@@ -434,6 +454,21 @@ func (mr *MockColibriServiceServerMockRecorder) ListStitchables(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStitchables", reflect.TypeOf((*MockColibriServiceServer)(nil).ListStitchables), arg0, arg1)
 }
 
+// ListTransportPaths mocks base method.
+func (m *MockColibriServiceServer) ListTransportPaths(arg0 context.Context, arg1 *colibri.ListTransportPathsRequest) (*colibri.ListTransportPathsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransportPaths", arg0, arg1)
+	ret0, _ := ret[0].(*colibri.ListTransportPathsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransportPaths indicates an expected call of ListTransportPaths.
+func (mr *MockColibriServiceServerMockRecorder) ListTransportPaths(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransportPaths", reflect.TypeOf((*MockColibriServiceServer)(nil).ListTransportPaths), arg0, arg1)
+}
+
 // SegmentSetup mocks base method.
 func (m *MockColibriServiceServer) SegmentSetup(arg0 context.Context, arg1 *colibri.SegmentSetupRequest) (*colibri.SegmentSetupResponse, error) {
 	m.ctrl.T.Helper()
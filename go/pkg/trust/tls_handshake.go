@@ -50,6 +50,8 @@ func NewTLSCryptoManager(loader X509KeyPairLoader, db DB) *TLSCryptoManager {
 }
 
 // GetCertificate retrieves a certificate to be presented during TLS handshake.
+// The key pair is loaded on each handshake, so that a rotated certificate is used as soon as
+// the loader provides it.
 func (m *TLSCryptoManager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	c, err := m.loader.LoadX509KeyPair(hello.Context(), x509.ExtKeyUsageServerAuth)
 	if err != nil {
@@ -59,6 +61,7 @@ func (m *TLSCryptoManager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Cert
 }
 
 // GetClientCertificate retrieves a client certificate to be presented during TLS handshake.
+// As with GetCertificate, the key pair is loaded on each handshake.
 func (m *TLSCryptoManager) GetClientCertificate(
 	reqInfo *tls.CertificateRequestInfo,
 ) (*tls.Certificate, error) {
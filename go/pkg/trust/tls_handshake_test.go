@@ -16,6 +16,7 @@ package trust_test
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"net"
 	"testing"
 	"time"
@@ -156,6 +157,72 @@ func TestHandshake(t *testing.T) {
 	assert.True(t, server.ConnectionState().HandshakeComplete)
 }
 
+func TestHandshakeCertificateRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	trc := xtest.LoadTRC(t, "testdata/common/trcs/ISD1-B1-S1.trc")
+	loadCert := func(as string) (tls.Certificate, []*x509.Certificate) {
+		dir := "testdata/common/ISD1/AS" + as + "/crypto/as/"
+		crtFile := dir + "ISD1-AS" + as + ".pem"
+		tlsCert, err := tls.LoadX509KeyPair(crtFile, dir+"cp-as.key")
+		require.NoError(t, err)
+		chain, err := cppki.ReadPEMCerts(crtFile)
+		require.NoError(t, err)
+		return tlsCert, chain
+	}
+	certA, chainA := loadCert("ff00_0_111")
+	certB, chainB := loadCert("ff00_0_112")
+
+	db := mock_trust.NewMockDB(ctrl)
+	db.EXPECT().SignedTRC(gomock.Any(), gomock.Any()).AnyTimes().Return(trc, nil)
+	loader := mock_trust.NewMockX509KeyPairLoader(ctrl)
+	// the server certificate is rotated between the two connections
+	gomock.InOrder(
+		loader.EXPECT().LoadX509KeyPair(gomock.Any(), x509.ExtKeyUsageServerAuth).
+			Return(&certA, nil),
+		loader.EXPECT().LoadX509KeyPair(gomock.Any(), x509.ExtKeyUsageServerAuth).
+			Return(&certB, nil),
+	)
+	loader.EXPECT().LoadX509KeyPair(gomock.Any(), x509.ExtKeyUsageClientAuth).
+		Times(2).Return(&certA, nil)
+
+	mgr := trust.NewTLSCryptoManager(loader, db)
+	clientConf := &tls.Config{
+		InsecureSkipVerify:    true,
+		GetClientCertificate:  mgr.GetClientCertificate,
+		VerifyPeerCertificate: mgr.VerifyServerCertificate,
+	}
+	serverConf := &tls.Config{
+		InsecureSkipVerify:    true,
+		GetCertificate:        mgr.GetCertificate,
+		VerifyPeerCertificate: mgr.VerifyClientCertificate,
+		ClientAuth:            tls.RequireAnyClientCert,
+	}
+
+	// the same configurations are used for both connections
+	for _, expected := range [][]*x509.Certificate{chainA, chainB} {
+		clientConn, serverConn := net.Pipe()
+		client := tls.Client(clientConn, clientConf)
+		server := tls.Server(serverConn, serverConf)
+		msg := []byte("hello")
+		errs := make(chan error, 1)
+		go func() {
+			_, err := server.Write(msg)
+			errs <- err
+		}()
+		buf := make([]byte, 100)
+		n, err := client.Read(buf)
+		require.NoError(t, err)
+		require.NoError(t, <-errs)
+		require.Equal(t, msg, buf[:n])
+		assert.Equal(t, expected, client.ConnectionState().PeerCertificates)
+		assert.Equal(t, chainA, server.ConnectionState().PeerCertificates)
+		clientConn.Close()
+		serverConn.Close()
+	}
+}
+
 func loadRawChain(t *testing.T, file string) [][]byte {
 	var chain [][]byte
 	for _, cert := range xtest.LoadChain(t, file) {